@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/wdmsyhh/simple-notes/internal/backup"
+)
+
+// backupCmd 在线备份数据库
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "在线备份数据库（SQLite 使用 VACUUM INTO，MySQL/PostgreSQL 逻辑导出），备份可以恢复到任意数据库",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		keep, _ := cmd.Flags().GetInt("keep")
+
+		s, err := openStore(getProfile())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		// 未指定输出文件时保存到数据目录的 backups 目录，与定时备份相同
+		if output == "" {
+			manager := backup.NewManager(s, filepath.Join(viper.GetString("data"), backup.DirName))
+			info, err := manager.Create(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Backup created: %s (%d bytes)\n", info.Path, info.Size)
+
+			removed, err := manager.Prune(keep)
+			if err != nil {
+				return err
+			}
+			for _, name := range removed {
+				fmt.Fprintf(cmd.OutOrStdout(), "Removed old backup: %s\n", name)
+			}
+			return nil
+		}
+
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		manifest, err := backup.Write(cmd.Context(), s, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(output)
+			return err
+		}
+
+		var rows int64
+		for _, table := range manifest.Tables {
+			rows += table.Rows
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Backup created: %s (%d tables, %d rows)\n", output, len(manifest.Tables), rows)
+		return nil
+	},
+}
+
+// restoreCmd 将备份恢复到当前配置的数据库
+var restoreCmd = &cobra.Command{
+	Use:   "restore <backup.zip>",
+	Short: "将备份恢复到当前配置的数据库（可以与备份来源的数据库类型不同），恢复后校验行数和校验和",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+
+		s, err := openStore(getProfile())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		result, err := backup.Restore(cmd.Context(), s, args[0], backup.RestoreOptions{Force: force})
+		if err != nil {
+			return err
+		}
+		printTableResults(cmd, result, "Restored")
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringP("output", "o", "", "输出文件（默认保存到数据目录下的 backups 目录）")
+	backupCmd.Flags().Int("keep", 0, "保存到 backups 目录时只保留最新的 N 个备份，为 0 时保留全部备份")
+
+	restoreCmd.Flags().Bool("force", false, "目标数据库中已有数据时先清空再恢复")
+
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+// printTableResults 输出每个数据表写入的行数和校验和
+func printTableResults(cmd *cobra.Command, result *backup.Result, action string) {
+	w := cmd.OutOrStdout()
+	var rows int64
+	for _, table := range result.Tables {
+		fmt.Fprintf(w, "%-12s %8d rows  sha256:%s\n", table.Name, table.Rows, table.Checksum)
+		rows += table.Rows
+	}
+	for _, name := range result.Skipped {
+		fmt.Fprintf(w, "%-12s skipped (unknown table)\n", name)
+	}
+	fmt.Fprintf(w, "\n%s %d tables, %d rows, verified.\n", action, len(result.Tables), rows)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/wdmsyhh/simple-notes/internal/dataexport"
+	"github.com/wdmsyhh/simple-notes/internal/markdown"
+	"github.com/wdmsyhh/simple-notes/internal/staticsite"
+)
+
+// exportCmd 导出命令
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出站点内容",
+}
+
+// exportStaticCmd 将已发布的公开笔记导出为静态网站
+var exportStaticCmd = &cobra.Command{
+	Use:   "static",
+	Short: "将已发布的公开笔记导出为静态网站（增量导出）",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		baseURL, _ := cmd.Flags().GetString("base-url")
+		output, _ := cmd.Flags().GetString("output")
+		force, _ := cmd.Flags().GetBool("force")
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+		if output == "" {
+			output = filepath.Join(viper.GetString("data"), "static")
+		}
+
+		s, err := openStore(getProfile())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		exporter, err := staticsite.NewExporter(s, markdown.NewRenderer(markdown.DefaultCacheSize))
+		if err != nil {
+			return err
+		}
+		result, err := exporter.Export(cmd.Context(), staticsite.Options{
+			OutputDir: output,
+			BaseURL:   baseURL,
+			Force:     force,
+			PageSize:  pageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported to %s: %d notes rendered, %d unchanged, %d removed, %d pages rendered, %d attachments copied\n",
+			output, result.NotesRendered, result.NotesSkipped, result.NotesRemoved, result.PagesRendered, result.AttachmentsCopied)
+		return nil
+	},
+}
+
+// exportDataCmd 将用户或整个实例的数据导出为 zip 压缩包
+var exportDataCmd = &cobra.Command{
+	Use:   "data",
+	Short: "将用户（或整个实例）的笔记、附件和评论导出为 zip 压缩包，可由 import markdown 重新导入",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		username, _ := cmd.Flags().GetString("user")
+		instance, _ := cmd.Flags().GetBool("instance")
+		output, _ := cmd.Flags().GetString("output")
+
+		s, err := openStore(getProfile())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		user, err := s.GetUserByUsername(cmd.Context(), username)
+		if err != nil || user == nil {
+			return fmt.Errorf("user not found: %s", username)
+		}
+		if output == "" {
+			output = dataexport.Filename(user, instance, time.Now())
+		}
+
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		err = dataexport.NewExporter(s).Export(cmd.Context(), f, dataexport.Options{UserID: user.ID, Instance: instance})
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(output)
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported to %s\n", output)
+		return nil
+	},
+}
+
+func init() {
+	exportStaticCmd.Flags().String("base-url", "", "站点根地址，例如 https://notes.example.com（必填）")
+	exportStaticCmd.Flags().StringP("output", "o", "", "输出目录（默认为数据目录下的 static 目录）")
+	exportStaticCmd.Flags().Bool("force", false, "忽略上次导出的记录，重新生成全部页面")
+	exportStaticCmd.Flags().Int("page-size", staticsite.DefaultPageSize, "首页每页显示的笔记数量")
+	_ = exportStaticCmd.MarkFlagRequired("base-url")
+
+	exportDataCmd.Flags().String("user", "", "导出数据的用户名（必填，导出整个实例时记录为导出人）")
+	exportDataCmd.Flags().Bool("instance", false, "导出整个实例（所有用户的笔记、附件和页面）")
+	exportDataCmd.Flags().StringP("output", "o", "", "输出文件（默认为当前目录下按用户名和时间命名的 zip 文件）")
+	_ = exportDataCmd.MarkFlagRequired("user")
+
+	exportCmd.AddCommand(exportStaticCmd)
+	exportCmd.AddCommand(exportDataCmd)
+	rootCmd.AddCommand(exportCmd)
+}
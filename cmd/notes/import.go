@@ -0,0 +1,224 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wdmsyhh/simple-notes/internal/importer"
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// importCmd 导入命令
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "从其他系统导入笔记",
+}
+
+// importMarkdownCmd 从目录或 zip 导入 Markdown 笔记
+var importMarkdownCmd = &cobra.Command{
+	Use:   "markdown <dir|zip>",
+	Short: "从 Obsidian/Hugo/Jekyll 目录或 zip 导入 Markdown 笔记",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("author")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		visibility, _ := cmd.Flags().GetString("visibility")
+
+		opts := importer.Options{DryRun: dryRun}
+		switch strings.ToLower(visibility) {
+		case "public":
+			opts.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PUBLIC
+		case "private":
+			opts.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PRIVATE
+		default:
+			return fmt.Errorf("invalid visibility: %s", visibility)
+		}
+
+		fsys, closer, err := openImportSource(args[0])
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+
+		s, err := openStore(getProfile())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		user, err := s.GetUserByUsername(cmd.Context(), username)
+		if err != nil || user == nil {
+			return fmt.Errorf("user not found: %s", username)
+		}
+		opts.AuthorID = user.ID
+
+		report, err := importer.NewImporter(s).ImportMarkdown(cmd.Context(), fsys, opts)
+		if err != nil {
+			return err
+		}
+		printImportReport(cmd.OutOrStdout(), report, dryRun)
+		return nil
+	},
+}
+
+// importWordPressCmd 从 WordPress 导出文件（WXR）导入文章、页面、评论和媒体
+var importWordPressCmd = &cobra.Command{
+	Use:   "wordpress <export.xml>",
+	Short: "从 WordPress 导出文件（WXR）导入文章、页面、分类、标签、评论和媒体",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("author")
+		uploadsDir, _ := cmd.Flags().GetString("uploads")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		visibility, _ := cmd.Flags().GetString("visibility")
+
+		opts := importer.Options{DryRun: dryRun}
+		switch strings.ToLower(visibility) {
+		case "public":
+			opts.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PUBLIC
+		case "private":
+			opts.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PRIVATE
+		default:
+			return fmt.Errorf("invalid visibility: %s", visibility)
+		}
+
+		var uploads fs.FS
+		if uploadsDir != "" {
+			info, err := os.Stat(uploadsDir)
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("uploads is not a directory: %s", uploadsDir)
+			}
+			uploads = os.DirFS(uploadsDir)
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		s, err := openStore(getProfile())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		user, err := s.GetUserByUsername(cmd.Context(), username)
+		if err != nil || user == nil {
+			return fmt.Errorf("user not found: %s", username)
+		}
+		opts.AuthorID = user.ID
+
+		report, err := importer.NewImporter(s).ImportWordPress(cmd.Context(), f, uploads, opts)
+		if err != nil {
+			return err
+		}
+		printImportReport(cmd.OutOrStdout(), report, dryRun)
+		return nil
+	},
+}
+
+func init() {
+	importMarkdownCmd.Flags().String("author", "", "导入笔记的作者用户名（必填）")
+	importMarkdownCmd.Flags().Bool("dry-run", false, "试运行，只输出导入报告，不写入任何数据")
+	importMarkdownCmd.Flags().String("visibility", "private", "未在 front matter 中指定可见性时使用的默认可见性（public/private）")
+	_ = importMarkdownCmd.MarkFlagRequired("author")
+
+	importWordPressCmd.Flags().String("author", "", "导入笔记的作者用户名（必填）")
+	importWordPressCmd.Flags().String("uploads", "", "WordPress 的 wp-content/uploads 目录，不指定时不导入媒体文件")
+	importWordPressCmd.Flags().Bool("dry-run", false, "试运行，只输出导入报告，不写入任何数据")
+	importWordPressCmd.Flags().String("visibility", "private", "草稿和待审核文章的可见性（public/private）")
+	_ = importWordPressCmd.MarkFlagRequired("author")
+
+	importCmd.AddCommand(importMarkdownCmd)
+	importCmd.AddCommand(importWordPressCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+// openImportSource 打开导入源，支持目录和 zip 文件
+func openImportSource(source string) (fs.FS, io.Closer, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.IsDir() {
+		return os.DirFS(source), io.NopCloser(nil), nil
+	}
+	reader, err := zip.OpenReader(source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open zip: %w", err)
+	}
+	return reader, reader, nil
+}
+
+// printImportReport 输出导入报告
+func printImportReport(w io.Writer, report *importer.Report, dryRun bool) {
+	counts := map[importer.Status]int{}
+	for _, note := range report.Notes {
+		counts[note.Status]++
+		line := fmt.Sprintf("%-12s %s (%s)", note.Status, note.Title, note.Path)
+		if note.NoteID > 0 {
+			line += fmt.Sprintf(" -> notes/%d", note.NoteID)
+		}
+		if note.Message != "" {
+			line += ": " + note.Message
+		}
+		fmt.Fprintln(w, line)
+	}
+	pageCounts := map[importer.Status]int{}
+	for _, page := range report.Pages {
+		pageCounts[page.Status]++
+		line := fmt.Sprintf("%-12s %s (%s)", page.Status, page.Title, page.Path)
+		if page.NoteID > 0 {
+			line += fmt.Sprintf(" -> pages/%d", page.NoteID)
+		}
+		if page.Message != "" {
+			line += ": " + page.Message
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	if len(report.Issues) > 0 {
+		fmt.Fprintln(w, "\nIssues:")
+		for _, issue := range report.Issues {
+			fmt.Fprintf(w, "  [%s] %s: %s\n", issue.Kind, issue.Path, issue.Message)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if dryRun {
+		fmt.Fprintln(w, "Dry run, nothing was written.")
+	}
+	fmt.Fprintf(w, "Notes: %d created, %d would be created, %d skipped, %d failed\n",
+		counts[importer.StatusCreated], counts[importer.StatusWouldCreate], counts[importer.StatusSkipped], counts[importer.StatusFailed])
+	if len(report.Pages) > 0 {
+		fmt.Fprintf(w, "Pages: %d created, %d would be created, %d skipped, %d failed\n",
+			pageCounts[importer.StatusCreated], pageCounts[importer.StatusWouldCreate], pageCounts[importer.StatusSkipped], pageCounts[importer.StatusFailed])
+	}
+	fmt.Fprintf(w, "New tags: %s\n", joinOrNone(report.CreatedTags))
+	fmt.Fprintf(w, "New categories: %s\n", joinOrNone(report.CreatedCategories))
+	fmt.Fprintf(w, "Attachments: %d\n", report.AttachmentsUploaded)
+	if report.CommentsImported > 0 {
+		fmt.Fprintf(w, "Comments: %d\n", report.CommentsImported)
+	}
+	if report.RedirectsRecorded > 0 {
+		fmt.Fprintf(w, "Redirects: %d\n", report.RedirectsRecorded)
+	}
+}
+
+// joinOrNone 以逗号连接字符串列表，为空时返回 "-"
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ", ")
+}
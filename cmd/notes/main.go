@@ -0,0 +1,131 @@
+// Simple Notes 服务器入口
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	"github.com/wdmsyhh/simple-notes/server"
+	"github.com/wdmsyhh/simple-notes/store"
+	"github.com/wdmsyhh/simple-notes/store/db"
+)
+
+// shutdownTimeout 优雅关闭服务器的最长等待时间
+const shutdownTimeout = 10 * time.Second
+
+// rootCmd 启动服务器的根命令
+var rootCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Simple Notes 笔记管理系统",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runServer(cmd.Context())
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.Flags().Int("port", 8080, "服务器监听端口")
+	rootCmd.Flags().Duration("backup-interval", 0, "定时备份的间隔，例如 24h，为 0 时不启用定时备份")
+	rootCmd.Flags().Int("backup-retention", 7, "定时备份保留的备份数量，为 0 时保留全部备份")
+	rootCmd.PersistentFlags().String("db-driver", "sqlite", "数据库驱动类型（sqlite/mysql/postgres）")
+	rootCmd.PersistentFlags().String("db-dsn", "./data/simple-notes.db", "数据库连接字符串")
+	rootCmd.PersistentFlags().String("data", "./data", "数据目录，用于存放静态站点导出、备份等文件")
+
+	for _, name := range []string{"port", "backup-interval", "backup-retention"} {
+		_ = viper.BindPFlag(name, rootCmd.Flags().Lookup(name))
+	}
+	for _, name := range []string{"db-driver", "db-dsn", "data"} {
+		_ = viper.BindPFlag(name, rootCmd.PersistentFlags().Lookup(name))
+	}
+
+	// 环境变量：NOTES_PORT、NOTES_DB_DRIVER、NOTES_DB_DSN、NOTES_DATA、NOTES_BACKUP_INTERVAL、NOTES_BACKUP_RETENTION
+	viper.SetEnvPrefix("notes")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+// getProfile 根据命令行参数和环境变量生成服务器配置
+func getProfile() *profile.Profile {
+	return &profile.Profile{
+		Driver: viper.GetString("db-driver"),
+		DSN:    viper.GetString("db-dsn"),
+		Data:   viper.GetString("data"),
+
+		BackupInterval:  viper.GetDuration("backup-interval"),
+		BackupRetention: viper.GetInt("backup-retention"),
+	}
+}
+
+// openStore 打开数据库并执行迁移
+func openStore(p *profile.Profile) (*store.Store, error) {
+	driver, err := db.NewDBDriver(p)
+	if err != nil {
+		return nil, err
+	}
+	s := store.NewStore(driver, p)
+	if err := s.RunMigrations(); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return s, nil
+}
+
+// runServer 启动服务器，收到退出信号后优雅关闭
+func runServer(ctx context.Context) error {
+	p := getProfile()
+	s, err := openStore(p)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	srv := server.NewServer(s, p, viper.GetInt("port"))
+	if err := srv.SetupRoutes(ctx); err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Start()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shutdown server: %v", err)
+		return err
+	}
+	return nil
+}
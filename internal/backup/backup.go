@@ -0,0 +1,225 @@
+// Package backup 数据库的在线备份和恢复
+// 备份是一个 zip 压缩包：SQLite 使用 VACUUM INTO 生成一致快照文件，MySQL 和 PostgreSQL
+// 在只读事务中逻辑导出全部数据表（每行一个 JSON 数组）；附件以二进制形式保存在数据库中，
+// 因此也包含在备份里。任意驱动生成的备份都可以恢复到任意驱动的数据库
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	"github.com/wdmsyhh/simple-notes/store"
+	"github.com/wdmsyhh/simple-notes/store/db/sqlite"
+)
+
+// FormatVersion 备份格式版本，格式发生不兼容变化时递增
+const FormatVersion = 1
+
+const (
+	// manifestPath 清单文件在压缩包中的路径
+	manifestPath = "manifest.json"
+	// snapshotPath SQLite 快照文件在压缩包中的路径
+	snapshotPath = "database.sqlite"
+	// tablesDir 逻辑导出的数据表在压缩包中的目录
+	tablesDir = "tables"
+)
+
+// Manifest 备份清单（manifest.json）
+type Manifest struct {
+	// Version 备份格式版本
+	Version int `json:"version"`
+	// CreatedAt 备份时间（RFC3339）
+	CreatedAt string `json:"created_at"`
+	// Driver 备份来源的数据库驱动
+	Driver string `json:"driver"`
+	// Snapshot SQLite 快照文件在压缩包中的路径，为空时数据表为逻辑导出
+	Snapshot string `json:"snapshot,omitempty"`
+	// Tables 每个数据表的行数和校验和
+	Tables []*TableEntry `json:"tables"`
+}
+
+// TableEntry 数据表信息
+type TableEntry struct {
+	// Name 表名
+	Name string `json:"name"`
+	// Path 逻辑导出文件在压缩包中的路径（JSON Lines），快照备份时为空
+	Path string `json:"path,omitempty"`
+	// Columns 导出的列，每行数据按此顺序排列
+	Columns []store.Column `json:"columns"`
+	// Rows 行数
+	Rows int64 `json:"rows"`
+	// Checksum 全部行编码后的 SHA-256 摘要（十六进制）
+	Checksum string `json:"checksum"`
+}
+
+// Write 将数据库的一致快照写入 w
+func Write(ctx context.Context, s *store.Store, w io.Writer) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Driver:    s.DriverName(),
+	}
+
+	zw := zip.NewWriter(w)
+	var err error
+	if s.DriverName() == "sqlite" {
+		err = writeSnapshot(ctx, s, zw, manifest)
+	} else {
+		err = writeTables(ctx, s, zw, manifest)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mw, err := zw.Create(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(mw)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// writeSnapshot 使用 VACUUM INTO 生成 SQLite 快照并写入压缩包
+func writeSnapshot(ctx context.Context, s *store.Store, zw *zip.Writer, manifest *Manifest) error {
+	dir, err := os.MkdirTemp("", "simple-notes-backup-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	snapshot := filepath.Join(dir, snapshotPath)
+	if err := s.VacuumInto(ctx, snapshot); err != nil {
+		return err
+	}
+
+	// 在快照上计算校验和，恢复时用于校验
+	snapshotStore, err := openSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	defer snapshotStore.Close()
+	for _, table := range store.Tables {
+		checksum, err := snapshotStore.TableChecksum(ctx, snapshotStore.GetDB(), table, table.Columns)
+		if err != nil {
+			return err
+		}
+		manifest.Tables = append(manifest.Tables, &TableEntry{
+			Name:     table.Name,
+			Columns:  table.Columns,
+			Rows:     checksum.Rows(),
+			Checksum: checksum.String(),
+		})
+	}
+
+	f, err := os.Open(snapshot)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fw, err := zw.Create(snapshotPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fw, f); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	manifest.Snapshot = snapshotPath
+	return nil
+}
+
+// writeTables 在只读事务中逻辑导出全部数据表
+func writeTables(ctx context.Context, s *store.Store, zw *zip.Writer, manifest *Manifest) error {
+	tx, err := s.BeginSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range store.Tables {
+		entry := &TableEntry{
+			Name:    table.Name,
+			Path:    path.Join(tablesDir, table.Name+".jsonl"),
+			Columns: table.Columns,
+		}
+		fw, err := zw.Create(entry.Path)
+		if err != nil {
+			return err
+		}
+		bw := bufio.NewWriter(fw)
+		checksum := store.NewChecksum()
+		err = s.ScanRows(ctx, tx, table, table.Columns, func(row []any) error {
+			encoded, err := store.EncodeRow(row)
+			if err != nil {
+				return err
+			}
+			checksum.Add(encoded)
+			if _, err := bw.Write(encoded); err != nil {
+				return err
+			}
+			return bw.WriteByte('\n')
+		})
+		if err != nil {
+			return err
+		}
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		entry.Rows = checksum.Rows()
+		entry.Checksum = checksum.String()
+		manifest.Tables = append(manifest.Tables, entry)
+	}
+	return nil
+}
+
+// openSnapshot 打开 SQLite 快照文件并执行迁移（旧版本的快照会升级到当前的表结构）
+func openSnapshot(path string) (*store.Store, error) {
+	p := &profile.Profile{Driver: "sqlite", DSN: path}
+	driver, err := sqlite.NewDB(p)
+	if err != nil {
+		return nil, err
+	}
+	s := store.NewStore(driver, p)
+	if err := s.RunMigrations(); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to migrate snapshot: %w", err)
+	}
+	return s, nil
+}
+
+// readManifest 读取压缩包中的备份清单
+func readManifest(zr *zip.Reader) (*Manifest, error) {
+	f, err := zr.Open(manifestPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New("not a backup archive: manifest.json not found")
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(f).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup version: %d", manifest.Version)
+	}
+	return &manifest, nil
+}
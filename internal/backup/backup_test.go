@@ -0,0 +1,249 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+	"github.com/wdmsyhh/simple-notes/store/db"
+)
+
+// newTestStore 在临时目录中创建 SQLite 数据库并执行迁移
+func newTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	dir := t.TempDir()
+	p := &profile.Profile{Driver: "sqlite", DSN: filepath.Join(dir, "test.db"), Data: dir}
+	driver, err := db.NewDBDriver(p)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	s := store.NewStore(driver, p)
+	t.Cleanup(func() { s.Close() })
+	if err := s.RunMigrations(); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	return s
+}
+
+// seedTestData 写入覆盖主要数据表的测试数据，返回笔记和附件的ID
+func seedTestData(t *testing.T, s *store.Store) (noteID, attachmentID int64) {
+	t.Helper()
+	ctx := context.Background()
+	user, err := s.CreateUser(ctx, &store.User{Username: "author", PasswordHash: "x", Role: store.RoleUser})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	authorID := strconv.FormatUint(uint64(user.ID), 10)
+	parent, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "parent", Slug: "parent", Visible: true})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	child, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "child", Slug: "child", ParentId: parent.Id, Visible: true})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	tag, err := s.CreateTag(ctx, &pbstore.Tag{NameText: "Go"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	target, err := s.CreateNote(ctx, &pbstore.Note{Title: "target", Content: "target", AuthorId: authorID, Published: true})
+	if err != nil {
+		t.Fatalf("CreateNote: %v", err)
+	}
+	note, err := s.CreateNote(ctx, &pbstore.Note{
+		Title:      "备份的笔记",
+		Content:    "see [[target]]",
+		AuthorId:   authorID,
+		CategoryId: strconv.FormatInt(child.Id, 10),
+		TagIds:     []string{strconv.FormatInt(tag.Id, 10)},
+		Published:  true,
+	})
+	if err != nil {
+		t.Fatalf("CreateNote: %v", err)
+	}
+	// 二进制附件（包含零字节和非 UTF-8 字节）
+	attachment, err := s.CreateAttachment(ctx, &pbstore.Attachment{
+		Filename: "image.png",
+		Type:     "image/png",
+		Size:     6,
+		Content:  []byte{0x89, 'P', 'N', 'G', 0x00, 0xff},
+		NoteId:   "notes/" + strconv.FormatInt(note.Id, 10),
+		AuthorId: authorID,
+	})
+	if err != nil {
+		t.Fatalf("CreateAttachment: %v", err)
+	}
+	// 软删除的笔记同样包含在备份中
+	if err := s.DeleteNote(ctx, target.Id); err != nil {
+		t.Fatalf("DeleteNote: %v", err)
+	}
+	return note.Id, attachment.Id
+}
+
+// assertSameTables 检查两个数据库中全部数据表的行数和校验和一致
+func assertSameTables(t *testing.T, want, got *store.Store) {
+	t.Helper()
+	ctx := context.Background()
+	for _, table := range store.Tables {
+		wantChecksum, err := want.TableChecksum(ctx, want.GetDB(), table, table.Columns)
+		if err != nil {
+			t.Fatalf("TableChecksum(%s): %v", table.Name, err)
+		}
+		gotChecksum, err := got.TableChecksum(ctx, got.GetDB(), table, table.Columns)
+		if err != nil {
+			t.Fatalf("TableChecksum(%s): %v", table.Name, err)
+		}
+		if gotChecksum.Rows() != wantChecksum.Rows() || gotChecksum.String() != wantChecksum.String() {
+			t.Errorf("table %s has %d rows (%s), want %d rows (%s)",
+				table.Name, gotChecksum.Rows(), gotChecksum.String(), wantChecksum.Rows(), wantChecksum.String())
+		}
+	}
+}
+
+// assertRestored 检查恢复后的数据可以正常读取，并且新写入的行不会与恢复的ID冲突
+func assertRestored(t *testing.T, s *store.Store, noteID, attachmentID int64) {
+	t.Helper()
+	ctx := context.Background()
+	note, err := s.GetNote(ctx, noteID)
+	if err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	if note.Title != "备份的笔记" || len(note.TagIds) != 1 {
+		t.Errorf("restored note = %q with tags %v, want the original note", note.Title, note.TagIds)
+	}
+	attachment, err := s.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		t.Fatalf("GetAttachment: %v", err)
+	}
+	if !bytes.Equal(attachment.Content, []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}) {
+		t.Errorf("restored attachment content = %x, want the original bytes", attachment.Content)
+	}
+	created, err := s.CreateNote(ctx, &pbstore.Note{Title: "after restore", AuthorId: note.AuthorId})
+	if err != nil {
+		t.Fatalf("CreateNote after restore: %v", err)
+	}
+	if created.Id <= noteID {
+		t.Errorf("note created after restore has ID %d, want greater than %d", created.Id, noteID)
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	src := newTestStore(t)
+	noteID, attachmentID := seedTestData(t, src)
+
+	// 逻辑导出（MySQL 和 PostgreSQL 使用的格式）同样可以恢复到 SQLite
+	writeLogical := func(t *testing.T, path string) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		manifest := &Manifest{Version: FormatVersion, CreatedAt: time.Now().UTC().Format(time.RFC3339), Driver: src.DriverName()}
+		if err := writeTables(ctx, src, zw, manifest); err != nil {
+			t.Fatalf("writeTables: %v", err)
+		}
+		mw, err := zw.Create(manifestPath)
+		if err != nil {
+			t.Fatalf("failed to create manifest: %v", err)
+		}
+		if err := json.NewEncoder(mw).Encode(manifest); err != nil {
+			t.Fatalf("failed to write manifest: %v", err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatalf("failed to close archive: %v", err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+			t.Fatalf("failed to write archive: %v", err)
+		}
+	}
+	writeSnapshotArchive := func(t *testing.T, path string) {
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("failed to create archive: %v", err)
+		}
+		defer f.Close()
+		manifest, err := Write(ctx, src, f)
+		if err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if manifest.Snapshot == "" {
+			t.Errorf("SQLite backup has no snapshot, want a VACUUM INTO snapshot")
+		}
+	}
+
+	for name, write := range map[string]func(*testing.T, string){
+		"snapshot": writeSnapshotArchive,
+		"logical":  writeLogical,
+	} {
+		t.Run(name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "backup.zip")
+			write(t, archive)
+
+			target := newTestStore(t)
+			if _, err := Restore(ctx, target, archive, RestoreOptions{}); err != nil {
+				t.Fatalf("Restore: %v", err)
+			}
+			assertSameTables(t, src, target)
+			assertRestored(t, target, noteID, attachmentID)
+
+			// 目标数据库已有数据时需要指定 Force，覆盖后与来源一致
+			if _, err := Restore(ctx, target, archive, RestoreOptions{}); !errors.Is(err, ErrTargetNotEmpty) {
+				t.Errorf("Restore into a non-empty database = %v, want %v", err, ErrTargetNotEmpty)
+			}
+			if _, err := Restore(ctx, target, archive, RestoreOptions{Force: true}); err != nil {
+				t.Fatalf("Restore with Force: %v", err)
+			}
+			assertSameTables(t, src, target)
+		})
+	}
+}
+
+func TestManagerPrune(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	seedTestData(t, s)
+	m := NewManager(s, filepath.Join(t.TempDir(), "backups"))
+
+	var created []string
+	for i := 0; i < 3; i++ {
+		info, err := m.Create(ctx)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if !IsBackupFilename(info.Filename) {
+			t.Errorf("Create = %q, want a backup filename", info.Filename)
+		}
+		created = append(created, info.Filename)
+	}
+	// 备份目录中的其他文件不受影响
+	other := filepath.Join(m.Dir(), "notes.txt")
+	if err := os.WriteFile(other, nil, 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	removed, err := m.Prune(1)
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("Prune removed %v, want the 2 oldest backups", removed)
+	}
+	backups, err := m.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(backups) != 1 || backups[0].Filename != created[2] {
+		t.Errorf("List after Prune = %v, want only %s", backups, created[2])
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("Prune removed an unrelated file: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// CopyOptions 跨数据库复制选项
+type CopyOptions struct {
+	// Force 目标数据库中已有数据时先清空再复制
+	Force bool
+}
+
+// Copy 将来源数据库的全部数据复制到目标数据库，两者可以是不同的驱动
+// 来源数据库在一个只读事务中读取，不会被修改（也不执行迁移，旧版本中不存在的列使用目标数据库的默认值）；
+// 目标数据库需要已执行迁移。按依赖顺序逐行写入并保留ID，写入后修正自增计数器，并校验每个数据表的行数和校验和
+func Copy(ctx context.Context, from, to *store.Store, opts CopyOptions) (*Result, error) {
+	src, err := newStoreSource(ctx, from, false)
+	if err != nil {
+		return nil, err
+	}
+	defer src.close()
+
+	return copyTables(ctx, src, to, opts.Force)
+}
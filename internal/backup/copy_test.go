@@ -0,0 +1,30 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+	src := newTestStore(t)
+	noteID, attachmentID := seedTestData(t, src)
+
+	target := newTestStore(t)
+	result, err := Copy(ctx, src, target, CopyOptions{})
+	if err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if len(result.Tables) != len(store.Tables) {
+		t.Errorf("Copy copied %d tables, want %d", len(result.Tables), len(store.Tables))
+	}
+	assertSameTables(t, src, target)
+	assertRestored(t, target, noteID, attachmentID)
+
+	if _, err := Copy(ctx, src, target, CopyOptions{}); !errors.Is(err, ErrTargetNotEmpty) {
+		t.Errorf("Copy into a non-empty database = %v, want %v", err, ErrTargetNotEmpty)
+	}
+}
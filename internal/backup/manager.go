@@ -0,0 +1,207 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// DirName 备份在数据目录中的目录
+	DirName = "backups"
+	// filenameLayout 备份文件名中的时间格式
+	filenameLayout = "20060102-150405"
+)
+
+// filenamePattern 由 Manager 生成的备份文件名
+var filenamePattern = regexp.MustCompile(`^simple-notes-backup-\d{8}-\d{6}\.zip$`)
+
+// ErrBackupInProgress 已有备份任务正在进行
+var ErrBackupInProgress = errors.New("backup already in progress")
+
+// Info 备份文件信息
+type Info struct {
+	// Filename 文件名
+	Filename string
+	// Path 文件路径
+	Path string
+	// Size 文件大小（字节）
+	Size int64
+	// CreatedAt 创建时间
+	CreatedAt time.Time
+}
+
+// Manager 管理备份目录中的备份文件，供命令行、管理接口和定时任务共用
+type Manager struct {
+	// store 数据存储实例
+	store *store.Store
+	// dir 备份目录
+	dir string
+	// mu 保证同一时间只有一个备份任务
+	mu sync.Mutex
+}
+
+// NewManager 创建备份管理器，备份文件保存在 dir 目录中
+func NewManager(s *store.Store, dir string) *Manager {
+	return &Manager{store: s, dir: dir}
+}
+
+// Dir 返回备份目录
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// Filename 返回指定时间的备份文件名
+func Filename(now time.Time) string {
+	return fmt.Sprintf("simple-notes-backup-%s.zip", now.UTC().Format(filenameLayout))
+}
+
+// IsBackupFilename 检查文件名是否为备份文件名，用于防止下载和删除备份目录以外的文件
+func IsBackupFilename(name string) bool {
+	return filenamePattern.MatchString(name)
+}
+
+// Create 在备份目录中创建一个新的备份
+// 先写入临时文件，完成后再重命名，备份目录中不会出现不完整的备份
+func (m *Manager) Create(ctx context.Context) (*Info, error) {
+	if !m.mu.TryLock() {
+		return nil, ErrBackupInProgress
+	}
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	f, err := os.CreateTemp(m.dir, ".backup-*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if _, err := Write(ctx, m.store, f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	// 同一秒内重复备份时等待到下一秒，避免覆盖已有的备份
+	now := time.Now()
+	filename := Filename(now)
+	for {
+		if _, err := os.Stat(filepath.Join(m.dir, filename)); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		now = now.Add(time.Second)
+		filename = Filename(now)
+	}
+	path := filepath.Join(m.dir, filename)
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+	return stat(path)
+}
+
+// List 列出备份目录中的备份，最新的在前
+func (m *Manager) List() ([]*Info, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var backups []*Info
+	for _, entry := range entries {
+		if entry.IsDir() || !IsBackupFilename(entry.Name()) {
+			continue
+		}
+		info, err := stat(filepath.Join(m.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, info)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Filename > backups[j].Filename
+	})
+	return backups, nil
+}
+
+// Prune 只保留最新的 keep 个备份，删除其余的备份，返回删除的文件名
+func (m *Manager) Prune(keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	backups, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, backups[i].Filename)
+	}
+	return removed, nil
+}
+
+// Run 启动定时备份任务，每隔 interval 创建一个备份并只保留最新的 keep 个，直到 ctx 取消
+func (m *Manager) Run(ctx context.Context, interval time.Duration, keep int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := m.Create(ctx)
+			if err != nil {
+				log.Printf("Scheduled backup failed: %v", err)
+				continue
+			}
+			log.Printf("Scheduled backup created: %s (%d bytes)", info.Filename, info.Size)
+			if _, err := m.Prune(keep); err != nil {
+				log.Printf("Failed to prune old backups: %v", err)
+			}
+		}
+	}
+}
+
+// stat 读取备份文件信息，创建时间从文件名中解析
+func stat(path string) (*Info, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	info := &Info{
+		Filename:  fi.Name(),
+		Path:      path,
+		Size:      fi.Size(),
+		CreatedAt: fi.ModTime(),
+	}
+	stamp := fi.Name()[len("simple-notes-backup-") : len(fi.Name())-len(".zip")]
+	if t, err := time.Parse(filenameLayout, stamp); err == nil {
+		info.CreatedAt = t
+	}
+	return info, nil
+}
@@ -0,0 +1,386 @@
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// maxRowSize 逻辑导出文件中单行的最大长度（附件以 base64 编码保存在一行中）
+const maxRowSize = 64 << 20
+
+// ErrTargetNotEmpty 目标数据库中已有数据
+var ErrTargetNotEmpty = errors.New("target database is not empty")
+
+// RestoreOptions 恢复选项
+type RestoreOptions struct {
+	// Force 目标数据库中已有数据时先清空再恢复
+	Force bool
+}
+
+// TableResult 单个数据表的恢复（或复制）结果
+type TableResult struct {
+	// Name 表名
+	Name string
+	// Rows 写入的行数
+	Rows int64
+	// Checksum 写入后在目标数据库中重新读取计算的校验和
+	Checksum string
+}
+
+// Result 恢复（或复制）结果
+type Result struct {
+	// Tables 每个数据表的结果
+	Tables []*TableResult
+	// Skipped 当前版本中不存在而跳过的数据表
+	Skipped []string
+}
+
+// source 待写入的数据来源
+type source interface {
+	// columns 返回数据表在来源中的列（只包含当前表结构中存在的列），来源中没有该表时返回 nil
+	columns(table *store.Table) []store.Column
+	// scan 按主键顺序读取数据表中的全部行
+	scan(ctx context.Context, table *store.Table, columns []store.Column, fn func(row []any) error) error
+}
+
+// Restore 将备份恢复到目标数据库，目标数据库可以是任意驱动
+// 目标数据库需要为空（或指定 Force），恢复在一个事务中完成，完成后校验行数和校验和
+func Restore(ctx context.Context, target *store.Store, archive string, opts RestoreOptions) (*Result, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer zr.Close()
+
+	manifest, err := readManifest(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var src source
+	if manifest.Snapshot != "" {
+		snapshot, cleanup, err := extractSnapshot(&zr.Reader, manifest.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		snapshotStore, err := openSnapshot(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		ss, err := newStoreSource(ctx, snapshotStore, true)
+		if err != nil {
+			snapshotStore.Close()
+			return nil, err
+		}
+		defer ss.close()
+		src = ss
+	} else {
+		as := &archiveSource{zr: &zr.Reader, tables: make(map[string]*TableEntry)}
+		for _, entry := range manifest.Tables {
+			as.tables[entry.Name] = entry
+		}
+		src = as
+	}
+
+	result, err := copyTables(ctx, src, target, opts.Force)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped = skippedTables(manifest)
+
+	// 备份的列与当前表结构一致时，校验和应与备份时记录的一致
+	expected := make(map[string]*TableEntry, len(manifest.Tables))
+	for _, entry := range manifest.Tables {
+		expected[entry.Name] = entry
+	}
+	for _, table := range result.Tables {
+		entry, ok := expected[table.Name]
+		if !ok || len(entry.Columns) != len(store.FindTable(table.Name).Columns) {
+			continue
+		}
+		if entry.Rows != table.Rows || entry.Checksum != table.Checksum {
+			return result, fmt.Errorf("table %s does not match the backup: %d rows (%s), expected %d rows (%s)",
+				table.Name, table.Rows, table.Checksum, entry.Rows, entry.Checksum)
+		}
+	}
+	return result, nil
+}
+
+// skippedTables 返回备份中存在但当前版本中不存在的数据表
+func skippedTables(manifest *Manifest) []string {
+	var skipped []string
+	for _, entry := range manifest.Tables {
+		if store.FindTable(entry.Name) == nil {
+			skipped = append(skipped, entry.Name)
+		}
+	}
+	return skipped
+}
+
+// copyTables 将来源中的全部数据表按依赖顺序写入目标数据库（保留ID），并校验写入结果
+func copyTables(ctx context.Context, src source, target *store.Store, force bool) (*Result, error) {
+	conn, err := target.GetDB().Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	restoreForeignKeys := target.DisableForeignKeys(ctx, conn)
+	defer restoreForeignKeys()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if !force {
+		for _, table := range store.Tables {
+			count, err := target.CountRows(ctx, tx, table)
+			if err != nil {
+				return nil, err
+			}
+			if count > table.SeedRows {
+				return nil, fmt.Errorf("%w: table %s has %d rows", ErrTargetNotEmpty, table.Name, count)
+			}
+		}
+	}
+	// 清空目标数据库（未指定 Force 时只有迁移自动写入的行），来源中没有工作区时下次启动由迁移重新创建默认工作区
+	if err := target.ClearTables(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	type written struct {
+		table    *store.Table
+		columns  []store.Column
+		checksum *store.Checksum
+	}
+	var writes []written
+	for _, table := range store.Tables {
+		columns := src.columns(table)
+		if columns == nil {
+			continue
+		}
+		checksum, err := insertRows(ctx, src, target, tx, table, columns)
+		if err != nil {
+			return nil, err
+		}
+		writes = append(writes, written{table: table, columns: columns, checksum: checksum})
+	}
+
+	// 在同一事务中重新读取写入的数据，行数和校验和应与来源一致，否则回滚
+	result := &Result{}
+	for _, w := range writes {
+		checksum, err := target.TableChecksum(ctx, tx, w.table, w.columns)
+		if err != nil {
+			return nil, err
+		}
+		if checksum.Rows() != w.checksum.Rows() || checksum.String() != w.checksum.String() {
+			return nil, fmt.Errorf("verification failed for table %s: wrote %d rows (%s), read back %d rows (%s)",
+				w.table.Name, w.checksum.Rows(), w.checksum.String(), checksum.Rows(), checksum.String())
+		}
+		result.Tables = append(result.Tables, &TableResult{Name: w.table.Name, Rows: checksum.Rows(), Checksum: checksum.String()})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	if err := target.ResetSequences(ctx, conn); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// insertRows 将来源中一个数据表的全部行写入目标数据库，返回写入数据的校验和
+func insertRows(ctx context.Context, src source, target *store.Store, tx *sql.Tx, table *store.Table, columns []store.Column) (*store.Checksum, error) {
+	stmt, err := target.PrepareInsert(ctx, tx, table, columns)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	checksum := store.NewChecksum()
+	err = src.scan(ctx, table, columns, func(row []any) error {
+		encoded, err := store.EncodeRow(row)
+		if err != nil {
+			return err
+		}
+		checksum.Add(encoded)
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf("failed to insert into %s: %w", table.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checksum, nil
+}
+
+// extractSnapshot 将压缩包中的 SQLite 快照解压到临时文件
+func extractSnapshot(zr *zip.Reader, name string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "simple-notes-restore-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	f, err := zr.Open(name)
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	snapshot := filepath.Join(dir, snapshotPath)
+	out, err := os.Create(snapshot)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	if _, err := io.Copy(out, f); err != nil {
+		out.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to extract snapshot: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return snapshot, cleanup, nil
+}
+
+// storeSource 从数据库读取数据，所有数据表在同一个只读事务中读取
+type storeSource struct {
+	// store 来源数据库
+	store *store.Store
+	// tx 只读事务
+	tx *sql.Tx
+	// tableColumns 每个数据表在来源数据库中存在的列
+	tableColumns map[string][]store.Column
+	// owned 是否由 storeSource 负责关闭数据库
+	owned bool
+}
+
+// newStoreSource 以 s 作为数据来源，只读取来源数据库中实际存在的表和列
+func newStoreSource(ctx context.Context, s *store.Store, owned bool) (*storeSource, error) {
+	tableColumns := make(map[string][]store.Column, len(store.Tables))
+	for _, table := range store.Tables {
+		columns, err := s.ExistingColumns(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		if len(columns) > 0 {
+			tableColumns[table.Name] = columns
+		}
+	}
+	tx, err := s.BeginSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &storeSource{store: s, tx: tx, tableColumns: tableColumns, owned: owned}, nil
+}
+
+// columns 返回数据表在来源数据库中存在的列
+func (ss *storeSource) columns(table *store.Table) []store.Column {
+	return ss.tableColumns[table.Name]
+}
+
+// scan 读取数据表中的全部行
+func (ss *storeSource) scan(ctx context.Context, table *store.Table, columns []store.Column, fn func(row []any) error) error {
+	return ss.store.ScanRows(ctx, ss.tx, table, columns, fn)
+}
+
+// close 结束只读事务
+func (ss *storeSource) close() {
+	_ = ss.tx.Rollback()
+	if ss.owned {
+		ss.store.Close()
+	}
+}
+
+// archiveSource 从压缩包中的逻辑导出文件读取数据
+type archiveSource struct {
+	// zr 备份压缩包
+	zr *zip.Reader
+	// tables 清单中的数据表
+	tables map[string]*TableEntry
+}
+
+// columns 返回备份中与当前表结构都存在的列
+// 备份中多出的列会被忽略，缺少的列使用数据库的默认值
+func (as *archiveSource) columns(table *store.Table) []store.Column {
+	entry, ok := as.tables[table.Name]
+	if !ok {
+		return nil
+	}
+	saved := make(map[string]bool, len(entry.Columns))
+	for _, column := range entry.Columns {
+		saved[column.Name] = true
+	}
+	columns := []store.Column{}
+	for _, column := range table.Columns {
+		if saved[column.Name] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// scan 逐行解码逻辑导出文件，只保留需要的列
+func (as *archiveSource) scan(ctx context.Context, table *store.Table, columns []store.Column, fn func(row []any) error) error {
+	entry := as.tables[table.Name]
+	f, err := as.zr.Open(entry.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", entry.Path, err)
+	}
+	defer f.Close()
+
+	index := make(map[string]int, len(entry.Columns))
+	for i, column := range entry.Columns {
+		index[column.Name] = i
+	}
+	// 按当前的列类型解码备份中的值
+	savedColumns := make([]store.Column, len(entry.Columns))
+	for i, column := range entry.Columns {
+		savedColumns[i] = column
+		for _, current := range table.Columns {
+			if current.Name == column.Name {
+				savedColumns[i].Type = current.Type
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64<<10), maxRowSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		saved, err := store.DecodeRow(savedColumns, scanner.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", entry.Path, line, err)
+		}
+		row := make([]any, len(columns))
+		for i, column := range columns {
+			row[i] = saved[index[column.Name]]
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", entry.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,606 @@
+// Package dataexport 将用户（或整个实例）的数据导出为 zip 压缩包
+// 压缩包中每篇笔记是一个带 front matter 的 Markdown 文件，附件保存为原始文件，
+// 另附机器可读的 manifest.json；格式可以直接由 importer 重新导入
+package dataexport
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// ScopeUser 导出单个用户的数据
+	ScopeUser = "user"
+	// ScopeInstance 导出整个实例的数据
+	ScopeInstance = "instance"
+
+	// notesDir 笔记在压缩包中的目录
+	notesDir = "notes"
+	// attachmentsDir 附件在压缩包中的目录
+	attachmentsDir = "attachments"
+	// listPageSize 分批查询笔记时每批的数量
+	listPageSize = 200
+	// maxNameLength 笔记文件名中标题部分的最大长度（字符）
+	maxNameLength = 60
+)
+
+var (
+	// attachmentLinkPattern 正文中的站内附件地址
+	attachmentLinkPattern = regexp.MustCompile(`([(<"'])/file/attachments/(\d+)/[^\s)<>"']*`)
+	// noteLinkPattern 正文中的站内笔记地址
+	noteLinkPattern = regexp.MustCompile(`([(<"'])/note/(\d+)/?([#)>"'])`)
+)
+
+// Options 导出选项
+type Options struct {
+	// UserID 发起导出的用户ID
+	UserID uint
+	// Instance 是否导出整个实例（所有用户的笔记、附件和页面）
+	Instance bool
+}
+
+// Exporter 数据导出器
+type Exporter struct {
+	// store 数据存储实例
+	store *store.Store
+}
+
+// NewExporter 创建数据导出器
+func NewExporter(s *store.Store) *Exporter {
+	return &Exporter{store: s}
+}
+
+// Filename 返回导出压缩包的默认文件名
+func Filename(user *store.User, instance bool, now time.Time) string {
+	name := "instance"
+	if !instance {
+		name = fileSafeName(user.Username)
+		if name == "" {
+			name = strconv.FormatUint(uint64(user.ID), 10)
+		}
+	}
+	return fmt.Sprintf("simple-notes-%s-%s.zip", name, now.UTC().Format("20060102-150405"))
+}
+
+// export 一次导出的状态
+type export struct {
+	// store 数据存储实例
+	store *store.Store
+	// opts 导出选项
+	opts Options
+	// zw 压缩包写入器
+	zw *zip.Writer
+	// manifest 导出清单
+	manifest *Manifest
+	// usernames 用户ID到用户名的映射
+	usernames map[string]string
+	// users 导出范围内的用户
+	users []*store.User
+	// categories 分类ID到分类的映射
+	categories map[int64]*storepb.Category
+	// tags 标签ID到标签的映射
+	tags map[string]*storepb.Tag
+	// notes 导出的笔记
+	notes []*storepb.Note
+	// notePaths 笔记ID到 Markdown 文件路径的映射
+	notePaths map[int64]string
+	// attachmentPaths 已导出附件ID到压缩包路径的映射
+	attachmentPaths map[int64]string
+}
+
+// Export 将数据导出为 zip 压缩包写入 w，导出范围包括全部工作区
+// 写入过程中出错时压缩包不完整，调用方应丢弃已写入的内容
+func (x *Exporter) Export(ctx context.Context, w io.Writer, opts Options) error {
+	ctx = store.AllWorkspaces(ctx)
+	e := &export{
+		store:           x.store,
+		opts:            opts,
+		zw:              zip.NewWriter(w),
+		usernames:       make(map[string]string),
+		categories:      make(map[int64]*storepb.Category),
+		tags:            make(map[string]*storepb.Tag),
+		notePaths:       make(map[int64]string),
+		attachmentPaths: make(map[int64]string),
+	}
+	e.manifest = &Manifest{
+		Version:     FormatVersion,
+		ExportedAt:  formatTime(time.Now().Unix()),
+		Scope:       ScopeUser,
+		Categories:  []*CategoryEntry{},
+		Tags:        []*TagEntry{},
+		Notes:       []*NoteEntry{},
+		Attachments: []*AttachmentEntry{},
+	}
+	if opts.Instance {
+		e.manifest.Scope = ScopeInstance
+	}
+
+	if err := e.load(ctx); err != nil {
+		return err
+	}
+	// 先写入附件，笔记正文中只改写已导出附件的地址
+	if err := e.writeAttachments(ctx); err != nil {
+		return err
+	}
+	if err := e.writeNotes(ctx); err != nil {
+		return err
+	}
+	if opts.Instance {
+		if err := e.loadPages(ctx); err != nil {
+			return err
+		}
+	}
+	e.collectTerms()
+
+	if err := e.writeJSON(manifestPath, e.manifest); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
+
+// load 读取用户、分类、标签和笔记
+func (e *export) load(ctx context.Context) error {
+	user, err := e.store.GetUserByID(ctx, e.opts.UserID)
+	if err != nil || user == nil {
+		return fmt.Errorf("user not found: %d", e.opts.UserID)
+	}
+	e.manifest.User = userEntry(user)
+
+	if e.opts.Instance {
+		if e.users, err = e.store.ListUsers(ctx); err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+		// 按ID排序，保证导出结果稳定
+		sort.Slice(e.users, func(i, j int) bool { return e.users[i].ID < e.users[j].ID })
+		for _, u := range e.users {
+			e.manifest.Users = append(e.manifest.Users, userEntry(u))
+		}
+	} else {
+		e.users = []*store.User{user}
+	}
+	for _, u := range e.users {
+		e.usernames[strconv.FormatUint(uint64(u.ID), 10)] = u.Username
+	}
+
+	categories, err := e.store.ListCategories(ctx, &apiv1.ListCategoriesRequest{IncludeHidden: true})
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+	for _, category := range categories {
+		e.categories[category.Id] = category
+	}
+
+	tags, _, err := e.store.ListTags(ctx, &apiv1.ListTagsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	for _, tag := range tags {
+		e.tags[strconv.FormatInt(tag.Id, 10)] = tag
+	}
+
+	// 实例导出包含所有作者的笔记（包括已删除用户的笔记）
+	authorID := ""
+	if !e.opts.Instance {
+		authorID = strconv.FormatUint(uint64(e.opts.UserID), 10)
+	}
+	for page := int32(1); ; page++ {
+		notes, _, err := e.store.ListNotes(ctx, &store.ListNotesRequest{
+			Page:               page,
+			PageSize:           listPageSize,
+			SortBy:             "id",
+			IncludeUnpublished: true,
+			AuthorID:           authorID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list notes: %w", err)
+		}
+		e.notes = append(e.notes, notes...)
+		if len(notes) < listPageSize {
+			break
+		}
+	}
+
+	for _, note := range e.notes {
+		name := fileSafeName(note.Title)
+		if name == "" {
+			e.notePaths[note.Id] = path.Join(notesDir, fmt.Sprintf("%d.md", note.Id))
+		} else {
+			e.notePaths[note.Id] = path.Join(notesDir, fmt.Sprintf("%d-%s.md", note.Id, name))
+		}
+	}
+	return nil
+}
+
+// writeAttachments 逐个用户写入附件，避免一次性读取全部附件内容
+func (e *export) writeAttachments(ctx context.Context) error {
+	for _, u := range e.users {
+		userID := u.ID
+		attachments, err := e.store.ListAttachments(ctx, nil, &userID)
+		if err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := e.writeAttachment(attachment); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeAttachment 写入单个附件并记录到清单
+func (e *export) writeAttachment(attachment *storepb.Attachment) error {
+	filename := fileSafeName(path.Base(attachment.Filename))
+	if filename == "" {
+		filename = "file"
+	}
+	p := path.Join(attachmentsDir, strconv.FormatInt(attachment.Id, 10), filename)
+
+	f, err := e.create(p, attachment.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(attachment.Content); err != nil {
+		return fmt.Errorf("failed to write attachment %d: %w", attachment.Id, err)
+	}
+
+	digest := sha256.Sum256(attachment.Content)
+	entry := &AttachmentEntry{
+		ID:        attachment.Id,
+		Path:      p,
+		Filename:  attachment.Filename,
+		Type:      attachment.Type,
+		Size:      int64(len(attachment.Content)),
+		SHA256:    hex.EncodeToString(digest[:]),
+		Author:    e.usernames[attachment.AuthorId],
+		CreatedAt: formatTime(attachment.CreatedAt),
+	}
+	fmt.Sscanf(attachment.NoteId, "notes/%d", &entry.NoteID)
+	e.manifest.Attachments = append(e.manifest.Attachments, entry)
+	e.attachmentPaths[attachment.Id] = p
+	return nil
+}
+
+// frontMatter 导出笔记的 front matter，字段名与 importer 识别的字段一致
+type frontMatter struct {
+	Title        string   `yaml:"title"`
+	Author       string   `yaml:"author,omitempty"`
+	Date         string   `yaml:"date"`
+	Created      string   `yaml:"created"`
+	Updated      string   `yaml:"updated"`
+	Published    bool     `yaml:"published"`
+	Visibility   string   `yaml:"visibility"`
+	Category     string   `yaml:"category,omitempty"`
+	CategoryPath []string `yaml:"category_path,omitempty,flow"`
+	Tags         []string `yaml:"tags,omitempty,flow"`
+	Summary      string   `yaml:"summary,omitempty"`
+}
+
+// writeNotes 写入笔记 Markdown 文件并记录到清单
+func (e *export) writeNotes(ctx context.Context) error {
+	attachmentsByNote := make(map[int64][]int64)
+	for _, attachment := range e.manifest.Attachments {
+		if attachment.NoteID > 0 {
+			attachmentsByNote[attachment.NoteID] = append(attachmentsByNote[attachment.NoteID], attachment.ID)
+		}
+	}
+
+	for _, note := range e.notes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		entry := &NoteEntry{
+			ID:          note.Id,
+			Path:        e.notePaths[note.Id],
+			Title:       note.Title,
+			Author:      e.usernames[note.AuthorId],
+			Tags:        e.tagNames(note.TagIds),
+			Visibility:  visibilityName(note.Visibility),
+			Published:   note.Published,
+			Summary:     note.Summary,
+			CoverImage:  note.CoverImage,
+			ViewCount:   note.ViewCount,
+			CreatedAt:   formatTime(note.CreatedAt),
+			UpdatedAt:   formatTime(note.UpdatedAt),
+			PublishedAt: formatTime(note.PublishedAt),
+			Attachments: attachmentsByNote[note.Id],
+			Comments:    []*CommentEntry{},
+		}
+		if entry.Attachments == nil {
+			entry.Attachments = []int64{}
+		}
+		if categoryID, err := strconv.ParseInt(note.CategoryId, 10, 64); err == nil {
+			if _, ok := e.categories[categoryID]; ok {
+				entry.CategoryID = categoryID
+				entry.CategoryPath = e.categoryPath(categoryID)
+			}
+		}
+
+		comments, err := e.store.ListComments(ctx, note.Id)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			entry.Comments = append(entry.Comments, &CommentEntry{
+				ID:        comment.Id,
+				ParentID:  comment.ParentId,
+				Author:    comment.Author,
+				Email:     comment.Email,
+				Content:   comment.Content,
+				Approved:  comment.Approved,
+				CreatedAt: formatTime(comment.CreatedAt),
+			})
+		}
+
+		content, err := e.noteMarkdown(note, entry)
+		if err != nil {
+			return fmt.Errorf("failed to export note %d: %w", note.Id, err)
+		}
+		f, err := e.create(entry.Path, note.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(content); err != nil {
+			return fmt.Errorf("failed to write note %d: %w", note.Id, err)
+		}
+		e.manifest.Notes = append(e.manifest.Notes, entry)
+	}
+	return nil
+}
+
+// noteMarkdown 生成笔记的 Markdown 文件内容
+func (e *export) noteMarkdown(note *storepb.Note, entry *NoteEntry) ([]byte, error) {
+	fm := frontMatter{
+		Title:        note.Title,
+		Date:         entry.PublishedAt,
+		Created:      entry.CreatedAt,
+		Updated:      entry.UpdatedAt,
+		Published:    note.Published,
+		Visibility:   entry.Visibility,
+		CategoryPath: entry.CategoryPath,
+		Tags:         entry.Tags,
+		Summary:      note.Summary,
+	}
+	if fm.Date == "" {
+		fm.Date = entry.CreatedAt
+	}
+	if len(entry.CategoryPath) > 0 {
+		fm.Category = entry.CategoryPath[len(entry.CategoryPath)-1]
+	}
+	if e.opts.Instance {
+		fm.Author = entry.Author
+	}
+
+	meta, err := yaml.Marshal(&fm)
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	sb.Write(meta)
+	sb.WriteString("---\n")
+	sb.WriteString(e.rewriteLinks(note.Content))
+	if !strings.HasSuffix(note.Content, "\n") {
+		sb.WriteString("\n")
+	}
+	return []byte(sb.String()), nil
+}
+
+// rewriteLinks 将正文中指向已导出附件和笔记的站内地址改写为压缩包内的相对路径
+// 笔记文件位于 notes 目录，附件位于 attachments 目录
+func (e *export) rewriteLinks(content string) string {
+	content = attachmentLinkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := attachmentLinkPattern.FindStringSubmatch(match)
+		id, _ := strconv.ParseInt(parts[2], 10, 64)
+		p, ok := e.attachmentPaths[id]
+		if !ok {
+			return match
+		}
+		return parts[1] + "../" + attachmentsDir + "/" + parts[2] + "/" + url.PathEscape(path.Base(p))
+	})
+	return noteLinkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := noteLinkPattern.FindStringSubmatch(match)
+		id, _ := strconv.ParseInt(parts[2], 10, 64)
+		p, ok := e.notePaths[id]
+		if !ok {
+			return match
+		}
+		return parts[1] + url.PathEscape(path.Base(p)) + parts[3]
+	})
+}
+
+// loadPages 记录独立页面（仅实例导出）
+func (e *export) loadPages(ctx context.Context) error {
+	pages, err := e.store.ListPages(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+	e.manifest.Pages = []*PageEntry{}
+	for _, page := range pages {
+		e.manifest.Pages = append(e.manifest.Pages, &PageEntry{
+			ID:           page.Id,
+			Title:        page.Title,
+			Slug:         page.Slug,
+			Content:      page.Content,
+			Published:    page.Published,
+			InNavigation: page.InNavigation,
+			Order:        page.Order,
+			CreatedAt:    formatTime(page.CreatedAt),
+			UpdatedAt:    formatTime(page.UpdatedAt),
+		})
+	}
+	return nil
+}
+
+// collectTerms 记录分类和标签，用户导出只包含笔记使用的分类（及其上级分类）和标签
+func (e *export) collectTerms() {
+	usedCategories := make(map[int64]bool)
+	usedTags := make(map[string]bool)
+	for _, note := range e.notes {
+		for _, tagID := range note.TagIds {
+			usedTags[tagID] = true
+		}
+		categoryID, err := strconv.ParseInt(note.CategoryId, 10, 64)
+		for err == nil && categoryID > 0 && !usedCategories[categoryID] {
+			category, ok := e.categories[categoryID]
+			if !ok {
+				break
+			}
+			usedCategories[categoryID] = true
+			categoryID = category.ParentId
+		}
+	}
+
+	for _, category := range e.categories {
+		if !e.opts.Instance && !usedCategories[category.Id] {
+			continue
+		}
+		e.manifest.Categories = append(e.manifest.Categories, &CategoryEntry{
+			ID:          category.Id,
+			Name:        category.NameText,
+			Description: category.Description,
+			ParentID:    category.ParentId,
+			Path:        e.categoryPath(category.Id),
+			Order:       category.Order,
+			Visible:     category.Visible,
+		})
+	}
+	sort.Slice(e.manifest.Categories, func(i, j int) bool { return e.manifest.Categories[i].ID < e.manifest.Categories[j].ID })
+
+	for id, tag := range e.tags {
+		if !e.opts.Instance && !usedTags[id] {
+			continue
+		}
+		e.manifest.Tags = append(e.manifest.Tags, &TagEntry{ID: tag.Id, Name: tag.NameText, Description: tag.Description})
+	}
+	sort.Slice(e.manifest.Tags, func(i, j int) bool { return e.manifest.Tags[i].ID < e.manifest.Tags[j].ID })
+}
+
+// categoryPath 返回从顶级分类到指定分类的名称路径，遇到循环引用时停止
+func (e *export) categoryPath(categoryID int64) []string {
+	var names []string
+	seen := make(map[int64]bool)
+	for categoryID > 0 && !seen[categoryID] {
+		category, ok := e.categories[categoryID]
+		if !ok {
+			break
+		}
+		seen[categoryID] = true
+		names = append([]string{category.NameText}, names...)
+		categoryID = category.ParentId
+	}
+	return names
+}
+
+// tagNames 返回标签ID对应的名称，忽略已删除的标签
+func (e *export) tagNames(tagIDs []string) []string {
+	names := []string{}
+	for _, id := range tagIDs {
+		if tag, ok := e.tags[id]; ok {
+			names = append(names, tag.NameText)
+		}
+	}
+	return names
+}
+
+// create 在压缩包中创建文件
+func (e *export) create(name string, modified int64) (io.Writer, error) {
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+	if modified > 0 {
+		header.Modified = time.Unix(modified, 0)
+	}
+	f, err := e.zw.CreateHeader(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// writeJSON 将数据以 JSON 格式写入压缩包
+func (e *export) writeJSON(name string, v any) error {
+	f, err := e.create(name, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// userEntry 将用户转换为清单中的用户信息（不包含密码哈希）
+func userEntry(user *store.User) *UserEntry {
+	return &UserEntry{
+		ID:        user.ID,
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		Avatar:    user.Avatar,
+		Bio:       user.Bio,
+		Role:      string(user.Role),
+		CreatedAt: formatTime(user.CreatedAt.Unix()),
+	}
+}
+
+// visibilityName 返回可见性在 front matter 中的名称
+func visibilityName(visibility storepb.NoteVisibility) string {
+	if visibility == storepb.NoteVisibility_NOTE_VISIBILITY_PUBLIC {
+		return "public"
+	}
+	return "private"
+}
+
+// formatTime 将 Unix 时间戳格式化为 RFC3339（UTC），零值返回空字符串
+func formatTime(unix int64) string {
+	if unix <= 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+// fileSafeName 将名称转换为可以安全用作文件名的形式
+// 保留字母、数字（包括中文）、点、连字符和下划线，其余字符替换为连字符
+func fileSafeName(name string) string {
+	var sb strings.Builder
+	count := 0
+	lastDash := false
+	for _, r := range strings.TrimSpace(name) {
+		if count >= maxNameLength {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || r == '-' {
+			sb.WriteRune(r)
+			lastDash = r == '-'
+		} else if !lastDash {
+			sb.WriteRune('-')
+			lastDash = true
+		}
+		count++
+	}
+	return strings.Trim(sb.String(), "-.")
+}
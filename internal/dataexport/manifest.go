@@ -0,0 +1,177 @@
+package dataexport
+
+// FormatVersion 导出格式版本，格式发生不兼容变化时递增
+const FormatVersion = 1
+
+// manifestPath 清单文件在压缩包中的路径
+const manifestPath = "manifest.json"
+
+// Manifest 导出清单（manifest.json），包含全部导出数据的机器可读描述
+type Manifest struct {
+	// Version 导出格式版本
+	Version int `json:"version"`
+	// ExportedAt 导出时间（RFC3339）
+	ExportedAt string `json:"exported_at"`
+	// Scope 导出范围：user（单个用户）或 instance（整个实例）
+	Scope string `json:"scope"`
+	// User 发起导出的用户
+	User *UserEntry `json:"user"`
+	// Users 全部用户（仅实例导出，不包含密码哈希）
+	Users []*UserEntry `json:"users,omitempty"`
+	// Categories 导出笔记使用的分类（实例导出时为全部分类）
+	Categories []*CategoryEntry `json:"categories"`
+	// Tags 导出笔记使用的标签（实例导出时为全部标签）
+	Tags []*TagEntry `json:"tags"`
+	// Notes 笔记
+	Notes []*NoteEntry `json:"notes"`
+	// Attachments 附件
+	Attachments []*AttachmentEntry `json:"attachments"`
+	// Pages 独立页面（仅实例导出）
+	Pages []*PageEntry `json:"pages,omitempty"`
+}
+
+// UserEntry 用户信息
+type UserEntry struct {
+	// ID 用户ID
+	ID uint `json:"id"`
+	// Username 用户名
+	Username string `json:"username"`
+	// Nickname 昵称
+	Nickname string `json:"nickname,omitempty"`
+	// Avatar 头像URL
+	Avatar string `json:"avatar,omitempty"`
+	// Bio 个人简介
+	Bio string `json:"bio,omitempty"`
+	// Role 用户角色
+	Role string `json:"role"`
+	// CreatedAt 创建时间（RFC3339）
+	CreatedAt string `json:"created_at"`
+}
+
+// CategoryEntry 分类信息
+type CategoryEntry struct {
+	// ID 分类ID
+	ID int64 `json:"id"`
+	// Name 分类名称
+	Name string `json:"name"`
+	// Description 描述
+	Description string `json:"description,omitempty"`
+	// ParentID 父分类ID，顶级分类为 0
+	ParentID int64 `json:"parent_id,omitempty"`
+	// Path 从顶级分类到该分类的名称路径
+	Path []string `json:"path"`
+	// Order 排序顺序
+	Order int32 `json:"order"`
+	// Visible 是否可见
+	Visible bool `json:"visible"`
+}
+
+// TagEntry 标签信息
+type TagEntry struct {
+	// ID 标签ID
+	ID int64 `json:"id"`
+	// Name 标签名称
+	Name string `json:"name"`
+	// Description 描述
+	Description string `json:"description,omitempty"`
+}
+
+// NoteEntry 笔记信息
+type NoteEntry struct {
+	// ID 笔记ID
+	ID int64 `json:"id"`
+	// Path Markdown 文件在压缩包中的路径
+	Path string `json:"path"`
+	// Title 标题
+	Title string `json:"title"`
+	// Author 作者用户名
+	Author string `json:"author"`
+	// CategoryID 分类ID，未分类时为 0
+	CategoryID int64 `json:"category_id,omitempty"`
+	// CategoryPath 分类路径
+	CategoryPath []string `json:"category_path,omitempty"`
+	// Tags 标签名称
+	Tags []string `json:"tags"`
+	// Visibility 可见性（public/private）
+	Visibility string `json:"visibility"`
+	// Published 是否已发布
+	Published bool `json:"published"`
+	// Summary 摘要
+	Summary string `json:"summary,omitempty"`
+	// CoverImage 封面图片URL
+	CoverImage string `json:"cover_image,omitempty"`
+	// ViewCount 浏览量
+	ViewCount int32 `json:"view_count"`
+	// CreatedAt 创建时间（RFC3339）
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新时间（RFC3339）
+	UpdatedAt string `json:"updated_at"`
+	// PublishedAt 发布时间（RFC3339），未发布时为空
+	PublishedAt string `json:"published_at,omitempty"`
+	// Attachments 关联的附件ID
+	Attachments []int64 `json:"attachments"`
+	// Comments 评论（包含未审核的评论）
+	Comments []*CommentEntry `json:"comments"`
+}
+
+// CommentEntry 评论信息
+type CommentEntry struct {
+	// ID 评论ID
+	ID int64 `json:"id"`
+	// ParentID 父评论ID，顶级评论为 0
+	ParentID int64 `json:"parent_id,omitempty"`
+	// Author 评论作者名称
+	Author string `json:"author"`
+	// Email 评论作者邮箱
+	Email string `json:"email"`
+	// Content 评论内容
+	Content string `json:"content"`
+	// Approved 是否已审核
+	Approved bool `json:"approved"`
+	// CreatedAt 创建时间（RFC3339）
+	CreatedAt string `json:"created_at"`
+}
+
+// AttachmentEntry 附件信息
+type AttachmentEntry struct {
+	// ID 附件ID
+	ID int64 `json:"id"`
+	// Path 附件在压缩包中的路径
+	Path string `json:"path"`
+	// Filename 原始文件名
+	Filename string `json:"filename"`
+	// Type MIME 类型
+	Type string `json:"type"`
+	// Size 文件大小（字节）
+	Size int64 `json:"size"`
+	// SHA256 文件内容的 SHA-256 摘要（十六进制）
+	SHA256 string `json:"sha256"`
+	// NoteID 关联的笔记ID，未关联时为 0
+	NoteID int64 `json:"note_id,omitempty"`
+	// Author 上传者用户名
+	Author string `json:"author"`
+	// CreatedAt 上传时间（RFC3339）
+	CreatedAt string `json:"created_at"`
+}
+
+// PageEntry 独立页面信息
+type PageEntry struct {
+	// ID 页面ID
+	ID int64 `json:"id"`
+	// Title 标题
+	Title string `json:"title"`
+	// Slug URL友好的标识符
+	Slug string `json:"slug"`
+	// Content 内容（Markdown）
+	Content string `json:"content"`
+	// Published 是否已发布
+	Published bool `json:"published"`
+	// InNavigation 是否显示在导航栏
+	InNavigation bool `json:"in_navigation"`
+	// Order 排序顺序
+	Order int32 `json:"order"`
+	// CreatedAt 创建时间（RFC3339）
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新时间（RFC3339）
+	UpdatedAt string `json:"updated_at"`
+}
@@ -0,0 +1,310 @@
+// filter 包解析列表接口的过滤表达式
+// 语法是 CEL 的一个小子集，例如：
+//
+//	tag in ["go", "db"] && created_at > "2026-01-01" && (visibility == "PRIVATE" || author == "users/3")
+//
+// 支持 &&、||、!、括号，比较运算符 ==、!=、<、<=、>、>=、in，以及 field.contains("text")。
+// 解析结果是与具体数据库无关的语法树，由调用方根据字段含义编译为参数化的 SQL
+package filter
+
+import (
+	"fmt"
+)
+
+// maxDepth 表达式的最大嵌套层数，避免恶意输入导致过深的递归
+const maxDepth = 32
+
+// Expr 过滤表达式节点
+type Expr interface {
+	expr()
+}
+
+// And 逻辑与
+type And struct {
+	Left, Right Expr
+}
+
+// Or 逻辑或
+type Or struct {
+	Left, Right Expr
+}
+
+// Not 逻辑非
+type Not struct {
+	Expr Expr
+}
+
+// Comparison 字段比较，例如 created_at > "2026-01-01"、tag in ["go"]、title.contains("go")
+type Comparison struct {
+	// Field 字段名称
+	Field string
+	// Op 运算符：==、!=、<、<=、>、>=、in、contains
+	Op string
+	// Values 比较的值，in 运算符可以有多个值，其他运算符只有一个值
+	Values []Value
+}
+
+func (And) expr()        {}
+func (Or) expr()         {}
+func (Not) expr()        {}
+func (Comparison) expr() {}
+
+// ValueKind 值的类型
+type ValueKind int
+
+const (
+	// StringValue 字符串
+	StringValue ValueKind = iota
+	// NumberValue 整数
+	NumberValue
+	// BoolValue 布尔值
+	BoolValue
+)
+
+// Value 表达式中的字面量
+type Value struct {
+	// Kind 值的类型
+	Kind ValueKind
+	// String 字符串值
+	String string
+	// Number 整数值
+	Number int64
+	// Bool 布尔值
+	Bool bool
+}
+
+// Error 过滤表达式的语法错误
+type Error struct {
+	// Pos 出错位置（字节偏移）
+	Pos int
+	// Msg 错误信息
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// Parse 解析过滤表达式，空字符串返回 nil
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	return expr, nil
+}
+
+// parser 递归下降解析器
+type parser struct {
+	// tokens 词法单元，最后一个为 tokenEOF
+	tokens []token
+	// pos 当前位置
+	pos int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// expect 读取指定类型的词法单元，类型不符时返回错误
+func (p *parser) expect(kind tokenKind, text string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind || (text != "" && tok.text != text) {
+		want := text
+		if want == "" {
+			want = kind.String()
+		}
+		return tok, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected %s, got %q", want, tok.text)}
+	}
+	return tok, nil
+}
+
+// parseOr or := and ("||" and)*
+func (p *parser) parseOr(depth int) (Expr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd and := unary ("&&" unary)*
+func (p *parser) parseAnd(depth int) (Expr, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseUnary unary := "!" unary | "(" or ")" | comparison
+func (p *parser) parseUnary(depth int) (Expr, error) {
+	if depth > maxDepth {
+		return nil, &Error{Pos: p.peek().pos, Msg: "expression is nested too deeply"}
+	}
+
+	tok := p.peek()
+	switch {
+	case tok.kind == tokenOperator && tok.text == "!":
+		p.next()
+		expr, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	case tok.kind == tokenPunct && tok.text == "(":
+		p.next()
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenPunct, ")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison comparison := ident op value | ident "in" list | ident "." "contains" "(" string ")"
+func (p *parser) parseComparison() (Expr, error) {
+	field, err := p.expect(tokenIdent, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.next()
+	switch {
+	case tok.kind == tokenPunct && tok.text == ".":
+		if _, err := p.expect(tokenIdent, "contains"); err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenPunct, "("); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenPunct, ")"); err != nil {
+			return nil, err
+		}
+		return Comparison{Field: field.text, Op: "contains", Values: []Value{value}}, nil
+	case tok.kind == tokenIdent && tok.text == "in":
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return Comparison{Field: field.text, Op: "in", Values: values}, nil
+	case tok.kind == tokenOperator && isComparisonOperator(tok.text):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return Comparison{Field: field.text, Op: tok.text, Values: []Value{value}}, nil
+	}
+	return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected comparison operator after %q, got %q", field.text, tok.text)}
+}
+
+// parseList list := "[" (value ("," value)*)? "]"
+func (p *parser) parseList() ([]Value, error) {
+	if _, err := p.expect(tokenPunct, "["); err != nil {
+		return nil, err
+	}
+	values := []Value{}
+	if p.peek().kind == tokenPunct && p.peek().text == "]" {
+		p.next()
+		return values, nil
+	}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		tok := p.next()
+		if tok.kind == tokenPunct && tok.text == "]" {
+			return values, nil
+		}
+		if tok.kind != tokenPunct || tok.text != "," {
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected \",\" or \"]\", got %q", tok.text)}
+		}
+	}
+}
+
+// parseValue value := string | number | true | false
+func (p *parser) parseValue() (Value, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return Value{Kind: StringValue, String: tok.text}, nil
+	case tokenNumber:
+		return Value{Kind: NumberValue, Number: tok.number}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return Value{Kind: BoolValue, Bool: true}, nil
+		case "false":
+			return Value{Kind: BoolValue, Bool: false}, nil
+		}
+	}
+	return Value{}, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected value, got %q", tok.text)}
+}
+
+// isComparisonOperator 判断是否为比较运算符
+func isComparisonOperator(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// Literal 返回值的字面量形式，用于错误信息
+func (v Value) Literal() string {
+	switch v.Kind {
+	case NumberValue:
+		return fmt.Sprintf("%d", v.Number)
+	case BoolValue:
+		return fmt.Sprintf("%t", v.Bool)
+	default:
+		return fmt.Sprintf("%q", v.String)
+	}
+}
@@ -0,0 +1,148 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind 词法单元的类型
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenPunct
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of input"
+	case tokenIdent:
+		return "identifier"
+	case tokenString:
+		return "string"
+	case tokenNumber:
+		return "number"
+	case tokenOperator:
+		return "operator"
+	default:
+		return "punctuation"
+	}
+}
+
+// token 词法单元
+type token struct {
+	// kind 类型
+	kind tokenKind
+	// text 文本，字符串为去掉引号并处理转义后的内容
+	text string
+	// number 整数值
+	number int64
+	// pos 在输入中的字节偏移
+	pos int
+}
+
+// lex 将过滤表达式切分为词法单元，最后追加一个 tokenEOF
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '"' || r == '\'':
+			text, end, err := lexString(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = end
+		case r == '-' || (r >= '0' && r <= '9'):
+			end := i + 1
+			for end < len(input) && input[end] >= '0' && input[end] <= '9' {
+				end++
+			}
+			number, err := strconv.ParseInt(input[i:end], 10, 64)
+			if err != nil {
+				return nil, &Error{Pos: i, Msg: fmt.Sprintf("invalid number %q", input[i:end])}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: input[i:end], number: number, pos: i})
+			i = end
+		case r == '_' || unicode.IsLetter(r):
+			end := i
+			for end < len(input) {
+				r, size := utf8.DecodeRuneInString(input[end:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: input[i:end], pos: i})
+			i = end
+		default:
+			if op := lexOperator(input[i:]); op != "" {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+				i += len(op)
+				continue
+			}
+			if strings.ContainsRune("()[],.", r) {
+				tokens = append(tokens, token{kind: tokenPunct, text: string(r), pos: i})
+				i += size
+				continue
+			}
+			return nil, &Error{Pos: i, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+// operators 运算符，双字符运算符在前，保证优先匹配
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!"}
+
+// lexOperator 返回输入开头的运算符，不是运算符时返回空字符串
+func lexOperator(input string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(input, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// lexString 读取从 start 开始的字符串字面量，支持 \" \' \\ \n \t 转义，返回内容和结束位置
+func lexString(input string, start int) (string, int, error) {
+	quote := input[start]
+	var b strings.Builder
+	for i := start + 1; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\':
+			i++
+			if i >= len(input) {
+				return "", 0, &Error{Pos: start, Msg: "unterminated string"}
+			}
+			switch input[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\'', '\\':
+				b.WriteByte(input[i])
+			default:
+				return "", 0, &Error{Pos: i - 1, Msg: fmt.Sprintf("invalid escape \\%c", input[i])}
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, &Error{Pos: start, Msg: "unterminated string"}
+}
@@ -0,0 +1,220 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// frontMatter 从 front matter 中解析出的笔记属性
+type frontMatter struct {
+	// Title 标题
+	Title string
+	// Tags 标签名称
+	Tags []string
+	// Category 分类名称
+	Category string
+	// Published 是否发布（未设置时为 nil）
+	Published *bool
+	// Date 发布时间（未设置时为零值）
+	Date time.Time
+	// Summary 摘要
+	Summary string
+	// Visibility 可见性（public/private，未设置时为空）
+	Visibility string
+}
+
+// dateLayouts 支持的日期格式
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// splitFrontMatter 拆分 front matter 和正文
+// 支持 YAML（以 --- 包围）和 TOML（以 +++ 包围），没有 front matter 时返回 nil
+func splitFrontMatter(content []byte) (meta map[string]any, body []byte, err error) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+
+	var delimiter string
+	switch {
+	case bytes.HasPrefix(content, []byte("---\n")):
+		delimiter = "---"
+	case bytes.HasPrefix(content, []byte("+++\n")):
+		delimiter = "+++"
+	default:
+		return nil, content, nil
+	}
+
+	rest := content[len(delimiter)+1:]
+	var raw []byte
+	if bytes.HasPrefix(rest, []byte(delimiter+"\n")) || bytes.Equal(rest, []byte(delimiter)) {
+		// 空的 front matter
+		raw, body = nil, bytes.TrimPrefix(rest[len(delimiter):], []byte("\n"))
+	} else {
+		end := bytes.Index(rest, []byte("\n"+delimiter+"\n"))
+		if end < 0 {
+			if !bytes.HasSuffix(rest, []byte("\n"+delimiter)) {
+				return nil, content, nil
+			}
+			end = len(rest) - len(delimiter) - 1
+		}
+		raw = rest[:end]
+		body = rest[min(end+len(delimiter)+2, len(rest)):]
+	}
+
+	meta = map[string]any{}
+	if delimiter == "+++" {
+		err = toml.Unmarshal(raw, &meta)
+	} else {
+		err = yaml.Unmarshal(raw, &meta)
+	}
+	if err != nil {
+		return nil, body, err
+	}
+	return meta, body, nil
+}
+
+// parseFrontMatter 将 front matter 转换为笔记属性
+// 兼容 Obsidian、Hugo 和 Jekyll 的常用字段
+func parseFrontMatter(meta map[string]any) *frontMatter {
+	fm := &frontMatter{}
+	values := make(map[string]any, len(meta))
+	for key, value := range meta {
+		values[strings.ToLower(key)] = value
+	}
+
+	fm.Title = stringValue(values["title"])
+	fm.Tags = stringList(values["tags"])
+	if fm.Tags == nil {
+		fm.Tags = stringList(values["tag"])
+	}
+	for i, tag := range fm.Tags {
+		fm.Tags[i] = strings.TrimPrefix(tag, "#")
+	}
+	if category := stringValue(values["category"]); category != "" {
+		fm.Category = category
+	} else if categories := stringList(values["categories"]); len(categories) > 0 {
+		fm.Category = categories[0]
+	}
+
+	// Jekyll 使用 published: false，Hugo 使用 draft: true
+	if published, ok := boolValue(values["published"]); ok {
+		fm.Published = &published
+	} else if draft, ok := boolValue(values["draft"]); ok {
+		published := !draft
+		fm.Published = &published
+	}
+
+	for _, key := range []string{"date", "publishdate", "created"} {
+		if date, ok := timeValue(values[key]); ok {
+			fm.Date = date
+			break
+		}
+	}
+	for _, key := range []string{"summary", "description", "excerpt"} {
+		if summary := stringValue(values[key]); summary != "" {
+			fm.Summary = summary
+			break
+		}
+	}
+	fm.Visibility = strings.ToLower(stringValue(values["visibility"]))
+	return fm
+}
+
+// stringValue 将 front matter 中的值转换为字符串
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case fmt.Stringer:
+		return strings.TrimSpace(v.String())
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+// stringList 将 front matter 中的列表或以逗号（或空格）分隔的字符串转换为字符串列表
+func stringList(value any) []string {
+	var items []string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		for _, item := range v {
+			items = append(items, stringValue(item))
+		}
+	case []string:
+		items = v
+	case string:
+		if strings.Contains(v, ",") {
+			items = strings.Split(v, ",")
+		} else {
+			items = strings.Fields(v)
+		}
+	default:
+		items = []string{stringValue(v)}
+	}
+
+	var result []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// boolValue 将 front matter 中的值转换为布尔值
+func boolValue(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+// timeValue 将 front matter 中的值转换为时间
+func timeValue(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, false
+	case time.Time:
+		return v, !v.IsZero()
+	case toml.LocalDate:
+		return v.AsTime(time.Local), true
+	case toml.LocalDateTime:
+		return v.AsTime(time.Local), true
+	default:
+		return parseTime(stringValue(v))
+	}
+}
+
+// parseTime 按支持的日期格式解析时间
+func parseTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,433 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// whitespacePattern 连续的空白字符
+	whitespacePattern = regexp.MustCompile(`[ \t\r\n\f]+`)
+	// lineStartPattern 段落开头会被解析为 Markdown 语法的字符
+	lineStartPattern = regexp.MustCompile(`(?m)^(#|>|[-+] |\d+\. )`)
+	// blankLinesPattern 三个及以上的连续换行
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+	// markdownEscaper 转义文本中的 Markdown 特殊字符
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`,
+	)
+)
+
+// blockElements 作为块级内容处理的元素
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true,
+	atom.Footer: true, atom.Main: true, atom.Aside: true, atom.Nav: true, atom.Figure: true,
+	atom.Figcaption: true, atom.Blockquote: true, atom.Pre: true, atom.Hr: true, atom.Table: true,
+	atom.Ul: true, atom.Ol: true, atom.Dl: true, atom.Dt: true, atom.Dd: true, atom.Address: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Iframe: true, atom.Video: true, atom.Audio: true, atom.Script: true, atom.Style: true,
+	atom.Noscript: true, atom.Form: true, atom.Details: true, atom.Summary: true,
+}
+
+// htmlToMarkdown 将 HTML 转换为 Markdown
+// 支持段落、标题、列表、引用、代码块、表格、链接和图片，其余元素只保留文本内容
+func htmlToMarkdown(content string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{Type: html.ElementNode, DataAtom: atom.Body, Data: "body"})
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+	root := &html.Node{Type: html.ElementNode, DataAtom: atom.Body, Data: "body"}
+	for _, node := range nodes {
+		root.AppendChild(node)
+	}
+
+	markdown := blankLinesPattern.ReplaceAllString(renderBlocks(root), "\n\n")
+	return strings.TrimSpace(markdown)
+}
+
+// mdBlock 渲染后的块级内容
+type mdBlock struct {
+	// text Markdown 文本
+	text string
+	// list 是否为列表（列表项中紧跟在文本后的子列表不需要空行分隔）
+	list bool
+}
+
+// renderBlocks 渲染容器元素的子节点，连续的行内内容合并为一个段落，块之间以空行分隔
+func renderBlocks(n *html.Node) string {
+	var blocks []mdBlock
+	var inline strings.Builder
+	flush := func() {
+		if text := cleanInline(inline.String()); text != "" {
+			blocks = append(blocks, mdBlock{text: text})
+		}
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && blockElements[child.DataAtom] {
+			flush()
+			if text := renderBlock(child); text != "" {
+				blocks = append(blocks, mdBlock{text: text, list: child.DataAtom == atom.Ul || child.DataAtom == atom.Ol})
+			}
+			continue
+		}
+		inline.WriteString(renderInline(child))
+	}
+	flush()
+
+	var sb strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			if block.list && !blocks[i-1].list && n.DataAtom == atom.Li {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(block.text)
+	}
+	return sb.String()
+}
+
+// renderBlock 渲染块级元素
+func renderBlock(n *html.Node) string {
+	switch n.DataAtom {
+	case atom.P, atom.Dt, atom.Dd, atom.Address, atom.Summary:
+		return cleanInline(renderChildren(n))
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := strings.ReplaceAll(cleanInline(renderChildren(n)), "\\\n", " ")
+		if text == "" {
+			return ""
+		}
+		level := int(n.Data[1] - '0')
+		return strings.Repeat("#", level) + " " + text
+	case atom.Hr:
+		return "---"
+	case atom.Pre:
+		return renderCodeBlock(n)
+	case atom.Blockquote:
+		return prefixLines(renderBlocks(n), "> ", ">")
+	case atom.Ul, atom.Ol:
+		return renderList(n)
+	case atom.Table:
+		return renderTable(n)
+	case atom.Iframe, atom.Video, atom.Audio:
+		// 嵌入的视频等内容保留为链接
+		src := attr(n, "src")
+		if src == "" {
+			for child := n.FirstChild; child != nil && src == ""; child = child.NextSibling {
+				if child.DataAtom == atom.Source {
+					src = attr(child, "src")
+				}
+			}
+		}
+		if src == "" {
+			return ""
+		}
+		return fmt.Sprintf("[%s](%s)", n.Data, src)
+	case atom.Script, atom.Style, atom.Noscript, atom.Form:
+		return ""
+	default:
+		return renderBlocks(n)
+	}
+}
+
+// renderInline 渲染行内节点
+func renderInline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return markdownEscaper.Replace(whitespacePattern.ReplaceAllString(n.Data, " "))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\\\n"
+	case atom.Strong, atom.B:
+		return wrapInline(renderChildren(n), "**")
+	case atom.Em, atom.I, atom.Cite:
+		return wrapInline(renderChildren(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline(renderChildren(n), "~~")
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		return renderInlineCode(textContent(n))
+	case atom.A:
+		text := strings.TrimSpace(renderChildren(n))
+		href := attr(n, "href")
+		if href == "" || text == "" {
+			return text
+		}
+		return fmt.Sprintf("[%s](%s%s)", text, linkDestination(href), linkTitle(attr(n, "title")))
+	case atom.Img:
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		alt := markdownEscaper.Replace(whitespacePattern.ReplaceAllString(attr(n, "alt"), " "))
+		return fmt.Sprintf("![%s](%s%s)", alt, linkDestination(src), linkTitle(attr(n, "title")))
+	case atom.Script, atom.Style:
+		return ""
+	default:
+		return renderChildren(n)
+	}
+}
+
+// renderChildren 以行内方式渲染所有子节点
+func renderChildren(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && blockElements[child.DataAtom] {
+			// 行内元素中的块级元素（例如链接中的 div）按段落处理
+			sb.WriteString("\n\n" + renderBlock(child) + "\n\n")
+			continue
+		}
+		sb.WriteString(renderInline(child))
+	}
+	return sb.String()
+}
+
+// cleanInline 整理段落中的空白：合并空格、去掉换行两侧的空格和末尾的硬换行
+func cleanInline(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(strings.Join(strings.Fields(line), " "))
+	}
+	text = strings.Join(lines, "\n")
+	text = blankLinesPattern.ReplaceAllString(text, "\n\n")
+	text = strings.TrimSpace(text)
+	for strings.HasSuffix(text, "\\") && !strings.HasSuffix(text, "\\\\") {
+		text = strings.TrimSpace(strings.TrimSuffix(text, "\\"))
+	}
+	return lineStartPattern.ReplaceAllString(text, `\$1`)
+}
+
+// wrapInline 用强调标记包围行内内容，首尾空白移到标记之外
+func wrapInline(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := text[:strings.Index(text, trimmed)]
+	end := text[len(start)+len(trimmed):]
+	return start + marker + trimmed + marker + end
+}
+
+// renderInlineCode 渲染行内代码，内容中有反引号时使用更长的反引号
+func renderInlineCode(code string) string {
+	code = whitespacePattern.ReplaceAllString(code, " ")
+	if strings.TrimSpace(code) == "" {
+		return code
+	}
+	fence := strings.Repeat("`", longestRun(code, '`')+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// renderCodeBlock 渲染代码块，从 class 中识别语言（language-go、lang-go、brush: go）
+func renderCodeBlock(n *html.Node) string {
+	lang := codeLanguage(attr(n, "class"))
+	for child := n.FirstChild; child != nil && lang == ""; child = child.NextSibling {
+		if child.DataAtom == atom.Code {
+			lang = codeLanguage(attr(child, "class"))
+		}
+	}
+
+	code := strings.Trim(textContent(n), "\n")
+	fence := "```"
+	if longestRun(code, '`') >= 3 {
+		fence = strings.Repeat("`", longestRun(code, '`')+1)
+	}
+	return fence + lang + "\n" + code + "\n" + fence
+}
+
+// codeLanguage 从 class 属性中识别代码语言
+func codeLanguage(class string) string {
+	for _, field := range strings.Fields(strings.ReplaceAll(class, ";", " ")) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if strings.HasPrefix(field, prefix) {
+				return strings.TrimPrefix(field, prefix)
+			}
+		}
+	}
+	// SyntaxHighlighter 插件：class="brush: go; gutter: false"
+	if fields := strings.Fields(class); len(fields) >= 2 && fields[0] == "brush:" {
+		return strings.TrimSuffix(fields[1], ";")
+	}
+	return ""
+}
+
+// renderList 渲染有序或无序列表，子列表缩进到列表项内容的位置
+func renderList(n *html.Node) string {
+	ordered := n.DataAtom == atom.Ol
+	index := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil && ordered {
+		index = start
+	}
+
+	var items []string
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", index)
+			index++
+		}
+		content := renderBlocks(child)
+		items = append(items, marker+prefixRestLines(content, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// renderTable 渲染 GFM 表格，第一行作为表头
+func renderTable(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			switch child.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(child)
+			case atom.Tr:
+				var cells []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.DataAtom == atom.Td || cell.DataAtom == atom.Th {
+						text := strings.ReplaceAll(cleanInline(renderChildren(cell)), "\\\n", " ")
+						text = strings.ReplaceAll(strings.ReplaceAll(text, "\n", " "), "|", `\|`)
+						cells = append(cells, text)
+					}
+				}
+				rows = append(rows, cells)
+			}
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for i := 0; i < columns; i++ {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// prefixLines 为每一行添加前缀，空行使用 emptyPrefix
+func prefixLines(text, prefix, emptyPrefix string) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prefixRestLines 为除第一行以外的非空行添加缩进
+func prefixRestLines(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// textContent 返回节点的纯文本内容，<br> 转换为换行
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		switch {
+		case node.Type == html.TextNode:
+			sb.WriteString(node.Data)
+		case node.Type == html.ElementNode && node.DataAtom == atom.Br:
+			sb.WriteString("\n")
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// linkDestination 返回链接地址，包含空格或括号时使用尖括号包围
+func linkDestination(href string) string {
+	if strings.ContainsAny(href, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(href) + ">"
+	}
+	return href
+}
+
+// linkTitle 返回链接标题部分
+func linkTitle(title string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return ""
+	}
+	return ` "` + strings.ReplaceAll(title, `"`, `\"`) + `"`
+}
+
+// attr 返回元素的属性值
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// longestRun 返回字符串中某个字符连续出现的最大次数
+func longestRun(s string, c byte) int {
+	longest, current := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
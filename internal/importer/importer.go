@@ -0,0 +1,279 @@
+// Package importer 从其他系统批量导入笔记
+package importer
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// Status 单篇笔记的导入结果
+type Status string
+
+const (
+	// StatusCreated 已创建
+	StatusCreated Status = "created"
+	// StatusWouldCreate 试运行时表示将会创建
+	StatusWouldCreate Status = "would_create"
+	// StatusSkipped 已跳过（例如已存在同名笔记）
+	StatusSkipped Status = "skipped"
+	// StatusFailed 导入失败
+	StatusFailed Status = "failed"
+)
+
+// IssueKind 导入问题的类型
+type IssueKind string
+
+const (
+	// IssueDuplicate 已存在同名笔记
+	IssueDuplicate IssueKind = "duplicate"
+	// IssueConflict 导入内容中存在同名笔记
+	IssueConflict IssueKind = "conflict"
+	// IssueMissingAttachment 引用的图片或文件不存在
+	IssueMissingAttachment IssueKind = "missing_attachment"
+	// IssueUnresolvedLink 无法解析的笔记链接
+	IssueUnresolvedLink IssueKind = "unresolved_link"
+	// IssueInvalidFrontMatter front matter 格式错误
+	IssueInvalidFrontMatter IssueKind = "invalid_front_matter"
+)
+
+// Options 导入选项
+type Options struct {
+	// AuthorID 导入笔记的作者ID
+	AuthorID uint
+	// DryRun 是否为试运行，试运行只生成报告，不写入任何数据
+	DryRun bool
+	// Visibility 未在 front matter 中指定可见性时使用的默认可见性，未指定时为私有
+	Visibility storepb.NoteVisibility
+}
+
+// NoteResult 单篇笔记的导入结果
+type NoteResult struct {
+	// Path 源文件路径
+	Path string
+	// Title 笔记标题
+	Title string
+	// Status 导入结果
+	Status Status
+	// NoteID 创建的笔记ID（跳过同名笔记时为已存在的笔记ID）
+	NoteID int64
+	// Message 附加说明（失败原因等）
+	Message string
+}
+
+// Issue 导入过程中发现的问题
+type Issue struct {
+	// Path 源文件路径
+	Path string
+	// Kind 问题类型
+	Kind IssueKind
+	// Message 问题说明
+	Message string
+}
+
+// Report 导入报告
+type Report struct {
+	// Notes 每篇笔记的导入结果
+	Notes []*NoteResult
+	// CreatedTags 新建（或试运行时将新建）的标签
+	CreatedTags []string
+	// CreatedCategories 新建（或试运行时将新建）的分类
+	CreatedCategories []string
+	// AttachmentsUploaded 上传（或试运行时将上传）的附件数量
+	AttachmentsUploaded int
+	// Pages 每个独立页面的导入结果（WordPress 导入）
+	Pages []*NoteResult
+	// CommentsImported 导入（或试运行时将导入）的评论数量（WordPress 导入）
+	CommentsImported int
+	// RedirectsRecorded 记录（或试运行时将记录）的旧地址重定向数量（WordPress 导入）
+	RedirectsRecorded int
+	// Issues 发现的问题
+	Issues []*Issue
+}
+
+// addIssue 记录一个导入问题
+func (r *Report) addIssue(path string, kind IssueKind, format string, args ...any) {
+	r.Issues = append(r.Issues, &Issue{Path: path, Kind: kind, Message: fmt.Sprintf(format, args...)})
+}
+
+// Importer 笔记导入器
+type Importer struct {
+	// store 数据存储实例
+	store *store.Store
+}
+
+// NewImporter 创建笔记导入器
+func NewImporter(s *store.Store) *Importer {
+	return &Importer{store: s}
+}
+
+// terms 按名称（不区分大小写）查找或创建标签和分类
+type terms struct {
+	// store 数据存储实例
+	store *store.Store
+	// dryRun 是否为试运行
+	dryRun bool
+	// report 导入报告
+	report *Report
+	// tags 标签名称（小写）到ID的映射，试运行时新标签的ID为 0
+	tags map[string]int64
+	// categories 分类名称（小写）到ID的映射，试运行时新分类的ID为 0
+	categories map[string]int64
+}
+
+// loadTerms 读取现有的标签和分类
+func (i *Importer) loadTerms(ctx context.Context, dryRun bool, report *Report) (*terms, error) {
+	t := &terms{
+		store:      i.store,
+		dryRun:     dryRun,
+		report:     report,
+		tags:       make(map[string]int64),
+		categories: make(map[string]int64),
+	}
+
+	tags, _, err := i.store.ListTags(ctx, &apiv1.ListTagsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	// 按规范化名称匹配标签，标签的别名也解析为该标签
+	for _, tag := range tags {
+		t.tags[store.TagNameKey(tag.NameText)] = tag.Id
+		for _, alias := range tag.Aliases {
+			t.tags[store.TagNameKey(alias)] = tag.Id
+		}
+	}
+
+	categories, err := i.store.ListCategories(ctx, &apiv1.ListCategoriesRequest{IncludeHidden: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	for _, category := range categories {
+		t.categories[strings.ToLower(category.NameText)] = category.Id
+	}
+	return t, nil
+}
+
+// tagIDs 返回标签名称对应的ID，不存在的标签会通过 Store.CreateTag 创建
+func (t *terms) tagIDs(ctx context.Context, names []string) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := store.TagNameKey(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		id, ok := t.tags[key]
+		if !ok {
+			t.report.CreatedTags = append(t.report.CreatedTags, name)
+			if !t.dryRun {
+				tag, err := t.store.CreateTag(ctx, &storepb.Tag{NameText: name})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create tag %s: %w", name, err)
+				}
+				id = tag.Id
+			}
+			t.tags[key] = id
+		}
+		if id > 0 {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+	}
+	return ids, nil
+}
+
+// categoryID 返回分类名称对应的ID，不存在的分类会自动创建在 parentID 下（0 表示顶级分类）
+func (t *terms) categoryID(ctx context.Context, name string, parentID int64) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	key := strings.ToLower(name)
+	id, ok := t.categories[key]
+	if !ok {
+		t.report.CreatedCategories = append(t.report.CreatedCategories, name)
+		if !t.dryRun {
+			category, err := t.store.CreateCategory(ctx, &storepb.Category{NameText: name, ParentId: parentID, Visible: true})
+			if err != nil {
+				return "", fmt.Errorf("failed to create category %s: %w", name, err)
+			}
+			id = category.Id
+		}
+		t.categories[key] = id
+	}
+	if id == 0 {
+		return "", nil
+	}
+	return strconv.FormatInt(id, 10), nil
+}
+
+// findDuplicate 查找作者已有的同名笔记，返回笔记ID，不存在时返回 0
+func (i *Importer) findDuplicate(ctx context.Context, authorID uint, title string) (int64, error) {
+	notes, _, err := i.store.ListNotes(ctx, &store.ListNotesRequest{
+		Page:               1,
+		PageSize:           100,
+		Search:             title,
+		IncludeUnpublished: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	author := strconv.FormatUint(uint64(authorID), 10)
+	for _, note := range notes {
+		if note.AuthorId == author && strings.EqualFold(note.Title, title) {
+			return note.Id, nil
+		}
+	}
+	return 0, nil
+}
+
+// uploadAttachment 上传附件，返回附件ID
+func (i *Importer) uploadAttachment(ctx context.Context, authorID uint, filename string, content []byte) (int64, error) {
+	attachment, err := i.store.CreateAttachment(ctx, &storepb.Attachment{
+		Filename: filename,
+		Type:     detectContentType(filename, content),
+		Size:     int64(len(content)),
+		Content:  content,
+		AuthorId: strconv.FormatUint(uint64(authorID), 10),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to upload attachment %s: %w", filename, err)
+	}
+	return attachment.Id, nil
+}
+
+// linkAttachment 将附件关联到笔记
+func (i *Importer) linkAttachment(ctx context.Context, attachmentID, noteID int64) error {
+	_, err := i.store.UpdateAttachment(ctx, &storepb.Attachment{
+		Name:   fmt.Sprintf("attachments/%d", attachmentID),
+		NoteId: fmt.Sprintf("notes/%d", noteID),
+	})
+	return err
+}
+
+// detectContentType 根据扩展名或内容判断文件类型
+func detectContentType(filename string, content []byte) string {
+	if contentType := mime.TypeByExtension(strings.ToLower(path.Ext(filename))); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(content)
+}
+
+// attachmentURL 返回附件的站内地址（由文件服务器提供）
+func attachmentURL(id int64, filename string) string {
+	return fmt.Sprintf("/file/attachments/%d/%s", id, url.PathEscape(filename))
+}
+
+// noteURL 返回笔记的站内地址
+func noteURL(id int64) string {
+	return fmt.Sprintf("/note/%d", id)
+}
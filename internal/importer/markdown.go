@@ -0,0 +1,586 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+const (
+	// MaxFileSize 单个文件（笔记或附件）的最大大小
+	MaxFileSize = 32 << 20
+	// MaxTotalSize 一次导入读取的文件总大小上限
+	MaxTotalSize = 512 << 20
+	// maxFiles 一次导入最多处理的文件数量
+	maxFiles = 10000
+)
+
+var (
+	// markdownLinkPattern 匹配 Markdown 图片和链接：![alt](dest "title") / [text](dest)
+	markdownLinkPattern = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\(\s*(<[^>\n]+>|[^)\s]+)((?:\s+"[^"\n]*")?)\s*\)`)
+	// wikiLinkPattern 匹配 Obsidian 风格的 [[笔记]]、[[笔记#标题|别名]] 以及嵌入 ![[文件]]
+	wikiLinkPattern = regexp.MustCompile(`(!?)\[\[([^\]|#\n]+)(#[^\]|\n]*)?(?:\|([^\]\n]*))?\]\]`)
+	// headingPattern 匹配一级标题
+	headingPattern = regexp.MustCompile(`(?m)^#\s+(.+?)\s*#*\s*$`)
+	// jekyllDatePattern 匹配 Jekyll 文章文件名中的日期前缀：2024-01-02-title.md
+	jekyllDatePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+	// imageExtensions 可以作为图片嵌入的扩展名
+	imageExtensions = map[string]bool{
+		".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".svg": true, ".bmp": true, ".avif": true,
+	}
+)
+
+// document 待导入的 Markdown 文件
+type document struct {
+	// path 文件路径
+	path string
+	// stem 不含扩展名的文件名
+	stem string
+	// meta front matter 中的属性
+	meta *frontMatter
+	// title 笔记标题
+	title string
+	// body 正文
+	body string
+	// result 导入结果
+	result *NoteResult
+}
+
+// markdownImport 一次 Markdown 导入任务的状态
+type markdownImport struct {
+	*Importer
+	// fsys 源文件系统（目录或 zip）
+	fsys fs.FS
+	// opts 导入选项
+	opts Options
+	// report 导入报告
+	report *Report
+	// docs 待导入的 Markdown 文件
+	docs []*document
+	// files 源中的全部文件路径
+	files map[string]bool
+	// filesByName 文件名（小写）到路径的映射，用于解析 Obsidian 的短链接
+	filesByName map[string]string
+	// notesByKey 路径、文件名或标题（小写）到文件的映射，用于解析笔记链接
+	notesByKey map[string]*document
+	// attachments 已上传的附件，键为源文件路径
+	attachments map[string]int64
+	// attachmentOwners 附件已关联的笔记，键为附件ID
+	attachmentOwners map[int64]bool
+	// totalSize 已读取的文件总大小
+	totalSize int64
+}
+
+// ImportMarkdown 从目录或 zip（以 fs.FS 表示）导入 Markdown 笔记
+// 支持 Obsidian、Hugo 和 Jekyll 的 YAML/TOML front matter，
+// 相对路径引用的图片和文件会上传为附件并改写地址，[[wiki 链接]] 和指向 .md 文件的链接会改写为笔记地址
+func (i *Importer) ImportMarkdown(ctx context.Context, fsys fs.FS, opts Options) (*Report, error) {
+	if opts.AuthorID == 0 {
+		return nil, errors.New("author is required")
+	}
+	if opts.Visibility == storepb.NoteVisibility_NOTE_VISIBILITY_UNSPECIFIED {
+		opts.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PRIVATE
+	}
+
+	m := &markdownImport{
+		Importer:         i,
+		fsys:             fsys,
+		opts:             opts,
+		report:           &Report{},
+		files:            make(map[string]bool),
+		filesByName:      make(map[string]string),
+		notesByKey:       make(map[string]*document),
+		attachments:      make(map[string]int64),
+		attachmentOwners: make(map[int64]bool),
+	}
+	if err := m.scan(); err != nil {
+		return nil, err
+	}
+	if len(m.docs) == 0 {
+		return nil, errors.New("no markdown files found")
+	}
+	if err := m.checkDuplicates(ctx); err != nil {
+		return nil, err
+	}
+
+	t, err := i.loadTerms(ctx, opts.DryRun, m.report)
+	if err != nil {
+		return nil, err
+	}
+
+	// 第一遍：创建笔记并上传附件
+	for _, doc := range m.docs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if doc.result.Status != "" {
+			continue
+		}
+		if err := m.createNote(ctx, t, doc); err != nil {
+			doc.result.Status = StatusFailed
+			doc.result.Message = err.Error()
+		}
+	}
+
+	// 第二遍：所有笔记都有了ID之后再改写笔记之间的链接
+	for _, doc := range m.docs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if doc.result.Status != StatusCreated && doc.result.Status != StatusWouldCreate {
+			continue
+		}
+		if err := m.resolveNoteLinks(ctx, doc); err != nil {
+			doc.result.Message = err.Error()
+		}
+	}
+
+	for _, doc := range m.docs {
+		m.report.Notes = append(m.report.Notes, doc.result)
+	}
+	return m.report, nil
+}
+
+// scan 遍历源文件，读取并解析全部 Markdown 文件
+func (m *markdownImport) scan() error {
+	var paths []string
+	err := fs.WalkDir(m.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			// 跳过隐藏目录（.obsidian、.git 等）
+			if p != "." && strings.HasPrefix(name, ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(name, ".") || !d.Type().IsRegular() {
+			return nil
+		}
+		if len(m.files) >= maxFiles {
+			return fmt.Errorf("too many files (max %d)", maxFiles)
+		}
+		m.files[p] = true
+		if _, ok := m.filesByName[strings.ToLower(name)]; !ok {
+			m.filesByName[strings.ToLower(name)] = p
+		}
+		// Hugo 的 _index.md 是目录页面，不作为笔记导入
+		if isMarkdownFile(name) && name != "_index.md" {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read import files: %w", err)
+	}
+
+	titles := make(map[string]*document)
+	for _, p := range paths {
+		doc := &document{path: p, stem: strings.TrimSuffix(path.Base(p), path.Ext(p)), result: &NoteResult{Path: p}}
+		m.docs = append(m.docs, doc)
+
+		content, err := m.readFile(p)
+		if err != nil {
+			doc.result.Status = StatusFailed
+			doc.result.Message = err.Error()
+			continue
+		}
+		meta, body, err := splitFrontMatter(content)
+		if err != nil {
+			m.report.addIssue(p, IssueInvalidFrontMatter, "%v", err)
+		}
+		doc.meta = parseFrontMatter(meta)
+		doc.body = string(body)
+
+		// Jekyll 文件名中的日期
+		stem := doc.stem
+		if match := jekyllDatePattern.FindStringSubmatch(stem); match != nil {
+			if doc.meta.Date.IsZero() {
+				doc.meta.Date, _ = parseTime(match[1])
+			}
+			stem = match[2]
+		}
+
+		doc.title = doc.meta.Title
+		if doc.title == "" {
+			if match := headingPattern.FindStringSubmatch(doc.body); match != nil {
+				doc.title = strings.TrimSpace(match[1])
+			} else {
+				doc.title = stem
+			}
+		}
+		doc.result.Title = doc.title
+
+		key := strings.ToLower(doc.title)
+		if other, ok := titles[key]; ok {
+			m.report.addIssue(p, IssueConflict, "title %q is also used by %s", doc.title, other.path)
+		} else {
+			titles[key] = doc
+		}
+
+		// 笔记链接可以使用路径、文件名或标题，先出现的文件优先
+		for _, k := range []string{
+			strings.ToLower(strings.TrimSuffix(p, path.Ext(p))),
+			strings.ToLower(doc.stem),
+			strings.ToLower(stem),
+			key,
+		} {
+			if _, ok := m.notesByKey[k]; !ok {
+				m.notesByKey[k] = doc
+			}
+		}
+	}
+	return nil
+}
+
+// checkDuplicates 检查作者是否已有同名笔记，同名笔记会被跳过
+func (m *markdownImport) checkDuplicates(ctx context.Context) error {
+	for _, doc := range m.docs {
+		if doc.result.Status != "" {
+			continue
+		}
+		noteID, err := m.findDuplicate(ctx, m.opts.AuthorID, doc.title)
+		if err != nil {
+			return fmt.Errorf("failed to check duplicates: %w", err)
+		}
+		if noteID > 0 {
+			doc.result.Status = StatusSkipped
+			doc.result.NoteID = noteID
+			doc.result.Message = "note with the same title already exists"
+			m.report.addIssue(doc.path, IssueDuplicate, "note %q already exists (%s)", doc.title, noteURL(noteID))
+		}
+	}
+	return nil
+}
+
+// createNote 上传笔记引用的附件并创建笔记
+func (m *markdownImport) createNote(ctx context.Context, t *terms, doc *document) error {
+	content, attachmentIDs, err := m.rewriteAttachments(ctx, doc)
+	if err != nil {
+		return err
+	}
+
+	tagIDs, err := t.tagIDs(ctx, doc.meta.Tags)
+	if err != nil {
+		return err
+	}
+	categoryID, err := t.categoryID(ctx, doc.meta.Category, 0)
+	if err != nil {
+		return err
+	}
+
+	note := &storepb.Note{
+		Title:      doc.title,
+		Content:    content,
+		Summary:    doc.meta.Summary,
+		CategoryId: categoryID,
+		TagIds:     tagIDs,
+		Published:  true,
+		AuthorId:   fmt.Sprintf("%d", m.opts.AuthorID),
+		Visibility: m.opts.Visibility,
+	}
+	if doc.meta.Published != nil {
+		note.Published = *doc.meta.Published
+	}
+	if !doc.meta.Date.IsZero() {
+		note.PublishedAt = doc.meta.Date.Unix()
+	}
+	switch doc.meta.Visibility {
+	case "public":
+		note.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PUBLIC
+	case "private":
+		note.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PRIVATE
+	}
+
+	if m.opts.DryRun {
+		doc.body = content
+		doc.result.Status = StatusWouldCreate
+		return nil
+	}
+
+	created, err := m.store.CreateNote(ctx, note)
+	if err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+	doc.body = created.Content
+	doc.result.Status = StatusCreated
+	doc.result.NoteID = created.Id
+
+	// 附件关联到第一篇引用它的笔记
+	for _, attachmentID := range attachmentIDs {
+		if m.attachmentOwners[attachmentID] {
+			continue
+		}
+		m.attachmentOwners[attachmentID] = true
+		if err := m.linkAttachment(ctx, attachmentID, created.Id); err != nil {
+			return fmt.Errorf("failed to link attachment %d: %w", attachmentID, err)
+		}
+	}
+	return nil
+}
+
+// rewriteAttachments 上传正文中以相对路径引用的图片和文件，并将地址改写为附件地址
+func (m *markdownImport) rewriteAttachments(ctx context.Context, doc *document) (string, []int64, error) {
+	var attachmentIDs []int64
+	var uploadErr error
+	dir := path.Dir(doc.path)
+
+	upload := func(p string) (string, bool) {
+		id, ok := m.attachments[p]
+		if !ok {
+			content, err := m.readFile(p)
+			if err != nil {
+				m.report.addIssue(doc.path, IssueMissingAttachment, "%s: %v", p, err)
+				return "", false
+			}
+			m.report.AttachmentsUploaded++
+			if !m.opts.DryRun {
+				if id, err = m.uploadAttachment(ctx, m.opts.AuthorID, path.Base(p), content); err != nil {
+					uploadErr = err
+					return "", false
+				}
+			}
+			m.attachments[p] = id
+		}
+		attachmentIDs = append(attachmentIDs, id)
+		return attachmentURL(id, path.Base(p)), true
+	}
+
+	content := mapOutsideCode(doc.body, func(text string) string {
+		text = markdownLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := markdownLinkPattern.FindStringSubmatch(match)
+			ref, ok := localRef(parts[3])
+			if !ok || isMarkdownFile(ref) {
+				return match
+			}
+			p, found := m.resolveFile(dir, ref, false)
+			if !found {
+				m.report.addIssue(doc.path, IssueMissingAttachment, "file not found: %s", ref)
+				return match
+			}
+			newURL, ok := upload(p)
+			if !ok {
+				return match
+			}
+			return fmt.Sprintf("%s[%s](%s%s)", parts[1], parts[2], newURL, parts[4])
+		})
+
+		// Obsidian 嵌入：![[image.png]]、![[file.pdf]]
+		return wikiLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := wikiLinkPattern.FindStringSubmatch(match)
+			target := strings.TrimSpace(parts[2])
+			if parts[1] != "!" || isMarkdownFile(target) || path.Ext(target) == "" {
+				return match
+			}
+			p, found := m.resolveFile(dir, target, true)
+			if !found {
+				m.report.addIssue(doc.path, IssueMissingAttachment, "file not found: %s", target)
+				return match
+			}
+			newURL, ok := upload(p)
+			if !ok {
+				return match
+			}
+			name := path.Base(p)
+			if imageExtensions[strings.ToLower(path.Ext(p))] {
+				return fmt.Sprintf("![%s](%s)", name, newURL)
+			}
+			return fmt.Sprintf("[%s](%s)", name, newURL)
+		})
+	})
+	return content, attachmentIDs, uploadErr
+}
+
+// resolveNoteLinks 将 [[wiki 链接]] 和指向 .md 文件的链接改写为笔记地址，无法解析的链接保持原样并记录
+func (m *markdownImport) resolveNoteLinks(ctx context.Context, doc *document) error {
+	dir := path.Dir(doc.path)
+	content := mapOutsideCode(doc.body, func(text string) string {
+		text = markdownLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := markdownLinkPattern.FindStringSubmatch(match)
+			ref, ok := localRef(parts[3])
+			if parts[1] == "!" || !ok || !isMarkdownFile(ref) {
+				return match
+			}
+			noteID, found := m.resolveNote(ctx, path.Join(dir, ref))
+			if !found {
+				m.report.addIssue(doc.path, IssueUnresolvedLink, "note not found: %s", ref)
+				return match
+			}
+			if noteID == 0 {
+				return match
+			}
+			return fmt.Sprintf("[%s](%s%s)", parts[2], noteURL(noteID), parts[4])
+		})
+
+		return wikiLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := wikiLinkPattern.FindStringSubmatch(match)
+			target := strings.TrimSpace(parts[2])
+			if parts[1] == "!" && !isMarkdownFile(target) && path.Ext(target) != "" {
+				// 附件嵌入已在第一遍处理
+				return match
+			}
+			noteID, found := m.resolveNote(ctx, target)
+			if !found {
+				m.report.addIssue(doc.path, IssueUnresolvedLink, "note not found: %s", target)
+				return match
+			}
+			if noteID == 0 {
+				return match
+			}
+			text := strings.TrimSpace(parts[4])
+			if text == "" {
+				text = target
+			}
+			return fmt.Sprintf("[%s](%s)", text, noteURL(noteID))
+		})
+	})
+
+	if m.opts.DryRun || content == doc.body {
+		return nil
+	}
+	note, err := m.store.GetNote(ctx, doc.result.NoteID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve links: %w", err)
+	}
+	note.Content = content
+	if doc.meta.Summary == "" {
+		// 自动生成的摘要中包含改写前的链接，清空后由存储层重新生成
+		note.Summary = ""
+	}
+	if _, err := m.store.UpdateNote(ctx, note); err != nil {
+		return fmt.Errorf("failed to resolve links: %w", err)
+	}
+	return nil
+}
+
+// resolveNote 根据路径、文件名或标题查找笔记，先查找本次导入的文件，再查找作者已有的笔记
+// 返回的笔记ID在试运行时可能为 0
+func (m *markdownImport) resolveNote(ctx context.Context, target string) (int64, bool) {
+	target = strings.TrimSuffix(strings.TrimSuffix(target, ".md"), ".markdown")
+	for _, key := range []string{strings.ToLower(path.Clean(target)), strings.ToLower(path.Base(target))} {
+		if doc, ok := m.notesByKey[key]; ok {
+			if doc.result.Status == StatusFailed {
+				return 0, false
+			}
+			return doc.result.NoteID, true
+		}
+	}
+	noteID, err := m.findDuplicate(ctx, m.opts.AuthorID, path.Base(target))
+	if err != nil || noteID == 0 {
+		return 0, false
+	}
+	return noteID, true
+}
+
+// resolveFile 解析引用的文件路径
+// 依次尝试相对于笔记所在目录、相对于根目录、Hugo 的 static 目录，byName 为 true 时还会按文件名查找（Obsidian）
+func (m *markdownImport) resolveFile(dir, ref string, byName bool) (string, bool) {
+	var candidates []string
+	if strings.HasPrefix(ref, "/") {
+		candidates = append(candidates, strings.TrimPrefix(ref, "/"), "static"+ref)
+	} else {
+		candidates = append(candidates, path.Join(dir, ref), ref)
+	}
+	for _, candidate := range candidates {
+		candidate = path.Clean(candidate)
+		if fs.ValidPath(candidate) && m.files[candidate] {
+			return candidate, true
+		}
+	}
+	if byName {
+		if p, ok := m.filesByName[strings.ToLower(path.Base(ref))]; ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// readFile 读取源文件，限制单个文件和总大小
+func (m *markdownImport) readFile(p string) ([]byte, error) {
+	f, err := m.fsys.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxFileSize {
+		return nil, fmt.Errorf("file too large (max %d MB)", MaxFileSize>>20)
+	}
+	m.totalSize += int64(len(content))
+	if m.totalSize > MaxTotalSize {
+		return nil, fmt.Errorf("import too large (max %d MB)", MaxTotalSize>>20)
+	}
+	return content, nil
+}
+
+// localRef 判断链接地址是否为本地相对路径，返回解码后的路径（不含查询参数和锚点）
+func localRef(dest string) (string, bool) {
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+	if dest == "" || strings.HasPrefix(dest, "#") || strings.HasPrefix(dest, "//") {
+		return "", false
+	}
+	u, err := url.Parse(dest)
+	if err != nil {
+		// 无法解析时按未编码的路径处理
+		return dest, !strings.Contains(dest, "://")
+	}
+	if u.Scheme != "" || u.Host != "" {
+		return "", false
+	}
+	if u.Path == "" {
+		return "", false
+	}
+	return u.Path, true
+}
+
+// isMarkdownFile 判断是否为 Markdown 文件
+func isMarkdownFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// mapOutsideCode 对代码块以外的文本应用转换，避免改写代码中的示例链接
+func mapOutsideCode(content string, fn func(string) string) string {
+	var sb strings.Builder
+	var segment strings.Builder
+	fence := ""
+	flush := func() {
+		sb.WriteString(fn(segment.String()))
+		segment.Reset()
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				flush()
+				fence = trimmed[:3]
+				sb.WriteString(line)
+				continue
+			}
+			segment.WriteString(line)
+			continue
+		}
+		sb.WriteString(line)
+		if strings.HasPrefix(trimmed, fence) {
+			fence = ""
+		}
+	}
+	flush()
+	return sb.String()
+}
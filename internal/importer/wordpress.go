@@ -0,0 +1,915 @@
+package importer
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+var (
+	// uploadsURLPattern 正文中指向 WordPress 上传目录的地址
+	uploadsURLPattern = regexp.MustCompile(`(?:(?:https?:)?//[^/\s"'<>()\[\]]+)?/wp-content/uploads/[^\s"'<>()\[\]?#]+`)
+	// resizedImagePattern WordPress 生成的缩略图文件名后缀，例如 photo-300x200.jpg
+	resizedImagePattern = regexp.MustCompile(`-\d+x\d+(\.[A-Za-z0-9]+)$`)
+	// captionPattern [caption] 短代码
+	captionPattern = regexp.MustCompile(`(?is)\[caption[^\]]*\]\s*((?:<a[^>]*>)?\s*<img[^>]*>\s*(?:</a>)?)(.*?)\[/caption\]`)
+	// preBlockPattern <pre> 代码块，自动分段时保持原样
+	preBlockPattern = regexp.MustCompile(`(?is)<pre[\s>].*?</pre>`)
+	// placeholderPattern 自动分段时代码块的占位符
+	placeholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+	// paragraphTagPattern 已经使用 <p> 分段的内容
+	paragraphTagPattern = regexp.MustCompile(`(?i)<p[\s>]`)
+	// paragraphSplitPattern 空行
+	paragraphSplitPattern = regexp.MustCompile(`\n[ \t]*\n`)
+	// blockStartPattern 以块级元素开头的段落不需要包裹 <p>
+	blockStartPattern = regexp.MustCompile(`(?i)^(<(div|ul|ol|li|table|thead|tbody|tr|td|th|blockquote|h[1-6]|pre|figure|hr|dl|section|iframe|!--)|\x00)`)
+	// markdownLinkDestPattern Markdown 链接地址
+	markdownLinkDestPattern = regexp.MustCompile(`(\]\()(<[^>\n]+>|[^)\s]+)`)
+)
+
+// wxrRSS WordPress 导出文件（WXR）的根元素
+// 字段只按本地名称匹配，兼容 WXR 1.0 到 1.2 不同的命名空间
+type wxrRSS struct {
+	Channel wxrChannel `xml:"channel"`
+}
+
+// wxrChannel 站点信息、分类、标签和全部内容
+type wxrChannel struct {
+	Link        string        `xml:"link"`
+	BaseSiteURL string        `xml:"base_site_url"`
+	BaseBlogURL string        `xml:"base_blog_url"`
+	Categories  []wxrCategory `xml:"category"`
+	Tags        []wxrTag      `xml:"tag"`
+	Items       []*wxrItem    `xml:"item"`
+}
+
+// wxrCategory 分类
+type wxrCategory struct {
+	Nicename    string `xml:"category_nicename"`
+	Parent      string `xml:"category_parent"`
+	Name        string `xml:"cat_name"`
+	Description string `xml:"category_description"`
+}
+
+// wxrTag 标签
+type wxrTag struct {
+	Slug string `xml:"tag_slug"`
+	Name string `xml:"tag_name"`
+}
+
+// wxrItem 文章、页面或媒体
+type wxrItem struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	GUID          string       `xml:"guid"`
+	Encoded       []wxrEncoded `xml:"encoded"`
+	PostID        string       `xml:"post_id"`
+	PostDate      string       `xml:"post_date"`
+	PostDateGMT   string       `xml:"post_date_gmt"`
+	PostName      string       `xml:"post_name"`
+	Status        string       `xml:"status"`
+	PostParent    string       `xml:"post_parent"`
+	MenuOrder     string       `xml:"menu_order"`
+	PostType      string       `xml:"post_type"`
+	PostPassword  string       `xml:"post_password"`
+	AttachmentURL string       `xml:"attachment_url"`
+	Terms         []wxrTerm    `xml:"category"`
+	Meta          []wxrMeta    `xml:"postmeta"`
+	Comments      []wxrComment `xml:"comment"`
+}
+
+// wxrEncoded content:encoded 或 excerpt:encoded
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// wxrTerm 文章所属的分类或标签
+type wxrTerm struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+// wxrMeta 文章自定义字段
+type wxrMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// wxrComment 评论
+type wxrComment struct {
+	ID       string `xml:"comment_id"`
+	Author   string `xml:"comment_author"`
+	Email    string `xml:"comment_author_email"`
+	Date     string `xml:"comment_date"`
+	DateGMT  string `xml:"comment_date_gmt"`
+	Content  string `xml:"comment_content"`
+	Approved string `xml:"comment_approved"`
+	Type     string `xml:"comment_type"`
+	Parent   string `xml:"comment_parent"`
+}
+
+// content 返回正文（content:encoded）
+func (item *wxrItem) content() string {
+	for _, encoded := range item.Encoded {
+		if strings.Contains(encoded.XMLName.Space, "purl.org/rss/1.0/modules/content") {
+			return encoded.Value
+		}
+	}
+	return ""
+}
+
+// excerpt 返回摘要（excerpt:encoded）
+func (item *wxrItem) excerpt() string {
+	for _, encoded := range item.Encoded {
+		if !strings.Contains(encoded.XMLName.Space, "purl.org/rss/1.0/modules/content") {
+			return encoded.Value
+		}
+	}
+	return ""
+}
+
+// ref 返回在导入报告中标识该内容的地址
+func (item *wxrItem) ref() string {
+	if item.Link != "" {
+		return item.Link
+	}
+	return fmt.Sprintf("?p=%s", item.PostID)
+}
+
+// meta 返回自定义字段的值
+func (item *wxrItem) meta(key string) string {
+	for _, meta := range item.Meta {
+		if meta.Key == key {
+			return meta.Value
+		}
+	}
+	return ""
+}
+
+// uploadedFile 已上传的附件
+type uploadedFile struct {
+	// id 附件ID，试运行时为 0
+	id int64
+	// filename 文件名
+	filename string
+}
+
+// wordpressNote 已创建的笔记，用于第二遍改写站内链接
+type wordpressNote struct {
+	// id 笔记ID
+	id int64
+	// content 笔记内容
+	content string
+	// hasExcerpt 是否有手写摘要
+	hasExcerpt bool
+}
+
+// wordpressImport 一次 WordPress 导入的状态
+type wordpressImport struct {
+	*Importer
+	// opts 导入选项
+	opts Options
+	// report 导入报告
+	report *Report
+	// terms 标签和分类
+	terms *terms
+	// uploads WordPress 上传目录，为 nil 时不导入附件
+	uploads fs.FS
+	// siteHost 原站点的主机名，用于识别站内链接
+	siteHost string
+	// categoryIDs 分类别名到分类ID的映射
+	categoryIDs map[string]string
+	// tagIDs 标签别名到标签ID的映射
+	tagIDs map[string]string
+	// uploaded 上传目录中的相对路径到附件的映射
+	uploaded map[string]uploadedFile
+	// media WordPress 媒体ID到媒体的映射
+	media map[string]*wxrItem
+	// attachmentOwners 已关联笔记的附件
+	attachmentOwners map[int64]bool
+	// notes WordPress 文章ID到笔记ID的映射
+	notes map[string]int64
+	// createdNotes 本次创建（或试运行时将创建）的文章
+	createdNotes map[string]bool
+	// redirects 旧地址到新地址的映射
+	redirects map[string]string
+	// created 本次创建的笔记
+	created []*wordpressNote
+	// totalSize 已读取的附件总大小
+	totalSize int64
+}
+
+// ImportWordPress 从 WordPress 导出文件（WXR）导入文章、页面、分类、标签、评论和媒体
+// 文章转换为 Markdown 笔记，页面导入为独立页面；uploads 为 wp-content/uploads 目录，
+// 其中被引用的文件会上传为附件；旧的固定链接会记录为重定向
+func (i *Importer) ImportWordPress(ctx context.Context, r io.Reader, uploads fs.FS, opts Options) (*Report, error) {
+	if opts.AuthorID == 0 {
+		return nil, fmt.Errorf("author is required")
+	}
+	if opts.Visibility == storepb.NoteVisibility_NOTE_VISIBILITY_UNSPECIFIED {
+		opts.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PRIVATE
+	}
+
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	var rss wxrRSS
+	if err := decoder.Decode(&rss); err != nil {
+		return nil, fmt.Errorf("failed to parse WordPress export: %w", err)
+	}
+	channel := &rss.Channel
+
+	report := &Report{}
+	t, err := i.loadTerms(ctx, opts.DryRun, report)
+	if err != nil {
+		return nil, err
+	}
+	m := &wordpressImport{
+		Importer:         i,
+		opts:             opts,
+		report:           report,
+		terms:            t,
+		uploads:          uploads,
+		categoryIDs:      make(map[string]string),
+		tagIDs:           make(map[string]string),
+		uploaded:         make(map[string]uploadedFile),
+		media:            make(map[string]*wxrItem),
+		attachmentOwners: make(map[int64]bool),
+		notes:            make(map[string]int64),
+		createdNotes:     make(map[string]bool),
+		redirects:        make(map[string]string),
+	}
+	for _, raw := range []string{channel.BaseBlogURL, channel.BaseSiteURL, channel.Link} {
+		if u, err := url.Parse(strings.TrimSpace(raw)); err == nil && u.Host != "" {
+			m.siteHost = strings.ToLower(u.Host)
+			break
+		}
+	}
+
+	if err := m.importTerms(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	var posts, pages, media []*wxrItem
+	for _, item := range channel.Items {
+		switch item.PostType {
+		case "post":
+			posts = append(posts, item)
+		case "page":
+			pages = append(pages, item)
+		case "attachment":
+			media = append(media, item)
+		}
+	}
+
+	for _, item := range media {
+		m.media[item.PostID] = item
+	}
+	// 页面地址在创建前即可确定，先记录下来以便改写文章中指向页面的链接
+	plannedPages := m.planPages(pages)
+	for _, item := range posts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := m.importPost(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+	for _, page := range plannedPages {
+		if err := m.importPage(ctx, page.item, page.slug); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.linkMedia(ctx, media); err != nil {
+		return nil, err
+	}
+	if err := m.resolveLinks(ctx); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// importTerms 创建分类（保留层级）和标签
+func (m *wordpressImport) importTerms(ctx context.Context, channel *wxrChannel) error {
+	categories := make(map[string]*wxrCategory, len(channel.Categories))
+	for i := range channel.Categories {
+		category := &channel.Categories[i]
+		categories[category.Nicename] = category
+	}
+
+	// 先创建上级分类，visiting 防止循环引用
+	visiting := make(map[string]bool)
+	var ensure func(nicename string) (string, error)
+	ensure = func(nicename string) (string, error) {
+		if id, ok := m.categoryIDs[nicename]; ok {
+			return id, nil
+		}
+		category, ok := categories[nicename]
+		if !ok || visiting[nicename] {
+			return "", nil
+		}
+		visiting[nicename] = true
+
+		var parentID int64
+		if category.Parent != "" {
+			parent, err := ensure(category.Parent)
+			if err != nil {
+				return "", err
+			}
+			parentID, _ = strconv.ParseInt(parent, 10, 64)
+		}
+		name := strings.TrimSpace(category.Name)
+		if name == "" {
+			name = category.Nicename
+		}
+		id, err := m.terms.categoryID(ctx, name, parentID)
+		if err != nil {
+			return "", err
+		}
+		m.categoryIDs[nicename] = id
+		return id, nil
+	}
+	for _, category := range channel.Categories {
+		if _, err := ensure(category.Nicename); err != nil {
+			return err
+		}
+	}
+
+	for _, tag := range channel.Tags {
+		name := strings.TrimSpace(tag.Name)
+		if name == "" {
+			name = tag.Slug
+		}
+		ids, err := m.terms.tagIDs(ctx, []string{name})
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			m.tagIDs[tag.Slug] = ids[0]
+		}
+	}
+	return nil
+}
+
+// mediaFile 上传媒体库中的文件，媒体不存在或文件缺失时返回 false
+func (m *wordpressImport) mediaFile(ctx context.Context, ref, mediaID string) (uploadedFile, bool, error) {
+	item, ok := m.media[mediaID]
+	if !ok {
+		return uploadedFile{}, false, nil
+	}
+	rel := uploadsPath(item.AttachmentURL)
+	if rel == "" {
+		m.report.addIssue(ref, IssueMissingAttachment, "not in the uploads directory: %s", item.AttachmentURL)
+		return uploadedFile{}, false, nil
+	}
+	return m.upload(ctx, ref, rel)
+}
+
+// linkMedia 上传本次创建的文章所属的媒体并关联到文章（已被其他文章引用的除外）
+// 所属文章已存在而被跳过的媒体不会重复上传
+func (m *wordpressImport) linkMedia(ctx context.Context, media []*wxrItem) error {
+	for _, item := range media {
+		noteID, ok := m.notes[item.PostParent]
+		if !ok || !m.createdNotes[item.PostParent] {
+			continue
+		}
+		file, ok, err := m.mediaFile(ctx, item.ref(), item.PostID)
+		if err != nil {
+			return err
+		}
+		if !ok || m.opts.DryRun || m.attachmentOwners[file.id] {
+			continue
+		}
+		m.attachmentOwners[file.id] = true
+		if err := m.linkAttachment(ctx, file.id, noteID); err != nil {
+			return fmt.Errorf("failed to link attachment %d: %w", file.id, err)
+		}
+	}
+	return nil
+}
+
+// importPost 将文章导入为笔记，并导入评论、记录旧地址
+func (m *wordpressImport) importPost(ctx context.Context, item *wxrItem) error {
+	switch item.Status {
+	case "trash", "auto-draft", "inherit":
+		return nil
+	}
+
+	title := strings.TrimSpace(item.Title)
+	if title == "" {
+		title = fmt.Sprintf("Untitled %s", item.PostID)
+	}
+	result := &NoteResult{Path: item.ref(), Title: title}
+	m.report.Notes = append(m.report.Notes, result)
+
+	noteID, err := m.findDuplicate(ctx, m.opts.AuthorID, title)
+	if err != nil {
+		return fmt.Errorf("failed to check duplicates: %w", err)
+	}
+	if noteID > 0 {
+		result.Status = StatusSkipped
+		result.NoteID = noteID
+		result.Message = "note with the same title already exists"
+		m.report.addIssue(item.ref(), IssueDuplicate, "note %q already exists (%s)", title, noteURL(noteID))
+		m.notes[item.PostID] = noteID
+		return m.recordRedirects(ctx, item, noteURL(noteID))
+	}
+
+	content, attachmentIDs, err := m.convert(ctx, item.ref(), item.content())
+	if err != nil {
+		return err
+	}
+	content = m.rewriteLinks(content)
+
+	var categoryID string
+	var tagNames []string
+	for _, term := range item.Terms {
+		switch term.Domain {
+		case "category":
+			if categoryID != "" {
+				continue
+			}
+			id, ok := m.categoryIDs[term.Nicename]
+			if !ok {
+				if id, err = m.terms.categoryID(ctx, strings.TrimSpace(term.Name), 0); err != nil {
+					return err
+				}
+			}
+			categoryID = id
+		case "post_tag":
+			tagNames = append(tagNames, strings.TrimSpace(term.Name))
+		}
+	}
+	tagIDs, err := m.terms.tagIDs(ctx, tagNames)
+	if err != nil {
+		return err
+	}
+
+	excerpt := strings.TrimSpace(item.excerpt())
+	note := &storepb.Note{
+		Title:      title,
+		Content:    content,
+		CategoryId: categoryID,
+		TagIds:     tagIDs,
+		AuthorId:   strconv.FormatUint(uint64(m.opts.AuthorID), 10),
+		Visibility: m.opts.Visibility,
+	}
+	if excerpt != "" {
+		note.Summary = htmlToMarkdown(wpautop(excerpt))
+	}
+	switch {
+	case item.PostPassword != "" || item.Status == "private":
+		// 受密码保护和私密文章导入为已发布的私有笔记
+		note.Published = true
+		note.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PRIVATE
+	case item.Status == "publish":
+		note.Published = true
+		note.Visibility = storepb.NoteVisibility_NOTE_VISIBILITY_PUBLIC
+	}
+	if date := wpTime(item.PostDateGMT, item.PostDate); note.Published && !date.IsZero() {
+		note.PublishedAt = date.Unix()
+	}
+	if thumbnailID := item.meta("_thumbnail_id"); thumbnailID != "" {
+		file, ok, err := m.mediaFile(ctx, item.ref(), thumbnailID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			note.CoverImage = attachmentURL(file.id, file.filename)
+		}
+	}
+
+	if m.opts.DryRun {
+		result.Status = StatusWouldCreate
+		m.notes[item.PostID] = 0
+		m.createdNotes[item.PostID] = true
+		if err := m.importComments(ctx, item, 0); err != nil {
+			return err
+		}
+		return m.recordRedirects(ctx, item, noteURL(0))
+	}
+
+	created, err := m.store.CreateNote(ctx, note)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+		return nil
+	}
+	result.Status = StatusCreated
+	result.NoteID = created.Id
+	m.notes[item.PostID] = created.Id
+	m.createdNotes[item.PostID] = true
+	m.created = append(m.created, &wordpressNote{id: created.Id, content: created.Content, hasExcerpt: excerpt != ""})
+
+	for _, attachmentID := range attachmentIDs {
+		if m.attachmentOwners[attachmentID] {
+			continue
+		}
+		m.attachmentOwners[attachmentID] = true
+		if err := m.linkAttachment(ctx, attachmentID, created.Id); err != nil {
+			return fmt.Errorf("failed to link attachment %d: %w", attachmentID, err)
+		}
+	}
+	if err := m.importComments(ctx, item, created.Id); err != nil {
+		return err
+	}
+	return m.recordRedirects(ctx, item, noteURL(created.Id))
+}
+
+// importComments 导入已审核的评论，保留回复关系（引用垃圾评论或待审核评论的回复作为顶级评论）
+func (m *wordpressImport) importComments(ctx context.Context, item *wxrItem, noteID int64) error {
+	comments := append([]wxrComment(nil), item.Comments...)
+	sort.SliceStable(comments, func(a, b int) bool {
+		idA, _ := strconv.ParseInt(comments[a].ID, 10, 64)
+		idB, _ := strconv.ParseInt(comments[b].ID, 10, 64)
+		return idA < idB
+	})
+
+	imported := make(map[string]int64, len(comments))
+	for _, comment := range comments {
+		// 只导入已审核的普通评论，忽略 pingback 和 trackback
+		if comment.Approved != "1" || (comment.Type != "" && comment.Type != "comment") {
+			continue
+		}
+		m.report.CommentsImported++
+		if m.opts.DryRun {
+			continue
+		}
+
+		author := strings.TrimSpace(comment.Author)
+		if author == "" {
+			author = "Anonymous"
+		}
+		c := &storepb.Comment{
+			NoteId:   fmt.Sprintf("notes/%d", noteID),
+			Author:   author,
+			Email:    strings.TrimSpace(comment.Email),
+			Content:  htmlToMarkdown(wpautop(comment.Content)),
+			ParentId: imported[comment.Parent],
+			Approved: true,
+		}
+		if date := wpTime(comment.DateGMT, comment.Date); !date.IsZero() {
+			c.CreatedAt = date.Unix()
+		}
+		created, err := m.store.CreateComment(ctx, c)
+		if err != nil {
+			return fmt.Errorf("failed to import comment %s of %s: %w", comment.ID, item.ref(), err)
+		}
+		imported[comment.ID] = created.Id
+	}
+	return nil
+}
+
+// plannedPage 待导入的页面
+type plannedPage struct {
+	// item WordPress 页面
+	item *wxrItem
+	// slug 页面标识符
+	slug string
+}
+
+// planPages 确定每个页面的标识符，并记录旧地址到新页面地址的映射
+func (m *wordpressImport) planPages(pages []*wxrItem) []*plannedPage {
+	var planned []*plannedPage
+	used := make(map[string]bool)
+	for _, item := range pages {
+		switch item.Status {
+		case "trash", "auto-draft", "inherit":
+			continue
+		}
+		slug, err := url.PathUnescape(item.PostName)
+		if err != nil || strings.TrimSpace(slug) == "" || strings.Contains(slug, "/") {
+			slug = "page-" + item.PostID
+		}
+		// 不同上级页面下的同名子页面
+		if used[slug] {
+			slug += "-" + item.PostID
+		}
+		used[slug] = true
+		planned = append(planned, &plannedPage{item: item, slug: slug})
+
+		if p := permalinkPath(item.Link); p != "" {
+			m.redirects[p] = pageURL(slug)
+		}
+	}
+	return planned
+}
+
+// importPage 将页面导入为独立页面
+func (m *wordpressImport) importPage(ctx context.Context, item *wxrItem, slug string) error {
+	title := strings.TrimSpace(item.Title)
+	if title == "" {
+		title = slug
+	}
+	result := &NoteResult{Path: item.ref(), Title: title}
+	m.report.Pages = append(m.report.Pages, result)
+
+	if existing, err := m.store.GetPageBySlug(ctx, slug); err == nil && existing != nil {
+		result.Status = StatusSkipped
+		result.NoteID = existing.Id
+		result.Message = "page with the same slug already exists"
+		m.report.addIssue(item.ref(), IssueDuplicate, "page %q already exists (%s)", slug, pageURL(slug))
+		return m.recordRedirects(ctx, item, pageURL(slug))
+	}
+
+	// 页面不能关联附件，页面引用的附件保持未关联状态
+	content, _, err := m.convert(ctx, item.ref(), item.content())
+	if err != nil {
+		return err
+	}
+	order, _ := strconv.Atoi(item.MenuOrder)
+	page := &storepb.Page{
+		Title:     title,
+		Slug:      slug,
+		Content:   m.rewriteLinks(content),
+		Published: item.Status == "publish",
+		Order:     int32(order),
+	}
+	if date := wpTime(item.PostDateGMT, item.PostDate); !date.IsZero() {
+		page.CreatedAt = date.Unix()
+	}
+
+	if m.opts.DryRun {
+		result.Status = StatusWouldCreate
+		return m.recordRedirects(ctx, item, pageURL(slug))
+	}
+	created, err := m.store.CreatePage(ctx, page)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+		return nil
+	}
+	result.Status = StatusCreated
+	result.NoteID = created.Id
+	return m.recordRedirects(ctx, item, pageURL(slug))
+}
+
+// convert 将 WordPress 正文转换为 Markdown，上传引用的上传目录中的文件并改写地址
+func (m *wordpressImport) convert(ctx context.Context, ref, content string) (string, []int64, error) {
+	var attachmentIDs []int64
+	var uploadErr error
+
+	content = captionPattern.ReplaceAllString(content, "<figure>$1<figcaption>$2</figcaption></figure>")
+	content = wpautop(content)
+	content = uploadsURLPattern.ReplaceAllStringFunc(content, func(match string) string {
+		rel := uploadsPath(match)
+		if rel == "" || uploadErr != nil {
+			return match
+		}
+		file, ok, err := m.upload(ctx, ref, rel)
+		if err != nil {
+			uploadErr = err
+			return match
+		}
+		if !ok {
+			return match
+		}
+		if file.id > 0 {
+			attachmentIDs = append(attachmentIDs, file.id)
+		}
+		return attachmentURL(file.id, file.filename)
+	})
+	if uploadErr != nil {
+		return "", nil, uploadErr
+	}
+	return htmlToMarkdown(content), attachmentIDs, nil
+}
+
+// upload 上传上传目录中的文件，同一文件只上传一次
+// 缩略图（photo-300x200.jpg）不存在时使用原图
+func (m *wordpressImport) upload(ctx context.Context, ref, rel string) (uploadedFile, bool, error) {
+	if file, ok := m.uploaded[rel]; ok {
+		return file, true, nil
+	}
+
+	candidates := []string{rel}
+	if original := resizedImagePattern.ReplaceAllString(rel, "$1"); original != rel {
+		candidates = append(candidates, original)
+	}
+	var content []byte
+	var found string
+	var readErr error
+	for _, candidate := range candidates {
+		if file, ok := m.uploaded[candidate]; ok {
+			m.uploaded[rel] = file
+			return file, true, nil
+		}
+		if m.uploads == nil || !fs.ValidPath(candidate) {
+			continue
+		}
+		if content, readErr = m.readUpload(candidate); readErr == nil {
+			found = candidate
+			break
+		}
+	}
+	if found == "" {
+		if readErr == nil || m.uploads == nil {
+			readErr = fs.ErrNotExist
+		}
+		m.report.addIssue(ref, IssueMissingAttachment, "%s: %v", rel, readErr)
+		return uploadedFile{}, false, nil
+	}
+
+	file := uploadedFile{filename: path.Base(found)}
+	m.report.AttachmentsUploaded++
+	if !m.opts.DryRun {
+		id, err := m.uploadAttachment(ctx, m.opts.AuthorID, file.filename, content)
+		if err != nil {
+			return uploadedFile{}, false, err
+		}
+		file.id = id
+	}
+	m.uploaded[found] = file
+	m.uploaded[rel] = file
+	return file, true, nil
+}
+
+// readUpload 读取上传目录中的文件，限制单个文件和总大小
+func (m *wordpressImport) readUpload(p string) ([]byte, error) {
+	f, err := m.uploads.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxFileSize {
+		return nil, fmt.Errorf("file too large (max %d MB)", MaxFileSize>>20)
+	}
+	m.totalSize += int64(len(content))
+	if m.totalSize > MaxTotalSize {
+		return nil, fmt.Errorf("import too large (max %d MB)", MaxTotalSize>>20)
+	}
+	return content, nil
+}
+
+// recordRedirects 记录文章或页面的旧地址（固定链接和 ?p=ID 形式的地址）
+func (m *wordpressImport) recordRedirects(ctx context.Context, item *wxrItem, target string) error {
+	for _, link := range []string{item.Link, item.GUID} {
+		source := permalinkPath(link)
+		if source == "" || source == "/" || source == target {
+			continue
+		}
+		if link == item.GUID && !strings.Contains(source, "?") {
+			// guid 只在是 ?p=ID 形式时作为旧地址
+			continue
+		}
+		m.redirects[source] = target
+		m.report.RedirectsRecorded++
+		if m.opts.DryRun {
+			continue
+		}
+		if err := m.store.SaveRedirect(ctx, source, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteLinks 将正文中指向原站点文章和页面的链接改写为新地址
+func (m *wordpressImport) rewriteLinks(content string) string {
+	return markdownLinkDestPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := markdownLinkDestPattern.FindStringSubmatch(match)
+		dest := strings.TrimSuffix(strings.TrimPrefix(parts[2], "<"), ">")
+		u, err := url.Parse(dest)
+		if err != nil || (u.Host != "" && strings.ToLower(u.Host) != m.siteHost) || (u.Host == "" && !strings.HasPrefix(u.Path, "/")) {
+			return match
+		}
+		target, ok := m.redirects[permalinkPath(dest)]
+		if !ok || strings.HasSuffix(target, "/0") {
+			return match
+		}
+		if u.Fragment != "" {
+			target += "#" + u.Fragment
+		}
+		return parts[1] + target
+	})
+}
+
+// resolveLinks 第二遍处理：改写文章中指向之后才导入的文章的链接
+func (m *wordpressImport) resolveLinks(ctx context.Context) error {
+	for _, created := range m.created {
+		content := m.rewriteLinks(created.content)
+		if content == created.content {
+			continue
+		}
+		note, err := m.store.GetNote(ctx, created.id)
+		if err != nil {
+			return fmt.Errorf("failed to resolve links: %w", err)
+		}
+		note.Content = content
+		if !created.hasExcerpt {
+			// 自动生成的摘要中包含改写前的链接，清空后由存储层重新生成
+			note.Summary = ""
+		}
+		if _, err := m.store.UpdateNote(ctx, note); err != nil {
+			return fmt.Errorf("failed to resolve links: %w", err)
+		}
+	}
+	return nil
+}
+
+// wpautop 将经典编辑器中以空行分隔的段落转换为 <p>，单个换行转换为 <br>
+// 区块编辑器（Gutenberg）的内容和已经使用 <p> 的内容保持原样
+func wpautop(content string) string {
+	if strings.Contains(content, "<!-- wp:") || paragraphTagPattern.MatchString(content) {
+		return content
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	var pres []string
+	content = preBlockPattern.ReplaceAllStringFunc(content, func(match string) string {
+		pres = append(pres, match)
+		return fmt.Sprintf("\x00%d\x00", len(pres)-1)
+	})
+
+	var sb strings.Builder
+	for _, chunk := range paragraphSplitPattern.Split(content, -1) {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+		if blockStartPattern.MatchString(chunk) {
+			sb.WriteString(chunk + "\n")
+		} else {
+			sb.WriteString("<p>" + strings.ReplaceAll(chunk, "\n", "<br>\n") + "</p>\n")
+		}
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(sb.String(), func(match string) string {
+		index, _ := strconv.Atoi(placeholderPattern.FindStringSubmatch(match)[1])
+		return pres[index]
+	})
+}
+
+// wpTime 解析 WordPress 的时间，优先使用 GMT 时间
+func wpTime(gmt, local string) time.Time {
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", strings.TrimSpace(gmt), time.UTC); err == nil {
+		return t
+	}
+	if t, ok := parseTime(strings.TrimSpace(local)); ok && t.Year() > 1 {
+		return t
+	}
+	return time.Time{}
+}
+
+// uploadsPath 返回地址在上传目录中的相对路径，不是上传目录中的文件时返回空字符串
+func uploadsPath(rawURL string) string {
+	const marker = "/wp-content/uploads/"
+	index := strings.Index(rawURL, marker)
+	if index < 0 {
+		return ""
+	}
+	rel := rawURL[index+len(marker):]
+	if end := strings.IndexAny(rel, "?#"); end >= 0 {
+		rel = rel[:end]
+	}
+	if unescaped, err := url.PathUnescape(rel); err == nil {
+		rel = unescaped
+	}
+	rel = path.Clean(rel)
+	if !fs.ValidPath(rel) || rel == "." {
+		return ""
+	}
+	return rel
+}
+
+// permalinkPath 返回旧地址的路径部分（保留查询参数，去掉末尾的斜杠），用作重定向的来源
+func permalinkPath(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (u.Path == "" && u.RawQuery == "") {
+		return ""
+	}
+	p := u.EscapedPath()
+	if p == "" {
+		p = "/"
+	}
+	if len(p) > 1 {
+		p = strings.TrimSuffix(p, "/")
+	}
+	if u.RawQuery != "" {
+		p += "?" + u.RawQuery
+	}
+	return p
+}
+
+// pageURL 返回独立页面的站内地址
+func pageURL(slug string) string {
+	return "/page/" + url.PathEscape(slug)
+}
@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+)
+
+// Cache 渲染结果缓存
+// 以笔记ID为键，并记录渲染时内容的 SHA-256 摘要，内容变化后旧结果自动失效；
+// 不使用 updated_at 判断，因为它只精确到秒，同一秒内的两次保存会命中旧结果。
+// 渲染选项在 Renderer 创建后不再变化，因此摘要只需要覆盖内容。
+// 超出容量时按最近最少使用（LRU）淘汰
+type Cache struct {
+	// mu 保护以下字段的并发访问
+	mu sync.Mutex
+	// capacity 最大缓存条目数
+	capacity int
+	// items 笔记ID到链表节点的映射
+	items map[int64]*list.Element
+	// order 按访问时间排序的链表，最近访问的在前
+	order *list.List
+}
+
+// cacheEntry 缓存条目
+type cacheEntry struct {
+	// noteID 笔记ID
+	noteID int64
+	// digest 渲染时内容的摘要
+	digest [sha256.Size]byte
+	// html 渲染结果
+	html string
+}
+
+// NewCache 创建新的渲染结果缓存
+func NewCache(capacity int) *Cache {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+	return &Cache{
+		capacity: capacity,
+		items:    make(map[int64]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// contentDigest 计算内容的摘要，作为缓存是否过期的依据
+func contentDigest(content string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(content))
+}
+
+// Get 获取缓存的渲染结果，仅当内容与缓存时一致才命中
+func (c *Cache) Get(noteID int64, content string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[noteID]
+	if !ok {
+		return "", false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if entry.digest != contentDigest(content) {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return entry.html, true
+}
+
+// Put 写入渲染结果，content 为渲染的原始内容
+func (c *Cache) Put(noteID int64, content, html string) {
+	digest := contentDigest(content)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[noteID]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.digest = digest
+		entry.html = html
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[noteID] = c.order.PushFront(&cacheEntry{noteID: noteID, digest: digest, html: html})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).noteID)
+	}
+}
+
+// Delete 删除指定笔记的缓存
+func (c *Cache) Delete(noteID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[noteID]; ok {
+		c.order.Remove(elem)
+		delete(c.items, noteID)
+	}
+}
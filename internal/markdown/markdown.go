@@ -0,0 +1,154 @@
+// markdown 包提供服务端 Markdown 渲染能力
+// 渲染规则与前端 MarkdownContent 组件保持一致，输出经过安全过滤的 HTML，
+// 供订阅源、邮件、搜索摘要和 SEO 页面等服务端场景使用
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// DefaultCacheSize 默认缓存的渲染结果数量
+const DefaultCacheSize = 512
+
+// Renderer Markdown 渲染器
+// 支持 GFM（表格、删除线、自动链接）、任务列表、脚注、代码高亮类名和标题锚点，
+// 渲染结果统一经过 HTML 安全过滤
+type Renderer struct {
+	// md goldmark 渲染实例
+	md goldmark.Markdown
+	// policy HTML 安全过滤策略
+	policy *bluemonday.Policy
+	// cache 按笔记缓存的渲染结果
+	cache *Cache
+}
+
+// NewRenderer 创建新的渲染器实例，cacheSize 为缓存的笔记数量上限
+func NewRenderer(cacheSize int) *Renderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			extension.Footnote,
+		),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+		),
+		goldmark.WithRendererOptions(
+			// 与前端 remark-breaks 保持一致：单个换行渲染为 <br>
+			html.WithHardWraps(),
+			// 允许原始 HTML（如 <font>），由后续的安全过滤统一处理
+			html.WithUnsafe(),
+		),
+	)
+
+	return &Renderer{
+		md:     md,
+		policy: newPolicy(),
+		cache:  NewCache(cacheSize),
+	}
+}
+
+// Render 将 Markdown 内容渲染为经过安全过滤的 HTML
+func (r *Renderer) Render(source string) (string, error) {
+	var buf bytes.Buffer
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	if err := r.md.Convert([]byte(source), &buf, parser.WithContext(ctx)); err != nil {
+		return "", err
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
+
+// RenderNote 渲染笔记内容，结果按笔记ID缓存，并以内容摘要判断缓存是否过期
+func (r *Renderer) RenderNote(noteID int64, content string) (string, error) {
+	if rendered, ok := r.cache.Get(noteID, content); ok {
+		return rendered, nil
+	}
+
+	rendered, err := r.Render(content)
+	if err != nil {
+		return "", err
+	}
+	r.cache.Put(noteID, content, rendered)
+	return rendered, nil
+}
+
+// Invalidate 使指定笔记的缓存失效（例如笔记被删除时）
+func (r *Renderer) Invalidate(noteID int64) {
+	r.cache.Delete(noteID)
+}
+
+// newPolicy 创建 HTML 安全过滤策略
+// 在 UGC 策略的基础上，放行渲染器自身生成的类名、锚点和任务列表复选框
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+
+	// 代码块语言类名，例如 language-go，供前端高亮库使用
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	// 标题锚点
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	// 脚注的锚点、类名和回链属性
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^fn(ref)?:[\w-]+$`)).OnElements("li", "sup")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^footnote(s|-ref|-backref)$`)).OnElements("a", "div", "sup")
+	policy.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-(noteref|backlink|endnotes)$`)).OnElements("a", "div")
+	// 任务列表复选框
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	// 与前端保持一致，允许 <font> 标签
+	policy.AllowAttrs("face", "size", "color").OnElements("font")
+
+	return policy
+}
+
+// headingIDs 生成标题锚点ID
+// goldmark 默认实现会丢弃非 ASCII 字符，中文标题会全部变成 "heading"，
+// 这里与 GitHub 的规则保持一致：保留 Unicode 字母和数字，空白替换为连字符
+type headingIDs struct {
+	// values 已使用的ID，用于去重
+	values map[string]bool
+}
+
+// newHeadingIDs 创建新的标题ID生成器（每次渲染一个）
+func newHeadingIDs() parser.IDs {
+	return &headingIDs{values: map[string]bool{}}
+}
+
+// Generate 根据标题文本生成唯一ID
+func (h *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	value = util.TrimLeftSpace(util.TrimRightSpace(value))
+	var sb strings.Builder
+	for _, r := range strings.ToLower(string(value)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_' || r == '-':
+			sb.WriteRune(r)
+		case unicode.IsSpace(r):
+			sb.WriteRune('-')
+		}
+	}
+	id := sb.String()
+	if id == "" {
+		id = "heading"
+	}
+
+	result := id
+	for i := 1; h.values[result]; i++ {
+		result = id + "-" + strconv.Itoa(i)
+	}
+	h.values[result] = true
+	return []byte(result)
+}
+
+// Put 记录外部指定的ID，避免重复
+func (h *headingIDs) Put(value []byte) {
+	h.values[string(value)] = true
+}
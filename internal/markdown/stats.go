@@ -0,0 +1,165 @@
+package markdown
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	// DefaultSummaryLength 自动生成摘要的最大字符数
+	DefaultSummaryLength = 200
+	// cjkCharsPerMinute 中日韩文字的阅读速度（字/分钟）
+	cjkCharsPerMinute = 300
+	// wordsPerMinute 拉丁语系等以空白分词文字的阅读速度（词/分钟）
+	wordsPerMinute = 200
+)
+
+// parserOnly 仅用于解析语法树的 goldmark 实例（提取纯文本时不需要渲染）
+var parserOnly = goldmark.New(goldmark.WithExtensions(extension.GFM, extension.Footnote))
+
+// TextStats 文本统计结果
+type TextStats struct {
+	// WordCount 字数：每个中日韩文字计为一个字，其余文字按连续的字母数字计为一个词
+	WordCount int
+	// CharCount 字符数（不含空白）
+	CharCount int
+	// ReadingTime 预计阅读时间（分钟），有内容时至少为1
+	ReadingTime int
+}
+
+// ComputeStats 统计 Markdown 内容的字数、字符数和阅读时间
+func ComputeStats(source string) TextStats {
+	plain := PlainText(source)
+
+	var stats TextStats
+	cjkCount := 0
+	inWord := false
+	for _, r := range plain {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+		stats.CharCount++
+
+		switch {
+		case isCJK(r):
+			cjkCount++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				stats.WordCount++
+				inWord = true
+			}
+		default:
+			// 标点符号不计入字数，但会断开单词
+			inWord = false
+		}
+	}
+
+	latinWords := stats.WordCount
+	stats.WordCount += cjkCount
+
+	if stats.CharCount > 0 {
+		minutes := float64(cjkCount)/cjkCharsPerMinute + float64(latinWords)/wordsPerMinute
+		stats.ReadingTime = int(math.Max(1, math.Ceil(minutes)))
+	}
+
+	return stats
+}
+
+// Summarize 从 Markdown 内容的开头几个段落生成纯文本摘要，超出 maxLength 个字符时截断
+func Summarize(source string, maxLength int) string {
+	if maxLength <= 0 {
+		maxLength = DefaultSummaryLength
+	}
+
+	src := []byte(source)
+	doc := parserOnly.Parser().Parse(text.NewReader(src))
+
+	var sb strings.Builder
+	for node := doc.FirstChild(); node != nil; node = node.NextSibling() {
+		// 只使用正文段落，跳过标题、代码块、表格等
+		if node.Kind() != ast.KindParagraph {
+			continue
+		}
+		paragraph := strings.Join(strings.Fields(extractText(node, src)), " ")
+		if paragraph == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(paragraph)
+		if utf8.RuneCountInString(sb.String()) >= maxLength {
+			break
+		}
+	}
+
+	return truncateRunes(sb.String(), maxLength)
+}
+
+// PlainText 提取 Markdown 内容中的纯文本（去除标记和原始 HTML）
+func PlainText(source string) string {
+	src := []byte(source)
+	doc := parserOnly.Parser().Parse(text.NewReader(src))
+	return extractText(doc, src)
+}
+
+// extractText 递归提取节点下的纯文本，块级节点之间以换行分隔
+func extractText(root ast.Node, source []byte) string {
+	var sb strings.Builder
+	_ = ast.Walk(root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if node.Type() == ast.TypeBlock {
+				sb.WriteString("\n")
+			}
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Text:
+			sb.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				sb.WriteString(" ")
+			}
+		case *ast.String:
+			sb.Write(n.Value)
+		case *ast.AutoLink:
+			sb.Write(n.Label(source))
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			lines := n.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				segment := lines.At(i)
+				sb.Write(segment.Value(source))
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return sb.String()
+}
+
+// truncateRunes 按字符截断字符串，截断时追加省略号
+func truncateRunes(s string, maxLength int) string {
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:maxLength-1])) + "…"
+}
+
+// isCJK 判断是否为按字计数的中日韩文字
+// 韩文以空格分词，按普通单词处理
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
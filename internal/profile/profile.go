@@ -1,9 +1,17 @@
 package profile
 
+import "time"
+
 // Profile 是启动服务器的配置
 type Profile struct {
 	// Driver 是数据库驱动类型 (sqlite, mysql, postgres)
 	Driver string
 	// DSN 是数据库连接字符串
 	DSN string
+	// Data 是数据目录，用于存放静态站点导出、备份等文件
+	Data string
+	// BackupInterval 是定时备份的间隔，为 0 时不启用定时备份
+	BackupInterval time.Duration
+	// BackupRetention 是定时备份保留的备份数量，为 0 时保留全部备份
+	BackupRetention int
 }
@@ -0,0 +1,182 @@
+package staticsite
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/feeds"
+)
+
+// sitemapURLSet sitemap 根节点
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL sitemap 中的一个地址
+type sitemapURL struct {
+	// Loc 页面地址
+	Loc string `xml:"loc"`
+	// LastMod 最后修改时间（可选）
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// exportFeeds 生成最近发布笔记的 RSS 和 Atom 订阅源
+func (x *export) exportFeeds(ctx context.Context) error {
+	baseURL := x.opts.BaseURL
+	feed := &feeds.Feed{
+		Title:       siteName,
+		Link:        &feeds.Link{Href: baseURL + "/"},
+		Description: siteName + " 最新发布的笔记",
+		Id:          baseURL + "/",
+		Updated:     time.Now(),
+	}
+
+	authors := map[string]*feeds.Author{}
+	for i, n := range x.notes {
+		if i >= maxFeedItems {
+			break
+		}
+		note := n.note
+		if i == 0 {
+			feed.Updated = time.Unix(note.UpdatedAt, 0)
+		}
+
+		content, err := x.renderer.RenderNote(note.Id, note.Content)
+		if err != nil {
+			return fmt.Errorf("failed to render note %d: %w", note.Id, err)
+		}
+		link := baseURL + n.view.URL
+		item := &feeds.Item{
+			Title:       note.Title,
+			Link:        &feeds.Link{Href: link},
+			Id:          link,
+			IsPermaLink: "true",
+			Description: html.EscapeString(note.Summary),
+			Content:     content,
+			Created:     n.view.PublishedAt,
+			Updated:     n.view.UpdatedAt,
+			Author:      x.getAuthor(ctx, authors, note.AuthorId),
+		}
+		// RSS 每个条目只允许一个 enclosure，使用笔记自身的第一个附件
+		for _, attachment := range n.attachments {
+			if attachment.NoteId != fmt.Sprintf("notes/%d", note.Id) || safeName(attachment.Filename) == "" {
+				continue
+			}
+			contentType := attachment.Type
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			item.Enclosure = &feeds.Enclosure{
+				Url:    baseURL + attachmentURL(attachment.Id, attachment.Filename),
+				Length: strconv.FormatInt(attachment.Size, 10),
+				Type:   contentType,
+			}
+			break
+		}
+		feed.Add(item)
+	}
+
+	var rss bytes.Buffer
+	if err := feed.WriteRss(&rss); err != nil {
+		return fmt.Errorf("failed to encode rss feed: %w", err)
+	}
+	if err := x.writeFile("feed.xml", rss.Bytes()); err != nil {
+		return err
+	}
+
+	var atom bytes.Buffer
+	if err := feed.WriteAtom(&atom); err != nil {
+		return fmt.Errorf("failed to encode atom feed: %w", err)
+	}
+	return x.writeFile("atom.xml", atom.Bytes())
+}
+
+// getAuthor 获取笔记作者信息，用户不存在时返回 nil
+func (x *export) getAuthor(ctx context.Context, cache map[string]*feeds.Author, authorID string) *feeds.Author {
+	if author, ok := cache[authorID]; ok {
+		return author
+	}
+
+	var author *feeds.Author
+	if id, err := strconv.ParseUint(authorID, 10, 32); err == nil {
+		if user, err := x.store.GetUserByID(ctx, uint(id)); err == nil && user != nil {
+			name := user.Nickname
+			if name == "" {
+				name = user.Username
+			}
+			author = &feeds.Author{Name: name}
+		}
+	}
+	cache[authorID] = author
+	return author
+}
+
+// exportSitemap 生成 sitemap.xml 和 robots.txt
+func (x *export) exportSitemap(ctx context.Context) error {
+	baseURL := x.opts.BaseURL
+	urls := []sitemapURL{{Loc: baseURL + "/"}}
+	for _, n := range x.notes {
+		urls = append(urls, sitemapURL{Loc: baseURL + n.view.URL, LastMod: formatLastMod(n.note.UpdatedAt)})
+	}
+	categoryIDs := make([]int64, 0, len(x.categories))
+	for id := range x.categories {
+		categoryIDs = append(categoryIDs, id)
+	}
+	slices.Sort(categoryIDs)
+	for _, id := range categoryIDs {
+		urls = append(urls, sitemapURL{Loc: baseURL + x.categories[id].URL})
+	}
+	tagged := make(map[int64]bool)
+	for _, n := range x.notes {
+		for _, tag := range n.view.Tags {
+			if !tagged[tag.ID] {
+				tagged[tag.ID] = true
+				urls = append(urls, sitemapURL{Loc: baseURL + tag.URL})
+			}
+		}
+	}
+	pages, err := x.store.ListPages(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+	for _, page := range pages {
+		if safeName(page.Slug) != "" {
+			urls = append(urls, sitemapURL{Loc: baseURL + pageURL(page.Slug), LastMod: formatLastMod(page.UpdatedAt)})
+		}
+	}
+	urls = append(urls, sitemapURL{Loc: baseURL + "/archives/"})
+
+	data, err := xml.MarshalIndent(&sitemapURLSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  urls,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sitemap: %w", err)
+	}
+	if err := x.writeFile("sitemap.xml", append([]byte(xml.Header), data...)); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("User-agent: *\n")
+	sb.WriteString("Allow: /\n")
+	sb.WriteString("\nSitemap: " + baseURL + "/sitemap.xml\n")
+	return x.writeFile("robots.txt", []byte(sb.String()))
+}
+
+// formatLastMod 将 Unix 时间戳格式化为 sitemap 的 lastmod（W3C 日期时间格式）
+func formatLastMod(timestamp int64) string {
+	if timestamp <= 0 {
+		return ""
+	}
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,92 @@
+package staticsite
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// manifestFilename 导出清单文件名，记录上次导出的内容，用于增量导出
+const manifestFilename = ".export-manifest.json"
+
+// manifest 导出清单
+type manifest struct {
+	// ThemeVersion 导出时使用的主题版本
+	ThemeVersion int `json:"theme_version"`
+	// BaseURL 导出时使用的站点根地址
+	BaseURL string `json:"base_url"`
+	// Layout 导出时站点公共部分（导航等）的摘要，变化后需要重新生成全部页面
+	Layout string `json:"layout"`
+	// Notes 已导出的笔记，键为笔记ID
+	Notes map[int64]*manifestEntry `json:"notes"`
+	// Pages 已导出的页面，键为页面ID
+	Pages map[int64]*manifestEntry `json:"pages"`
+	// Attachments 已导出的附件，键为附件ID
+	Attachments map[int64]*manifestEntry `json:"attachments"`
+}
+
+// manifestEntry 清单中的一项
+type manifestEntry struct {
+	// UpdatedAt 导出时内容的更新时间（Unix时间戳，秒）
+	UpdatedAt int64 `json:"updated_at"`
+	// Digest 影响页面内容的其他数据（分类、标签、附件等）的摘要
+	Digest string `json:"digest,omitempty"`
+	// Path 导出的文件或目录（相对于输出目录）
+	Path string `json:"path"`
+}
+
+// newManifest 创建空的导出清单
+func newManifest() *manifest {
+	return &manifest{
+		ThemeVersion: themeVersion,
+		Notes:        make(map[int64]*manifestEntry),
+		Pages:        make(map[int64]*manifestEntry),
+		Attachments:  make(map[int64]*manifestEntry),
+	}
+}
+
+// loadManifest 读取输出目录中的导出清单，不存在时返回空清单
+func loadManifest(outputDir string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, manifestFilename))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return newManifest(), nil
+		}
+		return nil, err
+	}
+
+	m := newManifest()
+	if err := json.Unmarshal(data, m); err != nil {
+		// 清单损坏时视为首次导出
+		return newManifest(), nil
+	}
+	if m.Notes == nil {
+		m.Notes = make(map[int64]*manifestEntry)
+	}
+	if m.Pages == nil {
+		m.Pages = make(map[int64]*manifestEntry)
+	}
+	if m.Attachments == nil {
+		m.Attachments = make(map[int64]*manifestEntry)
+	}
+	return m, nil
+}
+
+// save 将导出清单写入输出目录
+func (m *manifest) save(outputDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outputDir, manifestFilename), data, 0644)
+}
+
+// isFresh 判断清单中的内容是否仍是最新的（更新时间和摘要一致且导出文件存在）
+func isFresh(entry *manifestEntry, updatedAt int64, digest string, outputDir string) bool {
+	if entry == nil || entry.UpdatedAt != updatedAt || entry.Digest != digest {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(outputDir, entry.Path))
+	return err == nil
+}
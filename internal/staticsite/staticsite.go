@@ -0,0 +1,683 @@
+// Package staticsite 将已发布的公开笔记导出为静态网站，便于部署到静态托管服务
+package staticsite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/internal/markdown"
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// siteName 站点名称
+	siteName = "Simple Notes"
+	// siteDescription 站点默认描述
+	siteDescription = "一个简洁优雅的笔记管理系统，支持 Markdown 编辑、分类管理、标签系统等功能。"
+	// DefaultPageSize 首页每页显示的笔记数量
+	DefaultPageSize = 10
+	// listPageSize 分批查询笔记的数量
+	listPageSize = 100
+	// maxFeedItems 订阅源中最多包含的笔记数量
+	maxFeedItems = 20
+)
+
+// ErrExportInProgress 已有导出任务正在进行
+var ErrExportInProgress = errors.New("static site export already in progress")
+
+// attachmentRefPattern 匹配正文中引用的站内附件地址
+var attachmentRefPattern = regexp.MustCompile(`/file/attachments/(\d+)/`)
+
+// Options 导出选项
+type Options struct {
+	// OutputDir 输出目录
+	OutputDir string
+	// BaseURL 站点根地址，例如 https://notes.example.com（用于规范地址、订阅源和 sitemap）
+	BaseURL string
+	// Force 是否忽略导出清单，重新生成全部页面
+	Force bool
+	// PageSize 首页每页显示的笔记数量，默认为 DefaultPageSize
+	PageSize int
+}
+
+// Result 导出结果
+type Result struct {
+	// NotesRendered 重新生成的笔记数量
+	NotesRendered int
+	// NotesSkipped 未变化而跳过的笔记数量
+	NotesSkipped int
+	// NotesRemoved 已不再公开而删除的笔记数量
+	NotesRemoved int
+	// PagesRendered 重新生成的独立页面数量
+	PagesRendered int
+	// AttachmentsCopied 复制的附件数量
+	AttachmentsCopied int
+}
+
+// Exporter 静态网站导出器
+type Exporter struct {
+	// store 数据存储实例
+	store *store.Store
+	// renderer Markdown 渲染器
+	renderer *markdown.Renderer
+	// theme 内置主题
+	theme *theme
+	// mu 保证同一时间只有一个导出任务
+	mu sync.Mutex
+}
+
+// NewExporter 创建静态网站导出器
+func NewExporter(s *store.Store, renderer *markdown.Renderer) (*Exporter, error) {
+	t, err := loadTheme()
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{store: s, renderer: renderer, theme: t}, nil
+}
+
+// exportNote 待导出的笔记及其附件
+type exportNote struct {
+	// note 笔记
+	note *storepb.Note
+	// view 模板数据（不含正文）
+	view *noteView
+	// attachments 笔记附件（包括正文中引用的附件）
+	attachments []*storepb.Attachment
+}
+
+// export 一次导出任务的状态
+type export struct {
+	*Exporter
+	// opts 导出选项
+	opts Options
+	// manifest 导出清单
+	manifest *manifest
+	// site 站点信息
+	site *siteView
+	// categories 可见分类，键为分类ID
+	categories map[int64]*termView
+	// tags 标签，键为标签ID
+	tags map[int64]*termView
+	// notes 按发布时间倒序的笔记
+	notes []*exportNote
+	// result 导出结果
+	result *Result
+}
+
+// Export 导出静态网站，根据导出清单只重新生成有变化的笔记和页面
+func (e *Exporter) Export(ctx context.Context, opts Options) (*Result, error) {
+	if opts.OutputDir == "" {
+		return nil, errors.New("output directory is required")
+	}
+	opts.BaseURL = strings.TrimRight(strings.TrimSpace(opts.BaseURL), "/")
+	if !strings.HasPrefix(opts.BaseURL, "http://") && !strings.HasPrefix(opts.BaseURL, "https://") {
+		return nil, fmt.Errorf("invalid base url: %q", opts.BaseURL)
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+
+	if !e.mu.TryLock() {
+		return nil, ErrExportInProgress
+	}
+	defer e.mu.Unlock()
+
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	m, err := loadManifest(opts.OutputDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load export manifest: %w", err)
+	}
+
+	x := &export{Exporter: e, opts: opts, manifest: m, result: &Result{}}
+	if err := x.load(ctx); err != nil {
+		return nil, err
+	}
+
+	// 主题、站点地址或导航变化时重新生成全部页面
+	layout := x.layoutDigest()
+	if opts.Force || m.ThemeVersion != themeVersion || m.BaseURL != opts.BaseURL || m.Layout != layout {
+		x.manifest = newManifest()
+	}
+	x.manifest.BaseURL = opts.BaseURL
+	x.manifest.Layout = layout
+
+	steps := []func(context.Context) error{
+		x.exportNotes,
+		x.exportPages,
+		x.exportLists,
+		x.exportFeeds,
+		x.exportSitemap,
+		x.exportAssets,
+	}
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := step(ctx); err != nil {
+			// 保存已完成的部分，下次导出可以继续增量进行
+			_ = x.manifest.save(opts.OutputDir)
+			return nil, err
+		}
+	}
+
+	if err := x.manifest.save(opts.OutputDir); err != nil {
+		return nil, fmt.Errorf("failed to save export manifest: %w", err)
+	}
+	return x.result, nil
+}
+
+// load 读取导出需要的分类、标签、页面导航以及全部已发布的公开笔记
+func (x *export) load(ctx context.Context) error {
+	x.site = &siteView{Name: siteName, BaseURL: x.opts.BaseURL, Year: time.Now().Year()}
+
+	categories, err := x.store.ListCategories(ctx, &apiv1.ListCategoriesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+	x.categories = make(map[int64]*termView, len(categories))
+	for _, category := range categories {
+		x.categories[category.Id] = &termView{
+			ID:          category.Id,
+			Name:        category.NameText,
+			Description: category.Description,
+			URL:         fmt.Sprintf("/category/%d/", category.Id),
+		}
+	}
+
+	tags, _, err := x.store.ListTags(ctx, &apiv1.ListTagsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	x.tags = make(map[int64]*termView, len(tags))
+	for _, tag := range tags {
+		x.tags[tag.Id] = &termView{
+			ID:          tag.Id,
+			Name:        tag.NameText,
+			Description: tag.Description,
+			URL:         fmt.Sprintf("/tag/%d/", tag.Id),
+		}
+	}
+
+	pages, err := x.store.ListPages(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+	for _, page := range pages {
+		if page.InNavigation && safeName(page.Slug) != "" {
+			x.site.Navigation = append(x.site.Navigation, &linkView{Title: page.Title, URL: pageURL(page.Slug)})
+		}
+	}
+
+	// 已发布的公开笔记，按发布时间倒序分批查询
+	for page := int32(1); ; page++ {
+		notes, _, err := x.store.ListNotes(ctx, &store.ListNotesRequest{
+			Page:       page,
+			PageSize:   listPageSize,
+			SortBy:     "published_at",
+			SortDesc:   true,
+			Visibility: "PUBLIC",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list notes: %w", err)
+		}
+		for _, note := range notes {
+			x.notes = append(x.notes, &exportNote{note: note, view: x.noteView(note)})
+		}
+		if len(notes) < listPageSize {
+			break
+		}
+	}
+
+	return x.loadAttachments(ctx)
+}
+
+// loadAttachments 收集每篇笔记需要导出的附件
+// 除笔记自身的附件外，正文中引用的附件只有在属于其他导出笔记，
+// 或尚未关联笔记且与笔记作者相同时才会导出，避免泄露私有笔记的附件
+func (x *export) loadAttachments(ctx context.Context) error {
+	exported := make(map[string]bool, len(x.notes))
+	for _, n := range x.notes {
+		exported[fmt.Sprintf("notes/%d", n.note.Id)] = true
+	}
+
+	for _, n := range x.notes {
+		noteID := n.note.Id
+		attachments, err := x.store.ListAttachments(ctx, &noteID, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list attachments of note %d: %w", noteID, err)
+		}
+		n.attachments = attachments
+
+		seen := make(map[int64]bool, len(attachments))
+		for _, attachment := range attachments {
+			seen[attachment.Id] = true
+			if name := safeName(attachment.Filename); name != "" {
+				n.view.Attachments = append(n.view.Attachments, &attachmentView{
+					Filename: attachment.Filename,
+					URL:      attachmentURL(attachment.Id, attachment.Filename),
+				})
+			}
+		}
+
+		for _, match := range attachmentRefPattern.FindAllStringSubmatch(n.note.Content+"\n"+n.note.CoverImage, -1) {
+			id, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil || seen[id] {
+				continue
+			}
+			seen[id] = true
+			attachment, err := x.store.GetAttachment(ctx, id)
+			if err != nil {
+				continue
+			}
+			if exported[attachment.NoteId] || (attachment.NoteId == "" && attachment.AuthorId == n.note.AuthorId) {
+				n.attachments = append(n.attachments, attachment)
+			}
+		}
+	}
+	return nil
+}
+
+// noteView 将笔记转换为模板数据（不含正文）
+func (x *export) noteView(note *storepb.Note) *noteView {
+	view := &noteView{
+		ID:          note.Id,
+		Title:       note.Title,
+		Summary:     note.Summary,
+		URL:         noteURL(note.Id),
+		CoverImage:  note.CoverImage,
+		ReadingTime: note.ReadingTime,
+		PublishedAt: time.Unix(note.PublishedAt, 0),
+		UpdatedAt:   time.Unix(note.UpdatedAt, 0),
+	}
+	if note.PublishedAt <= 0 {
+		view.PublishedAt = time.Unix(note.CreatedAt, 0)
+	}
+	if categoryID, err := strconv.ParseInt(note.CategoryId, 10, 64); err == nil {
+		view.Category = x.categories[categoryID]
+	}
+	for _, tagID := range note.TagIds {
+		if id, err := strconv.ParseInt(tagID, 10, 64); err == nil {
+			if tag, ok := x.tags[id]; ok {
+				view.Tags = append(view.Tags, tag)
+			}
+		}
+	}
+	return view
+}
+
+// exportNotes 生成笔记页面并复制附件，未变化的笔记直接跳过
+func (x *export) exportNotes(ctx context.Context) error {
+	current := make(map[int64]bool, len(x.notes))
+	attachments := make(map[int64]bool)
+	for _, n := range x.notes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		current[n.note.Id] = true
+
+		for _, attachment := range n.attachments {
+			if attachments[attachment.Id] {
+				continue
+			}
+			attachments[attachment.Id] = true
+			if err := x.copyAttachment(attachment); err != nil {
+				return err
+			}
+		}
+
+		digest := noteDigest(n)
+		entry := x.manifest.Notes[n.note.Id]
+		if isFresh(entry, n.note.UpdatedAt, digest, x.opts.OutputDir) {
+			x.result.NotesSkipped++
+			continue
+		}
+
+		content, err := x.renderer.RenderNote(n.note.Id, n.note.Content)
+		if err != nil {
+			return fmt.Errorf("failed to render note %d: %w", n.note.Id, err)
+		}
+		view := *n.view
+		view.Content = template.HTML(content)
+
+		dir := fmt.Sprintf("note/%d", n.note.Id)
+		if err := x.writePage(dir, "note", &pageData{
+			Title:       n.note.Title,
+			Description: x.describe(n.note.Summary, n.note.Content),
+			Path:        n.view.URL,
+			Note:        &view,
+		}); err != nil {
+			return err
+		}
+		x.manifest.Notes[n.note.Id] = &manifestEntry{UpdatedAt: n.note.UpdatedAt, Digest: digest, Path: dir}
+		x.result.NotesRendered++
+	}
+
+	// 删除已不再公开的笔记和附件
+	for id, entry := range x.manifest.Notes {
+		if !current[id] {
+			if err := x.remove(entry.Path); err != nil {
+				return err
+			}
+			delete(x.manifest.Notes, id)
+			x.result.NotesRemoved++
+		}
+	}
+	for id, entry := range x.manifest.Attachments {
+		if !attachments[id] {
+			if err := x.remove(entry.Path); err != nil {
+				return err
+			}
+			delete(x.manifest.Attachments, id)
+		}
+	}
+	return nil
+}
+
+// copyAttachment 将附件写入与文件服务器相同的路径，使正文中的附件地址无需改写
+func (x *export) copyAttachment(attachment *storepb.Attachment) error {
+	name := safeName(attachment.Filename)
+	if name == "" {
+		return nil
+	}
+	dir := fmt.Sprintf("file/attachments/%d", attachment.Id)
+	if isFresh(x.manifest.Attachments[attachment.Id], attachment.UpdatedAt, name, x.opts.OutputDir) {
+		return nil
+	}
+
+	// 文件名可能已变化，先清理旧文件
+	if err := x.remove(dir); err != nil {
+		return err
+	}
+	if err := x.writeFile(dir+"/"+name, attachment.Content); err != nil {
+		return err
+	}
+	x.manifest.Attachments[attachment.Id] = &manifestEntry{UpdatedAt: attachment.UpdatedAt, Digest: name, Path: dir}
+	x.result.AttachmentsCopied++
+	return nil
+}
+
+// exportPages 生成已发布的独立页面
+func (x *export) exportPages(ctx context.Context) error {
+	pages, err := x.store.ListPages(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+
+	current := make(map[int64]bool, len(pages))
+	for _, page := range pages {
+		name := safeName(page.Slug)
+		if name == "" {
+			continue
+		}
+		current[page.Id] = true
+
+		dir := "page/" + name
+		entry := x.manifest.Pages[page.Id]
+		if isFresh(entry, page.UpdatedAt, name, x.opts.OutputDir) {
+			continue
+		}
+		// slug 可能已变化，先清理旧目录
+		if entry != nil && entry.Path != dir {
+			if err := x.remove(entry.Path); err != nil {
+				return err
+			}
+		}
+
+		content, err := x.renderer.Render(page.Content)
+		if err != nil {
+			return fmt.Errorf("failed to render page %s: %w", page.Slug, err)
+		}
+		if err := x.writePage(dir, "page", &pageData{
+			Title:       page.Title,
+			Description: markdown.Summarize(page.Content, markdown.DefaultSummaryLength),
+			Path:        pageURL(page.Slug),
+			Page:        &pageView{Title: page.Title, Content: template.HTML(content)},
+		}); err != nil {
+			return err
+		}
+		x.manifest.Pages[page.Id] = &manifestEntry{UpdatedAt: page.UpdatedAt, Digest: name, Path: dir}
+		x.result.PagesRendered++
+	}
+
+	for id, entry := range x.manifest.Pages {
+		if !current[id] {
+			if err := x.remove(entry.Path); err != nil {
+				return err
+			}
+			delete(x.manifest.Pages, id)
+		}
+	}
+	return nil
+}
+
+// exportLists 生成首页分页、分类、标签和归档页面
+// 这些页面只包含笔记的标题和摘要，每次导出都重新生成
+func (x *export) exportLists(_ context.Context) error {
+	// 清理旧的分页、分类和标签页面，避免残留已删除的内容
+	for _, dir := range []string{"p", "category", "tag"} {
+		if err := x.remove(dir); err != nil {
+			return err
+		}
+	}
+
+	views := make([]*noteView, len(x.notes))
+	for i, n := range x.notes {
+		views[i] = n.view
+	}
+
+	// 首页分页：第一页为 /，其余为 /p/{n}/
+	total := (len(views) + x.opts.PageSize - 1) / x.opts.PageSize
+	if total == 0 {
+		total = 1
+	}
+	for page := 1; page <= total; page++ {
+		start := (page - 1) * x.opts.PageSize
+		end := min(start+x.opts.PageSize, len(views))
+		pagination := &paginationView{Page: page, Total: total}
+		if page > 1 {
+			pagination.Prev = indexURL(page - 1)
+		}
+		if page < total {
+			pagination.Next = indexURL(page + 1)
+		}
+		data := &pageData{
+			Description: siteDescription,
+			Path:        indexURL(page),
+			Notes:       views[start:end],
+			Pagination:  pagination,
+		}
+		dir := strings.Trim(indexURL(page), "/")
+		if err := x.writePage(dir, "index", data); err != nil {
+			return err
+		}
+	}
+
+	// 分类页面
+	for _, category := range x.categories {
+		var notes []*noteView
+		for _, view := range views {
+			if view.Category != nil && view.Category.ID == category.ID {
+				notes = append(notes, view)
+			}
+		}
+		description := category.Description
+		if description == "" {
+			description = fmt.Sprintf("分类「%s」下的全部笔记", category.Name)
+		}
+		if err := x.writePage(strings.Trim(category.URL, "/"), "list", &pageData{
+			Title:       category.Name,
+			Description: description,
+			Path:        category.URL,
+			Notes:       notes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// 标签页面（只生成有公开笔记的标签）
+	tagNotes := make(map[int64][]*noteView)
+	for _, view := range views {
+		for _, tag := range view.Tags {
+			tagNotes[tag.ID] = append(tagNotes[tag.ID], view)
+		}
+	}
+	for tagID, notes := range tagNotes {
+		tag := x.tags[tagID]
+		description := tag.Description
+		if description == "" {
+			description = fmt.Sprintf("标签「%s」下的全部笔记", tag.Name)
+		}
+		if err := x.writePage(strings.Trim(tag.URL, "/"), "list", &pageData{
+			Title:       "#" + tag.Name,
+			Description: description,
+			Path:        tag.URL,
+			Notes:       notes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// 按月归档
+	var archives []*archiveView
+	for _, view := range views {
+		month := view.PublishedAt.Format("2006-01")
+		if len(archives) == 0 || archives[len(archives)-1].Month != month {
+			archives = append(archives, &archiveView{Month: month})
+		}
+		archive := archives[len(archives)-1]
+		archive.Notes = append(archive.Notes, view)
+	}
+	return x.writePage("archives", "archives", &pageData{
+		Title:    "归档",
+		Path:     "/archives/",
+		Archives: archives,
+	})
+}
+
+// exportAssets 写入主题的静态资源
+func (x *export) exportAssets(_ context.Context) error {
+	data, err := themeFiles.ReadFile("theme/style.css")
+	if err != nil {
+		return err
+	}
+	return x.writeFile("assets/style.css", data)
+}
+
+// layoutDigest 计算站点公共部分的摘要
+func (x *export) layoutDigest() string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%d", x.site.Name, x.site.Year)
+	for _, link := range x.site.Navigation {
+		fmt.Fprintf(hash, "|%s:%s", link.Title, link.URL)
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
+
+// noteDigest 计算笔记页面中除笔记本身以外的内容（分类、标签、附件）的摘要
+func noteDigest(n *exportNote) string {
+	hash := sha256.New()
+	if category := n.view.Category; category != nil {
+		fmt.Fprintf(hash, "c%d:%s|", category.ID, category.Name)
+	}
+	for _, tag := range n.view.Tags {
+		fmt.Fprintf(hash, "t%d:%s|", tag.ID, tag.Name)
+	}
+	for _, attachment := range n.view.Attachments {
+		fmt.Fprintf(hash, "a%s|", attachment.URL)
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
+
+// describe 返回页面描述，摘要为空时从正文生成
+func (x *export) describe(summary, content string) string {
+	if summary != "" {
+		return summary
+	}
+	return markdown.Summarize(content, markdown.DefaultSummaryLength)
+}
+
+// writePage 渲染页面模板并写入 {dir}/index.html
+func (x *export) writePage(dir, name string, data *pageData) error {
+	data.Site = x.site
+	content, err := x.theme.render(name, data)
+	if err != nil {
+		return err
+	}
+	return x.writeFile(filepath.Join(dir, "index.html"), content)
+}
+
+// writeFile 写入输出目录中的文件，先写临时文件再重命名，避免生成不完整的文件
+func (x *export) writeFile(path string, data []byte) error {
+	fullPath := filepath.Join(x.opts.OutputDir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	tmpPath := fullPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, fullPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// remove 删除输出目录中的文件或目录
+func (x *export) remove(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.RemoveAll(filepath.Join(x.opts.OutputDir, filepath.FromSlash(path))); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// safeName 检查文件名或 slug 能否直接作为路径中的一段，不能时返回空字符串
+func safeName(name string) string {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\\x00") {
+		return ""
+	}
+	return name
+}
+
+// noteURL 返回笔记页面地址
+func noteURL(id int64) string {
+	return fmt.Sprintf("/note/%d/", id)
+}
+
+// pageURL 返回独立页面地址
+func pageURL(slug string) string {
+	return "/page/" + url.PathEscape(slug) + "/"
+}
+
+// indexURL 返回首页分页地址
+func indexURL(page int) string {
+	if page <= 1 {
+		return "/"
+	}
+	return fmt.Sprintf("/p/%d/", page)
+}
+
+// attachmentURL 返回附件地址，与文件服务器的地址一致
+func attachmentURL(id int64, filename string) string {
+	return fmt.Sprintf("/file/attachments/%d/%s", id, url.PathEscape(filename))
+}
@@ -0,0 +1,168 @@
+package staticsite
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"time"
+)
+
+//go:embed theme/*
+var themeFiles embed.FS
+
+// themeVersion 主题版本，修改模板后需要递增，使下次导出时重新生成全部页面
+const themeVersion = 1
+
+// themePages 主题中的页面模板，每个页面与 layout.html 组合使用
+var themePages = []string{"index", "note", "list", "archives", "page"}
+
+// theme 已解析的主题模板
+type theme struct {
+	// pages 页面名称到模板的映射
+	pages map[string]*template.Template
+}
+
+// loadTheme 解析内置主题
+func loadTheme() (*theme, error) {
+	t := &theme{pages: make(map[string]*template.Template)}
+	for _, name := range themePages {
+		tmpl, err := template.ParseFS(themeFiles, "theme/layout.html", "theme/"+name+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse theme template %s: %w", name, err)
+		}
+		t.pages[name] = tmpl
+	}
+	return t, nil
+}
+
+// render 使用指定页面模板渲染数据
+func (t *theme) render(name string, data any) ([]byte, error) {
+	tmpl, ok := t.pages[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme template: %s", name)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// siteView 站点信息，每个页面都会用到
+type siteView struct {
+	// Name 站点名称
+	Name string
+	// BaseURL 站点根地址（不含末尾的斜杠）
+	BaseURL string
+	// Year 当前年份
+	Year int
+	// Navigation 导航中显示的页面
+	Navigation []*linkView
+}
+
+// linkView 链接
+type linkView struct {
+	// Title 链接文字
+	Title string
+	// URL 链接地址
+	URL string
+}
+
+// termView 分类或标签
+type termView struct {
+	// ID 分类或标签ID
+	ID int64
+	// Name 名称
+	Name string
+	// Description 描述
+	Description string
+	// URL 页面地址
+	URL string
+}
+
+// attachmentView 附件
+type attachmentView struct {
+	// Filename 文件名
+	Filename string
+	// URL 下载地址
+	URL string
+}
+
+// noteView 笔记
+type noteView struct {
+	// ID 笔记ID
+	ID int64
+	// Title 标题
+	Title string
+	// Summary 摘要
+	Summary string
+	// Content 渲染后的正文（已经过安全过滤）
+	Content template.HTML
+	// URL 页面地址
+	URL string
+	// CoverImage 封面图片
+	CoverImage string
+	// ReadingTime 阅读时间（分钟）
+	ReadingTime int32
+	// PublishedAt 发布时间
+	PublishedAt time.Time
+	// UpdatedAt 更新时间
+	UpdatedAt time.Time
+	// Category 分类（可选）
+	Category *termView
+	// Tags 标签
+	Tags []*termView
+	// Attachments 附件
+	Attachments []*attachmentView
+}
+
+// pageView 独立页面
+type pageView struct {
+	// Title 标题
+	Title string
+	// Content 渲染后的正文（已经过安全过滤）
+	Content template.HTML
+}
+
+// paginationView 分页信息
+type paginationView struct {
+	// Page 当前页码
+	Page int
+	// Total 总页数
+	Total int
+	// Prev 上一页地址（第一页为空）
+	Prev string
+	// Next 下一页地址（最后一页为空）
+	Next string
+}
+
+// archiveView 按月归档的笔记
+type archiveView struct {
+	// Month 月份，格式：2006-01
+	Month string
+	// Notes 当月发布的笔记
+	Notes []*noteView
+}
+
+// pageData 渲染模板时传入的数据
+type pageData struct {
+	// Site 站点信息
+	Site *siteView
+	// Title 页面标题（为空时只显示站点名称）
+	Title string
+	// Description 页面描述
+	Description string
+	// Path 页面路径，用于生成规范地址
+	Path string
+	// Notes 笔记列表（首页、分类、标签页）
+	Notes []*noteView
+	// Pagination 分页信息（首页）
+	Pagination *paginationView
+	// Note 笔记详情
+	Note *noteView
+	// Page 独立页面
+	Page *pageView
+	// Archives 归档
+	Archives []*archiveView
+}
@@ -53,6 +53,15 @@ const (
 	// CategoryServiceGetCategoryBySlugProcedure is the fully-qualified name of the CategoryService's
 	// GetCategoryBySlug RPC.
 	CategoryServiceGetCategoryBySlugProcedure = "/api.v1.CategoryService/GetCategoryBySlug"
+	// CategoryServiceGetCategoryTreeProcedure is the fully-qualified name of the CategoryService's
+	// GetCategoryTree RPC.
+	CategoryServiceGetCategoryTreeProcedure = "/api.v1.CategoryService/GetCategoryTree"
+	// CategoryServiceMoveCategoryProcedure is the fully-qualified name of the CategoryService's
+	// MoveCategory RPC.
+	CategoryServiceMoveCategoryProcedure = "/api.v1.CategoryService/MoveCategory"
+	// CategoryServiceReorderCategoriesProcedure is the fully-qualified name of the CategoryService's
+	// ReorderCategories RPC.
+	CategoryServiceReorderCategoriesProcedure = "/api.v1.CategoryService/ReorderCategories"
 )
 
 // CategoryServiceClient is a client for the api.v1.CategoryService service.
@@ -69,6 +78,12 @@ type CategoryServiceClient interface {
 	DeleteCategory(context.Context, *connect.Request[v1.DeleteCategoryRequest]) (*connect.Response[emptypb.Empty], error)
 	// GetCategoryBySlug 根据slug返回分类
 	GetCategoryBySlug(context.Context, *connect.Request[v1.GetCategoryBySlugRequest]) (*connect.Response[store.Category], error)
+	// GetCategoryTree 返回嵌套的分类树，包含每个分类的直接和递归已发布笔记数量
+	GetCategoryTree(context.Context, *connect.Request[v1.GetCategoryTreeRequest]) (*connect.Response[v1.GetCategoryTreeResponse], error)
+	// MoveCategory 将分类（连同其子分类）移动到新的父分类下
+	MoveCategory(context.Context, *connect.Request[v1.MoveCategoryRequest]) (*connect.Response[store.Category], error)
+	// ReorderCategories 批量设置分类的排序顺序
+	ReorderCategories(context.Context, *connect.Request[v1.ReorderCategoriesRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewCategoryServiceClient constructs a client for the api.v1.CategoryService service. By default,
@@ -118,6 +133,24 @@ func NewCategoryServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(categoryServiceMethods.ByName("GetCategoryBySlug")),
 			connect.WithClientOptions(opts...),
 		),
+		getCategoryTree: connect.NewClient[v1.GetCategoryTreeRequest, v1.GetCategoryTreeResponse](
+			httpClient,
+			baseURL+CategoryServiceGetCategoryTreeProcedure,
+			connect.WithSchema(categoryServiceMethods.ByName("GetCategoryTree")),
+			connect.WithClientOptions(opts...),
+		),
+		moveCategory: connect.NewClient[v1.MoveCategoryRequest, store.Category](
+			httpClient,
+			baseURL+CategoryServiceMoveCategoryProcedure,
+			connect.WithSchema(categoryServiceMethods.ByName("MoveCategory")),
+			connect.WithClientOptions(opts...),
+		),
+		reorderCategories: connect.NewClient[v1.ReorderCategoriesRequest, emptypb.Empty](
+			httpClient,
+			baseURL+CategoryServiceReorderCategoriesProcedure,
+			connect.WithSchema(categoryServiceMethods.ByName("ReorderCategories")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -129,6 +162,9 @@ type categoryServiceClient struct {
 	updateCategory    *connect.Client[v1.UpdateCategoryRequest, store.Category]
 	deleteCategory    *connect.Client[v1.DeleteCategoryRequest, emptypb.Empty]
 	getCategoryBySlug *connect.Client[v1.GetCategoryBySlugRequest, store.Category]
+	getCategoryTree   *connect.Client[v1.GetCategoryTreeRequest, v1.GetCategoryTreeResponse]
+	moveCategory      *connect.Client[v1.MoveCategoryRequest, store.Category]
+	reorderCategories *connect.Client[v1.ReorderCategoriesRequest, emptypb.Empty]
 }
 
 // ListCategories calls api.v1.CategoryService.ListCategories.
@@ -161,6 +197,21 @@ func (c *categoryServiceClient) GetCategoryBySlug(ctx context.Context, req *conn
 	return c.getCategoryBySlug.CallUnary(ctx, req)
 }
 
+// GetCategoryTree calls api.v1.CategoryService.GetCategoryTree.
+func (c *categoryServiceClient) GetCategoryTree(ctx context.Context, req *connect.Request[v1.GetCategoryTreeRequest]) (*connect.Response[v1.GetCategoryTreeResponse], error) {
+	return c.getCategoryTree.CallUnary(ctx, req)
+}
+
+// MoveCategory calls api.v1.CategoryService.MoveCategory.
+func (c *categoryServiceClient) MoveCategory(ctx context.Context, req *connect.Request[v1.MoveCategoryRequest]) (*connect.Response[store.Category], error) {
+	return c.moveCategory.CallUnary(ctx, req)
+}
+
+// ReorderCategories calls api.v1.CategoryService.ReorderCategories.
+func (c *categoryServiceClient) ReorderCategories(ctx context.Context, req *connect.Request[v1.ReorderCategoriesRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.reorderCategories.CallUnary(ctx, req)
+}
+
 // CategoryServiceHandler is an implementation of the api.v1.CategoryService service.
 type CategoryServiceHandler interface {
 	// ListCategories 返回分类列表
@@ -175,6 +226,12 @@ type CategoryServiceHandler interface {
 	DeleteCategory(context.Context, *connect.Request[v1.DeleteCategoryRequest]) (*connect.Response[emptypb.Empty], error)
 	// GetCategoryBySlug 根据slug返回分类
 	GetCategoryBySlug(context.Context, *connect.Request[v1.GetCategoryBySlugRequest]) (*connect.Response[store.Category], error)
+	// GetCategoryTree 返回嵌套的分类树，包含每个分类的直接和递归已发布笔记数量
+	GetCategoryTree(context.Context, *connect.Request[v1.GetCategoryTreeRequest]) (*connect.Response[v1.GetCategoryTreeResponse], error)
+	// MoveCategory 将分类（连同其子分类）移动到新的父分类下
+	MoveCategory(context.Context, *connect.Request[v1.MoveCategoryRequest]) (*connect.Response[store.Category], error)
+	// ReorderCategories 批量设置分类的排序顺序
+	ReorderCategories(context.Context, *connect.Request[v1.ReorderCategoriesRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewCategoryServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -220,6 +277,24 @@ func NewCategoryServiceHandler(svc CategoryServiceHandler, opts ...connect.Handl
 		connect.WithSchema(categoryServiceMethods.ByName("GetCategoryBySlug")),
 		connect.WithHandlerOptions(opts...),
 	)
+	categoryServiceGetCategoryTreeHandler := connect.NewUnaryHandler(
+		CategoryServiceGetCategoryTreeProcedure,
+		svc.GetCategoryTree,
+		connect.WithSchema(categoryServiceMethods.ByName("GetCategoryTree")),
+		connect.WithHandlerOptions(opts...),
+	)
+	categoryServiceMoveCategoryHandler := connect.NewUnaryHandler(
+		CategoryServiceMoveCategoryProcedure,
+		svc.MoveCategory,
+		connect.WithSchema(categoryServiceMethods.ByName("MoveCategory")),
+		connect.WithHandlerOptions(opts...),
+	)
+	categoryServiceReorderCategoriesHandler := connect.NewUnaryHandler(
+		CategoryServiceReorderCategoriesProcedure,
+		svc.ReorderCategories,
+		connect.WithSchema(categoryServiceMethods.ByName("ReorderCategories")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.CategoryService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case CategoryServiceListCategoriesProcedure:
@@ -234,6 +309,12 @@ func NewCategoryServiceHandler(svc CategoryServiceHandler, opts ...connect.Handl
 			categoryServiceDeleteCategoryHandler.ServeHTTP(w, r)
 		case CategoryServiceGetCategoryBySlugProcedure:
 			categoryServiceGetCategoryBySlugHandler.ServeHTTP(w, r)
+		case CategoryServiceGetCategoryTreeProcedure:
+			categoryServiceGetCategoryTreeHandler.ServeHTTP(w, r)
+		case CategoryServiceMoveCategoryProcedure:
+			categoryServiceMoveCategoryHandler.ServeHTTP(w, r)
+		case CategoryServiceReorderCategoriesProcedure:
+			categoryServiceReorderCategoriesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -266,3 +347,15 @@ func (UnimplementedCategoryServiceHandler) DeleteCategory(context.Context, *conn
 func (UnimplementedCategoryServiceHandler) GetCategoryBySlug(context.Context, *connect.Request[v1.GetCategoryBySlugRequest]) (*connect.Response[store.Category], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.CategoryService.GetCategoryBySlug is not implemented"))
 }
+
+func (UnimplementedCategoryServiceHandler) GetCategoryTree(context.Context, *connect.Request[v1.GetCategoryTreeRequest]) (*connect.Response[v1.GetCategoryTreeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.CategoryService.GetCategoryTree is not implemented"))
+}
+
+func (UnimplementedCategoryServiceHandler) MoveCategory(context.Context, *connect.Request[v1.MoveCategoryRequest]) (*connect.Response[store.Category], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.CategoryService.MoveCategory is not implemented"))
+}
+
+func (UnimplementedCategoryServiceHandler) ReorderCategories(context.Context, *connect.Request[v1.ReorderCategoriesRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.CategoryService.ReorderCategories is not implemented"))
+}
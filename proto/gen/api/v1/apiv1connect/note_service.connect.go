@@ -48,6 +48,58 @@ const (
 	// NoteServiceGetNoteBySlugProcedure is the fully-qualified name of the NoteService's GetNoteBySlug
 	// RPC.
 	NoteServiceGetNoteBySlugProcedure = "/api.v1.NoteService/GetNoteBySlug"
+	// NoteServiceRenderNoteProcedure is the fully-qualified name of the NoteService's RenderNote RPC.
+	NoteServiceRenderNoteProcedure = "/api.v1.NoteService/RenderNote"
+	// NoteServiceGetNoteStatsProcedure is the fully-qualified name of the NoteService's GetNoteStats
+	// RPC.
+	NoteServiceGetNoteStatsProcedure = "/api.v1.NoteService/GetNoteStats"
+	// NoteServiceImportNotesProcedure is the fully-qualified name of the NoteService's ImportNotes RPC.
+	NoteServiceImportNotesProcedure = "/api.v1.NoteService/ImportNotes"
+	// NoteServiceGetNoteDraftProcedure is the fully-qualified name of the NoteService's GetNoteDraft
+	// RPC.
+	NoteServiceGetNoteDraftProcedure = "/api.v1.NoteService/GetNoteDraft"
+	// NoteServiceSaveNoteDraftProcedure is the fully-qualified name of the NoteService's SaveNoteDraft
+	// RPC.
+	NoteServiceSaveNoteDraftProcedure = "/api.v1.NoteService/SaveNoteDraft"
+	// NoteServicePublishDraftProcedure is the fully-qualified name of the NoteService's PublishDraft
+	// RPC.
+	NoteServicePublishDraftProcedure = "/api.v1.NoteService/PublishDraft"
+	// NoteServiceDiscardDraftProcedure is the fully-qualified name of the NoteService's DiscardDraft
+	// RPC.
+	NoteServiceDiscardDraftProcedure = "/api.v1.NoteService/DiscardDraft"
+	// NoteServiceListNoteRevisionsProcedure is the fully-qualified name of the NoteService's
+	// ListNoteRevisions RPC.
+	NoteServiceListNoteRevisionsProcedure = "/api.v1.NoteService/ListNoteRevisions"
+	// NoteServiceListBacklinksProcedure is the fully-qualified name of the NoteService's ListBacklinks
+	// RPC.
+	NoteServiceListBacklinksProcedure = "/api.v1.NoteService/ListBacklinks"
+	// NoteServiceListUnresolvedLinksProcedure is the fully-qualified name of the NoteService's
+	// ListUnresolvedLinks RPC.
+	NoteServiceListUnresolvedLinksProcedure = "/api.v1.NoteService/ListUnresolvedLinks"
+	// NoteServiceGetNoteGraphProcedure is the fully-qualified name of the NoteService's GetNoteGraph
+	// RPC.
+	NoteServiceGetNoteGraphProcedure = "/api.v1.NoteService/GetNoteGraph"
+	// NoteServiceListRelatedNotesProcedure is the fully-qualified name of the NoteService's
+	// ListRelatedNotes RPC.
+	NoteServiceListRelatedNotesProcedure = "/api.v1.NoteService/ListRelatedNotes"
+	// NoteServiceCreateShareLinkProcedure is the fully-qualified name of the NoteService's
+	// CreateShareLink RPC.
+	NoteServiceCreateShareLinkProcedure = "/api.v1.NoteService/CreateShareLink"
+	// NoteServiceListShareLinksProcedure is the fully-qualified name of the NoteService's
+	// ListShareLinks RPC.
+	NoteServiceListShareLinksProcedure = "/api.v1.NoteService/ListShareLinks"
+	// NoteServiceRevokeShareLinkProcedure is the fully-qualified name of the NoteService's
+	// RevokeShareLink RPC.
+	NoteServiceRevokeShareLinkProcedure = "/api.v1.NoteService/RevokeShareLink"
+	// NoteServiceGrantNotePermissionProcedure is the fully-qualified name of the NoteService's
+	// GrantNotePermission RPC.
+	NoteServiceGrantNotePermissionProcedure = "/api.v1.NoteService/GrantNotePermission"
+	// NoteServiceListNotePermissionsProcedure is the fully-qualified name of the NoteService's
+	// ListNotePermissions RPC.
+	NoteServiceListNotePermissionsProcedure = "/api.v1.NoteService/ListNotePermissions"
+	// NoteServiceRevokeNotePermissionProcedure is the fully-qualified name of the NoteService's
+	// RevokeNotePermission RPC.
+	NoteServiceRevokeNotePermissionProcedure = "/api.v1.NoteService/RevokeNotePermission"
 )
 
 // NoteServiceClient is a client for the api.v1.NoteService service.
@@ -64,6 +116,43 @@ type NoteServiceClient interface {
 	DeleteNote(context.Context, *connect.Request[v1.DeleteNoteRequest]) (*connect.Response[emptypb.Empty], error)
 	// GetNoteBySlug 根据slug返回笔记
 	GetNoteBySlug(context.Context, *connect.Request[v1.GetNoteBySlugRequest]) (*connect.Response[store.Note], error)
+	// RenderNote 在服务端将笔记内容渲染为经过安全过滤的 HTML
+	RenderNote(context.Context, *connect.Request[v1.RenderNoteRequest]) (*connect.Response[v1.RenderNoteResponse], error)
+	// GetNoteStats 返回笔记的阅读统计（总浏览量和按天汇总的浏览量）
+	GetNoteStats(context.Context, *connect.Request[v1.GetNoteStatsRequest]) (*connect.Response[v1.NoteStats], error)
+	// ImportNotes 从 zip 压缩包批量导入 Markdown 笔记（支持 Obsidian/Hugo/Jekyll 的 front matter）
+	ImportNotes(context.Context, *connect.Request[v1.ImportNotesRequest]) (*connect.Response[v1.ImportNotesResponse], error)
+	// GetNoteDraft 返回当前用户对笔记的工作草稿
+	GetNoteDraft(context.Context, *connect.Request[v1.GetNoteDraftRequest]) (*connect.Response[store.NoteDraft], error)
+	// SaveNoteDraft 自动保存当前用户的工作草稿，不修改笔记的已发布内容
+	SaveNoteDraft(context.Context, *connect.Request[v1.SaveNoteDraftRequest]) (*connect.Response[store.NoteDraft], error)
+	// PublishDraft 将当前用户的工作草稿发布为笔记内容，记录修订并删除草稿
+	PublishDraft(context.Context, *connect.Request[v1.PublishDraftRequest]) (*connect.Response[store.Note], error)
+	// DiscardDraft 删除当前用户的工作草稿
+	DiscardDraft(context.Context, *connect.Request[v1.DiscardDraftRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListNoteRevisions 返回笔记的修订记录，按发布时间倒序
+	ListNoteRevisions(context.Context, *connect.Request[v1.ListNoteRevisionsRequest]) (*connect.Response[v1.ListNoteRevisionsResponse], error)
+	// ListBacklinks 返回通过 [[链接]] 引用该笔记的笔记，只包含访问者可以查看的笔记
+	ListBacklinks(context.Context, *connect.Request[v1.ListBacklinksRequest]) (*connect.Response[v1.ListBacklinksResponse], error)
+	// ListUnresolvedLinks 返回没有找到目标笔记的链接；管理员可以查看全部，其他用户只能查看自己笔记中的链接
+	ListUnresolvedLinks(context.Context, *connect.Request[v1.ListUnresolvedLinksRequest]) (*connect.Response[v1.ListUnresolvedLinksResponse], error)
+	// GetNoteGraph 返回笔记之间的关系图（链接、标签和分类），只包含访问者在笔记列表中可以看到的笔记
+	GetNoteGraph(context.Context, *connect.Request[v1.GetNoteGraphRequest]) (*connect.Response[v1.NoteGraph], error)
+	// ListRelatedNotes 返回与笔记内容相似的笔记，按相似度从高到低排列
+	ListRelatedNotes(context.Context, *connect.Request[v1.ListRelatedNotesRequest]) (*connect.Response[v1.ListRelatedNotesResponse], error)
+	// CreateShareLink 为笔记创建分享链接，持有链接的访问者无需登录即可查看笔记及其附件
+	CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[store.ShareLink], error)
+	// ListShareLinks 返回笔记的分享链接（不包含令牌），按创建时间倒序
+	ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error)
+	// RevokeShareLink 撤销分享链接，撤销后链接立即失效
+	RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[store.ShareLink], error)
+	// GrantNotePermission 授予用户对笔记或分类的协作者角色，已有权限时更新角色
+	// 笔记的权限由作者和管理员管理，分类的权限只有管理员可以管理
+	GrantNotePermission(context.Context, *connect.Request[v1.GrantNotePermissionRequest]) (*connect.Response[store.NotePermission], error)
+	// ListNotePermissions 返回笔记或分类的权限；笔记还包含从所属分类及其上级分类继承的权限
+	ListNotePermissions(context.Context, *connect.Request[v1.ListNotePermissionsRequest]) (*connect.Response[v1.ListNotePermissionsResponse], error)
+	// RevokeNotePermission 撤销用户对笔记或分类的权限
+	RevokeNotePermission(context.Context, *connect.Request[v1.RevokeNotePermissionRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewNoteServiceClient constructs a client for the api.v1.NoteService service. By default, it uses
@@ -113,17 +202,143 @@ func NewNoteServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(noteServiceMethods.ByName("GetNoteBySlug")),
 			connect.WithClientOptions(opts...),
 		),
+		renderNote: connect.NewClient[v1.RenderNoteRequest, v1.RenderNoteResponse](
+			httpClient,
+			baseURL+NoteServiceRenderNoteProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("RenderNote")),
+			connect.WithClientOptions(opts...),
+		),
+		getNoteStats: connect.NewClient[v1.GetNoteStatsRequest, v1.NoteStats](
+			httpClient,
+			baseURL+NoteServiceGetNoteStatsProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("GetNoteStats")),
+			connect.WithClientOptions(opts...),
+		),
+		importNotes: connect.NewClient[v1.ImportNotesRequest, v1.ImportNotesResponse](
+			httpClient,
+			baseURL+NoteServiceImportNotesProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("ImportNotes")),
+			connect.WithClientOptions(opts...),
+		),
+		getNoteDraft: connect.NewClient[v1.GetNoteDraftRequest, store.NoteDraft](
+			httpClient,
+			baseURL+NoteServiceGetNoteDraftProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("GetNoteDraft")),
+			connect.WithClientOptions(opts...),
+		),
+		saveNoteDraft: connect.NewClient[v1.SaveNoteDraftRequest, store.NoteDraft](
+			httpClient,
+			baseURL+NoteServiceSaveNoteDraftProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("SaveNoteDraft")),
+			connect.WithClientOptions(opts...),
+		),
+		publishDraft: connect.NewClient[v1.PublishDraftRequest, store.Note](
+			httpClient,
+			baseURL+NoteServicePublishDraftProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("PublishDraft")),
+			connect.WithClientOptions(opts...),
+		),
+		discardDraft: connect.NewClient[v1.DiscardDraftRequest, emptypb.Empty](
+			httpClient,
+			baseURL+NoteServiceDiscardDraftProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("DiscardDraft")),
+			connect.WithClientOptions(opts...),
+		),
+		listNoteRevisions: connect.NewClient[v1.ListNoteRevisionsRequest, v1.ListNoteRevisionsResponse](
+			httpClient,
+			baseURL+NoteServiceListNoteRevisionsProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("ListNoteRevisions")),
+			connect.WithClientOptions(opts...),
+		),
+		listBacklinks: connect.NewClient[v1.ListBacklinksRequest, v1.ListBacklinksResponse](
+			httpClient,
+			baseURL+NoteServiceListBacklinksProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("ListBacklinks")),
+			connect.WithClientOptions(opts...),
+		),
+		listUnresolvedLinks: connect.NewClient[v1.ListUnresolvedLinksRequest, v1.ListUnresolvedLinksResponse](
+			httpClient,
+			baseURL+NoteServiceListUnresolvedLinksProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("ListUnresolvedLinks")),
+			connect.WithClientOptions(opts...),
+		),
+		getNoteGraph: connect.NewClient[v1.GetNoteGraphRequest, v1.NoteGraph](
+			httpClient,
+			baseURL+NoteServiceGetNoteGraphProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("GetNoteGraph")),
+			connect.WithClientOptions(opts...),
+		),
+		listRelatedNotes: connect.NewClient[v1.ListRelatedNotesRequest, v1.ListRelatedNotesResponse](
+			httpClient,
+			baseURL+NoteServiceListRelatedNotesProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("ListRelatedNotes")),
+			connect.WithClientOptions(opts...),
+		),
+		createShareLink: connect.NewClient[v1.CreateShareLinkRequest, store.ShareLink](
+			httpClient,
+			baseURL+NoteServiceCreateShareLinkProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("CreateShareLink")),
+			connect.WithClientOptions(opts...),
+		),
+		listShareLinks: connect.NewClient[v1.ListShareLinksRequest, v1.ListShareLinksResponse](
+			httpClient,
+			baseURL+NoteServiceListShareLinksProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("ListShareLinks")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeShareLink: connect.NewClient[v1.RevokeShareLinkRequest, store.ShareLink](
+			httpClient,
+			baseURL+NoteServiceRevokeShareLinkProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("RevokeShareLink")),
+			connect.WithClientOptions(opts...),
+		),
+		grantNotePermission: connect.NewClient[v1.GrantNotePermissionRequest, store.NotePermission](
+			httpClient,
+			baseURL+NoteServiceGrantNotePermissionProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("GrantNotePermission")),
+			connect.WithClientOptions(opts...),
+		),
+		listNotePermissions: connect.NewClient[v1.ListNotePermissionsRequest, v1.ListNotePermissionsResponse](
+			httpClient,
+			baseURL+NoteServiceListNotePermissionsProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("ListNotePermissions")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeNotePermission: connect.NewClient[v1.RevokeNotePermissionRequest, emptypb.Empty](
+			httpClient,
+			baseURL+NoteServiceRevokeNotePermissionProcedure,
+			connect.WithSchema(noteServiceMethods.ByName("RevokeNotePermission")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // noteServiceClient implements NoteServiceClient.
 type noteServiceClient struct {
-	listNotes     *connect.Client[v1.ListNotesRequest, v1.ListNotesResponse]
-	getNote       *connect.Client[v1.GetNoteRequest, store.Note]
-	createNote    *connect.Client[v1.CreateNoteRequest, store.Note]
-	updateNote    *connect.Client[v1.UpdateNoteRequest, store.Note]
-	deleteNote    *connect.Client[v1.DeleteNoteRequest, emptypb.Empty]
-	getNoteBySlug *connect.Client[v1.GetNoteBySlugRequest, store.Note]
+	listNotes            *connect.Client[v1.ListNotesRequest, v1.ListNotesResponse]
+	getNote              *connect.Client[v1.GetNoteRequest, store.Note]
+	createNote           *connect.Client[v1.CreateNoteRequest, store.Note]
+	updateNote           *connect.Client[v1.UpdateNoteRequest, store.Note]
+	deleteNote           *connect.Client[v1.DeleteNoteRequest, emptypb.Empty]
+	getNoteBySlug        *connect.Client[v1.GetNoteBySlugRequest, store.Note]
+	renderNote           *connect.Client[v1.RenderNoteRequest, v1.RenderNoteResponse]
+	getNoteStats         *connect.Client[v1.GetNoteStatsRequest, v1.NoteStats]
+	importNotes          *connect.Client[v1.ImportNotesRequest, v1.ImportNotesResponse]
+	getNoteDraft         *connect.Client[v1.GetNoteDraftRequest, store.NoteDraft]
+	saveNoteDraft        *connect.Client[v1.SaveNoteDraftRequest, store.NoteDraft]
+	publishDraft         *connect.Client[v1.PublishDraftRequest, store.Note]
+	discardDraft         *connect.Client[v1.DiscardDraftRequest, emptypb.Empty]
+	listNoteRevisions    *connect.Client[v1.ListNoteRevisionsRequest, v1.ListNoteRevisionsResponse]
+	listBacklinks        *connect.Client[v1.ListBacklinksRequest, v1.ListBacklinksResponse]
+	listUnresolvedLinks  *connect.Client[v1.ListUnresolvedLinksRequest, v1.ListUnresolvedLinksResponse]
+	getNoteGraph         *connect.Client[v1.GetNoteGraphRequest, v1.NoteGraph]
+	listRelatedNotes     *connect.Client[v1.ListRelatedNotesRequest, v1.ListRelatedNotesResponse]
+	createShareLink      *connect.Client[v1.CreateShareLinkRequest, store.ShareLink]
+	listShareLinks       *connect.Client[v1.ListShareLinksRequest, v1.ListShareLinksResponse]
+	revokeShareLink      *connect.Client[v1.RevokeShareLinkRequest, store.ShareLink]
+	grantNotePermission  *connect.Client[v1.GrantNotePermissionRequest, store.NotePermission]
+	listNotePermissions  *connect.Client[v1.ListNotePermissionsRequest, v1.ListNotePermissionsResponse]
+	revokeNotePermission *connect.Client[v1.RevokeNotePermissionRequest, emptypb.Empty]
 }
 
 // ListNotes calls api.v1.NoteService.ListNotes.
@@ -156,6 +371,96 @@ func (c *noteServiceClient) GetNoteBySlug(ctx context.Context, req *connect.Requ
 	return c.getNoteBySlug.CallUnary(ctx, req)
 }
 
+// RenderNote calls api.v1.NoteService.RenderNote.
+func (c *noteServiceClient) RenderNote(ctx context.Context, req *connect.Request[v1.RenderNoteRequest]) (*connect.Response[v1.RenderNoteResponse], error) {
+	return c.renderNote.CallUnary(ctx, req)
+}
+
+// GetNoteStats calls api.v1.NoteService.GetNoteStats.
+func (c *noteServiceClient) GetNoteStats(ctx context.Context, req *connect.Request[v1.GetNoteStatsRequest]) (*connect.Response[v1.NoteStats], error) {
+	return c.getNoteStats.CallUnary(ctx, req)
+}
+
+// ImportNotes calls api.v1.NoteService.ImportNotes.
+func (c *noteServiceClient) ImportNotes(ctx context.Context, req *connect.Request[v1.ImportNotesRequest]) (*connect.Response[v1.ImportNotesResponse], error) {
+	return c.importNotes.CallUnary(ctx, req)
+}
+
+// GetNoteDraft calls api.v1.NoteService.GetNoteDraft.
+func (c *noteServiceClient) GetNoteDraft(ctx context.Context, req *connect.Request[v1.GetNoteDraftRequest]) (*connect.Response[store.NoteDraft], error) {
+	return c.getNoteDraft.CallUnary(ctx, req)
+}
+
+// SaveNoteDraft calls api.v1.NoteService.SaveNoteDraft.
+func (c *noteServiceClient) SaveNoteDraft(ctx context.Context, req *connect.Request[v1.SaveNoteDraftRequest]) (*connect.Response[store.NoteDraft], error) {
+	return c.saveNoteDraft.CallUnary(ctx, req)
+}
+
+// PublishDraft calls api.v1.NoteService.PublishDraft.
+func (c *noteServiceClient) PublishDraft(ctx context.Context, req *connect.Request[v1.PublishDraftRequest]) (*connect.Response[store.Note], error) {
+	return c.publishDraft.CallUnary(ctx, req)
+}
+
+// DiscardDraft calls api.v1.NoteService.DiscardDraft.
+func (c *noteServiceClient) DiscardDraft(ctx context.Context, req *connect.Request[v1.DiscardDraftRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.discardDraft.CallUnary(ctx, req)
+}
+
+// ListNoteRevisions calls api.v1.NoteService.ListNoteRevisions.
+func (c *noteServiceClient) ListNoteRevisions(ctx context.Context, req *connect.Request[v1.ListNoteRevisionsRequest]) (*connect.Response[v1.ListNoteRevisionsResponse], error) {
+	return c.listNoteRevisions.CallUnary(ctx, req)
+}
+
+// ListBacklinks calls api.v1.NoteService.ListBacklinks.
+func (c *noteServiceClient) ListBacklinks(ctx context.Context, req *connect.Request[v1.ListBacklinksRequest]) (*connect.Response[v1.ListBacklinksResponse], error) {
+	return c.listBacklinks.CallUnary(ctx, req)
+}
+
+// ListUnresolvedLinks calls api.v1.NoteService.ListUnresolvedLinks.
+func (c *noteServiceClient) ListUnresolvedLinks(ctx context.Context, req *connect.Request[v1.ListUnresolvedLinksRequest]) (*connect.Response[v1.ListUnresolvedLinksResponse], error) {
+	return c.listUnresolvedLinks.CallUnary(ctx, req)
+}
+
+// GetNoteGraph calls api.v1.NoteService.GetNoteGraph.
+func (c *noteServiceClient) GetNoteGraph(ctx context.Context, req *connect.Request[v1.GetNoteGraphRequest]) (*connect.Response[v1.NoteGraph], error) {
+	return c.getNoteGraph.CallUnary(ctx, req)
+}
+
+// ListRelatedNotes calls api.v1.NoteService.ListRelatedNotes.
+func (c *noteServiceClient) ListRelatedNotes(ctx context.Context, req *connect.Request[v1.ListRelatedNotesRequest]) (*connect.Response[v1.ListRelatedNotesResponse], error) {
+	return c.listRelatedNotes.CallUnary(ctx, req)
+}
+
+// CreateShareLink calls api.v1.NoteService.CreateShareLink.
+func (c *noteServiceClient) CreateShareLink(ctx context.Context, req *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[store.ShareLink], error) {
+	return c.createShareLink.CallUnary(ctx, req)
+}
+
+// ListShareLinks calls api.v1.NoteService.ListShareLinks.
+func (c *noteServiceClient) ListShareLinks(ctx context.Context, req *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error) {
+	return c.listShareLinks.CallUnary(ctx, req)
+}
+
+// RevokeShareLink calls api.v1.NoteService.RevokeShareLink.
+func (c *noteServiceClient) RevokeShareLink(ctx context.Context, req *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[store.ShareLink], error) {
+	return c.revokeShareLink.CallUnary(ctx, req)
+}
+
+// GrantNotePermission calls api.v1.NoteService.GrantNotePermission.
+func (c *noteServiceClient) GrantNotePermission(ctx context.Context, req *connect.Request[v1.GrantNotePermissionRequest]) (*connect.Response[store.NotePermission], error) {
+	return c.grantNotePermission.CallUnary(ctx, req)
+}
+
+// ListNotePermissions calls api.v1.NoteService.ListNotePermissions.
+func (c *noteServiceClient) ListNotePermissions(ctx context.Context, req *connect.Request[v1.ListNotePermissionsRequest]) (*connect.Response[v1.ListNotePermissionsResponse], error) {
+	return c.listNotePermissions.CallUnary(ctx, req)
+}
+
+// RevokeNotePermission calls api.v1.NoteService.RevokeNotePermission.
+func (c *noteServiceClient) RevokeNotePermission(ctx context.Context, req *connect.Request[v1.RevokeNotePermissionRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.revokeNotePermission.CallUnary(ctx, req)
+}
+
 // NoteServiceHandler is an implementation of the api.v1.NoteService service.
 type NoteServiceHandler interface {
 	// ListNotes 返回分页的笔记列表
@@ -170,6 +475,43 @@ type NoteServiceHandler interface {
 	DeleteNote(context.Context, *connect.Request[v1.DeleteNoteRequest]) (*connect.Response[emptypb.Empty], error)
 	// GetNoteBySlug 根据slug返回笔记
 	GetNoteBySlug(context.Context, *connect.Request[v1.GetNoteBySlugRequest]) (*connect.Response[store.Note], error)
+	// RenderNote 在服务端将笔记内容渲染为经过安全过滤的 HTML
+	RenderNote(context.Context, *connect.Request[v1.RenderNoteRequest]) (*connect.Response[v1.RenderNoteResponse], error)
+	// GetNoteStats 返回笔记的阅读统计（总浏览量和按天汇总的浏览量）
+	GetNoteStats(context.Context, *connect.Request[v1.GetNoteStatsRequest]) (*connect.Response[v1.NoteStats], error)
+	// ImportNotes 从 zip 压缩包批量导入 Markdown 笔记（支持 Obsidian/Hugo/Jekyll 的 front matter）
+	ImportNotes(context.Context, *connect.Request[v1.ImportNotesRequest]) (*connect.Response[v1.ImportNotesResponse], error)
+	// GetNoteDraft 返回当前用户对笔记的工作草稿
+	GetNoteDraft(context.Context, *connect.Request[v1.GetNoteDraftRequest]) (*connect.Response[store.NoteDraft], error)
+	// SaveNoteDraft 自动保存当前用户的工作草稿，不修改笔记的已发布内容
+	SaveNoteDraft(context.Context, *connect.Request[v1.SaveNoteDraftRequest]) (*connect.Response[store.NoteDraft], error)
+	// PublishDraft 将当前用户的工作草稿发布为笔记内容，记录修订并删除草稿
+	PublishDraft(context.Context, *connect.Request[v1.PublishDraftRequest]) (*connect.Response[store.Note], error)
+	// DiscardDraft 删除当前用户的工作草稿
+	DiscardDraft(context.Context, *connect.Request[v1.DiscardDraftRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListNoteRevisions 返回笔记的修订记录，按发布时间倒序
+	ListNoteRevisions(context.Context, *connect.Request[v1.ListNoteRevisionsRequest]) (*connect.Response[v1.ListNoteRevisionsResponse], error)
+	// ListBacklinks 返回通过 [[链接]] 引用该笔记的笔记，只包含访问者可以查看的笔记
+	ListBacklinks(context.Context, *connect.Request[v1.ListBacklinksRequest]) (*connect.Response[v1.ListBacklinksResponse], error)
+	// ListUnresolvedLinks 返回没有找到目标笔记的链接；管理员可以查看全部，其他用户只能查看自己笔记中的链接
+	ListUnresolvedLinks(context.Context, *connect.Request[v1.ListUnresolvedLinksRequest]) (*connect.Response[v1.ListUnresolvedLinksResponse], error)
+	// GetNoteGraph 返回笔记之间的关系图（链接、标签和分类），只包含访问者在笔记列表中可以看到的笔记
+	GetNoteGraph(context.Context, *connect.Request[v1.GetNoteGraphRequest]) (*connect.Response[v1.NoteGraph], error)
+	// ListRelatedNotes 返回与笔记内容相似的笔记，按相似度从高到低排列
+	ListRelatedNotes(context.Context, *connect.Request[v1.ListRelatedNotesRequest]) (*connect.Response[v1.ListRelatedNotesResponse], error)
+	// CreateShareLink 为笔记创建分享链接，持有链接的访问者无需登录即可查看笔记及其附件
+	CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[store.ShareLink], error)
+	// ListShareLinks 返回笔记的分享链接（不包含令牌），按创建时间倒序
+	ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error)
+	// RevokeShareLink 撤销分享链接，撤销后链接立即失效
+	RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[store.ShareLink], error)
+	// GrantNotePermission 授予用户对笔记或分类的协作者角色，已有权限时更新角色
+	// 笔记的权限由作者和管理员管理，分类的权限只有管理员可以管理
+	GrantNotePermission(context.Context, *connect.Request[v1.GrantNotePermissionRequest]) (*connect.Response[store.NotePermission], error)
+	// ListNotePermissions 返回笔记或分类的权限；笔记还包含从所属分类及其上级分类继承的权限
+	ListNotePermissions(context.Context, *connect.Request[v1.ListNotePermissionsRequest]) (*connect.Response[v1.ListNotePermissionsResponse], error)
+	// RevokeNotePermission 撤销用户对笔记或分类的权限
+	RevokeNotePermission(context.Context, *connect.Request[v1.RevokeNotePermissionRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewNoteServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -215,6 +557,114 @@ func NewNoteServiceHandler(svc NoteServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(noteServiceMethods.ByName("GetNoteBySlug")),
 		connect.WithHandlerOptions(opts...),
 	)
+	noteServiceRenderNoteHandler := connect.NewUnaryHandler(
+		NoteServiceRenderNoteProcedure,
+		svc.RenderNote,
+		connect.WithSchema(noteServiceMethods.ByName("RenderNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceGetNoteStatsHandler := connect.NewUnaryHandler(
+		NoteServiceGetNoteStatsProcedure,
+		svc.GetNoteStats,
+		connect.WithSchema(noteServiceMethods.ByName("GetNoteStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceImportNotesHandler := connect.NewUnaryHandler(
+		NoteServiceImportNotesProcedure,
+		svc.ImportNotes,
+		connect.WithSchema(noteServiceMethods.ByName("ImportNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceGetNoteDraftHandler := connect.NewUnaryHandler(
+		NoteServiceGetNoteDraftProcedure,
+		svc.GetNoteDraft,
+		connect.WithSchema(noteServiceMethods.ByName("GetNoteDraft")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceSaveNoteDraftHandler := connect.NewUnaryHandler(
+		NoteServiceSaveNoteDraftProcedure,
+		svc.SaveNoteDraft,
+		connect.WithSchema(noteServiceMethods.ByName("SaveNoteDraft")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServicePublishDraftHandler := connect.NewUnaryHandler(
+		NoteServicePublishDraftProcedure,
+		svc.PublishDraft,
+		connect.WithSchema(noteServiceMethods.ByName("PublishDraft")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceDiscardDraftHandler := connect.NewUnaryHandler(
+		NoteServiceDiscardDraftProcedure,
+		svc.DiscardDraft,
+		connect.WithSchema(noteServiceMethods.ByName("DiscardDraft")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceListNoteRevisionsHandler := connect.NewUnaryHandler(
+		NoteServiceListNoteRevisionsProcedure,
+		svc.ListNoteRevisions,
+		connect.WithSchema(noteServiceMethods.ByName("ListNoteRevisions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceListBacklinksHandler := connect.NewUnaryHandler(
+		NoteServiceListBacklinksProcedure,
+		svc.ListBacklinks,
+		connect.WithSchema(noteServiceMethods.ByName("ListBacklinks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceListUnresolvedLinksHandler := connect.NewUnaryHandler(
+		NoteServiceListUnresolvedLinksProcedure,
+		svc.ListUnresolvedLinks,
+		connect.WithSchema(noteServiceMethods.ByName("ListUnresolvedLinks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceGetNoteGraphHandler := connect.NewUnaryHandler(
+		NoteServiceGetNoteGraphProcedure,
+		svc.GetNoteGraph,
+		connect.WithSchema(noteServiceMethods.ByName("GetNoteGraph")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceListRelatedNotesHandler := connect.NewUnaryHandler(
+		NoteServiceListRelatedNotesProcedure,
+		svc.ListRelatedNotes,
+		connect.WithSchema(noteServiceMethods.ByName("ListRelatedNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceCreateShareLinkHandler := connect.NewUnaryHandler(
+		NoteServiceCreateShareLinkProcedure,
+		svc.CreateShareLink,
+		connect.WithSchema(noteServiceMethods.ByName("CreateShareLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceListShareLinksHandler := connect.NewUnaryHandler(
+		NoteServiceListShareLinksProcedure,
+		svc.ListShareLinks,
+		connect.WithSchema(noteServiceMethods.ByName("ListShareLinks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceRevokeShareLinkHandler := connect.NewUnaryHandler(
+		NoteServiceRevokeShareLinkProcedure,
+		svc.RevokeShareLink,
+		connect.WithSchema(noteServiceMethods.ByName("RevokeShareLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceGrantNotePermissionHandler := connect.NewUnaryHandler(
+		NoteServiceGrantNotePermissionProcedure,
+		svc.GrantNotePermission,
+		connect.WithSchema(noteServiceMethods.ByName("GrantNotePermission")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceListNotePermissionsHandler := connect.NewUnaryHandler(
+		NoteServiceListNotePermissionsProcedure,
+		svc.ListNotePermissions,
+		connect.WithSchema(noteServiceMethods.ByName("ListNotePermissions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	noteServiceRevokeNotePermissionHandler := connect.NewUnaryHandler(
+		NoteServiceRevokeNotePermissionProcedure,
+		svc.RevokeNotePermission,
+		connect.WithSchema(noteServiceMethods.ByName("RevokeNotePermission")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.NoteService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case NoteServiceListNotesProcedure:
@@ -229,6 +679,42 @@ func NewNoteServiceHandler(svc NoteServiceHandler, opts ...connect.HandlerOption
 			noteServiceDeleteNoteHandler.ServeHTTP(w, r)
 		case NoteServiceGetNoteBySlugProcedure:
 			noteServiceGetNoteBySlugHandler.ServeHTTP(w, r)
+		case NoteServiceRenderNoteProcedure:
+			noteServiceRenderNoteHandler.ServeHTTP(w, r)
+		case NoteServiceGetNoteStatsProcedure:
+			noteServiceGetNoteStatsHandler.ServeHTTP(w, r)
+		case NoteServiceImportNotesProcedure:
+			noteServiceImportNotesHandler.ServeHTTP(w, r)
+		case NoteServiceGetNoteDraftProcedure:
+			noteServiceGetNoteDraftHandler.ServeHTTP(w, r)
+		case NoteServiceSaveNoteDraftProcedure:
+			noteServiceSaveNoteDraftHandler.ServeHTTP(w, r)
+		case NoteServicePublishDraftProcedure:
+			noteServicePublishDraftHandler.ServeHTTP(w, r)
+		case NoteServiceDiscardDraftProcedure:
+			noteServiceDiscardDraftHandler.ServeHTTP(w, r)
+		case NoteServiceListNoteRevisionsProcedure:
+			noteServiceListNoteRevisionsHandler.ServeHTTP(w, r)
+		case NoteServiceListBacklinksProcedure:
+			noteServiceListBacklinksHandler.ServeHTTP(w, r)
+		case NoteServiceListUnresolvedLinksProcedure:
+			noteServiceListUnresolvedLinksHandler.ServeHTTP(w, r)
+		case NoteServiceGetNoteGraphProcedure:
+			noteServiceGetNoteGraphHandler.ServeHTTP(w, r)
+		case NoteServiceListRelatedNotesProcedure:
+			noteServiceListRelatedNotesHandler.ServeHTTP(w, r)
+		case NoteServiceCreateShareLinkProcedure:
+			noteServiceCreateShareLinkHandler.ServeHTTP(w, r)
+		case NoteServiceListShareLinksProcedure:
+			noteServiceListShareLinksHandler.ServeHTTP(w, r)
+		case NoteServiceRevokeShareLinkProcedure:
+			noteServiceRevokeShareLinkHandler.ServeHTTP(w, r)
+		case NoteServiceGrantNotePermissionProcedure:
+			noteServiceGrantNotePermissionHandler.ServeHTTP(w, r)
+		case NoteServiceListNotePermissionsProcedure:
+			noteServiceListNotePermissionsHandler.ServeHTTP(w, r)
+		case NoteServiceRevokeNotePermissionProcedure:
+			noteServiceRevokeNotePermissionHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -261,3 +747,75 @@ func (UnimplementedNoteServiceHandler) DeleteNote(context.Context, *connect.Requ
 func (UnimplementedNoteServiceHandler) GetNoteBySlug(context.Context, *connect.Request[v1.GetNoteBySlugRequest]) (*connect.Response[store.Note], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.GetNoteBySlug is not implemented"))
 }
+
+func (UnimplementedNoteServiceHandler) RenderNote(context.Context, *connect.Request[v1.RenderNoteRequest]) (*connect.Response[v1.RenderNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.RenderNote is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) GetNoteStats(context.Context, *connect.Request[v1.GetNoteStatsRequest]) (*connect.Response[v1.NoteStats], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.GetNoteStats is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) ImportNotes(context.Context, *connect.Request[v1.ImportNotesRequest]) (*connect.Response[v1.ImportNotesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.ImportNotes is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) GetNoteDraft(context.Context, *connect.Request[v1.GetNoteDraftRequest]) (*connect.Response[store.NoteDraft], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.GetNoteDraft is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) SaveNoteDraft(context.Context, *connect.Request[v1.SaveNoteDraftRequest]) (*connect.Response[store.NoteDraft], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.SaveNoteDraft is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) PublishDraft(context.Context, *connect.Request[v1.PublishDraftRequest]) (*connect.Response[store.Note], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.PublishDraft is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) DiscardDraft(context.Context, *connect.Request[v1.DiscardDraftRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.DiscardDraft is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) ListNoteRevisions(context.Context, *connect.Request[v1.ListNoteRevisionsRequest]) (*connect.Response[v1.ListNoteRevisionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.ListNoteRevisions is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) ListBacklinks(context.Context, *connect.Request[v1.ListBacklinksRequest]) (*connect.Response[v1.ListBacklinksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.ListBacklinks is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) ListUnresolvedLinks(context.Context, *connect.Request[v1.ListUnresolvedLinksRequest]) (*connect.Response[v1.ListUnresolvedLinksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.ListUnresolvedLinks is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) GetNoteGraph(context.Context, *connect.Request[v1.GetNoteGraphRequest]) (*connect.Response[v1.NoteGraph], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.GetNoteGraph is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) ListRelatedNotes(context.Context, *connect.Request[v1.ListRelatedNotesRequest]) (*connect.Response[v1.ListRelatedNotesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.ListRelatedNotes is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[store.ShareLink], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.CreateShareLink is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.ListShareLinks is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[store.ShareLink], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.RevokeShareLink is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) GrantNotePermission(context.Context, *connect.Request[v1.GrantNotePermissionRequest]) (*connect.Response[store.NotePermission], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.GrantNotePermission is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) ListNotePermissions(context.Context, *connect.Request[v1.ListNotePermissionsRequest]) (*connect.Response[v1.ListNotePermissionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.ListNotePermissions is not implemented"))
+}
+
+func (UnimplementedNoteServiceHandler) RevokeNotePermission(context.Context, *connect.Request[v1.RevokeNotePermissionRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.NoteService.RevokeNotePermission is not implemented"))
+}
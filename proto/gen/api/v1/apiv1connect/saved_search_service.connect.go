@@ -0,0 +1,268 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/v1/saved_search_service.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	store "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SavedSearchServiceName is the fully-qualified name of the SavedSearchService service.
+	SavedSearchServiceName = "api.v1.SavedSearchService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SavedSearchServiceListSavedSearchesProcedure is the fully-qualified name of the
+	// SavedSearchService's ListSavedSearches RPC.
+	SavedSearchServiceListSavedSearchesProcedure = "/api.v1.SavedSearchService/ListSavedSearches"
+	// SavedSearchServiceGetSavedSearchProcedure is the fully-qualified name of the SavedSearchService's
+	// GetSavedSearch RPC.
+	SavedSearchServiceGetSavedSearchProcedure = "/api.v1.SavedSearchService/GetSavedSearch"
+	// SavedSearchServiceCreateSavedSearchProcedure is the fully-qualified name of the
+	// SavedSearchService's CreateSavedSearch RPC.
+	SavedSearchServiceCreateSavedSearchProcedure = "/api.v1.SavedSearchService/CreateSavedSearch"
+	// SavedSearchServiceUpdateSavedSearchProcedure is the fully-qualified name of the
+	// SavedSearchService's UpdateSavedSearch RPC.
+	SavedSearchServiceUpdateSavedSearchProcedure = "/api.v1.SavedSearchService/UpdateSavedSearch"
+	// SavedSearchServiceDeleteSavedSearchProcedure is the fully-qualified name of the
+	// SavedSearchService's DeleteSavedSearch RPC.
+	SavedSearchServiceDeleteSavedSearchProcedure = "/api.v1.SavedSearchService/DeleteSavedSearch"
+	// SavedSearchServiceListSavedSearchNotesProcedure is the fully-qualified name of the
+	// SavedSearchService's ListSavedSearchNotes RPC.
+	SavedSearchServiceListSavedSearchNotesProcedure = "/api.v1.SavedSearchService/ListSavedSearchNotes"
+)
+
+// SavedSearchServiceClient is a client for the api.v1.SavedSearchService service.
+type SavedSearchServiceClient interface {
+	// ListSavedSearches 返回当前用户的和其他用户共享的保存的搜索，置顶的在前
+	ListSavedSearches(context.Context, *connect.Request[v1.ListSavedSearchesRequest]) (*connect.Response[v1.ListSavedSearchesResponse], error)
+	// GetSavedSearch 根据ID返回保存的搜索
+	GetSavedSearch(context.Context, *connect.Request[v1.GetSavedSearchRequest]) (*connect.Response[store.SavedSearch], error)
+	// CreateSavedSearch 创建保存的搜索
+	CreateSavedSearch(context.Context, *connect.Request[v1.CreateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error)
+	// UpdateSavedSearch 更新保存的搜索（仅创建者）
+	UpdateSavedSearch(context.Context, *connect.Request[v1.UpdateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error)
+	// DeleteSavedSearch 删除保存的搜索（仅创建者）
+	DeleteSavedSearch(context.Context, *connect.Request[v1.DeleteSavedSearchRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListSavedSearchNotes 执行保存的搜索并返回笔记，同时记录当前用户的查看时间
+	ListSavedSearchNotes(context.Context, *connect.Request[v1.ListSavedSearchNotesRequest]) (*connect.Response[v1.ListNotesResponse], error)
+}
+
+// NewSavedSearchServiceClient constructs a client for the api.v1.SavedSearchService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSavedSearchServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SavedSearchServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	savedSearchServiceMethods := v1.File_api_v1_saved_search_service_proto.Services().ByName("SavedSearchService").Methods()
+	return &savedSearchServiceClient{
+		listSavedSearches: connect.NewClient[v1.ListSavedSearchesRequest, v1.ListSavedSearchesResponse](
+			httpClient,
+			baseURL+SavedSearchServiceListSavedSearchesProcedure,
+			connect.WithSchema(savedSearchServiceMethods.ByName("ListSavedSearches")),
+			connect.WithClientOptions(opts...),
+		),
+		getSavedSearch: connect.NewClient[v1.GetSavedSearchRequest, store.SavedSearch](
+			httpClient,
+			baseURL+SavedSearchServiceGetSavedSearchProcedure,
+			connect.WithSchema(savedSearchServiceMethods.ByName("GetSavedSearch")),
+			connect.WithClientOptions(opts...),
+		),
+		createSavedSearch: connect.NewClient[v1.CreateSavedSearchRequest, store.SavedSearch](
+			httpClient,
+			baseURL+SavedSearchServiceCreateSavedSearchProcedure,
+			connect.WithSchema(savedSearchServiceMethods.ByName("CreateSavedSearch")),
+			connect.WithClientOptions(opts...),
+		),
+		updateSavedSearch: connect.NewClient[v1.UpdateSavedSearchRequest, store.SavedSearch](
+			httpClient,
+			baseURL+SavedSearchServiceUpdateSavedSearchProcedure,
+			connect.WithSchema(savedSearchServiceMethods.ByName("UpdateSavedSearch")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteSavedSearch: connect.NewClient[v1.DeleteSavedSearchRequest, emptypb.Empty](
+			httpClient,
+			baseURL+SavedSearchServiceDeleteSavedSearchProcedure,
+			connect.WithSchema(savedSearchServiceMethods.ByName("DeleteSavedSearch")),
+			connect.WithClientOptions(opts...),
+		),
+		listSavedSearchNotes: connect.NewClient[v1.ListSavedSearchNotesRequest, v1.ListNotesResponse](
+			httpClient,
+			baseURL+SavedSearchServiceListSavedSearchNotesProcedure,
+			connect.WithSchema(savedSearchServiceMethods.ByName("ListSavedSearchNotes")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// savedSearchServiceClient implements SavedSearchServiceClient.
+type savedSearchServiceClient struct {
+	listSavedSearches    *connect.Client[v1.ListSavedSearchesRequest, v1.ListSavedSearchesResponse]
+	getSavedSearch       *connect.Client[v1.GetSavedSearchRequest, store.SavedSearch]
+	createSavedSearch    *connect.Client[v1.CreateSavedSearchRequest, store.SavedSearch]
+	updateSavedSearch    *connect.Client[v1.UpdateSavedSearchRequest, store.SavedSearch]
+	deleteSavedSearch    *connect.Client[v1.DeleteSavedSearchRequest, emptypb.Empty]
+	listSavedSearchNotes *connect.Client[v1.ListSavedSearchNotesRequest, v1.ListNotesResponse]
+}
+
+// ListSavedSearches calls api.v1.SavedSearchService.ListSavedSearches.
+func (c *savedSearchServiceClient) ListSavedSearches(ctx context.Context, req *connect.Request[v1.ListSavedSearchesRequest]) (*connect.Response[v1.ListSavedSearchesResponse], error) {
+	return c.listSavedSearches.CallUnary(ctx, req)
+}
+
+// GetSavedSearch calls api.v1.SavedSearchService.GetSavedSearch.
+func (c *savedSearchServiceClient) GetSavedSearch(ctx context.Context, req *connect.Request[v1.GetSavedSearchRequest]) (*connect.Response[store.SavedSearch], error) {
+	return c.getSavedSearch.CallUnary(ctx, req)
+}
+
+// CreateSavedSearch calls api.v1.SavedSearchService.CreateSavedSearch.
+func (c *savedSearchServiceClient) CreateSavedSearch(ctx context.Context, req *connect.Request[v1.CreateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error) {
+	return c.createSavedSearch.CallUnary(ctx, req)
+}
+
+// UpdateSavedSearch calls api.v1.SavedSearchService.UpdateSavedSearch.
+func (c *savedSearchServiceClient) UpdateSavedSearch(ctx context.Context, req *connect.Request[v1.UpdateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error) {
+	return c.updateSavedSearch.CallUnary(ctx, req)
+}
+
+// DeleteSavedSearch calls api.v1.SavedSearchService.DeleteSavedSearch.
+func (c *savedSearchServiceClient) DeleteSavedSearch(ctx context.Context, req *connect.Request[v1.DeleteSavedSearchRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteSavedSearch.CallUnary(ctx, req)
+}
+
+// ListSavedSearchNotes calls api.v1.SavedSearchService.ListSavedSearchNotes.
+func (c *savedSearchServiceClient) ListSavedSearchNotes(ctx context.Context, req *connect.Request[v1.ListSavedSearchNotesRequest]) (*connect.Response[v1.ListNotesResponse], error) {
+	return c.listSavedSearchNotes.CallUnary(ctx, req)
+}
+
+// SavedSearchServiceHandler is an implementation of the api.v1.SavedSearchService service.
+type SavedSearchServiceHandler interface {
+	// ListSavedSearches 返回当前用户的和其他用户共享的保存的搜索，置顶的在前
+	ListSavedSearches(context.Context, *connect.Request[v1.ListSavedSearchesRequest]) (*connect.Response[v1.ListSavedSearchesResponse], error)
+	// GetSavedSearch 根据ID返回保存的搜索
+	GetSavedSearch(context.Context, *connect.Request[v1.GetSavedSearchRequest]) (*connect.Response[store.SavedSearch], error)
+	// CreateSavedSearch 创建保存的搜索
+	CreateSavedSearch(context.Context, *connect.Request[v1.CreateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error)
+	// UpdateSavedSearch 更新保存的搜索（仅创建者）
+	UpdateSavedSearch(context.Context, *connect.Request[v1.UpdateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error)
+	// DeleteSavedSearch 删除保存的搜索（仅创建者）
+	DeleteSavedSearch(context.Context, *connect.Request[v1.DeleteSavedSearchRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListSavedSearchNotes 执行保存的搜索并返回笔记，同时记录当前用户的查看时间
+	ListSavedSearchNotes(context.Context, *connect.Request[v1.ListSavedSearchNotesRequest]) (*connect.Response[v1.ListNotesResponse], error)
+}
+
+// NewSavedSearchServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSavedSearchServiceHandler(svc SavedSearchServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	savedSearchServiceMethods := v1.File_api_v1_saved_search_service_proto.Services().ByName("SavedSearchService").Methods()
+	savedSearchServiceListSavedSearchesHandler := connect.NewUnaryHandler(
+		SavedSearchServiceListSavedSearchesProcedure,
+		svc.ListSavedSearches,
+		connect.WithSchema(savedSearchServiceMethods.ByName("ListSavedSearches")),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedSearchServiceGetSavedSearchHandler := connect.NewUnaryHandler(
+		SavedSearchServiceGetSavedSearchProcedure,
+		svc.GetSavedSearch,
+		connect.WithSchema(savedSearchServiceMethods.ByName("GetSavedSearch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedSearchServiceCreateSavedSearchHandler := connect.NewUnaryHandler(
+		SavedSearchServiceCreateSavedSearchProcedure,
+		svc.CreateSavedSearch,
+		connect.WithSchema(savedSearchServiceMethods.ByName("CreateSavedSearch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedSearchServiceUpdateSavedSearchHandler := connect.NewUnaryHandler(
+		SavedSearchServiceUpdateSavedSearchProcedure,
+		svc.UpdateSavedSearch,
+		connect.WithSchema(savedSearchServiceMethods.ByName("UpdateSavedSearch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedSearchServiceDeleteSavedSearchHandler := connect.NewUnaryHandler(
+		SavedSearchServiceDeleteSavedSearchProcedure,
+		svc.DeleteSavedSearch,
+		connect.WithSchema(savedSearchServiceMethods.ByName("DeleteSavedSearch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedSearchServiceListSavedSearchNotesHandler := connect.NewUnaryHandler(
+		SavedSearchServiceListSavedSearchNotesProcedure,
+		svc.ListSavedSearchNotes,
+		connect.WithSchema(savedSearchServiceMethods.ByName("ListSavedSearchNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.SavedSearchService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SavedSearchServiceListSavedSearchesProcedure:
+			savedSearchServiceListSavedSearchesHandler.ServeHTTP(w, r)
+		case SavedSearchServiceGetSavedSearchProcedure:
+			savedSearchServiceGetSavedSearchHandler.ServeHTTP(w, r)
+		case SavedSearchServiceCreateSavedSearchProcedure:
+			savedSearchServiceCreateSavedSearchHandler.ServeHTTP(w, r)
+		case SavedSearchServiceUpdateSavedSearchProcedure:
+			savedSearchServiceUpdateSavedSearchHandler.ServeHTTP(w, r)
+		case SavedSearchServiceDeleteSavedSearchProcedure:
+			savedSearchServiceDeleteSavedSearchHandler.ServeHTTP(w, r)
+		case SavedSearchServiceListSavedSearchNotesProcedure:
+			savedSearchServiceListSavedSearchNotesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSavedSearchServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSavedSearchServiceHandler struct{}
+
+func (UnimplementedSavedSearchServiceHandler) ListSavedSearches(context.Context, *connect.Request[v1.ListSavedSearchesRequest]) (*connect.Response[v1.ListSavedSearchesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SavedSearchService.ListSavedSearches is not implemented"))
+}
+
+func (UnimplementedSavedSearchServiceHandler) GetSavedSearch(context.Context, *connect.Request[v1.GetSavedSearchRequest]) (*connect.Response[store.SavedSearch], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SavedSearchService.GetSavedSearch is not implemented"))
+}
+
+func (UnimplementedSavedSearchServiceHandler) CreateSavedSearch(context.Context, *connect.Request[v1.CreateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SavedSearchService.CreateSavedSearch is not implemented"))
+}
+
+func (UnimplementedSavedSearchServiceHandler) UpdateSavedSearch(context.Context, *connect.Request[v1.UpdateSavedSearchRequest]) (*connect.Response[store.SavedSearch], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SavedSearchService.UpdateSavedSearch is not implemented"))
+}
+
+func (UnimplementedSavedSearchServiceHandler) DeleteSavedSearch(context.Context, *connect.Request[v1.DeleteSavedSearchRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SavedSearchService.DeleteSavedSearch is not implemented"))
+}
+
+func (UnimplementedSavedSearchServiceHandler) ListSavedSearchNotes(context.Context, *connect.Request[v1.ListSavedSearchNotesRequest]) (*connect.Response[v1.ListNotesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SavedSearchService.ListSavedSearchNotes is not implemented"))
+}
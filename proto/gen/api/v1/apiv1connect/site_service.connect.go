@@ -0,0 +1,172 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/v1/site_service.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SiteServiceName is the fully-qualified name of the SiteService service.
+	SiteServiceName = "api.v1.SiteService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SiteServiceExportStaticSiteProcedure is the fully-qualified name of the SiteService's
+	// ExportStaticSite RPC.
+	SiteServiceExportStaticSiteProcedure = "/api.v1.SiteService/ExportStaticSite"
+	// SiteServiceCreateBackupProcedure is the fully-qualified name of the SiteService's CreateBackup
+	// RPC.
+	SiteServiceCreateBackupProcedure = "/api.v1.SiteService/CreateBackup"
+	// SiteServiceListBackupsProcedure is the fully-qualified name of the SiteService's ListBackups RPC.
+	SiteServiceListBackupsProcedure = "/api.v1.SiteService/ListBackups"
+)
+
+// SiteServiceClient is a client for the api.v1.SiteService service.
+type SiteServiceClient interface {
+	// ExportStaticSite 将已发布的公开笔记导出为静态网站（仅管理员）
+	ExportStaticSite(context.Context, *connect.Request[v1.ExportStaticSiteRequest]) (*connect.Response[v1.ExportStaticSiteResponse], error)
+	// CreateBackup 在数据目录的 backups 目录中创建数据库备份（仅系统管理员）
+	CreateBackup(context.Context, *connect.Request[v1.CreateBackupRequest]) (*connect.Response[v1.Backup], error)
+	// ListBackups 列出数据目录中的数据库备份，最新的在前（仅系统管理员）
+	ListBackups(context.Context, *connect.Request[v1.ListBackupsRequest]) (*connect.Response[v1.ListBackupsResponse], error)
+}
+
+// NewSiteServiceClient constructs a client for the api.v1.SiteService service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSiteServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SiteServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	siteServiceMethods := v1.File_api_v1_site_service_proto.Services().ByName("SiteService").Methods()
+	return &siteServiceClient{
+		exportStaticSite: connect.NewClient[v1.ExportStaticSiteRequest, v1.ExportStaticSiteResponse](
+			httpClient,
+			baseURL+SiteServiceExportStaticSiteProcedure,
+			connect.WithSchema(siteServiceMethods.ByName("ExportStaticSite")),
+			connect.WithClientOptions(opts...),
+		),
+		createBackup: connect.NewClient[v1.CreateBackupRequest, v1.Backup](
+			httpClient,
+			baseURL+SiteServiceCreateBackupProcedure,
+			connect.WithSchema(siteServiceMethods.ByName("CreateBackup")),
+			connect.WithClientOptions(opts...),
+		),
+		listBackups: connect.NewClient[v1.ListBackupsRequest, v1.ListBackupsResponse](
+			httpClient,
+			baseURL+SiteServiceListBackupsProcedure,
+			connect.WithSchema(siteServiceMethods.ByName("ListBackups")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// siteServiceClient implements SiteServiceClient.
+type siteServiceClient struct {
+	exportStaticSite *connect.Client[v1.ExportStaticSiteRequest, v1.ExportStaticSiteResponse]
+	createBackup     *connect.Client[v1.CreateBackupRequest, v1.Backup]
+	listBackups      *connect.Client[v1.ListBackupsRequest, v1.ListBackupsResponse]
+}
+
+// ExportStaticSite calls api.v1.SiteService.ExportStaticSite.
+func (c *siteServiceClient) ExportStaticSite(ctx context.Context, req *connect.Request[v1.ExportStaticSiteRequest]) (*connect.Response[v1.ExportStaticSiteResponse], error) {
+	return c.exportStaticSite.CallUnary(ctx, req)
+}
+
+// CreateBackup calls api.v1.SiteService.CreateBackup.
+func (c *siteServiceClient) CreateBackup(ctx context.Context, req *connect.Request[v1.CreateBackupRequest]) (*connect.Response[v1.Backup], error) {
+	return c.createBackup.CallUnary(ctx, req)
+}
+
+// ListBackups calls api.v1.SiteService.ListBackups.
+func (c *siteServiceClient) ListBackups(ctx context.Context, req *connect.Request[v1.ListBackupsRequest]) (*connect.Response[v1.ListBackupsResponse], error) {
+	return c.listBackups.CallUnary(ctx, req)
+}
+
+// SiteServiceHandler is an implementation of the api.v1.SiteService service.
+type SiteServiceHandler interface {
+	// ExportStaticSite 将已发布的公开笔记导出为静态网站（仅管理员）
+	ExportStaticSite(context.Context, *connect.Request[v1.ExportStaticSiteRequest]) (*connect.Response[v1.ExportStaticSiteResponse], error)
+	// CreateBackup 在数据目录的 backups 目录中创建数据库备份（仅系统管理员）
+	CreateBackup(context.Context, *connect.Request[v1.CreateBackupRequest]) (*connect.Response[v1.Backup], error)
+	// ListBackups 列出数据目录中的数据库备份，最新的在前（仅系统管理员）
+	ListBackups(context.Context, *connect.Request[v1.ListBackupsRequest]) (*connect.Response[v1.ListBackupsResponse], error)
+}
+
+// NewSiteServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSiteServiceHandler(svc SiteServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	siteServiceMethods := v1.File_api_v1_site_service_proto.Services().ByName("SiteService").Methods()
+	siteServiceExportStaticSiteHandler := connect.NewUnaryHandler(
+		SiteServiceExportStaticSiteProcedure,
+		svc.ExportStaticSite,
+		connect.WithSchema(siteServiceMethods.ByName("ExportStaticSite")),
+		connect.WithHandlerOptions(opts...),
+	)
+	siteServiceCreateBackupHandler := connect.NewUnaryHandler(
+		SiteServiceCreateBackupProcedure,
+		svc.CreateBackup,
+		connect.WithSchema(siteServiceMethods.ByName("CreateBackup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	siteServiceListBackupsHandler := connect.NewUnaryHandler(
+		SiteServiceListBackupsProcedure,
+		svc.ListBackups,
+		connect.WithSchema(siteServiceMethods.ByName("ListBackups")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.SiteService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SiteServiceExportStaticSiteProcedure:
+			siteServiceExportStaticSiteHandler.ServeHTTP(w, r)
+		case SiteServiceCreateBackupProcedure:
+			siteServiceCreateBackupHandler.ServeHTTP(w, r)
+		case SiteServiceListBackupsProcedure:
+			siteServiceListBackupsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSiteServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSiteServiceHandler struct{}
+
+func (UnimplementedSiteServiceHandler) ExportStaticSite(context.Context, *connect.Request[v1.ExportStaticSiteRequest]) (*connect.Response[v1.ExportStaticSiteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SiteService.ExportStaticSite is not implemented"))
+}
+
+func (UnimplementedSiteServiceHandler) CreateBackup(context.Context, *connect.Request[v1.CreateBackupRequest]) (*connect.Response[v1.Backup], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SiteService.CreateBackup is not implemented"))
+}
+
+func (UnimplementedSiteServiceHandler) ListBackups(context.Context, *connect.Request[v1.ListBackupsRequest]) (*connect.Response[v1.ListBackupsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.SiteService.ListBackups is not implemented"))
+}
@@ -47,6 +47,18 @@ const (
 	TagServiceDeleteTagProcedure = "/api.v1.TagService/DeleteTag"
 	// TagServiceGetTagBySlugProcedure is the fully-qualified name of the TagService's GetTagBySlug RPC.
 	TagServiceGetTagBySlugProcedure = "/api.v1.TagService/GetTagBySlug"
+	// TagServiceRecountTagsProcedure is the fully-qualified name of the TagService's RecountTags RPC.
+	TagServiceRecountTagsProcedure = "/api.v1.TagService/RecountTags"
+	// TagServiceMergeTagsProcedure is the fully-qualified name of the TagService's MergeTags RPC.
+	TagServiceMergeTagsProcedure = "/api.v1.TagService/MergeTags"
+	// TagServiceCreateTagAliasProcedure is the fully-qualified name of the TagService's CreateTagAlias
+	// RPC.
+	TagServiceCreateTagAliasProcedure = "/api.v1.TagService/CreateTagAlias"
+	// TagServiceDeleteTagAliasProcedure is the fully-qualified name of the TagService's DeleteTagAlias
+	// RPC.
+	TagServiceDeleteTagAliasProcedure = "/api.v1.TagService/DeleteTagAlias"
+	// TagServiceSuggestTagsProcedure is the fully-qualified name of the TagService's SuggestTags RPC.
+	TagServiceSuggestTagsProcedure = "/api.v1.TagService/SuggestTags"
 )
 
 // TagServiceClient is a client for the api.v1.TagService service.
@@ -63,6 +75,16 @@ type TagServiceClient interface {
 	DeleteTag(context.Context, *connect.Request[v1.DeleteTagRequest]) (*connect.Response[emptypb.Empty], error)
 	// GetTagBySlug 根据slug返回标签
 	GetTagBySlug(context.Context, *connect.Request[v1.GetTagBySlugRequest]) (*connect.Response[store.Tag], error)
+	// RecountTags 根据笔记标签关系重新计算所选工作区中标签的使用次数（仅工作区管理员）
+	RecountTags(context.Context, *connect.Request[v1.RecountTagsRequest]) (*connect.Response[v1.RecountTagsResponse], error)
+	// MergeTags 将多个标签合并到目标标签，来源标签的名称成为目标标签的别名（仅管理员）
+	MergeTags(context.Context, *connect.Request[v1.MergeTagsRequest]) (*connect.Response[store.Tag], error)
+	// CreateTagAlias 为标签添加别名（仅管理员）
+	CreateTagAlias(context.Context, *connect.Request[v1.CreateTagAliasRequest]) (*connect.Response[store.Tag], error)
+	// DeleteTagAlias 删除标签的别名（仅管理员）
+	DeleteTagAlias(context.Context, *connect.Request[v1.DeleteTagAliasRequest]) (*connect.Response[store.Tag], error)
+	// SuggestTags 返回名称或别名以指定前缀开头的标签，按使用次数排序
+	SuggestTags(context.Context, *connect.Request[v1.SuggestTagsRequest]) (*connect.Response[v1.SuggestTagsResponse], error)
 }
 
 // NewTagServiceClient constructs a client for the api.v1.TagService service. By default, it uses
@@ -112,17 +134,52 @@ func NewTagServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(tagServiceMethods.ByName("GetTagBySlug")),
 			connect.WithClientOptions(opts...),
 		),
+		recountTags: connect.NewClient[v1.RecountTagsRequest, v1.RecountTagsResponse](
+			httpClient,
+			baseURL+TagServiceRecountTagsProcedure,
+			connect.WithSchema(tagServiceMethods.ByName("RecountTags")),
+			connect.WithClientOptions(opts...),
+		),
+		mergeTags: connect.NewClient[v1.MergeTagsRequest, store.Tag](
+			httpClient,
+			baseURL+TagServiceMergeTagsProcedure,
+			connect.WithSchema(tagServiceMethods.ByName("MergeTags")),
+			connect.WithClientOptions(opts...),
+		),
+		createTagAlias: connect.NewClient[v1.CreateTagAliasRequest, store.Tag](
+			httpClient,
+			baseURL+TagServiceCreateTagAliasProcedure,
+			connect.WithSchema(tagServiceMethods.ByName("CreateTagAlias")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTagAlias: connect.NewClient[v1.DeleteTagAliasRequest, store.Tag](
+			httpClient,
+			baseURL+TagServiceDeleteTagAliasProcedure,
+			connect.WithSchema(tagServiceMethods.ByName("DeleteTagAlias")),
+			connect.WithClientOptions(opts...),
+		),
+		suggestTags: connect.NewClient[v1.SuggestTagsRequest, v1.SuggestTagsResponse](
+			httpClient,
+			baseURL+TagServiceSuggestTagsProcedure,
+			connect.WithSchema(tagServiceMethods.ByName("SuggestTags")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // tagServiceClient implements TagServiceClient.
 type tagServiceClient struct {
-	listTags     *connect.Client[v1.ListTagsRequest, v1.ListTagsResponse]
-	getTag       *connect.Client[v1.GetTagRequest, store.Tag]
-	createTag    *connect.Client[v1.CreateTagRequest, store.Tag]
-	updateTag    *connect.Client[v1.UpdateTagRequest, store.Tag]
-	deleteTag    *connect.Client[v1.DeleteTagRequest, emptypb.Empty]
-	getTagBySlug *connect.Client[v1.GetTagBySlugRequest, store.Tag]
+	listTags       *connect.Client[v1.ListTagsRequest, v1.ListTagsResponse]
+	getTag         *connect.Client[v1.GetTagRequest, store.Tag]
+	createTag      *connect.Client[v1.CreateTagRequest, store.Tag]
+	updateTag      *connect.Client[v1.UpdateTagRequest, store.Tag]
+	deleteTag      *connect.Client[v1.DeleteTagRequest, emptypb.Empty]
+	getTagBySlug   *connect.Client[v1.GetTagBySlugRequest, store.Tag]
+	recountTags    *connect.Client[v1.RecountTagsRequest, v1.RecountTagsResponse]
+	mergeTags      *connect.Client[v1.MergeTagsRequest, store.Tag]
+	createTagAlias *connect.Client[v1.CreateTagAliasRequest, store.Tag]
+	deleteTagAlias *connect.Client[v1.DeleteTagAliasRequest, store.Tag]
+	suggestTags    *connect.Client[v1.SuggestTagsRequest, v1.SuggestTagsResponse]
 }
 
 // ListTags calls api.v1.TagService.ListTags.
@@ -155,6 +212,31 @@ func (c *tagServiceClient) GetTagBySlug(ctx context.Context, req *connect.Reques
 	return c.getTagBySlug.CallUnary(ctx, req)
 }
 
+// RecountTags calls api.v1.TagService.RecountTags.
+func (c *tagServiceClient) RecountTags(ctx context.Context, req *connect.Request[v1.RecountTagsRequest]) (*connect.Response[v1.RecountTagsResponse], error) {
+	return c.recountTags.CallUnary(ctx, req)
+}
+
+// MergeTags calls api.v1.TagService.MergeTags.
+func (c *tagServiceClient) MergeTags(ctx context.Context, req *connect.Request[v1.MergeTagsRequest]) (*connect.Response[store.Tag], error) {
+	return c.mergeTags.CallUnary(ctx, req)
+}
+
+// CreateTagAlias calls api.v1.TagService.CreateTagAlias.
+func (c *tagServiceClient) CreateTagAlias(ctx context.Context, req *connect.Request[v1.CreateTagAliasRequest]) (*connect.Response[store.Tag], error) {
+	return c.createTagAlias.CallUnary(ctx, req)
+}
+
+// DeleteTagAlias calls api.v1.TagService.DeleteTagAlias.
+func (c *tagServiceClient) DeleteTagAlias(ctx context.Context, req *connect.Request[v1.DeleteTagAliasRequest]) (*connect.Response[store.Tag], error) {
+	return c.deleteTagAlias.CallUnary(ctx, req)
+}
+
+// SuggestTags calls api.v1.TagService.SuggestTags.
+func (c *tagServiceClient) SuggestTags(ctx context.Context, req *connect.Request[v1.SuggestTagsRequest]) (*connect.Response[v1.SuggestTagsResponse], error) {
+	return c.suggestTags.CallUnary(ctx, req)
+}
+
 // TagServiceHandler is an implementation of the api.v1.TagService service.
 type TagServiceHandler interface {
 	// ListTags 返回标签列表
@@ -169,6 +251,16 @@ type TagServiceHandler interface {
 	DeleteTag(context.Context, *connect.Request[v1.DeleteTagRequest]) (*connect.Response[emptypb.Empty], error)
 	// GetTagBySlug 根据slug返回标签
 	GetTagBySlug(context.Context, *connect.Request[v1.GetTagBySlugRequest]) (*connect.Response[store.Tag], error)
+	// RecountTags 根据笔记标签关系重新计算所选工作区中标签的使用次数（仅工作区管理员）
+	RecountTags(context.Context, *connect.Request[v1.RecountTagsRequest]) (*connect.Response[v1.RecountTagsResponse], error)
+	// MergeTags 将多个标签合并到目标标签，来源标签的名称成为目标标签的别名（仅管理员）
+	MergeTags(context.Context, *connect.Request[v1.MergeTagsRequest]) (*connect.Response[store.Tag], error)
+	// CreateTagAlias 为标签添加别名（仅管理员）
+	CreateTagAlias(context.Context, *connect.Request[v1.CreateTagAliasRequest]) (*connect.Response[store.Tag], error)
+	// DeleteTagAlias 删除标签的别名（仅管理员）
+	DeleteTagAlias(context.Context, *connect.Request[v1.DeleteTagAliasRequest]) (*connect.Response[store.Tag], error)
+	// SuggestTags 返回名称或别名以指定前缀开头的标签，按使用次数排序
+	SuggestTags(context.Context, *connect.Request[v1.SuggestTagsRequest]) (*connect.Response[v1.SuggestTagsResponse], error)
 }
 
 // NewTagServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -214,6 +306,36 @@ func NewTagServiceHandler(svc TagServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(tagServiceMethods.ByName("GetTagBySlug")),
 		connect.WithHandlerOptions(opts...),
 	)
+	tagServiceRecountTagsHandler := connect.NewUnaryHandler(
+		TagServiceRecountTagsProcedure,
+		svc.RecountTags,
+		connect.WithSchema(tagServiceMethods.ByName("RecountTags")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tagServiceMergeTagsHandler := connect.NewUnaryHandler(
+		TagServiceMergeTagsProcedure,
+		svc.MergeTags,
+		connect.WithSchema(tagServiceMethods.ByName("MergeTags")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tagServiceCreateTagAliasHandler := connect.NewUnaryHandler(
+		TagServiceCreateTagAliasProcedure,
+		svc.CreateTagAlias,
+		connect.WithSchema(tagServiceMethods.ByName("CreateTagAlias")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tagServiceDeleteTagAliasHandler := connect.NewUnaryHandler(
+		TagServiceDeleteTagAliasProcedure,
+		svc.DeleteTagAlias,
+		connect.WithSchema(tagServiceMethods.ByName("DeleteTagAlias")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tagServiceSuggestTagsHandler := connect.NewUnaryHandler(
+		TagServiceSuggestTagsProcedure,
+		svc.SuggestTags,
+		connect.WithSchema(tagServiceMethods.ByName("SuggestTags")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.TagService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TagServiceListTagsProcedure:
@@ -228,6 +350,16 @@ func NewTagServiceHandler(svc TagServiceHandler, opts ...connect.HandlerOption)
 			tagServiceDeleteTagHandler.ServeHTTP(w, r)
 		case TagServiceGetTagBySlugProcedure:
 			tagServiceGetTagBySlugHandler.ServeHTTP(w, r)
+		case TagServiceRecountTagsProcedure:
+			tagServiceRecountTagsHandler.ServeHTTP(w, r)
+		case TagServiceMergeTagsProcedure:
+			tagServiceMergeTagsHandler.ServeHTTP(w, r)
+		case TagServiceCreateTagAliasProcedure:
+			tagServiceCreateTagAliasHandler.ServeHTTP(w, r)
+		case TagServiceDeleteTagAliasProcedure:
+			tagServiceDeleteTagAliasHandler.ServeHTTP(w, r)
+		case TagServiceSuggestTagsProcedure:
+			tagServiceSuggestTagsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -260,3 +392,23 @@ func (UnimplementedTagServiceHandler) DeleteTag(context.Context, *connect.Reques
 func (UnimplementedTagServiceHandler) GetTagBySlug(context.Context, *connect.Request[v1.GetTagBySlugRequest]) (*connect.Response[store.Tag], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.TagService.GetTagBySlug is not implemented"))
 }
+
+func (UnimplementedTagServiceHandler) RecountTags(context.Context, *connect.Request[v1.RecountTagsRequest]) (*connect.Response[v1.RecountTagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.TagService.RecountTags is not implemented"))
+}
+
+func (UnimplementedTagServiceHandler) MergeTags(context.Context, *connect.Request[v1.MergeTagsRequest]) (*connect.Response[store.Tag], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.TagService.MergeTags is not implemented"))
+}
+
+func (UnimplementedTagServiceHandler) CreateTagAlias(context.Context, *connect.Request[v1.CreateTagAliasRequest]) (*connect.Response[store.Tag], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.TagService.CreateTagAlias is not implemented"))
+}
+
+func (UnimplementedTagServiceHandler) DeleteTagAlias(context.Context, *connect.Request[v1.DeleteTagAliasRequest]) (*connect.Response[store.Tag], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.TagService.DeleteTagAlias is not implemented"))
+}
+
+func (UnimplementedTagServiceHandler) SuggestTags(context.Context, *connect.Request[v1.SuggestTagsRequest]) (*connect.Response[v1.SuggestTagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.TagService.SuggestTags is not implemented"))
+}
@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/v1/workspace_service.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	store "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// WorkspaceServiceName is the fully-qualified name of the WorkspaceService service.
+	WorkspaceServiceName = "api.v1.WorkspaceService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// WorkspaceServiceListWorkspacesProcedure is the fully-qualified name of the WorkspaceService's
+	// ListWorkspaces RPC.
+	WorkspaceServiceListWorkspacesProcedure = "/api.v1.WorkspaceService/ListWorkspaces"
+	// WorkspaceServiceGetWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// GetWorkspace RPC.
+	WorkspaceServiceGetWorkspaceProcedure = "/api.v1.WorkspaceService/GetWorkspace"
+	// WorkspaceServiceCreateWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// CreateWorkspace RPC.
+	WorkspaceServiceCreateWorkspaceProcedure = "/api.v1.WorkspaceService/CreateWorkspace"
+	// WorkspaceServiceUpdateWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// UpdateWorkspace RPC.
+	WorkspaceServiceUpdateWorkspaceProcedure = "/api.v1.WorkspaceService/UpdateWorkspace"
+	// WorkspaceServiceDeleteWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// DeleteWorkspace RPC.
+	WorkspaceServiceDeleteWorkspaceProcedure = "/api.v1.WorkspaceService/DeleteWorkspace"
+	// WorkspaceServiceListWorkspaceMembersProcedure is the fully-qualified name of the
+	// WorkspaceService's ListWorkspaceMembers RPC.
+	WorkspaceServiceListWorkspaceMembersProcedure = "/api.v1.WorkspaceService/ListWorkspaceMembers"
+	// WorkspaceServiceSetWorkspaceMemberProcedure is the fully-qualified name of the WorkspaceService's
+	// SetWorkspaceMember RPC.
+	WorkspaceServiceSetWorkspaceMemberProcedure = "/api.v1.WorkspaceService/SetWorkspaceMember"
+	// WorkspaceServiceRemoveWorkspaceMemberProcedure is the fully-qualified name of the
+	// WorkspaceService's RemoveWorkspaceMember RPC.
+	WorkspaceServiceRemoveWorkspaceMemberProcedure = "/api.v1.WorkspaceService/RemoveWorkspaceMember"
+)
+
+// WorkspaceServiceClient is a client for the api.v1.WorkspaceService service.
+type WorkspaceServiceClient interface {
+	// ListWorkspaces 返回当前用户所属的工作区，系统管理员返回全部工作区
+	ListWorkspaces(context.Context, *connect.Request[v1.ListWorkspacesRequest]) (*connect.Response[v1.ListWorkspacesResponse], error)
+	// GetWorkspace 返回工作区，需要是工作区成员或系统管理员
+	GetWorkspace(context.Context, *connect.Request[v1.GetWorkspaceRequest]) (*connect.Response[store.Workspace], error)
+	// CreateWorkspace 创建工作区，创建者成为所有者
+	CreateWorkspace(context.Context, *connect.Request[v1.CreateWorkspaceRequest]) (*connect.Response[store.Workspace], error)
+	// UpdateWorkspace 更新工作区的名称和描述（所有者）
+	UpdateWorkspace(context.Context, *connect.Request[v1.UpdateWorkspaceRequest]) (*connect.Response[store.Workspace], error)
+	// DeleteWorkspace 删除没有内容的工作区（所有者），默认工作区不能删除
+	DeleteWorkspace(context.Context, *connect.Request[v1.DeleteWorkspaceRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListWorkspaceMembers 返回工作区成员，需要是工作区成员或系统管理员
+	ListWorkspaceMembers(context.Context, *connect.Request[v1.ListWorkspaceMembersRequest]) (*connect.Response[v1.ListWorkspaceMembersResponse], error)
+	// SetWorkspaceMember 添加成员或修改成员角色（所有者），工作区至少保留一个所有者
+	SetWorkspaceMember(context.Context, *connect.Request[v1.SetWorkspaceMemberRequest]) (*connect.Response[store.WorkspaceMember], error)
+	// RemoveWorkspaceMember 移除成员（所有者，或成员自己退出），工作区至少保留一个所有者
+	RemoveWorkspaceMember(context.Context, *connect.Request[v1.RemoveWorkspaceMemberRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewWorkspaceServiceClient constructs a client for the api.v1.WorkspaceService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWorkspaceServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WorkspaceServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	workspaceServiceMethods := v1.File_api_v1_workspace_service_proto.Services().ByName("WorkspaceService").Methods()
+	return &workspaceServiceClient{
+		listWorkspaces: connect.NewClient[v1.ListWorkspacesRequest, v1.ListWorkspacesResponse](
+			httpClient,
+			baseURL+WorkspaceServiceListWorkspacesProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("ListWorkspaces")),
+			connect.WithClientOptions(opts...),
+		),
+		getWorkspace: connect.NewClient[v1.GetWorkspaceRequest, store.Workspace](
+			httpClient,
+			baseURL+WorkspaceServiceGetWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("GetWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+		createWorkspace: connect.NewClient[v1.CreateWorkspaceRequest, store.Workspace](
+			httpClient,
+			baseURL+WorkspaceServiceCreateWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("CreateWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+		updateWorkspace: connect.NewClient[v1.UpdateWorkspaceRequest, store.Workspace](
+			httpClient,
+			baseURL+WorkspaceServiceUpdateWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("UpdateWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWorkspace: connect.NewClient[v1.DeleteWorkspaceRequest, emptypb.Empty](
+			httpClient,
+			baseURL+WorkspaceServiceDeleteWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("DeleteWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+		listWorkspaceMembers: connect.NewClient[v1.ListWorkspaceMembersRequest, v1.ListWorkspaceMembersResponse](
+			httpClient,
+			baseURL+WorkspaceServiceListWorkspaceMembersProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("ListWorkspaceMembers")),
+			connect.WithClientOptions(opts...),
+		),
+		setWorkspaceMember: connect.NewClient[v1.SetWorkspaceMemberRequest, store.WorkspaceMember](
+			httpClient,
+			baseURL+WorkspaceServiceSetWorkspaceMemberProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("SetWorkspaceMember")),
+			connect.WithClientOptions(opts...),
+		),
+		removeWorkspaceMember: connect.NewClient[v1.RemoveWorkspaceMemberRequest, emptypb.Empty](
+			httpClient,
+			baseURL+WorkspaceServiceRemoveWorkspaceMemberProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("RemoveWorkspaceMember")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// workspaceServiceClient implements WorkspaceServiceClient.
+type workspaceServiceClient struct {
+	listWorkspaces        *connect.Client[v1.ListWorkspacesRequest, v1.ListWorkspacesResponse]
+	getWorkspace          *connect.Client[v1.GetWorkspaceRequest, store.Workspace]
+	createWorkspace       *connect.Client[v1.CreateWorkspaceRequest, store.Workspace]
+	updateWorkspace       *connect.Client[v1.UpdateWorkspaceRequest, store.Workspace]
+	deleteWorkspace       *connect.Client[v1.DeleteWorkspaceRequest, emptypb.Empty]
+	listWorkspaceMembers  *connect.Client[v1.ListWorkspaceMembersRequest, v1.ListWorkspaceMembersResponse]
+	setWorkspaceMember    *connect.Client[v1.SetWorkspaceMemberRequest, store.WorkspaceMember]
+	removeWorkspaceMember *connect.Client[v1.RemoveWorkspaceMemberRequest, emptypb.Empty]
+}
+
+// ListWorkspaces calls api.v1.WorkspaceService.ListWorkspaces.
+func (c *workspaceServiceClient) ListWorkspaces(ctx context.Context, req *connect.Request[v1.ListWorkspacesRequest]) (*connect.Response[v1.ListWorkspacesResponse], error) {
+	return c.listWorkspaces.CallUnary(ctx, req)
+}
+
+// GetWorkspace calls api.v1.WorkspaceService.GetWorkspace.
+func (c *workspaceServiceClient) GetWorkspace(ctx context.Context, req *connect.Request[v1.GetWorkspaceRequest]) (*connect.Response[store.Workspace], error) {
+	return c.getWorkspace.CallUnary(ctx, req)
+}
+
+// CreateWorkspace calls api.v1.WorkspaceService.CreateWorkspace.
+func (c *workspaceServiceClient) CreateWorkspace(ctx context.Context, req *connect.Request[v1.CreateWorkspaceRequest]) (*connect.Response[store.Workspace], error) {
+	return c.createWorkspace.CallUnary(ctx, req)
+}
+
+// UpdateWorkspace calls api.v1.WorkspaceService.UpdateWorkspace.
+func (c *workspaceServiceClient) UpdateWorkspace(ctx context.Context, req *connect.Request[v1.UpdateWorkspaceRequest]) (*connect.Response[store.Workspace], error) {
+	return c.updateWorkspace.CallUnary(ctx, req)
+}
+
+// DeleteWorkspace calls api.v1.WorkspaceService.DeleteWorkspace.
+func (c *workspaceServiceClient) DeleteWorkspace(ctx context.Context, req *connect.Request[v1.DeleteWorkspaceRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteWorkspace.CallUnary(ctx, req)
+}
+
+// ListWorkspaceMembers calls api.v1.WorkspaceService.ListWorkspaceMembers.
+func (c *workspaceServiceClient) ListWorkspaceMembers(ctx context.Context, req *connect.Request[v1.ListWorkspaceMembersRequest]) (*connect.Response[v1.ListWorkspaceMembersResponse], error) {
+	return c.listWorkspaceMembers.CallUnary(ctx, req)
+}
+
+// SetWorkspaceMember calls api.v1.WorkspaceService.SetWorkspaceMember.
+func (c *workspaceServiceClient) SetWorkspaceMember(ctx context.Context, req *connect.Request[v1.SetWorkspaceMemberRequest]) (*connect.Response[store.WorkspaceMember], error) {
+	return c.setWorkspaceMember.CallUnary(ctx, req)
+}
+
+// RemoveWorkspaceMember calls api.v1.WorkspaceService.RemoveWorkspaceMember.
+func (c *workspaceServiceClient) RemoveWorkspaceMember(ctx context.Context, req *connect.Request[v1.RemoveWorkspaceMemberRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.removeWorkspaceMember.CallUnary(ctx, req)
+}
+
+// WorkspaceServiceHandler is an implementation of the api.v1.WorkspaceService service.
+type WorkspaceServiceHandler interface {
+	// ListWorkspaces 返回当前用户所属的工作区，系统管理员返回全部工作区
+	ListWorkspaces(context.Context, *connect.Request[v1.ListWorkspacesRequest]) (*connect.Response[v1.ListWorkspacesResponse], error)
+	// GetWorkspace 返回工作区，需要是工作区成员或系统管理员
+	GetWorkspace(context.Context, *connect.Request[v1.GetWorkspaceRequest]) (*connect.Response[store.Workspace], error)
+	// CreateWorkspace 创建工作区，创建者成为所有者
+	CreateWorkspace(context.Context, *connect.Request[v1.CreateWorkspaceRequest]) (*connect.Response[store.Workspace], error)
+	// UpdateWorkspace 更新工作区的名称和描述（所有者）
+	UpdateWorkspace(context.Context, *connect.Request[v1.UpdateWorkspaceRequest]) (*connect.Response[store.Workspace], error)
+	// DeleteWorkspace 删除没有内容的工作区（所有者），默认工作区不能删除
+	DeleteWorkspace(context.Context, *connect.Request[v1.DeleteWorkspaceRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListWorkspaceMembers 返回工作区成员，需要是工作区成员或系统管理员
+	ListWorkspaceMembers(context.Context, *connect.Request[v1.ListWorkspaceMembersRequest]) (*connect.Response[v1.ListWorkspaceMembersResponse], error)
+	// SetWorkspaceMember 添加成员或修改成员角色（所有者），工作区至少保留一个所有者
+	SetWorkspaceMember(context.Context, *connect.Request[v1.SetWorkspaceMemberRequest]) (*connect.Response[store.WorkspaceMember], error)
+	// RemoveWorkspaceMember 移除成员（所有者，或成员自己退出），工作区至少保留一个所有者
+	RemoveWorkspaceMember(context.Context, *connect.Request[v1.RemoveWorkspaceMemberRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewWorkspaceServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	workspaceServiceMethods := v1.File_api_v1_workspace_service_proto.Services().ByName("WorkspaceService").Methods()
+	workspaceServiceListWorkspacesHandler := connect.NewUnaryHandler(
+		WorkspaceServiceListWorkspacesProcedure,
+		svc.ListWorkspaces,
+		connect.WithSchema(workspaceServiceMethods.ByName("ListWorkspaces")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceGetWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceGetWorkspaceProcedure,
+		svc.GetWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("GetWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceCreateWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceCreateWorkspaceProcedure,
+		svc.CreateWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("CreateWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceUpdateWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceUpdateWorkspaceProcedure,
+		svc.UpdateWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("UpdateWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceDeleteWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceDeleteWorkspaceProcedure,
+		svc.DeleteWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("DeleteWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceListWorkspaceMembersHandler := connect.NewUnaryHandler(
+		WorkspaceServiceListWorkspaceMembersProcedure,
+		svc.ListWorkspaceMembers,
+		connect.WithSchema(workspaceServiceMethods.ByName("ListWorkspaceMembers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceSetWorkspaceMemberHandler := connect.NewUnaryHandler(
+		WorkspaceServiceSetWorkspaceMemberProcedure,
+		svc.SetWorkspaceMember,
+		connect.WithSchema(workspaceServiceMethods.ByName("SetWorkspaceMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceRemoveWorkspaceMemberHandler := connect.NewUnaryHandler(
+		WorkspaceServiceRemoveWorkspaceMemberProcedure,
+		svc.RemoveWorkspaceMember,
+		connect.WithSchema(workspaceServiceMethods.ByName("RemoveWorkspaceMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.WorkspaceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WorkspaceServiceListWorkspacesProcedure:
+			workspaceServiceListWorkspacesHandler.ServeHTTP(w, r)
+		case WorkspaceServiceGetWorkspaceProcedure:
+			workspaceServiceGetWorkspaceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceCreateWorkspaceProcedure:
+			workspaceServiceCreateWorkspaceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceUpdateWorkspaceProcedure:
+			workspaceServiceUpdateWorkspaceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceDeleteWorkspaceProcedure:
+			workspaceServiceDeleteWorkspaceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceListWorkspaceMembersProcedure:
+			workspaceServiceListWorkspaceMembersHandler.ServeHTTP(w, r)
+		case WorkspaceServiceSetWorkspaceMemberProcedure:
+			workspaceServiceSetWorkspaceMemberHandler.ServeHTTP(w, r)
+		case WorkspaceServiceRemoveWorkspaceMemberProcedure:
+			workspaceServiceRemoveWorkspaceMemberHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWorkspaceServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWorkspaceServiceHandler struct{}
+
+func (UnimplementedWorkspaceServiceHandler) ListWorkspaces(context.Context, *connect.Request[v1.ListWorkspacesRequest]) (*connect.Response[v1.ListWorkspacesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.ListWorkspaces is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) GetWorkspace(context.Context, *connect.Request[v1.GetWorkspaceRequest]) (*connect.Response[store.Workspace], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.GetWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) CreateWorkspace(context.Context, *connect.Request[v1.CreateWorkspaceRequest]) (*connect.Response[store.Workspace], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.CreateWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) UpdateWorkspace(context.Context, *connect.Request[v1.UpdateWorkspaceRequest]) (*connect.Response[store.Workspace], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.UpdateWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) DeleteWorkspace(context.Context, *connect.Request[v1.DeleteWorkspaceRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.DeleteWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) ListWorkspaceMembers(context.Context, *connect.Request[v1.ListWorkspaceMembersRequest]) (*connect.Response[v1.ListWorkspaceMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.ListWorkspaceMembers is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) SetWorkspaceMember(context.Context, *connect.Request[v1.SetWorkspaceMemberRequest]) (*connect.Response[store.WorkspaceMember], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.SetWorkspaceMember is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) RemoveWorkspaceMember(context.Context, *connect.Request[v1.RemoveWorkspaceMemberRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.WorkspaceService.RemoveWorkspaceMember is not implemented"))
+}
@@ -276,7 +276,9 @@ func (x *UpdateCategoryRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 type DeleteCategoryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// 资源名称，格式：categories/{category}
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 将笔记和子分类转移到的分类的资源名称（可选），为空时分类下有笔记或子分类则不能删除
+	ReassignTo    string `protobuf:"bytes,2,opt,name=reassign_to,json=reassignTo,proto3" json:"reassign_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -318,6 +320,13 @@ func (x *DeleteCategoryRequest) GetName() string {
 	return ""
 }
 
+func (x *DeleteCategoryRequest) GetReassignTo() string {
+	if x != nil {
+		return x.ReassignTo
+	}
+	return ""
+}
+
 // GetCategoryBySlugRequest 根据slug获取分类请求
 type GetCategoryBySlugRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -364,6 +373,336 @@ func (x *GetCategoryBySlugRequest) GetSlug() string {
 	return ""
 }
 
+// GetCategoryTreeRequest 获取分类树请求
+type GetCategoryTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否包含隐藏的分类，不包含时隐藏分类的子分类也不返回
+	IncludeHidden bool `protobuf:"varint,1,opt,name=include_hidden,json=includeHidden,proto3" json:"include_hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeRequest) Reset() {
+	*x = GetCategoryTreeRequest{}
+	mi := &file_api_v1_category_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeRequest) ProtoMessage() {}
+
+func (x *GetCategoryTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_category_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_category_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCategoryTreeRequest) GetIncludeHidden() bool {
+	if x != nil {
+		return x.IncludeHidden
+	}
+	return false
+}
+
+// GetCategoryTreeResponse 获取分类树响应
+type GetCategoryTreeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 顶级分类节点
+	Nodes         []*CategoryNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeResponse) Reset() {
+	*x = GetCategoryTreeResponse{}
+	mi := &file_api_v1_category_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeResponse) ProtoMessage() {}
+
+func (x *GetCategoryTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_category_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_category_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCategoryTreeResponse) GetNodes() []*CategoryNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// CategoryNode 分类树节点
+type CategoryNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 分类
+	Category *store.Category `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// 直接属于该分类的已发布笔记数量
+	DirectNoteCount int32 `protobuf:"varint,2,opt,name=direct_note_count,json=directNoteCount,proto3" json:"direct_note_count,omitempty"`
+	// 该分类及其所有子分类的已发布笔记数量
+	TotalNoteCount int32 `protobuf:"varint,3,opt,name=total_note_count,json=totalNoteCount,proto3" json:"total_note_count,omitempty"`
+	// 子分类节点
+	Children      []*CategoryNode `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryNode) Reset() {
+	*x = CategoryNode{}
+	mi := &file_api_v1_category_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryNode) ProtoMessage() {}
+
+func (x *CategoryNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_category_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryNode.ProtoReflect.Descriptor instead.
+func (*CategoryNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_category_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CategoryNode) GetCategory() *store.Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *CategoryNode) GetDirectNoteCount() int32 {
+	if x != nil {
+		return x.DirectNoteCount
+	}
+	return 0
+}
+
+func (x *CategoryNode) GetTotalNoteCount() int32 {
+	if x != nil {
+		return x.TotalNoteCount
+	}
+	return 0
+}
+
+func (x *CategoryNode) GetChildren() []*CategoryNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// MoveCategoryRequest 移动分类请求
+type MoveCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：categories/{category}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 新的父分类资源名称，为空时移动到顶级
+	Parent string `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	// 在新父分类下的排序顺序
+	Order         int32 `protobuf:"varint,3,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCategoryRequest) Reset() {
+	*x = MoveCategoryRequest{}
+	mi := &file_api_v1_category_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCategoryRequest) ProtoMessage() {}
+
+func (x *MoveCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_category_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCategoryRequest.ProtoReflect.Descriptor instead.
+func (*MoveCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_category_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MoveCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MoveCategoryRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *MoveCategoryRequest) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+// ReorderCategoriesRequest 批量排序分类请求
+type ReorderCategoriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 分类的排序顺序
+	Orders        []*CategoryOrder `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderCategoriesRequest) Reset() {
+	*x = ReorderCategoriesRequest{}
+	mi := &file_api_v1_category_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderCategoriesRequest) ProtoMessage() {}
+
+func (x *ReorderCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_category_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ReorderCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_category_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReorderCategoriesRequest) GetOrders() []*CategoryOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// CategoryOrder 分类的排序顺序
+type CategoryOrder struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：categories/{category}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 排序顺序
+	Order         int32 `protobuf:"varint,2,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryOrder) Reset() {
+	*x = CategoryOrder{}
+	mi := &file_api_v1_category_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryOrder) ProtoMessage() {}
+
+func (x *CategoryOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_category_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryOrder.ProtoReflect.Descriptor instead.
+func (*CategoryOrder) Descriptor() ([]byte, []int) {
+	return file_api_v1_category_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CategoryOrder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryOrder) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
 var File_api_v1_category_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_category_service_proto_rawDesc = "" +
@@ -383,18 +722,41 @@ const file_api_v1_category_service_proto_rawDesc = "" +
 	"\x15UpdateCategoryRequest\x12+\n" +
 	"\bcategory\x18\x01 \x01(\v2\x0f.store.CategoryR\bcategory\x12;\n" +
 	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMask\"+\n" +
+	"updateMask\"L\n" +
 	"\x15DeleteCategoryRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\".\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vreassign_to\x18\x02 \x01(\tR\n" +
+	"reassignTo\".\n" +
 	"\x18GetCategoryBySlugRequest\x12\x12\n" +
-	"\x04slug\x18\x01 \x01(\tR\x04slug2\xb3\x03\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"?\n" +
+	"\x16GetCategoryTreeRequest\x12%\n" +
+	"\x0einclude_hidden\x18\x01 \x01(\bR\rincludeHidden\"E\n" +
+	"\x17GetCategoryTreeResponse\x12*\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x14.api.v1.CategoryNodeR\x05nodes\"\xc3\x01\n" +
+	"\fCategoryNode\x12+\n" +
+	"\bcategory\x18\x01 \x01(\v2\x0f.store.CategoryR\bcategory\x12*\n" +
+	"\x11direct_note_count\x18\x02 \x01(\x05R\x0fdirectNoteCount\x12(\n" +
+	"\x10total_note_count\x18\x03 \x01(\x05R\x0etotalNoteCount\x120\n" +
+	"\bchildren\x18\x04 \x03(\v2\x14.api.v1.CategoryNodeR\bchildren\"W\n" +
+	"\x13MoveCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06parent\x18\x02 \x01(\tR\x06parent\x12\x14\n" +
+	"\x05order\x18\x03 \x01(\x05R\x05order\"I\n" +
+	"\x18ReorderCategoriesRequest\x12-\n" +
+	"\x06orders\x18\x01 \x03(\v2\x15.api.v1.CategoryOrderR\x06orders\"9\n" +
+	"\rCategoryOrder\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05order\x18\x02 \x01(\x05R\x05order2\x94\x05\n" +
 	"\x0fCategoryService\x12O\n" +
 	"\x0eListCategories\x12\x1d.api.v1.ListCategoriesRequest\x1a\x1e.api.v1.ListCategoriesResponse\x12:\n" +
 	"\vGetCategory\x12\x1a.api.v1.GetCategoryRequest\x1a\x0f.store.Category\x12@\n" +
 	"\x0eCreateCategory\x12\x1d.api.v1.CreateCategoryRequest\x1a\x0f.store.Category\x12@\n" +
 	"\x0eUpdateCategory\x12\x1d.api.v1.UpdateCategoryRequest\x1a\x0f.store.Category\x12G\n" +
 	"\x0eDeleteCategory\x12\x1d.api.v1.DeleteCategoryRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
-	"\x11GetCategoryBySlug\x12 .api.v1.GetCategoryBySlugRequest\x1a\x0f.store.CategoryB\x93\x01\n" +
+	"\x11GetCategoryBySlug\x12 .api.v1.GetCategoryBySlugRequest\x1a\x0f.store.Category\x12R\n" +
+	"\x0fGetCategoryTree\x12\x1e.api.v1.GetCategoryTreeRequest\x1a\x1f.api.v1.GetCategoryTreeResponse\x12<\n" +
+	"\fMoveCategory\x12\x1b.api.v1.MoveCategoryRequest\x1a\x0f.store.Category\x12M\n" +
+	"\x11ReorderCategories\x12 .api.v1.ReorderCategoriesRequest\x1a\x16.google.protobuf.EmptyB\x93\x01\n" +
 	"\n" +
 	"com.api.v1B\x14CategoryServiceProtoP\x01Z6github.com/wdmsyhh/simple-notes/proto/gen/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
 
@@ -410,7 +772,7 @@ func file_api_v1_category_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_category_service_proto_rawDescData
 }
 
-var file_api_v1_category_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_category_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_api_v1_category_service_proto_goTypes = []any{
 	(*ListCategoriesRequest)(nil),    // 0: api.v1.ListCategoriesRequest
 	(*ListCategoriesResponse)(nil),   // 1: api.v1.ListCategoriesResponse
@@ -419,32 +781,48 @@ var file_api_v1_category_service_proto_goTypes = []any{
 	(*UpdateCategoryRequest)(nil),    // 4: api.v1.UpdateCategoryRequest
 	(*DeleteCategoryRequest)(nil),    // 5: api.v1.DeleteCategoryRequest
 	(*GetCategoryBySlugRequest)(nil), // 6: api.v1.GetCategoryBySlugRequest
-	(*store.Category)(nil),           // 7: store.Category
-	(*fieldmaskpb.FieldMask)(nil),    // 8: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),            // 9: google.protobuf.Empty
+	(*GetCategoryTreeRequest)(nil),   // 7: api.v1.GetCategoryTreeRequest
+	(*GetCategoryTreeResponse)(nil),  // 8: api.v1.GetCategoryTreeResponse
+	(*CategoryNode)(nil),             // 9: api.v1.CategoryNode
+	(*MoveCategoryRequest)(nil),      // 10: api.v1.MoveCategoryRequest
+	(*ReorderCategoriesRequest)(nil), // 11: api.v1.ReorderCategoriesRequest
+	(*CategoryOrder)(nil),            // 12: api.v1.CategoryOrder
+	(*store.Category)(nil),           // 13: store.Category
+	(*fieldmaskpb.FieldMask)(nil),    // 14: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),            // 15: google.protobuf.Empty
 }
 var file_api_v1_category_service_proto_depIdxs = []int32{
-	7,  // 0: api.v1.ListCategoriesResponse.categories:type_name -> store.Category
-	7,  // 1: api.v1.CreateCategoryRequest.category:type_name -> store.Category
-	7,  // 2: api.v1.UpdateCategoryRequest.category:type_name -> store.Category
-	8,  // 3: api.v1.UpdateCategoryRequest.update_mask:type_name -> google.protobuf.FieldMask
-	0,  // 4: api.v1.CategoryService.ListCategories:input_type -> api.v1.ListCategoriesRequest
-	2,  // 5: api.v1.CategoryService.GetCategory:input_type -> api.v1.GetCategoryRequest
-	3,  // 6: api.v1.CategoryService.CreateCategory:input_type -> api.v1.CreateCategoryRequest
-	4,  // 7: api.v1.CategoryService.UpdateCategory:input_type -> api.v1.UpdateCategoryRequest
-	5,  // 8: api.v1.CategoryService.DeleteCategory:input_type -> api.v1.DeleteCategoryRequest
-	6,  // 9: api.v1.CategoryService.GetCategoryBySlug:input_type -> api.v1.GetCategoryBySlugRequest
-	1,  // 10: api.v1.CategoryService.ListCategories:output_type -> api.v1.ListCategoriesResponse
-	7,  // 11: api.v1.CategoryService.GetCategory:output_type -> store.Category
-	7,  // 12: api.v1.CategoryService.CreateCategory:output_type -> store.Category
-	7,  // 13: api.v1.CategoryService.UpdateCategory:output_type -> store.Category
-	9,  // 14: api.v1.CategoryService.DeleteCategory:output_type -> google.protobuf.Empty
-	7,  // 15: api.v1.CategoryService.GetCategoryBySlug:output_type -> store.Category
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 0: api.v1.ListCategoriesResponse.categories:type_name -> store.Category
+	13, // 1: api.v1.CreateCategoryRequest.category:type_name -> store.Category
+	13, // 2: api.v1.UpdateCategoryRequest.category:type_name -> store.Category
+	14, // 3: api.v1.UpdateCategoryRequest.update_mask:type_name -> google.protobuf.FieldMask
+	9,  // 4: api.v1.GetCategoryTreeResponse.nodes:type_name -> api.v1.CategoryNode
+	13, // 5: api.v1.CategoryNode.category:type_name -> store.Category
+	9,  // 6: api.v1.CategoryNode.children:type_name -> api.v1.CategoryNode
+	12, // 7: api.v1.ReorderCategoriesRequest.orders:type_name -> api.v1.CategoryOrder
+	0,  // 8: api.v1.CategoryService.ListCategories:input_type -> api.v1.ListCategoriesRequest
+	2,  // 9: api.v1.CategoryService.GetCategory:input_type -> api.v1.GetCategoryRequest
+	3,  // 10: api.v1.CategoryService.CreateCategory:input_type -> api.v1.CreateCategoryRequest
+	4,  // 11: api.v1.CategoryService.UpdateCategory:input_type -> api.v1.UpdateCategoryRequest
+	5,  // 12: api.v1.CategoryService.DeleteCategory:input_type -> api.v1.DeleteCategoryRequest
+	6,  // 13: api.v1.CategoryService.GetCategoryBySlug:input_type -> api.v1.GetCategoryBySlugRequest
+	7,  // 14: api.v1.CategoryService.GetCategoryTree:input_type -> api.v1.GetCategoryTreeRequest
+	10, // 15: api.v1.CategoryService.MoveCategory:input_type -> api.v1.MoveCategoryRequest
+	11, // 16: api.v1.CategoryService.ReorderCategories:input_type -> api.v1.ReorderCategoriesRequest
+	1,  // 17: api.v1.CategoryService.ListCategories:output_type -> api.v1.ListCategoriesResponse
+	13, // 18: api.v1.CategoryService.GetCategory:output_type -> store.Category
+	13, // 19: api.v1.CategoryService.CreateCategory:output_type -> store.Category
+	13, // 20: api.v1.CategoryService.UpdateCategory:output_type -> store.Category
+	15, // 21: api.v1.CategoryService.DeleteCategory:output_type -> google.protobuf.Empty
+	13, // 22: api.v1.CategoryService.GetCategoryBySlug:output_type -> store.Category
+	8,  // 23: api.v1.CategoryService.GetCategoryTree:output_type -> api.v1.GetCategoryTreeResponse
+	13, // 24: api.v1.CategoryService.MoveCategory:output_type -> store.Category
+	15, // 25: api.v1.CategoryService.ReorderCategories:output_type -> google.protobuf.Empty
+	17, // [17:26] is the sub-list for method output_type
+	8,  // [8:17] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_category_service_proto_init() }
@@ -458,7 +836,7 @@ func file_api_v1_category_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_category_service_proto_rawDesc), len(file_api_v1_category_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
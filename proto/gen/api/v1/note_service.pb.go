@@ -24,6 +24,120 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// NoteGraphNodeType 关系图节点类型
+type NoteGraphNodeType int32
+
+const (
+	// 未指定
+	NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_UNSPECIFIED NoteGraphNodeType = 0
+	// 笔记
+	NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_NOTE NoteGraphNodeType = 1
+	// 标签
+	NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_TAG NoteGraphNodeType = 2
+	// 分类
+	NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_CATEGORY NoteGraphNodeType = 3
+)
+
+// Enum value maps for NoteGraphNodeType.
+var (
+	NoteGraphNodeType_name = map[int32]string{
+		0: "NOTE_GRAPH_NODE_TYPE_UNSPECIFIED",
+		1: "NOTE_GRAPH_NODE_TYPE_NOTE",
+		2: "NOTE_GRAPH_NODE_TYPE_TAG",
+		3: "NOTE_GRAPH_NODE_TYPE_CATEGORY",
+	}
+	NoteGraphNodeType_value = map[string]int32{
+		"NOTE_GRAPH_NODE_TYPE_UNSPECIFIED": 0,
+		"NOTE_GRAPH_NODE_TYPE_NOTE":        1,
+		"NOTE_GRAPH_NODE_TYPE_TAG":         2,
+		"NOTE_GRAPH_NODE_TYPE_CATEGORY":    3,
+	}
+)
+
+func (x NoteGraphNodeType) Enum() *NoteGraphNodeType {
+	p := new(NoteGraphNodeType)
+	*p = x
+	return p
+}
+
+func (x NoteGraphNodeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NoteGraphNodeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_note_service_proto_enumTypes[0].Descriptor()
+}
+
+func (NoteGraphNodeType) Type() protoreflect.EnumType {
+	return &file_api_v1_note_service_proto_enumTypes[0]
+}
+
+func (x NoteGraphNodeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NoteGraphNodeType.Descriptor instead.
+func (NoteGraphNodeType) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{0}
+}
+
+// NoteGraphEdgeType 关系图边类型
+type NoteGraphEdgeType int32
+
+const (
+	// 未指定
+	NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_UNSPECIFIED NoteGraphEdgeType = 0
+	// 笔记之间的 [[链接]]
+	NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_LINK NoteGraphEdgeType = 1
+	// 笔记到标签
+	NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_TAG NoteGraphEdgeType = 2
+	// 笔记到分类
+	NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_CATEGORY NoteGraphEdgeType = 3
+)
+
+// Enum value maps for NoteGraphEdgeType.
+var (
+	NoteGraphEdgeType_name = map[int32]string{
+		0: "NOTE_GRAPH_EDGE_TYPE_UNSPECIFIED",
+		1: "NOTE_GRAPH_EDGE_TYPE_LINK",
+		2: "NOTE_GRAPH_EDGE_TYPE_TAG",
+		3: "NOTE_GRAPH_EDGE_TYPE_CATEGORY",
+	}
+	NoteGraphEdgeType_value = map[string]int32{
+		"NOTE_GRAPH_EDGE_TYPE_UNSPECIFIED": 0,
+		"NOTE_GRAPH_EDGE_TYPE_LINK":        1,
+		"NOTE_GRAPH_EDGE_TYPE_TAG":         2,
+		"NOTE_GRAPH_EDGE_TYPE_CATEGORY":    3,
+	}
+)
+
+func (x NoteGraphEdgeType) Enum() *NoteGraphEdgeType {
+	p := new(NoteGraphEdgeType)
+	*p = x
+	return p
+}
+
+func (x NoteGraphEdgeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NoteGraphEdgeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_note_service_proto_enumTypes[1].Descriptor()
+}
+
+func (NoteGraphEdgeType) Type() protoreflect.EnumType {
+	return &file_api_v1_note_service_proto_enumTypes[1]
+}
+
+func (x NoteGraphEdgeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NoteGraphEdgeType.Descriptor instead.
+func (NoteGraphEdgeType) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{1}
+}
+
 // ListNotesRequest 列出笔记请求
 type ListNotesRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -37,10 +151,17 @@ type ListNotesRequest struct {
 	TagId string `protobuf:"bytes,4,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
 	// 搜索关键词（可选）
 	Search string `protobuf:"bytes,5,opt,name=search,proto3" json:"search,omitempty"`
-	// 排序字段（可选）
+	// 排序字段（可选），可以是逗号分隔的多个字段，每个字段后可以跟 asc 或 desc，例如 "published_at desc, title"
+	// 支持 published_at（默认）、created_at、updated_at、title、view_count、word_count、reading_time、id
 	SortBy string `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
-	// 是否降序排序
-	SortDesc      bool `protobuf:"varint,7,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	// 未指定方向的排序字段是否降序排序
+	SortDesc bool `protobuf:"varint,7,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	// 按分类过滤时是否包含子分类下的笔记
+	IncludeDescendants bool `protobuf:"varint,8,opt,name=include_descendants,json=includeDescendants,proto3" json:"include_descendants,omitempty"`
+	// 上一页响应中的 next_page_token，指定时忽略 page，从上一页之后继续读取
+	PageToken string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// 过滤表达式（可选），例如 tag in ["go", "db"] && created_at > "2026-01-01" && author == "users/3"
+	Filter        string `protobuf:"bytes,10,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -124,6 +245,27 @@ func (x *ListNotesRequest) GetSortDesc() bool {
 	return false
 }
 
+func (x *ListNotesRequest) GetIncludeDescendants() bool {
+	if x != nil {
+		return x.IncludeDescendants
+	}
+	return false
+}
+
+func (x *ListNotesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListNotesRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 // ListNotesResponse 列出笔记响应
 type ListNotesResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -136,7 +278,9 @@ type ListNotesResponse struct {
 	// 每页大小
 	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// 总页数
-	TotalPages    int32 `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	TotalPages int32 `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	// 下一页的游标，没有更多笔记时为空
+	NextPageToken string `protobuf:"bytes,6,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -206,11 +350,25 @@ func (x *ListNotesResponse) GetTotalPages() int32 {
 	return 0
 }
 
+func (x *ListNotesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // GetNoteRequest 获取笔记请求
 type GetNoteRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// 资源名称，格式：notes/{note}
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 是否同时返回服务端渲染的 HTML（填充到 Note.rendered_html）
+	RenderedHtml bool `protobuf:"varint,2,opt,name=rendered_html,json=renderedHtml,proto3" json:"rendered_html,omitempty"`
+	// 分享令牌，访问者无权查看笔记时使用分享链接访问，每次访问计入链接的查看次数
+	// 使用分享令牌访问时，内容中的附件地址会附加访问附件所需的参数
+	ShareToken string `protobuf:"bytes,3,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	// 分享链接的访问密码，链接设置了密码时必填
+	SharePassword string `protobuf:"bytes,4,opt,name=share_password,json=sharePassword,proto3" json:"share_password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -252,6 +410,27 @@ func (x *GetNoteRequest) GetName() string {
 	return ""
 }
 
+func (x *GetNoteRequest) GetRenderedHtml() bool {
+	if x != nil {
+		return x.RenderedHtml
+	}
+	return false
+}
+
+func (x *GetNoteRequest) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+func (x *GetNoteRequest) GetSharePassword() string {
+	if x != nil {
+		return x.SharePassword
+	}
+	return ""
+}
+
 // CreateNoteRequest 创建笔记请求
 type CreateNoteRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -445,49 +624,2139 @@ func (x *GetNoteBySlugRequest) GetSlug() string {
 	return ""
 }
 
-var File_api_v1_note_service_proto protoreflect.FileDescriptor
+// RenderNoteRequest 渲染笔记请求
+type RenderNoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 要渲染的 Markdown 内容（可选，未提供 name 时使用，例如编辑器预览）
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_v1_note_service_proto_rawDesc = "" +
-	"\n" +
-	"\x19api/v1/note_service.proto\x12\x06api.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x10store/note.proto\"\xc9\x01\n" +
-	"\x10ListNotesRequest\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
-	"\vcategory_id\x18\x03 \x01(\tR\n" +
-	"categoryId\x12\x15\n" +
-	"\x06tag_id\x18\x04 \x01(\tR\x05tagId\x12\x16\n" +
-	"\x06search\x18\x05 \x01(\tR\x06search\x12\x17\n" +
-	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x1b\n" +
-	"\tsort_desc\x18\a \x01(\bR\bsortDesc\"\x9e\x01\n" +
-	"\x11ListNotesResponse\x12!\n" +
-	"\x05notes\x18\x01 \x03(\v2\v.store.NoteR\x05notes\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
-	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
-	"totalPages\"$\n" +
-	"\x0eGetNoteRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"4\n" +
-	"\x11CreateNoteRequest\x12\x1f\n" +
-	"\x04note\x18\x01 \x01(\v2\v.store.NoteR\x04note\"q\n" +
-	"\x11UpdateNoteRequest\x12\x1f\n" +
-	"\x04note\x18\x01 \x01(\v2\v.store.NoteR\x04note\x12;\n" +
-	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMask\"'\n" +
-	"\x11DeleteNoteRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"*\n" +
-	"\x14GetNoteBySlugRequest\x12\x12\n" +
-	"\x04slug\x18\x01 \x01(\tR\x04slug2\xe8\x02\n" +
-	"\vNoteService\x12@\n" +
-	"\tListNotes\x12\x18.api.v1.ListNotesRequest\x1a\x19.api.v1.ListNotesResponse\x12.\n" +
-	"\aGetNote\x12\x16.api.v1.GetNoteRequest\x1a\v.store.Note\x124\n" +
-	"\n" +
-	"CreateNote\x12\x19.api.v1.CreateNoteRequest\x1a\v.store.Note\x124\n" +
-	"\n" +
-	"UpdateNote\x12\x19.api.v1.UpdateNoteRequest\x1a\v.store.Note\x12?\n" +
-	"\n" +
-	"DeleteNote\x12\x19.api.v1.DeleteNoteRequest\x1a\x16.google.protobuf.Empty\x12:\n" +
-	"\rGetNoteBySlug\x12\x1c.api.v1.GetNoteBySlugRequest\x1a\v.store.NoteB\x8f\x01\n" +
+func (x *RenderNoteRequest) Reset() {
+	*x = RenderNoteRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderNoteRequest) ProtoMessage() {}
+
+func (x *RenderNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderNoteRequest.ProtoReflect.Descriptor instead.
+func (*RenderNoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RenderNoteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenderNoteRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// RenderNoteResponse 渲染笔记响应
+type RenderNoteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 经过安全过滤的 HTML
+	Html          string `protobuf:"bytes,1,opt,name=html,proto3" json:"html,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderNoteResponse) Reset() {
+	*x = RenderNoteResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderNoteResponse) ProtoMessage() {}
+
+func (x *RenderNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderNoteResponse.ProtoReflect.Descriptor instead.
+func (*RenderNoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RenderNoteResponse) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+// GetNoteStatsRequest 获取笔记统计请求
+type GetNoteStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 返回最近多少天的每日浏览量（默认30天，最多365天）
+	Days          int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteStatsRequest) Reset() {
+	*x = GetNoteStatsRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteStatsRequest) ProtoMessage() {}
+
+func (x *GetNoteStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetNoteStatsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetNoteStatsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+// DailyViewCount 单日浏览量
+type DailyViewCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 日期，格式：YYYY-MM-DD（UTC）
+	Date string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// 当日浏览量
+	ViewCount     int64 `protobuf:"varint,2,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyViewCount) Reset() {
+	*x = DailyViewCount{}
+	mi := &file_api_v1_note_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyViewCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyViewCount) ProtoMessage() {}
+
+func (x *DailyViewCount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyViewCount.ProtoReflect.Descriptor instead.
+func (*DailyViewCount) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DailyViewCount) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyViewCount) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+// NoteStats 笔记统计
+type NoteStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 总浏览量（包含尚未写入数据库的浏览）
+	ViewCount int64 `protobuf:"varint,2,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	// 字数
+	WordCount int32 `protobuf:"varint,3,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	// 预计阅读时间（分钟）
+	ReadingTime int32 `protobuf:"varint,4,opt,name=reading_time,json=readingTime,proto3" json:"reading_time,omitempty"`
+	// 每日浏览量，按日期升序，没有浏览的日期浏览量为0
+	DailyViews    []*DailyViewCount `protobuf:"bytes,5,rep,name=daily_views,json=dailyViews,proto3" json:"daily_views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteStats) Reset() {
+	*x = NoteStats{}
+	mi := &file_api_v1_note_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteStats) ProtoMessage() {}
+
+func (x *NoteStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteStats.ProtoReflect.Descriptor instead.
+func (*NoteStats) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NoteStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NoteStats) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *NoteStats) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *NoteStats) GetReadingTime() int32 {
+	if x != nil {
+		return x.ReadingTime
+	}
+	return 0
+}
+
+func (x *NoteStats) GetDailyViews() []*DailyViewCount {
+	if x != nil {
+		return x.DailyViews
+	}
+	return nil
+}
+
+// ImportNotesRequest 导入笔记请求
+type ImportNotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// zip 压缩包内容，包含 Markdown 文件以及引用的图片和附件
+	Zip []byte `protobuf:"bytes,1,opt,name=zip,proto3" json:"zip,omitempty"`
+	// 是否为试运行，试运行只返回导入报告，不写入任何数据
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// 未在 front matter 中指定可见性时使用的默认可见性（默认私有）
+	Visibility    store.NoteVisibility `protobuf:"varint,3,opt,name=visibility,proto3,enum=store.NoteVisibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportNotesRequest) Reset() {
+	*x = ImportNotesRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportNotesRequest) ProtoMessage() {}
+
+func (x *ImportNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportNotesRequest.ProtoReflect.Descriptor instead.
+func (*ImportNotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ImportNotesRequest) GetZip() []byte {
+	if x != nil {
+		return x.Zip
+	}
+	return nil
+}
+
+func (x *ImportNotesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportNotesRequest) GetVisibility() store.NoteVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return store.NoteVisibility(0)
+}
+
+// ImportedNote 单篇笔记的导入结果
+type ImportedNote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 压缩包中的文件路径
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// 笔记标题
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// 导入结果：created（已创建）、would_create（试运行时将创建）、skipped（已存在同名笔记）、failed（失败）
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// 创建的笔记资源名称，格式：notes/{note}（跳过时为已存在的笔记）
+	Note string `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	// 附加说明（失败原因等）
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedNote) Reset() {
+	*x = ImportedNote{}
+	mi := &file_api_v1_note_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedNote) ProtoMessage() {}
+
+func (x *ImportedNote) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedNote.ProtoReflect.Descriptor instead.
+func (*ImportedNote) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImportedNote) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ImportedNote) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ImportedNote) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportedNote) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ImportedNote) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ImportIssue 导入过程中发现的问题
+type ImportIssue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 压缩包中的文件路径
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// 问题类型：duplicate、conflict、missing_attachment、unresolved_link、invalid_front_matter
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// 问题说明
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportIssue) Reset() {
+	*x = ImportIssue{}
+	mi := &file_api_v1_note_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportIssue) ProtoMessage() {}
+
+func (x *ImportIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportIssue.ProtoReflect.Descriptor instead.
+func (*ImportIssue) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportIssue) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ImportIssue) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ImportIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ImportNotesResponse 导入笔记响应
+type ImportNotesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 每篇笔记的导入结果
+	Notes []*ImportedNote `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	// 新建（或试运行时将新建）的标签
+	CreatedTags []string `protobuf:"bytes,2,rep,name=created_tags,json=createdTags,proto3" json:"created_tags,omitempty"`
+	// 新建（或试运行时将新建）的分类
+	CreatedCategories []string `protobuf:"bytes,3,rep,name=created_categories,json=createdCategories,proto3" json:"created_categories,omitempty"`
+	// 上传（或试运行时将上传）的附件数量
+	AttachmentsUploaded int32 `protobuf:"varint,4,opt,name=attachments_uploaded,json=attachmentsUploaded,proto3" json:"attachments_uploaded,omitempty"`
+	// 发现的问题（冲突、重复、缺失的附件、无法解析的链接等）
+	Issues []*ImportIssue `protobuf:"bytes,5,rep,name=issues,proto3" json:"issues,omitempty"`
+	// 是否为试运行
+	DryRun        bool `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportNotesResponse) Reset() {
+	*x = ImportNotesResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportNotesResponse) ProtoMessage() {}
+
+func (x *ImportNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportNotesResponse.ProtoReflect.Descriptor instead.
+func (*ImportNotesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportNotesResponse) GetNotes() []*ImportedNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *ImportNotesResponse) GetCreatedTags() []string {
+	if x != nil {
+		return x.CreatedTags
+	}
+	return nil
+}
+
+func (x *ImportNotesResponse) GetCreatedCategories() []string {
+	if x != nil {
+		return x.CreatedCategories
+	}
+	return nil
+}
+
+func (x *ImportNotesResponse) GetAttachmentsUploaded() int32 {
+	if x != nil {
+		return x.AttachmentsUploaded
+	}
+	return 0
+}
+
+func (x *ImportNotesResponse) GetIssues() []*ImportIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *ImportNotesResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// GetNoteDraftRequest 获取笔记草稿请求
+type GetNoteDraftRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteDraftRequest) Reset() {
+	*x = GetNoteDraftRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteDraftRequest) ProtoMessage() {}
+
+func (x *GetNoteDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteDraftRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteDraftRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetNoteDraftRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// SaveNoteDraftRequest 保存笔记草稿请求
+type SaveNoteDraftRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 要保存的草稿，name 格式：notes/{note}/draft
+	Draft         *store.NoteDraft `protobuf:"bytes,1,opt,name=draft,proto3" json:"draft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveNoteDraftRequest) Reset() {
+	*x = SaveNoteDraftRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveNoteDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveNoteDraftRequest) ProtoMessage() {}
+
+func (x *SaveNoteDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveNoteDraftRequest.ProtoReflect.Descriptor instead.
+func (*SaveNoteDraftRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SaveNoteDraftRequest) GetDraft() *store.NoteDraft {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
+// PublishDraftRequest 发布笔记草稿请求
+type PublishDraftRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishDraftRequest) Reset() {
+	*x = PublishDraftRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishDraftRequest) ProtoMessage() {}
+
+func (x *PublishDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishDraftRequest.ProtoReflect.Descriptor instead.
+func (*PublishDraftRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PublishDraftRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// DiscardDraftRequest 删除笔记草稿请求
+type DiscardDraftRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardDraftRequest) Reset() {
+	*x = DiscardDraftRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardDraftRequest) ProtoMessage() {}
+
+func (x *DiscardDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardDraftRequest.ProtoReflect.Descriptor instead.
+func (*DiscardDraftRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DiscardDraftRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListNoteRevisionsRequest 列出笔记修订记录请求
+type ListNoteRevisionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNoteRevisionsRequest) Reset() {
+	*x = ListNoteRevisionsRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNoteRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNoteRevisionsRequest) ProtoMessage() {}
+
+func (x *ListNoteRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNoteRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListNoteRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListNoteRevisionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListNoteRevisionsResponse 列出笔记修订记录响应
+type ListNoteRevisionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 修订记录，按发布时间倒序
+	Revisions     []*store.NoteRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNoteRevisionsResponse) Reset() {
+	*x = ListNoteRevisionsResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNoteRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNoteRevisionsResponse) ProtoMessage() {}
+
+func (x *ListNoteRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNoteRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListNoteRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListNoteRevisionsResponse) GetRevisions() []*store.NoteRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// ListBacklinksRequest 列出反向链接请求
+type ListBacklinksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBacklinksRequest) Reset() {
+	*x = ListBacklinksRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBacklinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBacklinksRequest) ProtoMessage() {}
+
+func (x *ListBacklinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBacklinksRequest.ProtoReflect.Descriptor instead.
+func (*ListBacklinksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListBacklinksRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListBacklinksResponse 列出反向链接响应
+type ListBacklinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 链接到该笔记的笔记，按更新时间倒序
+	Notes         []*store.Note `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBacklinksResponse) Reset() {
+	*x = ListBacklinksResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBacklinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBacklinksResponse) ProtoMessage() {}
+
+func (x *ListBacklinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBacklinksResponse.ProtoReflect.Descriptor instead.
+func (*ListBacklinksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListBacklinksResponse) GetNotes() []*store.Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// ListUnresolvedLinksRequest 列出未解析链接请求
+type ListUnresolvedLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUnresolvedLinksRequest) Reset() {
+	*x = ListUnresolvedLinksRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUnresolvedLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUnresolvedLinksRequest) ProtoMessage() {}
+
+func (x *ListUnresolvedLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUnresolvedLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListUnresolvedLinksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{24}
+}
+
+// ListUnresolvedLinksResponse 列出未解析链接响应
+type ListUnresolvedLinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 没有找到目标笔记的链接，按链接目标排序
+	Links         []*store.NoteLink `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUnresolvedLinksResponse) Reset() {
+	*x = ListUnresolvedLinksResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUnresolvedLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUnresolvedLinksResponse) ProtoMessage() {}
+
+func (x *ListUnresolvedLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUnresolvedLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListUnresolvedLinksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListUnresolvedLinksResponse) GetLinks() []*store.NoteLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// ListRelatedNotesRequest 列出相关笔记请求
+type ListRelatedNotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 返回的数量，默认 5，最大 20
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelatedNotesRequest) Reset() {
+	*x = ListRelatedNotesRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelatedNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelatedNotesRequest) ProtoMessage() {}
+
+func (x *ListRelatedNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelatedNotesRequest.ProtoReflect.Descriptor instead.
+func (*ListRelatedNotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListRelatedNotesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListRelatedNotesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// RelatedNote 相关笔记
+type RelatedNote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 笔记
+	Note *store.Note `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// 相似度，范围 (0, 1]
+	Score         float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedNote) Reset() {
+	*x = RelatedNote{}
+	mi := &file_api_v1_note_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedNote) ProtoMessage() {}
+
+func (x *RelatedNote) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedNote.ProtoReflect.Descriptor instead.
+func (*RelatedNote) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RelatedNote) GetNote() *store.Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *RelatedNote) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// ListRelatedNotesResponse 列出相关笔记响应
+type ListRelatedNotesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 相关笔记，按相似度从高到低排列；索引尚未建立时为空
+	RelatedNotes  []*RelatedNote `protobuf:"bytes,1,rep,name=related_notes,json=relatedNotes,proto3" json:"related_notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelatedNotesResponse) Reset() {
+	*x = ListRelatedNotesResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelatedNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelatedNotesResponse) ProtoMessage() {}
+
+func (x *ListRelatedNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelatedNotesResponse.ProtoReflect.Descriptor instead.
+func (*ListRelatedNotesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListRelatedNotesResponse) GetRelatedNotes() []*RelatedNote {
+	if x != nil {
+		return x.RelatedNotes
+	}
+	return nil
+}
+
+// CreateShareLinkRequest 创建分享链接请求
+type CreateShareLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 访问密码，可选
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// 过期时间（Unix时间戳，秒），可选，0 表示永不过期
+	ExpireAt int64 `protobuf:"varint,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	// 最多查看次数，可选，0 表示不限制
+	MaxViews      int32 `protobuf:"varint,4,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateShareLinkRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+// ListShareLinksRequest 列出分享链接请求
+type ListShareLinksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksRequest) ProtoMessage() {}
+
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListShareLinksRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListShareLinksResponse 列出分享链接响应
+type ListShareLinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 分享链接，按创建时间倒序
+	ShareLinks    []*store.ShareLink `protobuf:"bytes,1,rep,name=share_links,json=shareLinks,proto3" json:"share_links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListShareLinksResponse) GetShareLinks() []*store.ShareLink {
+	if x != nil {
+		return x.ShareLinks
+	}
+	return nil
+}
+
+// RevokeShareLinkRequest 撤销分享链接请求
+type RevokeShareLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}/shareLinks/{share_link}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkRequest) ProtoMessage() {}
+
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RevokeShareLinkRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GrantNotePermissionRequest 授予权限请求
+type GrantNotePermissionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 授权的资源，格式：notes/{note} 或 categories/{category}
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// 被授权的用户，格式：users/{user}
+	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// 角色
+	Role          store.NotePermissionRole `protobuf:"varint,3,opt,name=role,proto3,enum=store.NotePermissionRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantNotePermissionRequest) Reset() {
+	*x = GrantNotePermissionRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantNotePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantNotePermissionRequest) ProtoMessage() {}
+
+func (x *GrantNotePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantNotePermissionRequest.ProtoReflect.Descriptor instead.
+func (*GrantNotePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GrantNotePermissionRequest) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *GrantNotePermissionRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GrantNotePermissionRequest) GetRole() store.NotePermissionRole {
+	if x != nil {
+		return x.Role
+	}
+	return store.NotePermissionRole(0)
+}
+
+// ListNotePermissionsRequest 列出权限请求
+type ListNotePermissionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源，格式：notes/{note} 或 categories/{category}
+	Resource      string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotePermissionsRequest) Reset() {
+	*x = ListNotePermissionsRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotePermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotePermissionsRequest) ProtoMessage() {}
+
+func (x *ListNotePermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotePermissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotePermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListNotePermissionsRequest) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+// ListNotePermissionsResponse 列出权限响应
+type ListNotePermissionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 权限，按资源和用户排序；继承的权限的 resource 为授权的分类
+	Permissions   []*store.NotePermission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotePermissionsResponse) Reset() {
+	*x = ListNotePermissionsResponse{}
+	mi := &file_api_v1_note_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotePermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotePermissionsResponse) ProtoMessage() {}
+
+func (x *ListNotePermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotePermissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotePermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListNotePermissionsResponse) GetPermissions() []*store.NotePermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// RevokeNotePermissionRequest 撤销权限请求
+type RevokeNotePermissionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}/permissions/{user} 或 categories/{category}/permissions/{user}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeNotePermissionRequest) Reset() {
+	*x = RevokeNotePermissionRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeNotePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeNotePermissionRequest) ProtoMessage() {}
+
+func (x *RevokeNotePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeNotePermissionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeNotePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RevokeNotePermissionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetNoteGraphRequest 获取笔记关系图请求
+// 未指定 note 和 tag 时返回全部笔记的关系图
+type GetNoteGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 中心笔记，格式：notes/{note}，指定时只返回与该笔记在 depth 跳链接以内的笔记
+	Note string `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// 从中心笔记出发的链接跳数，默认 1，最大 5
+	Depth int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// 标签，格式：tags/{tag}，指定时只返回带有该标签的笔记
+	Tag string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	// 过滤表达式，语法同笔记列表的 filter
+	Filter string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 是否包含标签节点及笔记到标签的边
+	IncludeTags bool `protobuf:"varint,5,opt,name=include_tags,json=includeTags,proto3" json:"include_tags,omitempty"`
+	// 是否包含分类节点及笔记到分类的边
+	IncludeCategories bool `protobuf:"varint,6,opt,name=include_categories,json=includeCategories,proto3" json:"include_categories,omitempty"`
+	// 最多返回的笔记节点数量，默认 500，最大 2000；超出时保留权重最高的笔记
+	MaxNodes      int32 `protobuf:"varint,7,opt,name=max_nodes,json=maxNodes,proto3" json:"max_nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteGraphRequest) Reset() {
+	*x = GetNoteGraphRequest{}
+	mi := &file_api_v1_note_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteGraphRequest) ProtoMessage() {}
+
+func (x *GetNoteGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteGraphRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetNoteGraphRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *GetNoteGraphRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *GetNoteGraphRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *GetNoteGraphRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *GetNoteGraphRequest) GetIncludeTags() bool {
+	if x != nil {
+		return x.IncludeTags
+	}
+	return false
+}
+
+func (x *GetNoteGraphRequest) GetIncludeCategories() bool {
+	if x != nil {
+		return x.IncludeCategories
+	}
+	return false
+}
+
+func (x *GetNoteGraphRequest) GetMaxNodes() int32 {
+	if x != nil {
+		return x.MaxNodes
+	}
+	return 0
+}
+
+// NoteGraph 笔记关系图
+type NoteGraph struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 节点
+	Nodes []*NoteGraphNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// 边
+	Edges []*NoteGraphEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// 笔记数量超过 max_nodes 时为 true
+	Truncated     bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteGraph) Reset() {
+	*x = NoteGraph{}
+	mi := &file_api_v1_note_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteGraph) ProtoMessage() {}
+
+func (x *NoteGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteGraph.ProtoReflect.Descriptor instead.
+func (*NoteGraph) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *NoteGraph) GetNodes() []*NoteGraphNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *NoteGraph) GetEdges() []*NoteGraphEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *NoteGraph) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// NoteGraphNode 关系图节点
+type NoteGraphNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}、tags/{tag} 或 categories/{category}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 节点类型
+	Type NoteGraphNodeType `protobuf:"varint,2,opt,name=type,proto3,enum=api.v1.NoteGraphNodeType" json:"type,omitempty"`
+	// 显示的文本（笔记标题、标签或分类名称）
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// 浏览次数（仅笔记）
+	ViewCount int64 `protobuf:"varint,4,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	// 与该节点相连的边数
+	Degree int32 `protobuf:"varint,5,opt,name=degree,proto3" json:"degree,omitempty"`
+	// 节点权重，笔记为 1 + 链接数 + ln(1 + 浏览次数)，标签和分类为 1 + 相连的笔记数
+	Weight        float64 `protobuf:"fixed64,6,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteGraphNode) Reset() {
+	*x = NoteGraphNode{}
+	mi := &file_api_v1_note_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteGraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteGraphNode) ProtoMessage() {}
+
+func (x *NoteGraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteGraphNode.ProtoReflect.Descriptor instead.
+func (*NoteGraphNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *NoteGraphNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NoteGraphNode) GetType() NoteGraphNodeType {
+	if x != nil {
+		return x.Type
+	}
+	return NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_UNSPECIFIED
+}
+
+func (x *NoteGraphNode) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NoteGraphNode) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *NoteGraphNode) GetDegree() int32 {
+	if x != nil {
+		return x.Degree
+	}
+	return 0
+}
+
+func (x *NoteGraphNode) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// NoteGraphEdge 关系图的边
+type NoteGraphEdge struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 起点的资源名称
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// 终点的资源名称
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// 边类型
+	Type          NoteGraphEdgeType `protobuf:"varint,3,opt,name=type,proto3,enum=api.v1.NoteGraphEdgeType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteGraphEdge) Reset() {
+	*x = NoteGraphEdge{}
+	mi := &file_api_v1_note_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteGraphEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteGraphEdge) ProtoMessage() {}
+
+func (x *NoteGraphEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_note_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteGraphEdge.ProtoReflect.Descriptor instead.
+func (*NoteGraphEdge) Descriptor() ([]byte, []int) {
+	return file_api_v1_note_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *NoteGraphEdge) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *NoteGraphEdge) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *NoteGraphEdge) GetType() NoteGraphEdgeType {
+	if x != nil {
+		return x.Type
+	}
+	return NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_UNSPECIFIED
+}
+
+var File_api_v1_note_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_note_service_proto_rawDesc = "" +
+	"\n" +
+	"\x19api/v1/note_service.proto\x12\x06api.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x10store/note.proto\"\xb1\x02\n" +
+	"\x10ListNotesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\tR\n" +
+	"categoryId\x12\x15\n" +
+	"\x06tag_id\x18\x04 \x01(\tR\x05tagId\x12\x16\n" +
+	"\x06search\x18\x05 \x01(\tR\x06search\x12\x17\n" +
+	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\a \x01(\bR\bsortDesc\x12/\n" +
+	"\x13include_descendants\x18\b \x01(\bR\x12includeDescendants\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06filter\x18\n" +
+	" \x01(\tR\x06filter\"\xc6\x01\n" +
+	"\x11ListNotesResponse\x12!\n" +
+	"\x05notes\x18\x01 \x03(\v2\v.store.NoteR\x05notes\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\x12&\n" +
+	"\x0fnext_page_token\x18\x06 \x01(\tR\rnextPageToken\"\x91\x01\n" +
+	"\x0eGetNoteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rrendered_html\x18\x02 \x01(\bR\frenderedHtml\x12\x1f\n" +
+	"\vshare_token\x18\x03 \x01(\tR\n" +
+	"shareToken\x12%\n" +
+	"\x0eshare_password\x18\x04 \x01(\tR\rsharePassword\"4\n" +
+	"\x11CreateNoteRequest\x12\x1f\n" +
+	"\x04note\x18\x01 \x01(\v2\v.store.NoteR\x04note\"q\n" +
+	"\x11UpdateNoteRequest\x12\x1f\n" +
+	"\x04note\x18\x01 \x01(\v2\v.store.NoteR\x04note\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"'\n" +
+	"\x11DeleteNoteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"*\n" +
+	"\x14GetNoteBySlugRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"A\n" +
+	"\x11RenderNoteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"(\n" +
+	"\x12RenderNoteResponse\x12\x12\n" +
+	"\x04html\x18\x01 \x01(\tR\x04html\"=\n" +
+	"\x13GetNoteStatsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"C\n" +
+	"\x0eDailyViewCount\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x02 \x01(\x03R\tviewCount\"\xb9\x01\n" +
+	"\tNoteStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x02 \x01(\x03R\tviewCount\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x03 \x01(\x05R\twordCount\x12!\n" +
+	"\freading_time\x18\x04 \x01(\x05R\vreadingTime\x127\n" +
+	"\vdaily_views\x18\x05 \x03(\v2\x16.api.v1.DailyViewCountR\n" +
+	"dailyViews\"v\n" +
+	"\x12ImportNotesRequest\x12\x10\n" +
+	"\x03zip\x18\x01 \x01(\fR\x03zip\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x125\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\x0e2\x15.store.NoteVisibilityR\n" +
+	"visibility\"~\n" +
+	"\fImportedNote\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"O\n" +
+	"\vImportIssue\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x8c\x02\n" +
+	"\x13ImportNotesResponse\x12*\n" +
+	"\x05notes\x18\x01 \x03(\v2\x14.api.v1.ImportedNoteR\x05notes\x12!\n" +
+	"\fcreated_tags\x18\x02 \x03(\tR\vcreatedTags\x12-\n" +
+	"\x12created_categories\x18\x03 \x03(\tR\x11createdCategories\x121\n" +
+	"\x14attachments_uploaded\x18\x04 \x01(\x05R\x13attachmentsUploaded\x12+\n" +
+	"\x06issues\x18\x05 \x03(\v2\x13.api.v1.ImportIssueR\x06issues\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\")\n" +
+	"\x13GetNoteDraftRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\">\n" +
+	"\x14SaveNoteDraftRequest\x12&\n" +
+	"\x05draft\x18\x01 \x01(\v2\x10.store.NoteDraftR\x05draft\")\n" +
+	"\x13PublishDraftRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\")\n" +
+	"\x13DiscardDraftRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\".\n" +
+	"\x18ListNoteRevisionsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"N\n" +
+	"\x19ListNoteRevisionsResponse\x121\n" +
+	"\trevisions\x18\x01 \x03(\v2\x13.store.NoteRevisionR\trevisions\"*\n" +
+	"\x14ListBacklinksRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\":\n" +
+	"\x15ListBacklinksResponse\x12!\n" +
+	"\x05notes\x18\x01 \x03(\v2\v.store.NoteR\x05notes\"\x1c\n" +
+	"\x1aListUnresolvedLinksRequest\"D\n" +
+	"\x1bListUnresolvedLinksResponse\x12%\n" +
+	"\x05links\x18\x01 \x03(\v2\x0f.store.NoteLinkR\x05links\"J\n" +
+	"\x17ListRelatedNotesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"D\n" +
+	"\vRelatedNote\x12\x1f\n" +
+	"\x04note\x18\x01 \x01(\v2\v.store.NoteR\x04note\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"T\n" +
+	"\x18ListRelatedNotesResponse\x128\n" +
+	"\rrelated_notes\x18\x01 \x03(\v2\x13.api.v1.RelatedNoteR\frelatedNotes\"\x82\x01\n" +
+	"\x16CreateShareLinkRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\texpire_at\x18\x03 \x01(\x03R\bexpireAt\x12\x1b\n" +
+	"\tmax_views\x18\x04 \x01(\x05R\bmaxViews\"+\n" +
+	"\x15ListShareLinksRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"K\n" +
+	"\x16ListShareLinksResponse\x121\n" +
+	"\vshare_links\x18\x01 \x03(\v2\x10.store.ShareLinkR\n" +
+	"shareLinks\",\n" +
+	"\x16RevokeShareLinkRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"{\n" +
+	"\x1aGrantNotePermissionRequest\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.store.NotePermissionRoleR\x04role\"8\n" +
+	"\x1aListNotePermissionsRequest\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\"V\n" +
+	"\x1bListNotePermissionsResponse\x127\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x15.store.NotePermissionR\vpermissions\"1\n" +
+	"\x1bRevokeNotePermissionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xd8\x01\n" +
+	"\x13GetNoteGraphRequest\x12\x12\n" +
+	"\x04note\x18\x01 \x01(\tR\x04note\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\x12!\n" +
+	"\finclude_tags\x18\x05 \x01(\bR\vincludeTags\x12-\n" +
+	"\x12include_categories\x18\x06 \x01(\bR\x11includeCategories\x12\x1b\n" +
+	"\tmax_nodes\x18\a \x01(\x05R\bmaxNodes\"\x83\x01\n" +
+	"\tNoteGraph\x12+\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x15.api.v1.NoteGraphNodeR\x05nodes\x12+\n" +
+	"\x05edges\x18\x02 \x03(\v2\x15.api.v1.NoteGraphEdgeR\x05edges\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\"\xb7\x01\n" +
+	"\rNoteGraphNode\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x19.api.v1.NoteGraphNodeTypeR\x04type\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x04 \x01(\x03R\tviewCount\x12\x16\n" +
+	"\x06degree\x18\x05 \x01(\x05R\x06degree\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x01R\x06weight\"n\n" +
+	"\rNoteGraphEdge\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.api.v1.NoteGraphEdgeTypeR\x04type*\x99\x01\n" +
+	"\x11NoteGraphNodeType\x12$\n" +
+	" NOTE_GRAPH_NODE_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19NOTE_GRAPH_NODE_TYPE_NOTE\x10\x01\x12\x1c\n" +
+	"\x18NOTE_GRAPH_NODE_TYPE_TAG\x10\x02\x12!\n" +
+	"\x1dNOTE_GRAPH_NODE_TYPE_CATEGORY\x10\x03*\x99\x01\n" +
+	"\x11NoteGraphEdgeType\x12$\n" +
+	" NOTE_GRAPH_EDGE_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19NOTE_GRAPH_EDGE_TYPE_LINK\x10\x01\x12\x1c\n" +
+	"\x18NOTE_GRAPH_EDGE_TYPE_TAG\x10\x02\x12!\n" +
+	"\x1dNOTE_GRAPH_EDGE_TYPE_CATEGORY\x10\x032\xb5\r\n" +
+	"\vNoteService\x12@\n" +
+	"\tListNotes\x12\x18.api.v1.ListNotesRequest\x1a\x19.api.v1.ListNotesResponse\x12.\n" +
+	"\aGetNote\x12\x16.api.v1.GetNoteRequest\x1a\v.store.Note\x124\n" +
+	"\n" +
+	"CreateNote\x12\x19.api.v1.CreateNoteRequest\x1a\v.store.Note\x124\n" +
+	"\n" +
+	"UpdateNote\x12\x19.api.v1.UpdateNoteRequest\x1a\v.store.Note\x12?\n" +
+	"\n" +
+	"DeleteNote\x12\x19.api.v1.DeleteNoteRequest\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\rGetNoteBySlug\x12\x1c.api.v1.GetNoteBySlugRequest\x1a\v.store.Note\x12C\n" +
+	"\n" +
+	"RenderNote\x12\x19.api.v1.RenderNoteRequest\x1a\x1a.api.v1.RenderNoteResponse\x12>\n" +
+	"\fGetNoteStats\x12\x1b.api.v1.GetNoteStatsRequest\x1a\x11.api.v1.NoteStats\x12F\n" +
+	"\vImportNotes\x12\x1a.api.v1.ImportNotesRequest\x1a\x1b.api.v1.ImportNotesResponse\x12=\n" +
+	"\fGetNoteDraft\x12\x1b.api.v1.GetNoteDraftRequest\x1a\x10.store.NoteDraft\x12?\n" +
+	"\rSaveNoteDraft\x12\x1c.api.v1.SaveNoteDraftRequest\x1a\x10.store.NoteDraft\x128\n" +
+	"\fPublishDraft\x12\x1b.api.v1.PublishDraftRequest\x1a\v.store.Note\x12C\n" +
+	"\fDiscardDraft\x12\x1b.api.v1.DiscardDraftRequest\x1a\x16.google.protobuf.Empty\x12X\n" +
+	"\x11ListNoteRevisions\x12 .api.v1.ListNoteRevisionsRequest\x1a!.api.v1.ListNoteRevisionsResponse\x12L\n" +
+	"\rListBacklinks\x12\x1c.api.v1.ListBacklinksRequest\x1a\x1d.api.v1.ListBacklinksResponse\x12^\n" +
+	"\x13ListUnresolvedLinks\x12\".api.v1.ListUnresolvedLinksRequest\x1a#.api.v1.ListUnresolvedLinksResponse\x12>\n" +
+	"\fGetNoteGraph\x12\x1b.api.v1.GetNoteGraphRequest\x1a\x11.api.v1.NoteGraph\x12U\n" +
+	"\x10ListRelatedNotes\x12\x1f.api.v1.ListRelatedNotesRequest\x1a .api.v1.ListRelatedNotesResponse\x12C\n" +
+	"\x0fCreateShareLink\x12\x1e.api.v1.CreateShareLinkRequest\x1a\x10.store.ShareLink\x12O\n" +
+	"\x0eListShareLinks\x12\x1d.api.v1.ListShareLinksRequest\x1a\x1e.api.v1.ListShareLinksResponse\x12C\n" +
+	"\x0fRevokeShareLink\x12\x1e.api.v1.RevokeShareLinkRequest\x1a\x10.store.ShareLink\x12P\n" +
+	"\x13GrantNotePermission\x12\".api.v1.GrantNotePermissionRequest\x1a\x15.store.NotePermission\x12^\n" +
+	"\x13ListNotePermissions\x12\".api.v1.ListNotePermissionsRequest\x1a#.api.v1.ListNotePermissionsResponse\x12S\n" +
+	"\x14RevokeNotePermission\x12#.api.v1.RevokeNotePermissionRequest\x1a\x16.google.protobuf.EmptyB\x8f\x01\n" +
 	"\n" +
 	"com.api.v1B\x10NoteServiceProtoP\x01Z6github.com/wdmsyhh/simple-notes/proto/gen/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
 
@@ -503,41 +2772,138 @@ func file_api_v1_note_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_note_service_proto_rawDescData
 }
 
-var file_api_v1_note_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_note_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_note_service_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_api_v1_note_service_proto_goTypes = []any{
-	(*ListNotesRequest)(nil),      // 0: api.v1.ListNotesRequest
-	(*ListNotesResponse)(nil),     // 1: api.v1.ListNotesResponse
-	(*GetNoteRequest)(nil),        // 2: api.v1.GetNoteRequest
-	(*CreateNoteRequest)(nil),     // 3: api.v1.CreateNoteRequest
-	(*UpdateNoteRequest)(nil),     // 4: api.v1.UpdateNoteRequest
-	(*DeleteNoteRequest)(nil),     // 5: api.v1.DeleteNoteRequest
-	(*GetNoteBySlugRequest)(nil),  // 6: api.v1.GetNoteBySlugRequest
-	(*store.Note)(nil),            // 7: store.Note
-	(*fieldmaskpb.FieldMask)(nil), // 8: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+	(NoteGraphNodeType)(0),              // 0: api.v1.NoteGraphNodeType
+	(NoteGraphEdgeType)(0),              // 1: api.v1.NoteGraphEdgeType
+	(*ListNotesRequest)(nil),            // 2: api.v1.ListNotesRequest
+	(*ListNotesResponse)(nil),           // 3: api.v1.ListNotesResponse
+	(*GetNoteRequest)(nil),              // 4: api.v1.GetNoteRequest
+	(*CreateNoteRequest)(nil),           // 5: api.v1.CreateNoteRequest
+	(*UpdateNoteRequest)(nil),           // 6: api.v1.UpdateNoteRequest
+	(*DeleteNoteRequest)(nil),           // 7: api.v1.DeleteNoteRequest
+	(*GetNoteBySlugRequest)(nil),        // 8: api.v1.GetNoteBySlugRequest
+	(*RenderNoteRequest)(nil),           // 9: api.v1.RenderNoteRequest
+	(*RenderNoteResponse)(nil),          // 10: api.v1.RenderNoteResponse
+	(*GetNoteStatsRequest)(nil),         // 11: api.v1.GetNoteStatsRequest
+	(*DailyViewCount)(nil),              // 12: api.v1.DailyViewCount
+	(*NoteStats)(nil),                   // 13: api.v1.NoteStats
+	(*ImportNotesRequest)(nil),          // 14: api.v1.ImportNotesRequest
+	(*ImportedNote)(nil),                // 15: api.v1.ImportedNote
+	(*ImportIssue)(nil),                 // 16: api.v1.ImportIssue
+	(*ImportNotesResponse)(nil),         // 17: api.v1.ImportNotesResponse
+	(*GetNoteDraftRequest)(nil),         // 18: api.v1.GetNoteDraftRequest
+	(*SaveNoteDraftRequest)(nil),        // 19: api.v1.SaveNoteDraftRequest
+	(*PublishDraftRequest)(nil),         // 20: api.v1.PublishDraftRequest
+	(*DiscardDraftRequest)(nil),         // 21: api.v1.DiscardDraftRequest
+	(*ListNoteRevisionsRequest)(nil),    // 22: api.v1.ListNoteRevisionsRequest
+	(*ListNoteRevisionsResponse)(nil),   // 23: api.v1.ListNoteRevisionsResponse
+	(*ListBacklinksRequest)(nil),        // 24: api.v1.ListBacklinksRequest
+	(*ListBacklinksResponse)(nil),       // 25: api.v1.ListBacklinksResponse
+	(*ListUnresolvedLinksRequest)(nil),  // 26: api.v1.ListUnresolvedLinksRequest
+	(*ListUnresolvedLinksResponse)(nil), // 27: api.v1.ListUnresolvedLinksResponse
+	(*ListRelatedNotesRequest)(nil),     // 28: api.v1.ListRelatedNotesRequest
+	(*RelatedNote)(nil),                 // 29: api.v1.RelatedNote
+	(*ListRelatedNotesResponse)(nil),    // 30: api.v1.ListRelatedNotesResponse
+	(*CreateShareLinkRequest)(nil),      // 31: api.v1.CreateShareLinkRequest
+	(*ListShareLinksRequest)(nil),       // 32: api.v1.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),      // 33: api.v1.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),      // 34: api.v1.RevokeShareLinkRequest
+	(*GrantNotePermissionRequest)(nil),  // 35: api.v1.GrantNotePermissionRequest
+	(*ListNotePermissionsRequest)(nil),  // 36: api.v1.ListNotePermissionsRequest
+	(*ListNotePermissionsResponse)(nil), // 37: api.v1.ListNotePermissionsResponse
+	(*RevokeNotePermissionRequest)(nil), // 38: api.v1.RevokeNotePermissionRequest
+	(*GetNoteGraphRequest)(nil),         // 39: api.v1.GetNoteGraphRequest
+	(*NoteGraph)(nil),                   // 40: api.v1.NoteGraph
+	(*NoteGraphNode)(nil),               // 41: api.v1.NoteGraphNode
+	(*NoteGraphEdge)(nil),               // 42: api.v1.NoteGraphEdge
+	(*store.Note)(nil),                  // 43: store.Note
+	(*fieldmaskpb.FieldMask)(nil),       // 44: google.protobuf.FieldMask
+	(store.NoteVisibility)(0),           // 45: store.NoteVisibility
+	(*store.NoteDraft)(nil),             // 46: store.NoteDraft
+	(*store.NoteRevision)(nil),          // 47: store.NoteRevision
+	(*store.NoteLink)(nil),              // 48: store.NoteLink
+	(*store.ShareLink)(nil),             // 49: store.ShareLink
+	(store.NotePermissionRole)(0),       // 50: store.NotePermissionRole
+	(*store.NotePermission)(nil),        // 51: store.NotePermission
+	(*emptypb.Empty)(nil),               // 52: google.protobuf.Empty
 }
 var file_api_v1_note_service_proto_depIdxs = []int32{
-	7,  // 0: api.v1.ListNotesResponse.notes:type_name -> store.Note
-	7,  // 1: api.v1.CreateNoteRequest.note:type_name -> store.Note
-	7,  // 2: api.v1.UpdateNoteRequest.note:type_name -> store.Note
-	8,  // 3: api.v1.UpdateNoteRequest.update_mask:type_name -> google.protobuf.FieldMask
-	0,  // 4: api.v1.NoteService.ListNotes:input_type -> api.v1.ListNotesRequest
-	2,  // 5: api.v1.NoteService.GetNote:input_type -> api.v1.GetNoteRequest
-	3,  // 6: api.v1.NoteService.CreateNote:input_type -> api.v1.CreateNoteRequest
-	4,  // 7: api.v1.NoteService.UpdateNote:input_type -> api.v1.UpdateNoteRequest
-	5,  // 8: api.v1.NoteService.DeleteNote:input_type -> api.v1.DeleteNoteRequest
-	6,  // 9: api.v1.NoteService.GetNoteBySlug:input_type -> api.v1.GetNoteBySlugRequest
-	1,  // 10: api.v1.NoteService.ListNotes:output_type -> api.v1.ListNotesResponse
-	7,  // 11: api.v1.NoteService.GetNote:output_type -> store.Note
-	7,  // 12: api.v1.NoteService.CreateNote:output_type -> store.Note
-	7,  // 13: api.v1.NoteService.UpdateNote:output_type -> store.Note
-	9,  // 14: api.v1.NoteService.DeleteNote:output_type -> google.protobuf.Empty
-	7,  // 15: api.v1.NoteService.GetNoteBySlug:output_type -> store.Note
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	43, // 0: api.v1.ListNotesResponse.notes:type_name -> store.Note
+	43, // 1: api.v1.CreateNoteRequest.note:type_name -> store.Note
+	43, // 2: api.v1.UpdateNoteRequest.note:type_name -> store.Note
+	44, // 3: api.v1.UpdateNoteRequest.update_mask:type_name -> google.protobuf.FieldMask
+	12, // 4: api.v1.NoteStats.daily_views:type_name -> api.v1.DailyViewCount
+	45, // 5: api.v1.ImportNotesRequest.visibility:type_name -> store.NoteVisibility
+	15, // 6: api.v1.ImportNotesResponse.notes:type_name -> api.v1.ImportedNote
+	16, // 7: api.v1.ImportNotesResponse.issues:type_name -> api.v1.ImportIssue
+	46, // 8: api.v1.SaveNoteDraftRequest.draft:type_name -> store.NoteDraft
+	47, // 9: api.v1.ListNoteRevisionsResponse.revisions:type_name -> store.NoteRevision
+	43, // 10: api.v1.ListBacklinksResponse.notes:type_name -> store.Note
+	48, // 11: api.v1.ListUnresolvedLinksResponse.links:type_name -> store.NoteLink
+	43, // 12: api.v1.RelatedNote.note:type_name -> store.Note
+	29, // 13: api.v1.ListRelatedNotesResponse.related_notes:type_name -> api.v1.RelatedNote
+	49, // 14: api.v1.ListShareLinksResponse.share_links:type_name -> store.ShareLink
+	50, // 15: api.v1.GrantNotePermissionRequest.role:type_name -> store.NotePermissionRole
+	51, // 16: api.v1.ListNotePermissionsResponse.permissions:type_name -> store.NotePermission
+	41, // 17: api.v1.NoteGraph.nodes:type_name -> api.v1.NoteGraphNode
+	42, // 18: api.v1.NoteGraph.edges:type_name -> api.v1.NoteGraphEdge
+	0,  // 19: api.v1.NoteGraphNode.type:type_name -> api.v1.NoteGraphNodeType
+	1,  // 20: api.v1.NoteGraphEdge.type:type_name -> api.v1.NoteGraphEdgeType
+	2,  // 21: api.v1.NoteService.ListNotes:input_type -> api.v1.ListNotesRequest
+	4,  // 22: api.v1.NoteService.GetNote:input_type -> api.v1.GetNoteRequest
+	5,  // 23: api.v1.NoteService.CreateNote:input_type -> api.v1.CreateNoteRequest
+	6,  // 24: api.v1.NoteService.UpdateNote:input_type -> api.v1.UpdateNoteRequest
+	7,  // 25: api.v1.NoteService.DeleteNote:input_type -> api.v1.DeleteNoteRequest
+	8,  // 26: api.v1.NoteService.GetNoteBySlug:input_type -> api.v1.GetNoteBySlugRequest
+	9,  // 27: api.v1.NoteService.RenderNote:input_type -> api.v1.RenderNoteRequest
+	11, // 28: api.v1.NoteService.GetNoteStats:input_type -> api.v1.GetNoteStatsRequest
+	14, // 29: api.v1.NoteService.ImportNotes:input_type -> api.v1.ImportNotesRequest
+	18, // 30: api.v1.NoteService.GetNoteDraft:input_type -> api.v1.GetNoteDraftRequest
+	19, // 31: api.v1.NoteService.SaveNoteDraft:input_type -> api.v1.SaveNoteDraftRequest
+	20, // 32: api.v1.NoteService.PublishDraft:input_type -> api.v1.PublishDraftRequest
+	21, // 33: api.v1.NoteService.DiscardDraft:input_type -> api.v1.DiscardDraftRequest
+	22, // 34: api.v1.NoteService.ListNoteRevisions:input_type -> api.v1.ListNoteRevisionsRequest
+	24, // 35: api.v1.NoteService.ListBacklinks:input_type -> api.v1.ListBacklinksRequest
+	26, // 36: api.v1.NoteService.ListUnresolvedLinks:input_type -> api.v1.ListUnresolvedLinksRequest
+	39, // 37: api.v1.NoteService.GetNoteGraph:input_type -> api.v1.GetNoteGraphRequest
+	28, // 38: api.v1.NoteService.ListRelatedNotes:input_type -> api.v1.ListRelatedNotesRequest
+	31, // 39: api.v1.NoteService.CreateShareLink:input_type -> api.v1.CreateShareLinkRequest
+	32, // 40: api.v1.NoteService.ListShareLinks:input_type -> api.v1.ListShareLinksRequest
+	34, // 41: api.v1.NoteService.RevokeShareLink:input_type -> api.v1.RevokeShareLinkRequest
+	35, // 42: api.v1.NoteService.GrantNotePermission:input_type -> api.v1.GrantNotePermissionRequest
+	36, // 43: api.v1.NoteService.ListNotePermissions:input_type -> api.v1.ListNotePermissionsRequest
+	38, // 44: api.v1.NoteService.RevokeNotePermission:input_type -> api.v1.RevokeNotePermissionRequest
+	3,  // 45: api.v1.NoteService.ListNotes:output_type -> api.v1.ListNotesResponse
+	43, // 46: api.v1.NoteService.GetNote:output_type -> store.Note
+	43, // 47: api.v1.NoteService.CreateNote:output_type -> store.Note
+	43, // 48: api.v1.NoteService.UpdateNote:output_type -> store.Note
+	52, // 49: api.v1.NoteService.DeleteNote:output_type -> google.protobuf.Empty
+	43, // 50: api.v1.NoteService.GetNoteBySlug:output_type -> store.Note
+	10, // 51: api.v1.NoteService.RenderNote:output_type -> api.v1.RenderNoteResponse
+	13, // 52: api.v1.NoteService.GetNoteStats:output_type -> api.v1.NoteStats
+	17, // 53: api.v1.NoteService.ImportNotes:output_type -> api.v1.ImportNotesResponse
+	46, // 54: api.v1.NoteService.GetNoteDraft:output_type -> store.NoteDraft
+	46, // 55: api.v1.NoteService.SaveNoteDraft:output_type -> store.NoteDraft
+	43, // 56: api.v1.NoteService.PublishDraft:output_type -> store.Note
+	52, // 57: api.v1.NoteService.DiscardDraft:output_type -> google.protobuf.Empty
+	23, // 58: api.v1.NoteService.ListNoteRevisions:output_type -> api.v1.ListNoteRevisionsResponse
+	25, // 59: api.v1.NoteService.ListBacklinks:output_type -> api.v1.ListBacklinksResponse
+	27, // 60: api.v1.NoteService.ListUnresolvedLinks:output_type -> api.v1.ListUnresolvedLinksResponse
+	40, // 61: api.v1.NoteService.GetNoteGraph:output_type -> api.v1.NoteGraph
+	30, // 62: api.v1.NoteService.ListRelatedNotes:output_type -> api.v1.ListRelatedNotesResponse
+	49, // 63: api.v1.NoteService.CreateShareLink:output_type -> store.ShareLink
+	33, // 64: api.v1.NoteService.ListShareLinks:output_type -> api.v1.ListShareLinksResponse
+	49, // 65: api.v1.NoteService.RevokeShareLink:output_type -> store.ShareLink
+	51, // 66: api.v1.NoteService.GrantNotePermission:output_type -> store.NotePermission
+	37, // 67: api.v1.NoteService.ListNotePermissions:output_type -> api.v1.ListNotePermissionsResponse
+	52, // 68: api.v1.NoteService.RevokeNotePermission:output_type -> google.protobuf.Empty
+	45, // [45:69] is the sub-list for method output_type
+	21, // [21:45] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_note_service_proto_init() }
@@ -550,13 +2916,14 @@ func file_api_v1_note_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_note_service_proto_rawDesc), len(file_api_v1_note_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_note_service_proto_goTypes,
 		DependencyIndexes: file_api_v1_note_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_note_service_proto_enumTypes,
 		MessageInfos:      file_api_v1_note_service_proto_msgTypes,
 	}.Build()
 	File_api_v1_note_service_proto = out.File
@@ -21,12 +21,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NoteService_ListNotes_FullMethodName     = "/api.v1.NoteService/ListNotes"
-	NoteService_GetNote_FullMethodName       = "/api.v1.NoteService/GetNote"
-	NoteService_CreateNote_FullMethodName    = "/api.v1.NoteService/CreateNote"
-	NoteService_UpdateNote_FullMethodName    = "/api.v1.NoteService/UpdateNote"
-	NoteService_DeleteNote_FullMethodName    = "/api.v1.NoteService/DeleteNote"
-	NoteService_GetNoteBySlug_FullMethodName = "/api.v1.NoteService/GetNoteBySlug"
+	NoteService_ListNotes_FullMethodName            = "/api.v1.NoteService/ListNotes"
+	NoteService_GetNote_FullMethodName              = "/api.v1.NoteService/GetNote"
+	NoteService_CreateNote_FullMethodName           = "/api.v1.NoteService/CreateNote"
+	NoteService_UpdateNote_FullMethodName           = "/api.v1.NoteService/UpdateNote"
+	NoteService_DeleteNote_FullMethodName           = "/api.v1.NoteService/DeleteNote"
+	NoteService_GetNoteBySlug_FullMethodName        = "/api.v1.NoteService/GetNoteBySlug"
+	NoteService_RenderNote_FullMethodName           = "/api.v1.NoteService/RenderNote"
+	NoteService_GetNoteStats_FullMethodName         = "/api.v1.NoteService/GetNoteStats"
+	NoteService_ImportNotes_FullMethodName          = "/api.v1.NoteService/ImportNotes"
+	NoteService_GetNoteDraft_FullMethodName         = "/api.v1.NoteService/GetNoteDraft"
+	NoteService_SaveNoteDraft_FullMethodName        = "/api.v1.NoteService/SaveNoteDraft"
+	NoteService_PublishDraft_FullMethodName         = "/api.v1.NoteService/PublishDraft"
+	NoteService_DiscardDraft_FullMethodName         = "/api.v1.NoteService/DiscardDraft"
+	NoteService_ListNoteRevisions_FullMethodName    = "/api.v1.NoteService/ListNoteRevisions"
+	NoteService_ListBacklinks_FullMethodName        = "/api.v1.NoteService/ListBacklinks"
+	NoteService_ListUnresolvedLinks_FullMethodName  = "/api.v1.NoteService/ListUnresolvedLinks"
+	NoteService_GetNoteGraph_FullMethodName         = "/api.v1.NoteService/GetNoteGraph"
+	NoteService_ListRelatedNotes_FullMethodName     = "/api.v1.NoteService/ListRelatedNotes"
+	NoteService_CreateShareLink_FullMethodName      = "/api.v1.NoteService/CreateShareLink"
+	NoteService_ListShareLinks_FullMethodName       = "/api.v1.NoteService/ListShareLinks"
+	NoteService_RevokeShareLink_FullMethodName      = "/api.v1.NoteService/RevokeShareLink"
+	NoteService_GrantNotePermission_FullMethodName  = "/api.v1.NoteService/GrantNotePermission"
+	NoteService_ListNotePermissions_FullMethodName  = "/api.v1.NoteService/ListNotePermissions"
+	NoteService_RevokeNotePermission_FullMethodName = "/api.v1.NoteService/RevokeNotePermission"
 )
 
 // NoteServiceClient is the client API for NoteService service.
@@ -47,6 +65,43 @@ type NoteServiceClient interface {
 	DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// GetNoteBySlug 根据slug返回笔记
 	GetNoteBySlug(ctx context.Context, in *GetNoteBySlugRequest, opts ...grpc.CallOption) (*store.Note, error)
+	// RenderNote 在服务端将笔记内容渲染为经过安全过滤的 HTML
+	RenderNote(ctx context.Context, in *RenderNoteRequest, opts ...grpc.CallOption) (*RenderNoteResponse, error)
+	// GetNoteStats 返回笔记的阅读统计（总浏览量和按天汇总的浏览量）
+	GetNoteStats(ctx context.Context, in *GetNoteStatsRequest, opts ...grpc.CallOption) (*NoteStats, error)
+	// ImportNotes 从 zip 压缩包批量导入 Markdown 笔记（支持 Obsidian/Hugo/Jekyll 的 front matter）
+	ImportNotes(ctx context.Context, in *ImportNotesRequest, opts ...grpc.CallOption) (*ImportNotesResponse, error)
+	// GetNoteDraft 返回当前用户对笔记的工作草稿
+	GetNoteDraft(ctx context.Context, in *GetNoteDraftRequest, opts ...grpc.CallOption) (*store.NoteDraft, error)
+	// SaveNoteDraft 自动保存当前用户的工作草稿，不修改笔记的已发布内容
+	SaveNoteDraft(ctx context.Context, in *SaveNoteDraftRequest, opts ...grpc.CallOption) (*store.NoteDraft, error)
+	// PublishDraft 将当前用户的工作草稿发布为笔记内容，记录修订并删除草稿
+	PublishDraft(ctx context.Context, in *PublishDraftRequest, opts ...grpc.CallOption) (*store.Note, error)
+	// DiscardDraft 删除当前用户的工作草稿
+	DiscardDraft(ctx context.Context, in *DiscardDraftRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListNoteRevisions 返回笔记的修订记录，按发布时间倒序
+	ListNoteRevisions(ctx context.Context, in *ListNoteRevisionsRequest, opts ...grpc.CallOption) (*ListNoteRevisionsResponse, error)
+	// ListBacklinks 返回通过 [[链接]] 引用该笔记的笔记，只包含访问者可以查看的笔记
+	ListBacklinks(ctx context.Context, in *ListBacklinksRequest, opts ...grpc.CallOption) (*ListBacklinksResponse, error)
+	// ListUnresolvedLinks 返回没有找到目标笔记的链接；管理员可以查看全部，其他用户只能查看自己笔记中的链接
+	ListUnresolvedLinks(ctx context.Context, in *ListUnresolvedLinksRequest, opts ...grpc.CallOption) (*ListUnresolvedLinksResponse, error)
+	// GetNoteGraph 返回笔记之间的关系图（链接、标签和分类），只包含访问者在笔记列表中可以看到的笔记
+	GetNoteGraph(ctx context.Context, in *GetNoteGraphRequest, opts ...grpc.CallOption) (*NoteGraph, error)
+	// ListRelatedNotes 返回与笔记内容相似的笔记，按相似度从高到低排列
+	ListRelatedNotes(ctx context.Context, in *ListRelatedNotesRequest, opts ...grpc.CallOption) (*ListRelatedNotesResponse, error)
+	// CreateShareLink 为笔记创建分享链接，持有链接的访问者无需登录即可查看笔记及其附件
+	CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*store.ShareLink, error)
+	// ListShareLinks 返回笔记的分享链接（不包含令牌），按创建时间倒序
+	ListShareLinks(ctx context.Context, in *ListShareLinksRequest, opts ...grpc.CallOption) (*ListShareLinksResponse, error)
+	// RevokeShareLink 撤销分享链接，撤销后链接立即失效
+	RevokeShareLink(ctx context.Context, in *RevokeShareLinkRequest, opts ...grpc.CallOption) (*store.ShareLink, error)
+	// GrantNotePermission 授予用户对笔记或分类的协作者角色，已有权限时更新角色
+	// 笔记的权限由作者和管理员管理，分类的权限只有管理员可以管理
+	GrantNotePermission(ctx context.Context, in *GrantNotePermissionRequest, opts ...grpc.CallOption) (*store.NotePermission, error)
+	// ListNotePermissions 返回笔记或分类的权限；笔记还包含从所属分类及其上级分类继承的权限
+	ListNotePermissions(ctx context.Context, in *ListNotePermissionsRequest, opts ...grpc.CallOption) (*ListNotePermissionsResponse, error)
+	// RevokeNotePermission 撤销用户对笔记或分类的权限
+	RevokeNotePermission(ctx context.Context, in *RevokeNotePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type noteServiceClient struct {
@@ -117,6 +172,186 @@ func (c *noteServiceClient) GetNoteBySlug(ctx context.Context, in *GetNoteBySlug
 	return out, nil
 }
 
+func (c *noteServiceClient) RenderNote(ctx context.Context, in *RenderNoteRequest, opts ...grpc.CallOption) (*RenderNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderNoteResponse)
+	err := c.cc.Invoke(ctx, NoteService_RenderNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteStats(ctx context.Context, in *GetNoteStatsRequest, opts ...grpc.CallOption) (*NoteStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteStats)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ImportNotes(ctx context.Context, in *ImportNotesRequest, opts ...grpc.CallOption) (*ImportNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_ImportNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteDraft(ctx context.Context, in *GetNoteDraftRequest, opts ...grpc.CallOption) (*store.NoteDraft, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.NoteDraft)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) SaveNoteDraft(ctx context.Context, in *SaveNoteDraftRequest, opts ...grpc.CallOption) (*store.NoteDraft, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.NoteDraft)
+	err := c.cc.Invoke(ctx, NoteService_SaveNoteDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) PublishDraft(ctx context.Context, in *PublishDraftRequest, opts ...grpc.CallOption) (*store.Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.Note)
+	err := c.cc.Invoke(ctx, NoteService_PublishDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DiscardDraft(ctx context.Context, in *DiscardDraftRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NoteService_DiscardDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListNoteRevisions(ctx context.Context, in *ListNoteRevisionsRequest, opts ...grpc.CallOption) (*ListNoteRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNoteRevisionsResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListNoteRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListBacklinks(ctx context.Context, in *ListBacklinksRequest, opts ...grpc.CallOption) (*ListBacklinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBacklinksResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListBacklinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListUnresolvedLinks(ctx context.Context, in *ListUnresolvedLinksRequest, opts ...grpc.CallOption) (*ListUnresolvedLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUnresolvedLinksResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListUnresolvedLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteGraph(ctx context.Context, in *GetNoteGraphRequest, opts ...grpc.CallOption) (*NoteGraph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteGraph)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListRelatedNotes(ctx context.Context, in *ListRelatedNotesRequest, opts ...grpc.CallOption) (*ListRelatedNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRelatedNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListRelatedNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*store.ShareLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.ShareLink)
+	err := c.cc.Invoke(ctx, NoteService_CreateShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListShareLinks(ctx context.Context, in *ListShareLinksRequest, opts ...grpc.CallOption) (*ListShareLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShareLinksResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListShareLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RevokeShareLink(ctx context.Context, in *RevokeShareLinkRequest, opts ...grpc.CallOption) (*store.ShareLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.ShareLink)
+	err := c.cc.Invoke(ctx, NoteService_RevokeShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GrantNotePermission(ctx context.Context, in *GrantNotePermissionRequest, opts ...grpc.CallOption) (*store.NotePermission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.NotePermission)
+	err := c.cc.Invoke(ctx, NoteService_GrantNotePermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListNotePermissions(ctx context.Context, in *ListNotePermissionsRequest, opts ...grpc.CallOption) (*ListNotePermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotePermissionsResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListNotePermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RevokeNotePermission(ctx context.Context, in *RevokeNotePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NoteService_RevokeNotePermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NoteServiceServer is the server API for NoteService service.
 // All implementations must embed UnimplementedNoteServiceServer
 // for forward compatibility.
@@ -135,6 +370,43 @@ type NoteServiceServer interface {
 	DeleteNote(context.Context, *DeleteNoteRequest) (*emptypb.Empty, error)
 	// GetNoteBySlug 根据slug返回笔记
 	GetNoteBySlug(context.Context, *GetNoteBySlugRequest) (*store.Note, error)
+	// RenderNote 在服务端将笔记内容渲染为经过安全过滤的 HTML
+	RenderNote(context.Context, *RenderNoteRequest) (*RenderNoteResponse, error)
+	// GetNoteStats 返回笔记的阅读统计（总浏览量和按天汇总的浏览量）
+	GetNoteStats(context.Context, *GetNoteStatsRequest) (*NoteStats, error)
+	// ImportNotes 从 zip 压缩包批量导入 Markdown 笔记（支持 Obsidian/Hugo/Jekyll 的 front matter）
+	ImportNotes(context.Context, *ImportNotesRequest) (*ImportNotesResponse, error)
+	// GetNoteDraft 返回当前用户对笔记的工作草稿
+	GetNoteDraft(context.Context, *GetNoteDraftRequest) (*store.NoteDraft, error)
+	// SaveNoteDraft 自动保存当前用户的工作草稿，不修改笔记的已发布内容
+	SaveNoteDraft(context.Context, *SaveNoteDraftRequest) (*store.NoteDraft, error)
+	// PublishDraft 将当前用户的工作草稿发布为笔记内容，记录修订并删除草稿
+	PublishDraft(context.Context, *PublishDraftRequest) (*store.Note, error)
+	// DiscardDraft 删除当前用户的工作草稿
+	DiscardDraft(context.Context, *DiscardDraftRequest) (*emptypb.Empty, error)
+	// ListNoteRevisions 返回笔记的修订记录，按发布时间倒序
+	ListNoteRevisions(context.Context, *ListNoteRevisionsRequest) (*ListNoteRevisionsResponse, error)
+	// ListBacklinks 返回通过 [[链接]] 引用该笔记的笔记，只包含访问者可以查看的笔记
+	ListBacklinks(context.Context, *ListBacklinksRequest) (*ListBacklinksResponse, error)
+	// ListUnresolvedLinks 返回没有找到目标笔记的链接；管理员可以查看全部，其他用户只能查看自己笔记中的链接
+	ListUnresolvedLinks(context.Context, *ListUnresolvedLinksRequest) (*ListUnresolvedLinksResponse, error)
+	// GetNoteGraph 返回笔记之间的关系图（链接、标签和分类），只包含访问者在笔记列表中可以看到的笔记
+	GetNoteGraph(context.Context, *GetNoteGraphRequest) (*NoteGraph, error)
+	// ListRelatedNotes 返回与笔记内容相似的笔记，按相似度从高到低排列
+	ListRelatedNotes(context.Context, *ListRelatedNotesRequest) (*ListRelatedNotesResponse, error)
+	// CreateShareLink 为笔记创建分享链接，持有链接的访问者无需登录即可查看笔记及其附件
+	CreateShareLink(context.Context, *CreateShareLinkRequest) (*store.ShareLink, error)
+	// ListShareLinks 返回笔记的分享链接（不包含令牌），按创建时间倒序
+	ListShareLinks(context.Context, *ListShareLinksRequest) (*ListShareLinksResponse, error)
+	// RevokeShareLink 撤销分享链接，撤销后链接立即失效
+	RevokeShareLink(context.Context, *RevokeShareLinkRequest) (*store.ShareLink, error)
+	// GrantNotePermission 授予用户对笔记或分类的协作者角色，已有权限时更新角色
+	// 笔记的权限由作者和管理员管理，分类的权限只有管理员可以管理
+	GrantNotePermission(context.Context, *GrantNotePermissionRequest) (*store.NotePermission, error)
+	// ListNotePermissions 返回笔记或分类的权限；笔记还包含从所属分类及其上级分类继承的权限
+	ListNotePermissions(context.Context, *ListNotePermissionsRequest) (*ListNotePermissionsResponse, error)
+	// RevokeNotePermission 撤销用户对笔记或分类的权限
+	RevokeNotePermission(context.Context, *RevokeNotePermissionRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedNoteServiceServer()
 }
 
@@ -163,6 +435,60 @@ func (UnimplementedNoteServiceServer) DeleteNote(context.Context, *DeleteNoteReq
 func (UnimplementedNoteServiceServer) GetNoteBySlug(context.Context, *GetNoteBySlugRequest) (*store.Note, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetNoteBySlug not implemented")
 }
+func (UnimplementedNoteServiceServer) RenderNote(context.Context, *RenderNoteRequest) (*RenderNoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenderNote not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteStats(context.Context, *GetNoteStatsRequest) (*NoteStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteStats not implemented")
+}
+func (UnimplementedNoteServiceServer) ImportNotes(context.Context, *ImportNotesRequest) (*ImportNotesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteDraft(context.Context, *GetNoteDraftRequest) (*store.NoteDraft, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteDraft not implemented")
+}
+func (UnimplementedNoteServiceServer) SaveNoteDraft(context.Context, *SaveNoteDraftRequest) (*store.NoteDraft, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveNoteDraft not implemented")
+}
+func (UnimplementedNoteServiceServer) PublishDraft(context.Context, *PublishDraftRequest) (*store.Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method PublishDraft not implemented")
+}
+func (UnimplementedNoteServiceServer) DiscardDraft(context.Context, *DiscardDraftRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiscardDraft not implemented")
+}
+func (UnimplementedNoteServiceServer) ListNoteRevisions(context.Context, *ListNoteRevisionsRequest) (*ListNoteRevisionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNoteRevisions not implemented")
+}
+func (UnimplementedNoteServiceServer) ListBacklinks(context.Context, *ListBacklinksRequest) (*ListBacklinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBacklinks not implemented")
+}
+func (UnimplementedNoteServiceServer) ListUnresolvedLinks(context.Context, *ListUnresolvedLinksRequest) (*ListUnresolvedLinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUnresolvedLinks not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteGraph(context.Context, *GetNoteGraphRequest) (*NoteGraph, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteGraph not implemented")
+}
+func (UnimplementedNoteServiceServer) ListRelatedNotes(context.Context, *ListRelatedNotesRequest) (*ListRelatedNotesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRelatedNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) CreateShareLink(context.Context, *CreateShareLinkRequest) (*store.ShareLink, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedNoteServiceServer) ListShareLinks(context.Context, *ListShareLinksRequest) (*ListShareLinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListShareLinks not implemented")
+}
+func (UnimplementedNoteServiceServer) RevokeShareLink(context.Context, *RevokeShareLinkRequest) (*store.ShareLink, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeShareLink not implemented")
+}
+func (UnimplementedNoteServiceServer) GrantNotePermission(context.Context, *GrantNotePermissionRequest) (*store.NotePermission, error) {
+	return nil, status.Error(codes.Unimplemented, "method GrantNotePermission not implemented")
+}
+func (UnimplementedNoteServiceServer) ListNotePermissions(context.Context, *ListNotePermissionsRequest) (*ListNotePermissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNotePermissions not implemented")
+}
+func (UnimplementedNoteServiceServer) RevokeNotePermission(context.Context, *RevokeNotePermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeNotePermission not implemented")
+}
 func (UnimplementedNoteServiceServer) mustEmbedUnimplementedNoteServiceServer() {}
 func (UnimplementedNoteServiceServer) testEmbeddedByValue()                     {}
 
@@ -292,6 +618,330 @@ func _NoteService_GetNoteBySlug_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NoteService_RenderNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RenderNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RenderNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RenderNote(ctx, req.(*RenderNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteStats(ctx, req.(*GetNoteStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ImportNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ImportNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ImportNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ImportNotes(ctx, req.(*ImportNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteDraft(ctx, req.(*GetNoteDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_SaveNoteDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveNoteDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).SaveNoteDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_SaveNoteDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).SaveNoteDraft(ctx, req.(*SaveNoteDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_PublishDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).PublishDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_PublishDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).PublishDraft(ctx, req.(*PublishDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_DiscardDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DiscardDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DiscardDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DiscardDraft(ctx, req.(*DiscardDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListNoteRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNoteRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListNoteRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListNoteRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListNoteRevisions(ctx, req.(*ListNoteRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListBacklinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBacklinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListBacklinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListBacklinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListBacklinks(ctx, req.(*ListBacklinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListUnresolvedLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnresolvedLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListUnresolvedLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListUnresolvedLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListUnresolvedLinks(ctx, req.(*ListUnresolvedLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteGraph(ctx, req.(*GetNoteGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListRelatedNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelatedNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListRelatedNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListRelatedNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListRelatedNotes(ctx, req.(*ListRelatedNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).CreateShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_CreateShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).CreateShareLink(ctx, req.(*CreateShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListShareLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShareLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListShareLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListShareLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListShareLinks(ctx, req.(*ListShareLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RevokeShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RevokeShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RevokeShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RevokeShareLink(ctx, req.(*RevokeShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GrantNotePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantNotePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GrantNotePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GrantNotePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GrantNotePermission(ctx, req.(*GrantNotePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListNotePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotePermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListNotePermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListNotePermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListNotePermissions(ctx, req.(*ListNotePermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RevokeNotePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeNotePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RevokeNotePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RevokeNotePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RevokeNotePermission(ctx, req.(*RevokeNotePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NoteService_ServiceDesc is the grpc.ServiceDesc for NoteService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,6 +973,78 @@ var NoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNoteBySlug",
 			Handler:    _NoteService_GetNoteBySlug_Handler,
 		},
+		{
+			MethodName: "RenderNote",
+			Handler:    _NoteService_RenderNote_Handler,
+		},
+		{
+			MethodName: "GetNoteStats",
+			Handler:    _NoteService_GetNoteStats_Handler,
+		},
+		{
+			MethodName: "ImportNotes",
+			Handler:    _NoteService_ImportNotes_Handler,
+		},
+		{
+			MethodName: "GetNoteDraft",
+			Handler:    _NoteService_GetNoteDraft_Handler,
+		},
+		{
+			MethodName: "SaveNoteDraft",
+			Handler:    _NoteService_SaveNoteDraft_Handler,
+		},
+		{
+			MethodName: "PublishDraft",
+			Handler:    _NoteService_PublishDraft_Handler,
+		},
+		{
+			MethodName: "DiscardDraft",
+			Handler:    _NoteService_DiscardDraft_Handler,
+		},
+		{
+			MethodName: "ListNoteRevisions",
+			Handler:    _NoteService_ListNoteRevisions_Handler,
+		},
+		{
+			MethodName: "ListBacklinks",
+			Handler:    _NoteService_ListBacklinks_Handler,
+		},
+		{
+			MethodName: "ListUnresolvedLinks",
+			Handler:    _NoteService_ListUnresolvedLinks_Handler,
+		},
+		{
+			MethodName: "GetNoteGraph",
+			Handler:    _NoteService_GetNoteGraph_Handler,
+		},
+		{
+			MethodName: "ListRelatedNotes",
+			Handler:    _NoteService_ListRelatedNotes_Handler,
+		},
+		{
+			MethodName: "CreateShareLink",
+			Handler:    _NoteService_CreateShareLink_Handler,
+		},
+		{
+			MethodName: "ListShareLinks",
+			Handler:    _NoteService_ListShareLinks_Handler,
+		},
+		{
+			MethodName: "RevokeShareLink",
+			Handler:    _NoteService_RevokeShareLink_Handler,
+		},
+		{
+			MethodName: "GrantNotePermission",
+			Handler:    _NoteService_GrantNotePermission_Handler,
+		},
+		{
+			MethodName: "ListNotePermissions",
+			Handler:    _NoteService_ListNotePermissions_Handler,
+		},
+		{
+			MethodName: "RevokeNotePermission",
+			Handler:    _NoteService_RevokeNotePermission_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/note_service.proto",
@@ -0,0 +1,473 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/saved_search_service.proto
+
+package apiv1
+
+import (
+	store "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListSavedSearchesRequest 列出保存的搜索请求
+type ListSavedSearchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedSearchesRequest) Reset() {
+	*x = ListSavedSearchesRequest{}
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedSearchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedSearchesRequest) ProtoMessage() {}
+
+func (x *ListSavedSearchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedSearchesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_saved_search_service_proto_rawDescGZIP(), []int{0}
+}
+
+// ListSavedSearchesResponse 列出保存的搜索响应
+type ListSavedSearchesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 保存的搜索列表
+	SavedSearches []*store.SavedSearch `protobuf:"bytes,1,rep,name=saved_searches,json=savedSearches,proto3" json:"saved_searches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedSearchesResponse) Reset() {
+	*x = ListSavedSearchesResponse{}
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedSearchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedSearchesResponse) ProtoMessage() {}
+
+func (x *ListSavedSearchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedSearchesResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_saved_search_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSavedSearchesResponse) GetSavedSearches() []*store.SavedSearch {
+	if x != nil {
+		return x.SavedSearches
+	}
+	return nil
+}
+
+// GetSavedSearchRequest 获取保存的搜索请求
+type GetSavedSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：savedSearches/{saved_search}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSavedSearchRequest) Reset() {
+	*x = GetSavedSearchRequest{}
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedSearchRequest) ProtoMessage() {}
+
+func (x *GetSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*GetSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_saved_search_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSavedSearchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// CreateSavedSearchRequest 创建保存的搜索请求
+type CreateSavedSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 要创建的保存的搜索
+	SavedSearch   *store.SavedSearch `protobuf:"bytes,1,opt,name=saved_search,json=savedSearch,proto3" json:"saved_search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSavedSearchRequest) Reset() {
+	*x = CreateSavedSearchRequest{}
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedSearchRequest) ProtoMessage() {}
+
+func (x *CreateSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*CreateSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_saved_search_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSavedSearchRequest) GetSavedSearch() *store.SavedSearch {
+	if x != nil {
+		return x.SavedSearch
+	}
+	return nil
+}
+
+// UpdateSavedSearchRequest 更新保存的搜索请求
+type UpdateSavedSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 要更新的保存的搜索
+	SavedSearch *store.SavedSearch `protobuf:"bytes,1,opt,name=saved_search,json=savedSearch,proto3" json:"saved_search,omitempty"`
+	// 字段掩码，指定要更新的字段（name_text、filter、sort_by、sort_desc、pinned、shared、feed_enabled），为空时更新全部字段
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSavedSearchRequest) Reset() {
+	*x = UpdateSavedSearchRequest{}
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSavedSearchRequest) ProtoMessage() {}
+
+func (x *UpdateSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_saved_search_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateSavedSearchRequest) GetSavedSearch() *store.SavedSearch {
+	if x != nil {
+		return x.SavedSearch
+	}
+	return nil
+}
+
+func (x *UpdateSavedSearchRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// DeleteSavedSearchRequest 删除保存的搜索请求
+type DeleteSavedSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：savedSearches/{saved_search}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedSearchRequest) Reset() {
+	*x = DeleteSavedSearchRequest{}
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedSearchRequest) ProtoMessage() {}
+
+func (x *DeleteSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_saved_search_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteSavedSearchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListSavedSearchNotesRequest 执行保存的搜索请求
+type ListSavedSearchNotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：savedSearches/{saved_search}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 每页大小
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 上一页响应中的 next_page_token
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedSearchNotesRequest) Reset() {
+	*x = ListSavedSearchNotesRequest{}
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedSearchNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedSearchNotesRequest) ProtoMessage() {}
+
+func (x *ListSavedSearchNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_saved_search_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedSearchNotesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchNotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_saved_search_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSavedSearchNotesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListSavedSearchNotesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSavedSearchNotesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+var File_api_v1_saved_search_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_saved_search_service_proto_rawDesc = "" +
+	"\n" +
+	"!api/v1/saved_search_service.proto\x12\x06api.v1\x1a\x19api/v1/note_service.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x10store/note.proto\"\x1a\n" +
+	"\x18ListSavedSearchesRequest\"V\n" +
+	"\x19ListSavedSearchesResponse\x129\n" +
+	"\x0esaved_searches\x18\x01 \x03(\v2\x12.store.SavedSearchR\rsavedSearches\"+\n" +
+	"\x15GetSavedSearchRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Q\n" +
+	"\x18CreateSavedSearchRequest\x125\n" +
+	"\fsaved_search\x18\x01 \x01(\v2\x12.store.SavedSearchR\vsavedSearch\"\x8e\x01\n" +
+	"\x18UpdateSavedSearchRequest\x125\n" +
+	"\fsaved_search\x18\x01 \x01(\v2\x12.store.SavedSearchR\vsavedSearch\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\".\n" +
+	"\x18DeleteSavedSearchRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"m\n" +
+	"\x1bListSavedSearchNotesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken2\xf0\x03\n" +
+	"\x12SavedSearchService\x12X\n" +
+	"\x11ListSavedSearches\x12 .api.v1.ListSavedSearchesRequest\x1a!.api.v1.ListSavedSearchesResponse\x12C\n" +
+	"\x0eGetSavedSearch\x12\x1d.api.v1.GetSavedSearchRequest\x1a\x12.store.SavedSearch\x12I\n" +
+	"\x11CreateSavedSearch\x12 .api.v1.CreateSavedSearchRequest\x1a\x12.store.SavedSearch\x12I\n" +
+	"\x11UpdateSavedSearch\x12 .api.v1.UpdateSavedSearchRequest\x1a\x12.store.SavedSearch\x12M\n" +
+	"\x11DeleteSavedSearch\x12 .api.v1.DeleteSavedSearchRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x14ListSavedSearchNotes\x12#.api.v1.ListSavedSearchNotesRequest\x1a\x19.api.v1.ListNotesResponseB\x96\x01\n" +
+	"\n" +
+	"com.api.v1B\x17SavedSearchServiceProtoP\x01Z6github.com/wdmsyhh/simple-notes/proto/gen/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
+
+var (
+	file_api_v1_saved_search_service_proto_rawDescOnce sync.Once
+	file_api_v1_saved_search_service_proto_rawDescData []byte
+)
+
+func file_api_v1_saved_search_service_proto_rawDescGZIP() []byte {
+	file_api_v1_saved_search_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_saved_search_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_saved_search_service_proto_rawDesc), len(file_api_v1_saved_search_service_proto_rawDesc)))
+	})
+	return file_api_v1_saved_search_service_proto_rawDescData
+}
+
+var file_api_v1_saved_search_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_saved_search_service_proto_goTypes = []any{
+	(*ListSavedSearchesRequest)(nil),    // 0: api.v1.ListSavedSearchesRequest
+	(*ListSavedSearchesResponse)(nil),   // 1: api.v1.ListSavedSearchesResponse
+	(*GetSavedSearchRequest)(nil),       // 2: api.v1.GetSavedSearchRequest
+	(*CreateSavedSearchRequest)(nil),    // 3: api.v1.CreateSavedSearchRequest
+	(*UpdateSavedSearchRequest)(nil),    // 4: api.v1.UpdateSavedSearchRequest
+	(*DeleteSavedSearchRequest)(nil),    // 5: api.v1.DeleteSavedSearchRequest
+	(*ListSavedSearchNotesRequest)(nil), // 6: api.v1.ListSavedSearchNotesRequest
+	(*store.SavedSearch)(nil),           // 7: store.SavedSearch
+	(*fieldmaskpb.FieldMask)(nil),       // 8: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),               // 9: google.protobuf.Empty
+	(*ListNotesResponse)(nil),           // 10: api.v1.ListNotesResponse
+}
+var file_api_v1_saved_search_service_proto_depIdxs = []int32{
+	7,  // 0: api.v1.ListSavedSearchesResponse.saved_searches:type_name -> store.SavedSearch
+	7,  // 1: api.v1.CreateSavedSearchRequest.saved_search:type_name -> store.SavedSearch
+	7,  // 2: api.v1.UpdateSavedSearchRequest.saved_search:type_name -> store.SavedSearch
+	8,  // 3: api.v1.UpdateSavedSearchRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 4: api.v1.SavedSearchService.ListSavedSearches:input_type -> api.v1.ListSavedSearchesRequest
+	2,  // 5: api.v1.SavedSearchService.GetSavedSearch:input_type -> api.v1.GetSavedSearchRequest
+	3,  // 6: api.v1.SavedSearchService.CreateSavedSearch:input_type -> api.v1.CreateSavedSearchRequest
+	4,  // 7: api.v1.SavedSearchService.UpdateSavedSearch:input_type -> api.v1.UpdateSavedSearchRequest
+	5,  // 8: api.v1.SavedSearchService.DeleteSavedSearch:input_type -> api.v1.DeleteSavedSearchRequest
+	6,  // 9: api.v1.SavedSearchService.ListSavedSearchNotes:input_type -> api.v1.ListSavedSearchNotesRequest
+	1,  // 10: api.v1.SavedSearchService.ListSavedSearches:output_type -> api.v1.ListSavedSearchesResponse
+	7,  // 11: api.v1.SavedSearchService.GetSavedSearch:output_type -> store.SavedSearch
+	7,  // 12: api.v1.SavedSearchService.CreateSavedSearch:output_type -> store.SavedSearch
+	7,  // 13: api.v1.SavedSearchService.UpdateSavedSearch:output_type -> store.SavedSearch
+	9,  // 14: api.v1.SavedSearchService.DeleteSavedSearch:output_type -> google.protobuf.Empty
+	10, // 15: api.v1.SavedSearchService.ListSavedSearchNotes:output_type -> api.v1.ListNotesResponse
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_saved_search_service_proto_init() }
+func file_api_v1_saved_search_service_proto_init() {
+	if File_api_v1_saved_search_service_proto != nil {
+		return
+	}
+	file_api_v1_note_service_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_saved_search_service_proto_rawDesc), len(file_api_v1_saved_search_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_saved_search_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_saved_search_service_proto_depIdxs,
+		MessageInfos:      file_api_v1_saved_search_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_saved_search_service_proto = out.File
+	file_api_v1_saved_search_service_proto_goTypes = nil
+	file_api_v1_saved_search_service_proto_depIdxs = nil
+}
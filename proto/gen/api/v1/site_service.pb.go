@@ -0,0 +1,454 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/site_service.proto
+
+package apiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExportStaticSiteRequest 导出静态网站请求
+type ExportStaticSiteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 站点根地址，例如 https://notes.example.com，用于规范地址、订阅源和 sitemap
+	BaseUrl string `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// 是否忽略上次导出的记录，重新生成全部页面
+	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStaticSiteRequest) Reset() {
+	*x = ExportStaticSiteRequest{}
+	mi := &file_api_v1_site_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStaticSiteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStaticSiteRequest) ProtoMessage() {}
+
+func (x *ExportStaticSiteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_site_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStaticSiteRequest.ProtoReflect.Descriptor instead.
+func (*ExportStaticSiteRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_site_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportStaticSiteRequest) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *ExportStaticSiteRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// ExportStaticSiteResponse 导出静态网站响应
+type ExportStaticSiteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 导出目录（服务器上的路径）
+	OutputDir string `protobuf:"bytes,1,opt,name=output_dir,json=outputDir,proto3" json:"output_dir,omitempty"`
+	// 重新生成的笔记数量
+	NotesRendered int32 `protobuf:"varint,2,opt,name=notes_rendered,json=notesRendered,proto3" json:"notes_rendered,omitempty"`
+	// 未变化而跳过的笔记数量
+	NotesSkipped int32 `protobuf:"varint,3,opt,name=notes_skipped,json=notesSkipped,proto3" json:"notes_skipped,omitempty"`
+	// 已不再公开而删除的笔记数量
+	NotesRemoved int32 `protobuf:"varint,4,opt,name=notes_removed,json=notesRemoved,proto3" json:"notes_removed,omitempty"`
+	// 重新生成的独立页面数量
+	PagesRendered int32 `protobuf:"varint,5,opt,name=pages_rendered,json=pagesRendered,proto3" json:"pages_rendered,omitempty"`
+	// 复制的附件数量
+	AttachmentsCopied int32 `protobuf:"varint,6,opt,name=attachments_copied,json=attachmentsCopied,proto3" json:"attachments_copied,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExportStaticSiteResponse) Reset() {
+	*x = ExportStaticSiteResponse{}
+	mi := &file_api_v1_site_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStaticSiteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStaticSiteResponse) ProtoMessage() {}
+
+func (x *ExportStaticSiteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_site_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStaticSiteResponse.ProtoReflect.Descriptor instead.
+func (*ExportStaticSiteResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_site_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportStaticSiteResponse) GetOutputDir() string {
+	if x != nil {
+		return x.OutputDir
+	}
+	return ""
+}
+
+func (x *ExportStaticSiteResponse) GetNotesRendered() int32 {
+	if x != nil {
+		return x.NotesRendered
+	}
+	return 0
+}
+
+func (x *ExportStaticSiteResponse) GetNotesSkipped() int32 {
+	if x != nil {
+		return x.NotesSkipped
+	}
+	return 0
+}
+
+func (x *ExportStaticSiteResponse) GetNotesRemoved() int32 {
+	if x != nil {
+		return x.NotesRemoved
+	}
+	return 0
+}
+
+func (x *ExportStaticSiteResponse) GetPagesRendered() int32 {
+	if x != nil {
+		return x.PagesRendered
+	}
+	return 0
+}
+
+func (x *ExportStaticSiteResponse) GetAttachmentsCopied() int32 {
+	if x != nil {
+		return x.AttachmentsCopied
+	}
+	return 0
+}
+
+// Backup 数据库备份
+type Backup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 备份文件名
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// 文件大小（字节）
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// 创建时间（Unix 时间戳）
+	CreatedAt int64 `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 下载地址
+	DownloadUrl   string `protobuf:"bytes,4,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Backup) Reset() {
+	*x = Backup{}
+	mi := &file_api_v1_site_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backup) ProtoMessage() {}
+
+func (x *Backup) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_site_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backup.ProtoReflect.Descriptor instead.
+func (*Backup) Descriptor() ([]byte, []int) {
+	return file_api_v1_site_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Backup) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Backup) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Backup) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Backup) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+// CreateBackupRequest 创建数据库备份请求
+type CreateBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBackupRequest) Reset() {
+	*x = CreateBackupRequest{}
+	mi := &file_api_v1_site_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackupRequest) ProtoMessage() {}
+
+func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_site_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBackupRequest.ProtoReflect.Descriptor instead.
+func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_site_service_proto_rawDescGZIP(), []int{3}
+}
+
+// ListBackupsRequest 列出数据库备份请求
+type ListBackupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupsRequest) Reset() {
+	*x = ListBackupsRequest{}
+	mi := &file_api_v1_site_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupsRequest) ProtoMessage() {}
+
+func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_site_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupsRequest.ProtoReflect.Descriptor instead.
+func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_site_service_proto_rawDescGZIP(), []int{4}
+}
+
+// ListBackupsResponse 列出数据库备份响应
+type ListBackupsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 备份列表，最新的在前
+	Backups       []*Backup `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupsResponse) Reset() {
+	*x = ListBackupsResponse{}
+	mi := &file_api_v1_site_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupsResponse) ProtoMessage() {}
+
+func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_site_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
+func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_site_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBackupsResponse) GetBackups() []*Backup {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+var File_api_v1_site_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_site_service_proto_rawDesc = "" +
+	"\n" +
+	"\x19api/v1/site_service.proto\x12\x06api.v1\"J\n" +
+	"\x17ExportStaticSiteRequest\x12\x19\n" +
+	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"\x80\x02\n" +
+	"\x18ExportStaticSiteResponse\x12\x1d\n" +
+	"\n" +
+	"output_dir\x18\x01 \x01(\tR\toutputDir\x12%\n" +
+	"\x0enotes_rendered\x18\x02 \x01(\x05R\rnotesRendered\x12#\n" +
+	"\rnotes_skipped\x18\x03 \x01(\x05R\fnotesSkipped\x12#\n" +
+	"\rnotes_removed\x18\x04 \x01(\x05R\fnotesRemoved\x12%\n" +
+	"\x0epages_rendered\x18\x05 \x01(\x05R\rpagesRendered\x12-\n" +
+	"\x12attachments_copied\x18\x06 \x01(\x05R\x11attachmentsCopied\"z\n" +
+	"\x06Backup\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fdownload_url\x18\x04 \x01(\tR\vdownloadUrl\"\x15\n" +
+	"\x13CreateBackupRequest\"\x14\n" +
+	"\x12ListBackupsRequest\"?\n" +
+	"\x13ListBackupsResponse\x12(\n" +
+	"\abackups\x18\x01 \x03(\v2\x0e.api.v1.BackupR\abackups2\xe9\x01\n" +
+	"\vSiteService\x12U\n" +
+	"\x10ExportStaticSite\x12\x1f.api.v1.ExportStaticSiteRequest\x1a .api.v1.ExportStaticSiteResponse\x12;\n" +
+	"\fCreateBackup\x12\x1b.api.v1.CreateBackupRequest\x1a\x0e.api.v1.Backup\x12F\n" +
+	"\vListBackups\x12\x1a.api.v1.ListBackupsRequest\x1a\x1b.api.v1.ListBackupsResponseB\x8f\x01\n" +
+	"\n" +
+	"com.api.v1B\x10SiteServiceProtoP\x01Z6github.com/wdmsyhh/simple-notes/proto/gen/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
+
+var (
+	file_api_v1_site_service_proto_rawDescOnce sync.Once
+	file_api_v1_site_service_proto_rawDescData []byte
+)
+
+func file_api_v1_site_service_proto_rawDescGZIP() []byte {
+	file_api_v1_site_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_site_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_site_service_proto_rawDesc), len(file_api_v1_site_service_proto_rawDesc)))
+	})
+	return file_api_v1_site_service_proto_rawDescData
+}
+
+var file_api_v1_site_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_v1_site_service_proto_goTypes = []any{
+	(*ExportStaticSiteRequest)(nil),  // 0: api.v1.ExportStaticSiteRequest
+	(*ExportStaticSiteResponse)(nil), // 1: api.v1.ExportStaticSiteResponse
+	(*Backup)(nil),                   // 2: api.v1.Backup
+	(*CreateBackupRequest)(nil),      // 3: api.v1.CreateBackupRequest
+	(*ListBackupsRequest)(nil),       // 4: api.v1.ListBackupsRequest
+	(*ListBackupsResponse)(nil),      // 5: api.v1.ListBackupsResponse
+}
+var file_api_v1_site_service_proto_depIdxs = []int32{
+	2, // 0: api.v1.ListBackupsResponse.backups:type_name -> api.v1.Backup
+	0, // 1: api.v1.SiteService.ExportStaticSite:input_type -> api.v1.ExportStaticSiteRequest
+	3, // 2: api.v1.SiteService.CreateBackup:input_type -> api.v1.CreateBackupRequest
+	4, // 3: api.v1.SiteService.ListBackups:input_type -> api.v1.ListBackupsRequest
+	1, // 4: api.v1.SiteService.ExportStaticSite:output_type -> api.v1.ExportStaticSiteResponse
+	2, // 5: api.v1.SiteService.CreateBackup:output_type -> api.v1.Backup
+	5, // 6: api.v1.SiteService.ListBackups:output_type -> api.v1.ListBackupsResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_site_service_proto_init() }
+func file_api_v1_site_service_proto_init() {
+	if File_api_v1_site_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_site_service_proto_rawDesc), len(file_api_v1_site_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_site_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_site_service_proto_depIdxs,
+		MessageInfos:      file_api_v1_site_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_site_service_proto = out.File
+	file_api_v1_site_service_proto_goTypes = nil
+	file_api_v1_site_service_proto_depIdxs = nil
+}
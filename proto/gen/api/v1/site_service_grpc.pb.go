@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: api/v1/site_service.proto
+
+package apiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SiteService_ExportStaticSite_FullMethodName = "/api.v1.SiteService/ExportStaticSite"
+	SiteService_CreateBackup_FullMethodName     = "/api.v1.SiteService/CreateBackup"
+	SiteService_ListBackups_FullMethodName      = "/api.v1.SiteService/ListBackups"
+)
+
+// SiteServiceClient is the client API for SiteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SiteService 处理站点管理相关操作的服务
+type SiteServiceClient interface {
+	// ExportStaticSite 将已发布的公开笔记导出为静态网站（仅管理员）
+	ExportStaticSite(ctx context.Context, in *ExportStaticSiteRequest, opts ...grpc.CallOption) (*ExportStaticSiteResponse, error)
+	// CreateBackup 在数据目录的 backups 目录中创建数据库备份（仅系统管理员）
+	CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*Backup, error)
+	// ListBackups 列出数据目录中的数据库备份，最新的在前（仅系统管理员）
+	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
+}
+
+type siteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSiteServiceClient(cc grpc.ClientConnInterface) SiteServiceClient {
+	return &siteServiceClient{cc}
+}
+
+func (c *siteServiceClient) ExportStaticSite(ctx context.Context, in *ExportStaticSiteRequest, opts ...grpc.CallOption) (*ExportStaticSiteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportStaticSiteResponse)
+	err := c.cc.Invoke(ctx, SiteService_ExportStaticSite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siteServiceClient) CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*Backup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Backup)
+	err := c.cc.Invoke(ctx, SiteService_CreateBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siteServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, SiteService_ListBackups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SiteServiceServer is the server API for SiteService service.
+// All implementations must embed UnimplementedSiteServiceServer
+// for forward compatibility.
+//
+// SiteService 处理站点管理相关操作的服务
+type SiteServiceServer interface {
+	// ExportStaticSite 将已发布的公开笔记导出为静态网站（仅管理员）
+	ExportStaticSite(context.Context, *ExportStaticSiteRequest) (*ExportStaticSiteResponse, error)
+	// CreateBackup 在数据目录的 backups 目录中创建数据库备份（仅系统管理员）
+	CreateBackup(context.Context, *CreateBackupRequest) (*Backup, error)
+	// ListBackups 列出数据目录中的数据库备份，最新的在前（仅系统管理员）
+	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
+	mustEmbedUnimplementedSiteServiceServer()
+}
+
+// UnimplementedSiteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSiteServiceServer struct{}
+
+func (UnimplementedSiteServiceServer) ExportStaticSite(context.Context, *ExportStaticSiteRequest) (*ExportStaticSiteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportStaticSite not implemented")
+}
+func (UnimplementedSiteServiceServer) CreateBackup(context.Context, *CreateBackupRequest) (*Backup, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateBackup not implemented")
+}
+func (UnimplementedSiteServiceServer) ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBackups not implemented")
+}
+func (UnimplementedSiteServiceServer) mustEmbedUnimplementedSiteServiceServer() {}
+func (UnimplementedSiteServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeSiteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SiteServiceServer will
+// result in compilation errors.
+type UnsafeSiteServiceServer interface {
+	mustEmbedUnimplementedSiteServiceServer()
+}
+
+func RegisterSiteServiceServer(s grpc.ServiceRegistrar, srv SiteServiceServer) {
+	// If the following call panics, it indicates UnimplementedSiteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SiteService_ServiceDesc, srv)
+}
+
+func _SiteService_ExportStaticSite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportStaticSiteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiteServiceServer).ExportStaticSite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiteService_ExportStaticSite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiteServiceServer).ExportStaticSite(ctx, req.(*ExportStaticSiteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiteService_CreateBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiteServiceServer).CreateBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiteService_CreateBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiteServiceServer).CreateBackup(ctx, req.(*CreateBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiteService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiteServiceServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiteService_ListBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiteServiceServer).ListBackups(ctx, req.(*ListBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SiteService_ServiceDesc is the grpc.ServiceDesc for SiteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SiteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.v1.SiteService",
+	HandlerType: (*SiteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ExportStaticSite",
+			Handler:    _SiteService_ExportStaticSite_Handler,
+		},
+		{
+			MethodName: "CreateBackup",
+			Handler:    _SiteService_CreateBackup_Handler,
+		},
+		{
+			MethodName: "ListBackups",
+			Handler:    _SiteService_ListBackups_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/site_service.proto",
+}
@@ -373,6 +373,364 @@ func (x *GetTagBySlugRequest) GetSlug() string {
 	return ""
 }
 
+// RecountTagsRequest 重新计算标签计数请求
+type RecountTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecountTagsRequest) Reset() {
+	*x = RecountTagsRequest{}
+	mi := &file_api_v1_tag_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecountTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecountTagsRequest) ProtoMessage() {}
+
+func (x *RecountTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_tag_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecountTagsRequest.ProtoReflect.Descriptor instead.
+func (*RecountTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_tag_service_proto_rawDescGZIP(), []int{7}
+}
+
+// RecountTagsResponse 重新计算标签计数响应
+type RecountTagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 计数被修正的标签数量
+	UpdatedTags int32 `protobuf:"varint,1,opt,name=updated_tags,json=updatedTags,proto3" json:"updated_tags,omitempty"`
+	// 删除的无效笔记标签关联数量（笔记或标签已不存在）
+	RemovedOrphans int32 `protobuf:"varint,2,opt,name=removed_orphans,json=removedOrphans,proto3" json:"removed_orphans,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecountTagsResponse) Reset() {
+	*x = RecountTagsResponse{}
+	mi := &file_api_v1_tag_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecountTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecountTagsResponse) ProtoMessage() {}
+
+func (x *RecountTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_tag_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecountTagsResponse.ProtoReflect.Descriptor instead.
+func (*RecountTagsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_tag_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RecountTagsResponse) GetUpdatedTags() int32 {
+	if x != nil {
+		return x.UpdatedTags
+	}
+	return 0
+}
+
+func (x *RecountTagsResponse) GetRemovedOrphans() int32 {
+	if x != nil {
+		return x.RemovedOrphans
+	}
+	return 0
+}
+
+// MergeTagsRequest 合并标签请求
+type MergeTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 目标标签的资源名称，格式：tags/{tag}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 要合并到目标标签的标签资源名称
+	SourceNames   []string `protobuf:"bytes,2,rep,name=source_names,json=sourceNames,proto3" json:"source_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_api_v1_tag_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_tag_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_tag_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MergeTagsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MergeTagsRequest) GetSourceNames() []string {
+	if x != nil {
+		return x.SourceNames
+	}
+	return nil
+}
+
+// CreateTagAliasRequest 添加标签别名请求
+type CreateTagAliasRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：tags/{tag}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 别名
+	Alias         string `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTagAliasRequest) Reset() {
+	*x = CreateTagAliasRequest{}
+	mi := &file_api_v1_tag_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTagAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTagAliasRequest) ProtoMessage() {}
+
+func (x *CreateTagAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_tag_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTagAliasRequest.ProtoReflect.Descriptor instead.
+func (*CreateTagAliasRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_tag_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateTagAliasRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTagAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+// DeleteTagAliasRequest 删除标签别名请求
+type DeleteTagAliasRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：tags/{tag}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 别名
+	Alias         string `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTagAliasRequest) Reset() {
+	*x = DeleteTagAliasRequest{}
+	mi := &file_api_v1_tag_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTagAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagAliasRequest) ProtoMessage() {}
+
+func (x *DeleteTagAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_tag_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagAliasRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagAliasRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_tag_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteTagAliasRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteTagAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+// SuggestTagsRequest 标签自动补全请求
+type SuggestTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 名称前缀（忽略大小写和全角/半角），为空时返回最常用的标签
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// 返回数量，默认10，最大50
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestTagsRequest) Reset() {
+	*x = SuggestTagsRequest{}
+	mi := &file_api_v1_tag_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestTagsRequest) ProtoMessage() {}
+
+func (x *SuggestTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_tag_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestTagsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_tag_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SuggestTagsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SuggestTagsResponse 标签自动补全响应
+type SuggestTagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 标签列表
+	Tags          []*store.Tag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestTagsResponse) Reset() {
+	*x = SuggestTagsResponse{}
+	mi := &file_api_v1_tag_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestTagsResponse) ProtoMessage() {}
+
+func (x *SuggestTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_tag_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestTagsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestTagsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_tag_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SuggestTagsResponse) GetTags() []*store.Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 var File_api_v1_tag_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_tag_service_proto_rawDesc = "" +
@@ -398,7 +756,26 @@ const file_api_v1_tag_service_proto_rawDesc = "" +
 	"\x10DeleteTagRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\")\n" +
 	"\x13GetTagBySlugRequest\x12\x12\n" +
-	"\x04slug\x18\x01 \x01(\tR\x04slug2\xd6\x02\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"\x14\n" +
+	"\x12RecountTagsRequest\"a\n" +
+	"\x13RecountTagsResponse\x12!\n" +
+	"\fupdated_tags\x18\x01 \x01(\x05R\vupdatedTags\x12'\n" +
+	"\x0fremoved_orphans\x18\x02 \x01(\x05R\x0eremovedOrphans\"I\n" +
+	"\x10MergeTagsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fsource_names\x18\x02 \x03(\tR\vsourceNames\"A\n" +
+	"\x15CreateTagAliasRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\"A\n" +
+	"\x15DeleteTagAliasRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\"B\n" +
+	"\x12SuggestTagsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"5\n" +
+	"\x13SuggestTagsResponse\x12\x1e\n" +
+	"\x04tags\x18\x01 \x03(\v2\n" +
+	".store.TagR\x04tags2\x93\x05\n" +
 	"\n" +
 	"TagService\x12=\n" +
 	"\bListTags\x12\x17.api.v1.ListTagsRequest\x1a\x18.api.v1.ListTagsResponse\x12+\n" +
@@ -410,7 +787,15 @@ const file_api_v1_tag_service_proto_rawDesc = "" +
 	".store.Tag\x12=\n" +
 	"\tDeleteTag\x12\x18.api.v1.DeleteTagRequest\x1a\x16.google.protobuf.Empty\x127\n" +
 	"\fGetTagBySlug\x12\x1b.api.v1.GetTagBySlugRequest\x1a\n" +
-	".store.TagB\x8e\x01\n" +
+	".store.Tag\x12F\n" +
+	"\vRecountTags\x12\x1a.api.v1.RecountTagsRequest\x1a\x1b.api.v1.RecountTagsResponse\x121\n" +
+	"\tMergeTags\x12\x18.api.v1.MergeTagsRequest\x1a\n" +
+	".store.Tag\x12;\n" +
+	"\x0eCreateTagAlias\x12\x1d.api.v1.CreateTagAliasRequest\x1a\n" +
+	".store.Tag\x12;\n" +
+	"\x0eDeleteTagAlias\x12\x1d.api.v1.DeleteTagAliasRequest\x1a\n" +
+	".store.Tag\x12F\n" +
+	"\vSuggestTags\x12\x1a.api.v1.SuggestTagsRequest\x1a\x1b.api.v1.SuggestTagsResponseB\x8e\x01\n" +
 	"\n" +
 	"com.api.v1B\x0fTagServiceProtoP\x01Z6github.com/wdmsyhh/simple-notes/proto/gen/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
 
@@ -426,7 +811,7 @@ func file_api_v1_tag_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_tag_service_proto_rawDescData
 }
 
-var file_api_v1_tag_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_tag_service_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_api_v1_tag_service_proto_goTypes = []any{
 	(*ListTagsRequest)(nil),       // 0: api.v1.ListTagsRequest
 	(*ListTagsResponse)(nil),      // 1: api.v1.ListTagsResponse
@@ -435,32 +820,50 @@ var file_api_v1_tag_service_proto_goTypes = []any{
 	(*UpdateTagRequest)(nil),      // 4: api.v1.UpdateTagRequest
 	(*DeleteTagRequest)(nil),      // 5: api.v1.DeleteTagRequest
 	(*GetTagBySlugRequest)(nil),   // 6: api.v1.GetTagBySlugRequest
-	(*store.Tag)(nil),             // 7: store.Tag
-	(*fieldmaskpb.FieldMask)(nil), // 8: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+	(*RecountTagsRequest)(nil),    // 7: api.v1.RecountTagsRequest
+	(*RecountTagsResponse)(nil),   // 8: api.v1.RecountTagsResponse
+	(*MergeTagsRequest)(nil),      // 9: api.v1.MergeTagsRequest
+	(*CreateTagAliasRequest)(nil), // 10: api.v1.CreateTagAliasRequest
+	(*DeleteTagAliasRequest)(nil), // 11: api.v1.DeleteTagAliasRequest
+	(*SuggestTagsRequest)(nil),    // 12: api.v1.SuggestTagsRequest
+	(*SuggestTagsResponse)(nil),   // 13: api.v1.SuggestTagsResponse
+	(*store.Tag)(nil),             // 14: store.Tag
+	(*fieldmaskpb.FieldMask)(nil), // 15: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),         // 16: google.protobuf.Empty
 }
 var file_api_v1_tag_service_proto_depIdxs = []int32{
-	7,  // 0: api.v1.ListTagsResponse.tags:type_name -> store.Tag
-	7,  // 1: api.v1.CreateTagRequest.tag:type_name -> store.Tag
-	7,  // 2: api.v1.UpdateTagRequest.tag:type_name -> store.Tag
-	8,  // 3: api.v1.UpdateTagRequest.update_mask:type_name -> google.protobuf.FieldMask
-	0,  // 4: api.v1.TagService.ListTags:input_type -> api.v1.ListTagsRequest
-	2,  // 5: api.v1.TagService.GetTag:input_type -> api.v1.GetTagRequest
-	3,  // 6: api.v1.TagService.CreateTag:input_type -> api.v1.CreateTagRequest
-	4,  // 7: api.v1.TagService.UpdateTag:input_type -> api.v1.UpdateTagRequest
-	5,  // 8: api.v1.TagService.DeleteTag:input_type -> api.v1.DeleteTagRequest
-	6,  // 9: api.v1.TagService.GetTagBySlug:input_type -> api.v1.GetTagBySlugRequest
-	1,  // 10: api.v1.TagService.ListTags:output_type -> api.v1.ListTagsResponse
-	7,  // 11: api.v1.TagService.GetTag:output_type -> store.Tag
-	7,  // 12: api.v1.TagService.CreateTag:output_type -> store.Tag
-	7,  // 13: api.v1.TagService.UpdateTag:output_type -> store.Tag
-	9,  // 14: api.v1.TagService.DeleteTag:output_type -> google.protobuf.Empty
-	7,  // 15: api.v1.TagService.GetTagBySlug:output_type -> store.Tag
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	14, // 0: api.v1.ListTagsResponse.tags:type_name -> store.Tag
+	14, // 1: api.v1.CreateTagRequest.tag:type_name -> store.Tag
+	14, // 2: api.v1.UpdateTagRequest.tag:type_name -> store.Tag
+	15, // 3: api.v1.UpdateTagRequest.update_mask:type_name -> google.protobuf.FieldMask
+	14, // 4: api.v1.SuggestTagsResponse.tags:type_name -> store.Tag
+	0,  // 5: api.v1.TagService.ListTags:input_type -> api.v1.ListTagsRequest
+	2,  // 6: api.v1.TagService.GetTag:input_type -> api.v1.GetTagRequest
+	3,  // 7: api.v1.TagService.CreateTag:input_type -> api.v1.CreateTagRequest
+	4,  // 8: api.v1.TagService.UpdateTag:input_type -> api.v1.UpdateTagRequest
+	5,  // 9: api.v1.TagService.DeleteTag:input_type -> api.v1.DeleteTagRequest
+	6,  // 10: api.v1.TagService.GetTagBySlug:input_type -> api.v1.GetTagBySlugRequest
+	7,  // 11: api.v1.TagService.RecountTags:input_type -> api.v1.RecountTagsRequest
+	9,  // 12: api.v1.TagService.MergeTags:input_type -> api.v1.MergeTagsRequest
+	10, // 13: api.v1.TagService.CreateTagAlias:input_type -> api.v1.CreateTagAliasRequest
+	11, // 14: api.v1.TagService.DeleteTagAlias:input_type -> api.v1.DeleteTagAliasRequest
+	12, // 15: api.v1.TagService.SuggestTags:input_type -> api.v1.SuggestTagsRequest
+	1,  // 16: api.v1.TagService.ListTags:output_type -> api.v1.ListTagsResponse
+	14, // 17: api.v1.TagService.GetTag:output_type -> store.Tag
+	14, // 18: api.v1.TagService.CreateTag:output_type -> store.Tag
+	14, // 19: api.v1.TagService.UpdateTag:output_type -> store.Tag
+	16, // 20: api.v1.TagService.DeleteTag:output_type -> google.protobuf.Empty
+	14, // 21: api.v1.TagService.GetTagBySlug:output_type -> store.Tag
+	8,  // 22: api.v1.TagService.RecountTags:output_type -> api.v1.RecountTagsResponse
+	14, // 23: api.v1.TagService.MergeTags:output_type -> store.Tag
+	14, // 24: api.v1.TagService.CreateTagAlias:output_type -> store.Tag
+	14, // 25: api.v1.TagService.DeleteTagAlias:output_type -> store.Tag
+	13, // 26: api.v1.TagService.SuggestTags:output_type -> api.v1.SuggestTagsResponse
+	16, // [16:27] is the sub-list for method output_type
+	5,  // [5:16] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_tag_service_proto_init() }
@@ -474,7 +877,7 @@ func file_api_v1_tag_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_tag_service_proto_rawDesc), len(file_api_v1_tag_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,12 +21,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TagService_ListTags_FullMethodName     = "/api.v1.TagService/ListTags"
-	TagService_GetTag_FullMethodName       = "/api.v1.TagService/GetTag"
-	TagService_CreateTag_FullMethodName    = "/api.v1.TagService/CreateTag"
-	TagService_UpdateTag_FullMethodName    = "/api.v1.TagService/UpdateTag"
-	TagService_DeleteTag_FullMethodName    = "/api.v1.TagService/DeleteTag"
-	TagService_GetTagBySlug_FullMethodName = "/api.v1.TagService/GetTagBySlug"
+	TagService_ListTags_FullMethodName       = "/api.v1.TagService/ListTags"
+	TagService_GetTag_FullMethodName         = "/api.v1.TagService/GetTag"
+	TagService_CreateTag_FullMethodName      = "/api.v1.TagService/CreateTag"
+	TagService_UpdateTag_FullMethodName      = "/api.v1.TagService/UpdateTag"
+	TagService_DeleteTag_FullMethodName      = "/api.v1.TagService/DeleteTag"
+	TagService_GetTagBySlug_FullMethodName   = "/api.v1.TagService/GetTagBySlug"
+	TagService_RecountTags_FullMethodName    = "/api.v1.TagService/RecountTags"
+	TagService_MergeTags_FullMethodName      = "/api.v1.TagService/MergeTags"
+	TagService_CreateTagAlias_FullMethodName = "/api.v1.TagService/CreateTagAlias"
+	TagService_DeleteTagAlias_FullMethodName = "/api.v1.TagService/DeleteTagAlias"
+	TagService_SuggestTags_FullMethodName    = "/api.v1.TagService/SuggestTags"
 )
 
 // TagServiceClient is the client API for TagService service.
@@ -47,6 +52,16 @@ type TagServiceClient interface {
 	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// GetTagBySlug 根据slug返回标签
 	GetTagBySlug(ctx context.Context, in *GetTagBySlugRequest, opts ...grpc.CallOption) (*store.Tag, error)
+	// RecountTags 根据笔记标签关系重新计算所选工作区中标签的使用次数（仅工作区管理员）
+	RecountTags(ctx context.Context, in *RecountTagsRequest, opts ...grpc.CallOption) (*RecountTagsResponse, error)
+	// MergeTags 将多个标签合并到目标标签，来源标签的名称成为目标标签的别名（仅管理员）
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*store.Tag, error)
+	// CreateTagAlias 为标签添加别名（仅管理员）
+	CreateTagAlias(ctx context.Context, in *CreateTagAliasRequest, opts ...grpc.CallOption) (*store.Tag, error)
+	// DeleteTagAlias 删除标签的别名（仅管理员）
+	DeleteTagAlias(ctx context.Context, in *DeleteTagAliasRequest, opts ...grpc.CallOption) (*store.Tag, error)
+	// SuggestTags 返回名称或别名以指定前缀开头的标签，按使用次数排序
+	SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error)
 }
 
 type tagServiceClient struct {
@@ -117,6 +132,56 @@ func (c *tagServiceClient) GetTagBySlug(ctx context.Context, in *GetTagBySlugReq
 	return out, nil
 }
 
+func (c *tagServiceClient) RecountTags(ctx context.Context, in *RecountTagsRequest, opts ...grpc.CallOption) (*RecountTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecountTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_RecountTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*store.Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.Tag)
+	err := c.cc.Invoke(ctx, TagService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) CreateTagAlias(ctx context.Context, in *CreateTagAliasRequest, opts ...grpc.CallOption) (*store.Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.Tag)
+	err := c.cc.Invoke(ctx, TagService_CreateTagAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) DeleteTagAlias(ctx context.Context, in *DeleteTagAliasRequest, opts ...grpc.CallOption) (*store.Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(store.Tag)
+	err := c.cc.Invoke(ctx, TagService_DeleteTagAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_SuggestTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TagServiceServer is the server API for TagService service.
 // All implementations must embed UnimplementedTagServiceServer
 // for forward compatibility.
@@ -135,6 +200,16 @@ type TagServiceServer interface {
 	DeleteTag(context.Context, *DeleteTagRequest) (*emptypb.Empty, error)
 	// GetTagBySlug 根据slug返回标签
 	GetTagBySlug(context.Context, *GetTagBySlugRequest) (*store.Tag, error)
+	// RecountTags 根据笔记标签关系重新计算所选工作区中标签的使用次数（仅工作区管理员）
+	RecountTags(context.Context, *RecountTagsRequest) (*RecountTagsResponse, error)
+	// MergeTags 将多个标签合并到目标标签，来源标签的名称成为目标标签的别名（仅管理员）
+	MergeTags(context.Context, *MergeTagsRequest) (*store.Tag, error)
+	// CreateTagAlias 为标签添加别名（仅管理员）
+	CreateTagAlias(context.Context, *CreateTagAliasRequest) (*store.Tag, error)
+	// DeleteTagAlias 删除标签的别名（仅管理员）
+	DeleteTagAlias(context.Context, *DeleteTagAliasRequest) (*store.Tag, error)
+	// SuggestTags 返回名称或别名以指定前缀开头的标签，按使用次数排序
+	SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error)
 	mustEmbedUnimplementedTagServiceServer()
 }
 
@@ -163,6 +238,21 @@ func (UnimplementedTagServiceServer) DeleteTag(context.Context, *DeleteTagReques
 func (UnimplementedTagServiceServer) GetTagBySlug(context.Context, *GetTagBySlugRequest) (*store.Tag, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTagBySlug not implemented")
 }
+func (UnimplementedTagServiceServer) RecountTags(context.Context, *RecountTagsRequest) (*RecountTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecountTags not implemented")
+}
+func (UnimplementedTagServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*store.Tag, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedTagServiceServer) CreateTagAlias(context.Context, *CreateTagAliasRequest) (*store.Tag, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTagAlias not implemented")
+}
+func (UnimplementedTagServiceServer) DeleteTagAlias(context.Context, *DeleteTagAliasRequest) (*store.Tag, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTagAlias not implemented")
+}
+func (UnimplementedTagServiceServer) SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuggestTags not implemented")
+}
 func (UnimplementedTagServiceServer) mustEmbedUnimplementedTagServiceServer() {}
 func (UnimplementedTagServiceServer) testEmbeddedByValue()                    {}
 
@@ -292,6 +382,96 @@ func _TagService_GetTagBySlug_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TagService_RecountTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecountTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).RecountTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_RecountTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).RecountTags(ctx, req.(*RecountTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_CreateTagAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).CreateTagAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_CreateTagAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).CreateTagAlias(ctx, req.(*CreateTagAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_DeleteTagAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).DeleteTagAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_DeleteTagAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).DeleteTagAlias(ctx, req.(*DeleteTagAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_SuggestTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).SuggestTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_SuggestTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).SuggestTags(ctx, req.(*SuggestTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TagService_ServiceDesc is the grpc.ServiceDesc for TagService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,6 +503,26 @@ var TagService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTagBySlug",
 			Handler:    _TagService_GetTagBySlug_Handler,
 		},
+		{
+			MethodName: "RecountTags",
+			Handler:    _TagService_RecountTags_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _TagService_MergeTags_Handler,
+		},
+		{
+			MethodName: "CreateTagAlias",
+			Handler:    _TagService_CreateTagAlias_Handler,
+		},
+		{
+			MethodName: "DeleteTagAlias",
+			Handler:    _TagService_DeleteTagAlias_Handler,
+		},
+		{
+			MethodName: "SuggestTags",
+			Handler:    _TagService_SuggestTags_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/tag_service.proto",
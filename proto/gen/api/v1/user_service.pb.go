@@ -510,6 +510,107 @@ func (x *ListUsersResponse) GetPageSize() int32 {
 	return 0
 }
 
+// ExportMyDataRequest 导出数据请求
+type ExportMyDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否导出整个实例（所有用户的笔记、附件和页面），仅管理员可用
+	Instance      bool `protobuf:"varint,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportMyDataRequest) GetInstance() bool {
+	if x != nil {
+		return x.Instance
+	}
+	return false
+}
+
+// ExportMyDataResponse 导出数据响应
+type ExportMyDataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 建议的文件名
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// zip 压缩包内容：notes/ 下的 Markdown 文件、attachments/ 下的附件和 manifest.json
+	Content       []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataResponse) Reset() {
+	*x = ExportMyDataResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataResponse) ProtoMessage() {}
+
+func (x *ExportMyDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportMyDataResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportMyDataResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportMyDataResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
 var File_api_v1_user_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_user_service_proto_rawDesc = "" +
@@ -541,7 +642,12 @@ const file_api_v1_user_service_proto_rawDesc = "" +
 	"\x05users\x18\x01 \x03(\v2\v.store.UserR\x05users\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xb0\x03\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"1\n" +
+	"\x13ExportMyDataRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\bR\binstance\"L\n" +
+	"\x14ExportMyDataResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent2\xfb\x03\n" +
 	"\vUserService\x128\n" +
 	"\fRegisterUser\x12\x1b.api.v1.RegisterUserRequest\x1a\v.store.User\x12@\n" +
 	"\tLoginUser\x12\x18.api.v1.LoginUserRequest\x1a\x19.api.v1.LoginUserResponse\x12.\n" +
@@ -551,7 +657,8 @@ const file_api_v1_user_service_proto_rawDesc = "" +
 	"UpdateUser\x12\x19.api.v1.UpdateUserRequest\x1a\v.store.User\x12?\n" +
 	"\n" +
 	"DeleteUser\x12\x19.api.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
-	"\tListUsers\x12\x18.api.v1.ListUsersRequest\x1a\x19.api.v1.ListUsersResponseB\x8f\x01\n" +
+	"\tListUsers\x12\x18.api.v1.ListUsersRequest\x1a\x19.api.v1.ListUsersResponse\x12I\n" +
+	"\fExportMyData\x12\x1b.api.v1.ExportMyDataRequest\x1a\x1c.api.v1.ExportMyDataResponseB\x8f\x01\n" +
 	"\n" +
 	"com.api.v1B\x10UserServiceProtoP\x01Z6github.com/wdmsyhh/simple-notes/proto/gen/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
 
@@ -567,7 +674,7 @@ func file_api_v1_user_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_user_service_proto_rawDescData
 }
 
-var file_api_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_api_v1_user_service_proto_goTypes = []any{
 	(*RegisterUserRequest)(nil),   // 0: api.v1.RegisterUserRequest
 	(*LoginUserRequest)(nil),      // 1: api.v1.LoginUserRequest
@@ -578,16 +685,18 @@ var file_api_v1_user_service_proto_goTypes = []any{
 	(*DeleteUserRequest)(nil),     // 6: api.v1.DeleteUserRequest
 	(*ListUsersRequest)(nil),      // 7: api.v1.ListUsersRequest
 	(*ListUsersResponse)(nil),     // 8: api.v1.ListUsersResponse
-	(*store.User)(nil),            // 9: store.User
-	(*fieldmaskpb.FieldMask)(nil), // 10: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 11: google.protobuf.Empty
+	(*ExportMyDataRequest)(nil),   // 9: api.v1.ExportMyDataRequest
+	(*ExportMyDataResponse)(nil),  // 10: api.v1.ExportMyDataResponse
+	(*store.User)(nil),            // 11: store.User
+	(*fieldmaskpb.FieldMask)(nil), // 12: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),         // 13: google.protobuf.Empty
 }
 var file_api_v1_user_service_proto_depIdxs = []int32{
-	9,  // 0: api.v1.RegisterUserRequest.user:type_name -> store.User
-	9,  // 1: api.v1.LoginUserResponse.user:type_name -> store.User
-	9,  // 2: api.v1.UpdateUserRequest.user:type_name -> store.User
-	10, // 3: api.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
-	9,  // 4: api.v1.ListUsersResponse.users:type_name -> store.User
+	11, // 0: api.v1.RegisterUserRequest.user:type_name -> store.User
+	11, // 1: api.v1.LoginUserResponse.user:type_name -> store.User
+	11, // 2: api.v1.UpdateUserRequest.user:type_name -> store.User
+	12, // 3: api.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	11, // 4: api.v1.ListUsersResponse.users:type_name -> store.User
 	0,  // 5: api.v1.UserService.RegisterUser:input_type -> api.v1.RegisterUserRequest
 	1,  // 6: api.v1.UserService.LoginUser:input_type -> api.v1.LoginUserRequest
 	3,  // 7: api.v1.UserService.GetUser:input_type -> api.v1.GetUserRequest
@@ -595,15 +704,17 @@ var file_api_v1_user_service_proto_depIdxs = []int32{
 	5,  // 9: api.v1.UserService.UpdateUser:input_type -> api.v1.UpdateUserRequest
 	6,  // 10: api.v1.UserService.DeleteUser:input_type -> api.v1.DeleteUserRequest
 	7,  // 11: api.v1.UserService.ListUsers:input_type -> api.v1.ListUsersRequest
-	9,  // 12: api.v1.UserService.RegisterUser:output_type -> store.User
-	2,  // 13: api.v1.UserService.LoginUser:output_type -> api.v1.LoginUserResponse
-	9,  // 14: api.v1.UserService.GetUser:output_type -> store.User
-	9,  // 15: api.v1.UserService.GetCurrentUser:output_type -> store.User
-	9,  // 16: api.v1.UserService.UpdateUser:output_type -> store.User
-	11, // 17: api.v1.UserService.DeleteUser:output_type -> google.protobuf.Empty
-	8,  // 18: api.v1.UserService.ListUsers:output_type -> api.v1.ListUsersResponse
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
+	9,  // 12: api.v1.UserService.ExportMyData:input_type -> api.v1.ExportMyDataRequest
+	11, // 13: api.v1.UserService.RegisterUser:output_type -> store.User
+	2,  // 14: api.v1.UserService.LoginUser:output_type -> api.v1.LoginUserResponse
+	11, // 15: api.v1.UserService.GetUser:output_type -> store.User
+	11, // 16: api.v1.UserService.GetCurrentUser:output_type -> store.User
+	11, // 17: api.v1.UserService.UpdateUser:output_type -> store.User
+	13, // 18: api.v1.UserService.DeleteUser:output_type -> google.protobuf.Empty
+	8,  // 19: api.v1.UserService.ListUsers:output_type -> api.v1.ListUsersResponse
+	10, // 20: api.v1.UserService.ExportMyData:output_type -> api.v1.ExportMyDataResponse
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
 	5,  // [5:5] is the sub-list for extension type_name
 	5,  // [5:5] is the sub-list for extension extendee
 	0,  // [0:5] is the sub-list for field type_name
@@ -620,7 +731,7 @@ func file_api_v1_user_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_user_service_proto_rawDesc), len(file_api_v1_user_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,629 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/workspace_service.proto
+
+package apiv1
+
+import (
+	store "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListWorkspacesRequest 列出工作区请求
+type ListWorkspacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesRequest) Reset() {
+	*x = ListWorkspacesRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesRequest) ProtoMessage() {}
+
+func (x *ListWorkspacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{0}
+}
+
+// ListWorkspacesResponse 列出工作区响应
+type ListWorkspacesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 工作区列表，按创建时间升序
+	Workspaces    []*store.Workspace `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListWorkspacesResponse) GetWorkspaces() []*store.Workspace {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+// GetWorkspaceRequest 获取工作区请求
+type GetWorkspaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：workspaces/{slug}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkspaceRequest) Reset() {
+	*x = GetWorkspaceRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkspaceRequest) ProtoMessage() {}
+
+func (x *GetWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// CreateWorkspaceRequest 创建工作区请求
+type CreateWorkspaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 要创建的工作区，slug 和 name_text 必填
+	Workspace     *store.Workspace `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateWorkspaceRequest) GetWorkspace() *store.Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+// UpdateWorkspaceRequest 更新工作区请求
+type UpdateWorkspaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 要更新的工作区
+	Workspace *store.Workspace `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	// 字段掩码，指定要更新的字段（name_text、description），为空时更新全部字段
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWorkspaceRequest) Reset() {
+	*x = UpdateWorkspaceRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWorkspaceRequest) ProtoMessage() {}
+
+func (x *UpdateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateWorkspaceRequest) GetWorkspace() *store.Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+func (x *UpdateWorkspaceRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// DeleteWorkspaceRequest 删除工作区请求
+type DeleteWorkspaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：workspaces/{slug}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWorkspaceRequest) Reset() {
+	*x = DeleteWorkspaceRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkspaceRequest) ProtoMessage() {}
+
+func (x *DeleteWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListWorkspaceMembersRequest 列出工作区成员请求
+type ListWorkspaceMembersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 工作区，格式：workspaces/{slug}
+	Parent        string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceMembersRequest) Reset() {
+	*x = ListWorkspaceMembersRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceMembersRequest) ProtoMessage() {}
+
+func (x *ListWorkspaceMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListWorkspaceMembersRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+// ListWorkspaceMembersResponse 列出工作区成员响应
+type ListWorkspaceMembersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 成员列表，按用户ID排序
+	Members       []*store.WorkspaceMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceMembersResponse) Reset() {
+	*x = ListWorkspaceMembersResponse{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceMembersResponse) ProtoMessage() {}
+
+func (x *ListWorkspaceMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListWorkspaceMembersResponse) GetMembers() []*store.WorkspaceMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// SetWorkspaceMemberRequest 设置工作区成员请求
+type SetWorkspaceMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 工作区，格式：workspaces/{slug}
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// 用户，格式：users/{user}
+	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// 角色
+	Role          store.WorkspaceRole `protobuf:"varint,3,opt,name=role,proto3,enum=store.WorkspaceRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkspaceMemberRequest) Reset() {
+	*x = SetWorkspaceMemberRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkspaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkspaceMemberRequest) ProtoMessage() {}
+
+func (x *SetWorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetWorkspaceMemberRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *SetWorkspaceMemberRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *SetWorkspaceMemberRequest) GetRole() store.WorkspaceRole {
+	if x != nil {
+		return x.Role
+	}
+	return store.WorkspaceRole(0)
+}
+
+// RemoveWorkspaceMemberRequest 移除工作区成员请求
+type RemoveWorkspaceMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：workspaces/{slug}/members/{user}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWorkspaceMemberRequest) Reset() {
+	*x = RemoveWorkspaceMemberRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWorkspaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWorkspaceMemberRequest) ProtoMessage() {}
+
+func (x *RemoveWorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoveWorkspaceMemberRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_api_v1_workspace_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_workspace_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/v1/workspace_service.proto\x12\x06api.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x10store/note.proto\"\x17\n" +
+	"\x15ListWorkspacesRequest\"J\n" +
+	"\x16ListWorkspacesResponse\x120\n" +
+	"\n" +
+	"workspaces\x18\x01 \x03(\v2\x10.store.WorkspaceR\n" +
+	"workspaces\")\n" +
+	"\x13GetWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"H\n" +
+	"\x16CreateWorkspaceRequest\x12.\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x10.store.WorkspaceR\tworkspace\"\x85\x01\n" +
+	"\x16UpdateWorkspaceRequest\x12.\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x10.store.WorkspaceR\tworkspace\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\",\n" +
+	"\x16DeleteWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"5\n" +
+	"\x1bListWorkspaceMembersRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\"P\n" +
+	"\x1cListWorkspaceMembersResponse\x120\n" +
+	"\amembers\x18\x01 \x03(\v2\x16.store.WorkspaceMemberR\amembers\"q\n" +
+	"\x19SetWorkspaceMemberRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12(\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x14.store.WorkspaceRoleR\x04role\"2\n" +
+	"\x1cRemoveWorkspaceMemberRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name2\x82\x05\n" +
+	"\x10WorkspaceService\x12O\n" +
+	"\x0eListWorkspaces\x12\x1d.api.v1.ListWorkspacesRequest\x1a\x1e.api.v1.ListWorkspacesResponse\x12=\n" +
+	"\fGetWorkspace\x12\x1b.api.v1.GetWorkspaceRequest\x1a\x10.store.Workspace\x12C\n" +
+	"\x0fCreateWorkspace\x12\x1e.api.v1.CreateWorkspaceRequest\x1a\x10.store.Workspace\x12C\n" +
+	"\x0fUpdateWorkspace\x12\x1e.api.v1.UpdateWorkspaceRequest\x1a\x10.store.Workspace\x12I\n" +
+	"\x0fDeleteWorkspace\x12\x1e.api.v1.DeleteWorkspaceRequest\x1a\x16.google.protobuf.Empty\x12a\n" +
+	"\x14ListWorkspaceMembers\x12#.api.v1.ListWorkspaceMembersRequest\x1a$.api.v1.ListWorkspaceMembersResponse\x12O\n" +
+	"\x12SetWorkspaceMember\x12!.api.v1.SetWorkspaceMemberRequest\x1a\x16.store.WorkspaceMember\x12U\n" +
+	"\x15RemoveWorkspaceMember\x12$.api.v1.RemoveWorkspaceMemberRequest\x1a\x16.google.protobuf.EmptyB\x94\x01\n" +
+	"\n" +
+	"com.api.v1B\x15WorkspaceServiceProtoP\x01Z6github.com/wdmsyhh/simple-notes/proto/gen/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
+
+var (
+	file_api_v1_workspace_service_proto_rawDescOnce sync.Once
+	file_api_v1_workspace_service_proto_rawDescData []byte
+)
+
+func file_api_v1_workspace_service_proto_rawDescGZIP() []byte {
+	file_api_v1_workspace_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_workspace_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_workspace_service_proto_rawDesc), len(file_api_v1_workspace_service_proto_rawDesc)))
+	})
+	return file_api_v1_workspace_service_proto_rawDescData
+}
+
+var file_api_v1_workspace_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_v1_workspace_service_proto_goTypes = []any{
+	(*ListWorkspacesRequest)(nil),        // 0: api.v1.ListWorkspacesRequest
+	(*ListWorkspacesResponse)(nil),       // 1: api.v1.ListWorkspacesResponse
+	(*GetWorkspaceRequest)(nil),          // 2: api.v1.GetWorkspaceRequest
+	(*CreateWorkspaceRequest)(nil),       // 3: api.v1.CreateWorkspaceRequest
+	(*UpdateWorkspaceRequest)(nil),       // 4: api.v1.UpdateWorkspaceRequest
+	(*DeleteWorkspaceRequest)(nil),       // 5: api.v1.DeleteWorkspaceRequest
+	(*ListWorkspaceMembersRequest)(nil),  // 6: api.v1.ListWorkspaceMembersRequest
+	(*ListWorkspaceMembersResponse)(nil), // 7: api.v1.ListWorkspaceMembersResponse
+	(*SetWorkspaceMemberRequest)(nil),    // 8: api.v1.SetWorkspaceMemberRequest
+	(*RemoveWorkspaceMemberRequest)(nil), // 9: api.v1.RemoveWorkspaceMemberRequest
+	(*store.Workspace)(nil),              // 10: store.Workspace
+	(*fieldmaskpb.FieldMask)(nil),        // 11: google.protobuf.FieldMask
+	(*store.WorkspaceMember)(nil),        // 12: store.WorkspaceMember
+	(store.WorkspaceRole)(0),             // 13: store.WorkspaceRole
+	(*emptypb.Empty)(nil),                // 14: google.protobuf.Empty
+}
+var file_api_v1_workspace_service_proto_depIdxs = []int32{
+	10, // 0: api.v1.ListWorkspacesResponse.workspaces:type_name -> store.Workspace
+	10, // 1: api.v1.CreateWorkspaceRequest.workspace:type_name -> store.Workspace
+	10, // 2: api.v1.UpdateWorkspaceRequest.workspace:type_name -> store.Workspace
+	11, // 3: api.v1.UpdateWorkspaceRequest.update_mask:type_name -> google.protobuf.FieldMask
+	12, // 4: api.v1.ListWorkspaceMembersResponse.members:type_name -> store.WorkspaceMember
+	13, // 5: api.v1.SetWorkspaceMemberRequest.role:type_name -> store.WorkspaceRole
+	0,  // 6: api.v1.WorkspaceService.ListWorkspaces:input_type -> api.v1.ListWorkspacesRequest
+	2,  // 7: api.v1.WorkspaceService.GetWorkspace:input_type -> api.v1.GetWorkspaceRequest
+	3,  // 8: api.v1.WorkspaceService.CreateWorkspace:input_type -> api.v1.CreateWorkspaceRequest
+	4,  // 9: api.v1.WorkspaceService.UpdateWorkspace:input_type -> api.v1.UpdateWorkspaceRequest
+	5,  // 10: api.v1.WorkspaceService.DeleteWorkspace:input_type -> api.v1.DeleteWorkspaceRequest
+	6,  // 11: api.v1.WorkspaceService.ListWorkspaceMembers:input_type -> api.v1.ListWorkspaceMembersRequest
+	8,  // 12: api.v1.WorkspaceService.SetWorkspaceMember:input_type -> api.v1.SetWorkspaceMemberRequest
+	9,  // 13: api.v1.WorkspaceService.RemoveWorkspaceMember:input_type -> api.v1.RemoveWorkspaceMemberRequest
+	1,  // 14: api.v1.WorkspaceService.ListWorkspaces:output_type -> api.v1.ListWorkspacesResponse
+	10, // 15: api.v1.WorkspaceService.GetWorkspace:output_type -> store.Workspace
+	10, // 16: api.v1.WorkspaceService.CreateWorkspace:output_type -> store.Workspace
+	10, // 17: api.v1.WorkspaceService.UpdateWorkspace:output_type -> store.Workspace
+	14, // 18: api.v1.WorkspaceService.DeleteWorkspace:output_type -> google.protobuf.Empty
+	7,  // 19: api.v1.WorkspaceService.ListWorkspaceMembers:output_type -> api.v1.ListWorkspaceMembersResponse
+	12, // 20: api.v1.WorkspaceService.SetWorkspaceMember:output_type -> store.WorkspaceMember
+	14, // 21: api.v1.WorkspaceService.RemoveWorkspaceMember:output_type -> google.protobuf.Empty
+	14, // [14:22] is the sub-list for method output_type
+	6,  // [6:14] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_workspace_service_proto_init() }
+func file_api_v1_workspace_service_proto_init() {
+	if File_api_v1_workspace_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_workspace_service_proto_rawDesc), len(file_api_v1_workspace_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_workspace_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_workspace_service_proto_depIdxs,
+		MessageInfos:      file_api_v1_workspace_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_workspace_service_proto = out.File
+	file_api_v1_workspace_service_proto_goTypes = nil
+	file_api_v1_workspace_service_proto_depIdxs = nil
+}
@@ -74,6 +74,63 @@ func (NoteVisibility) EnumDescriptor() ([]byte, []int) {
 	return file_store_note_proto_rawDescGZIP(), []int{0}
 }
 
+// NotePermissionRole 笔记协作者角色枚举，数值越大权限越多
+type NotePermissionRole int32
+
+const (
+	// 未指定
+	NotePermissionRole_NOTE_PERMISSION_ROLE_UNSPECIFIED NotePermissionRole = 0
+	// 查看者：可以查看笔记（包括私有和未发布的笔记）及其附件和评论
+	NotePermissionRole_NOTE_PERMISSION_ROLE_VIEWER NotePermissionRole = 1
+	// 评论者：在查看者的基础上可以发表评论
+	NotePermissionRole_NOTE_PERMISSION_ROLE_COMMENTER NotePermissionRole = 2
+	// 编辑者：在评论者的基础上可以编辑笔记、草稿和附件，以及管理评论
+	NotePermissionRole_NOTE_PERMISSION_ROLE_EDITOR NotePermissionRole = 3
+)
+
+// Enum value maps for NotePermissionRole.
+var (
+	NotePermissionRole_name = map[int32]string{
+		0: "NOTE_PERMISSION_ROLE_UNSPECIFIED",
+		1: "NOTE_PERMISSION_ROLE_VIEWER",
+		2: "NOTE_PERMISSION_ROLE_COMMENTER",
+		3: "NOTE_PERMISSION_ROLE_EDITOR",
+	}
+	NotePermissionRole_value = map[string]int32{
+		"NOTE_PERMISSION_ROLE_UNSPECIFIED": 0,
+		"NOTE_PERMISSION_ROLE_VIEWER":      1,
+		"NOTE_PERMISSION_ROLE_COMMENTER":   2,
+		"NOTE_PERMISSION_ROLE_EDITOR":      3,
+	}
+)
+
+func (x NotePermissionRole) Enum() *NotePermissionRole {
+	p := new(NotePermissionRole)
+	*p = x
+	return p
+}
+
+func (x NotePermissionRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotePermissionRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_note_proto_enumTypes[1].Descriptor()
+}
+
+func (NotePermissionRole) Type() protoreflect.EnumType {
+	return &file_store_note_proto_enumTypes[1]
+}
+
+func (x NotePermissionRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotePermissionRole.Descriptor instead.
+func (NotePermissionRole) EnumDescriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{1}
+}
+
 // UserRole 用户角色枚举
 type UserRole int32
 
@@ -115,11 +172,11 @@ func (x UserRole) String() string {
 }
 
 func (UserRole) Descriptor() protoreflect.EnumDescriptor {
-	return file_store_note_proto_enumTypes[1].Descriptor()
+	return file_store_note_proto_enumTypes[2].Descriptor()
 }
 
 func (UserRole) Type() protoreflect.EnumType {
-	return &file_store_note_proto_enumTypes[1]
+	return &file_store_note_proto_enumTypes[2]
 }
 
 func (x UserRole) Number() protoreflect.EnumNumber {
@@ -128,7 +185,68 @@ func (x UserRole) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use UserRole.Descriptor instead.
 func (UserRole) EnumDescriptor() ([]byte, []int) {
-	return file_store_note_proto_rawDescGZIP(), []int{1}
+	return file_store_note_proto_rawDescGZIP(), []int{2}
+}
+
+// WorkspaceRole 工作区成员角色枚举，数值越大权限越多
+type WorkspaceRole int32
+
+const (
+	// 未指定（不是成员）
+	WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED WorkspaceRole = 0
+	// 查看者：可以查看工作区中的笔记，不能创建内容
+	WorkspaceRole_WORKSPACE_ROLE_VIEWER WorkspaceRole = 1
+	// 成员：可以创建笔记、分类、标签和附件，管理自己的笔记
+	WorkspaceRole_WORKSPACE_ROLE_MEMBER WorkspaceRole = 2
+	// 管理员：可以管理工作区中的全部内容
+	WorkspaceRole_WORKSPACE_ROLE_ADMIN WorkspaceRole = 3
+	// 所有者：在管理员的基础上可以修改和删除工作区、管理成员
+	WorkspaceRole_WORKSPACE_ROLE_OWNER WorkspaceRole = 4
+)
+
+// Enum value maps for WorkspaceRole.
+var (
+	WorkspaceRole_name = map[int32]string{
+		0: "WORKSPACE_ROLE_UNSPECIFIED",
+		1: "WORKSPACE_ROLE_VIEWER",
+		2: "WORKSPACE_ROLE_MEMBER",
+		3: "WORKSPACE_ROLE_ADMIN",
+		4: "WORKSPACE_ROLE_OWNER",
+	}
+	WorkspaceRole_value = map[string]int32{
+		"WORKSPACE_ROLE_UNSPECIFIED": 0,
+		"WORKSPACE_ROLE_VIEWER":      1,
+		"WORKSPACE_ROLE_MEMBER":      2,
+		"WORKSPACE_ROLE_ADMIN":       3,
+		"WORKSPACE_ROLE_OWNER":       4,
+	}
+)
+
+func (x WorkspaceRole) Enum() *WorkspaceRole {
+	p := new(WorkspaceRole)
+	*p = x
+	return p
+}
+
+func (x WorkspaceRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_note_proto_enumTypes[3].Descriptor()
+}
+
+func (WorkspaceRole) Type() protoreflect.EnumType {
+	return &file_store_note_proto_enumTypes[3]
+}
+
+func (x WorkspaceRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceRole.Descriptor instead.
+func (WorkspaceRole) EnumDescriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{3}
 }
 
 // Note 笔记消息
@@ -167,7 +285,17 @@ type Note struct {
 	// 浏览次数
 	ViewCount int32 `protobuf:"varint,16,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
 	// 可见性
-	Visibility    NoteVisibility `protobuf:"varint,17,opt,name=visibility,proto3,enum=store.NoteVisibility" json:"visibility,omitempty"`
+	Visibility NoteVisibility `protobuf:"varint,17,opt,name=visibility,proto3,enum=store.NoteVisibility" json:"visibility,omitempty"`
+	// 服务端渲染的 HTML（仅在请求时返回，不存储）
+	RenderedHtml string `protobuf:"bytes,18,opt,name=rendered_html,json=renderedHtml,proto3" json:"rendered_html,omitempty"`
+	// 字数（中日文字按字计数，其余按单词计数），保存时由服务端计算
+	WordCount int32 `protobuf:"varint,19,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	// 字符数（不含空白），保存时由服务端计算
+	CharCount int32 `protobuf:"varint,20,opt,name=char_count,json=charCount,proto3" json:"char_count,omitempty"`
+	// 是否等待定时发布：发布时 published_at 在未来的笔记先不发布，到达 published_at 时自动发布
+	Scheduled bool `protobuf:"varint,21,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// 过期时间（Unix时间戳，秒，0 表示不过期），到达后自动取消发布
+	ExpireAt      int64 `protobuf:"varint,22,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -321,47 +449,87 @@ func (x *Note) GetVisibility() NoteVisibility {
 	return NoteVisibility_NOTE_VISIBILITY_UNSPECIFIED
 }
 
-// Category 分类消息
-type Category struct {
+func (x *Note) GetRenderedHtml() string {
+	if x != nil {
+		return x.RenderedHtml
+	}
+	return ""
+}
+
+func (x *Note) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *Note) GetCharCount() int32 {
+	if x != nil {
+		return x.CharCount
+	}
+	return 0
+}
+
+func (x *Note) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *Note) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+// NoteDraft 笔记草稿消息，每个用户对每篇笔记有一份工作草稿
+// 自动保存只写入草稿，发布草稿后才会更新笔记的已发布内容
+type NoteDraft struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 资源名称，格式：categories/{category}
+	// 资源名称，格式：notes/{note}/draft
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 笔记ID
+	NoteId int64 `protobuf:"varint,2,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// 编辑者，格式：users/{user}（输出字段）
+	Editor string `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	// 标题
+	Title string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	// 内容
+	Content string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	// 摘要
+	Summary string `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`
 	// 分类ID
-	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	// 分类名称
-	NameText string `protobuf:"bytes,3,opt,name=name_text,json=nameText,proto3" json:"name_text,omitempty"`
-	// URL友好的标识符
-	Slug string `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
-	// 描述
-	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	// 父分类ID
-	ParentId int64 `protobuf:"varint,6,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	// 排序顺序
-	Order int32 `protobuf:"varint,7,opt,name=order,proto3" json:"order,omitempty"`
-	// 是否可见
-	Visible bool `protobuf:"varint,8,opt,name=visible,proto3" json:"visible,omitempty"`
+	CategoryId string `protobuf:"bytes,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// 标签ID列表
+	TagIds []string `protobuf:"bytes,8,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	// 封面图片URL
+	CoverImage string `protobuf:"bytes,9,opt,name=cover_image,json=coverImage,proto3" json:"cover_image,omitempty"`
+	// 可见性
+	Visibility NoteVisibility `protobuf:"varint,10,opt,name=visibility,proto3,enum=store.NoteVisibility" json:"visibility,omitempty"`
 	// 创建时间（Unix时间戳，秒）
-	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// 更新时间（Unix时间戳，秒）
-	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedAt int64 `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 最后自动保存时间（Unix时间戳，秒）
+	UpdatedAt     int64 `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Category) Reset() {
-	*x = Category{}
+func (x *NoteDraft) Reset() {
+	*x = NoteDraft{}
 	mi := &file_store_note_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Category) String() string {
+func (x *NoteDraft) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Category) ProtoMessage() {}
+func (*NoteDraft) ProtoMessage() {}
 
-func (x *Category) ProtoReflect() protoreflect.Message {
+func (x *NoteDraft) ProtoReflect() protoreflect.Message {
 	mi := &file_store_note_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,118 +541,132 @@ func (x *Category) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Category.ProtoReflect.Descriptor instead.
-func (*Category) Descriptor() ([]byte, []int) {
+// Deprecated: Use NoteDraft.ProtoReflect.Descriptor instead.
+func (*NoteDraft) Descriptor() ([]byte, []int) {
 	return file_store_note_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Category) GetName() string {
+func (x *NoteDraft) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Category) GetId() int64 {
+func (x *NoteDraft) GetNoteId() int64 {
 	if x != nil {
-		return x.Id
+		return x.NoteId
 	}
 	return 0
 }
 
-func (x *Category) GetNameText() string {
+func (x *NoteDraft) GetEditor() string {
 	if x != nil {
-		return x.NameText
+		return x.Editor
 	}
 	return ""
 }
 
-func (x *Category) GetSlug() string {
+func (x *NoteDraft) GetTitle() string {
 	if x != nil {
-		return x.Slug
+		return x.Title
 	}
 	return ""
 }
 
-func (x *Category) GetDescription() string {
+func (x *NoteDraft) GetContent() string {
 	if x != nil {
-		return x.Description
+		return x.Content
 	}
 	return ""
 }
 
-func (x *Category) GetParentId() int64 {
+func (x *NoteDraft) GetSummary() string {
 	if x != nil {
-		return x.ParentId
+		return x.Summary
 	}
-	return 0
+	return ""
 }
 
-func (x *Category) GetOrder() int32 {
+func (x *NoteDraft) GetCategoryId() string {
 	if x != nil {
-		return x.Order
+		return x.CategoryId
 	}
-	return 0
+	return ""
 }
 
-func (x *Category) GetVisible() bool {
+func (x *NoteDraft) GetTagIds() []string {
 	if x != nil {
-		return x.Visible
+		return x.TagIds
 	}
-	return false
+	return nil
 }
 
-func (x *Category) GetCreatedAt() int64 {
+func (x *NoteDraft) GetCoverImage() string {
+	if x != nil {
+		return x.CoverImage
+	}
+	return ""
+}
+
+func (x *NoteDraft) GetVisibility() NoteVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return NoteVisibility_NOTE_VISIBILITY_UNSPECIFIED
+}
+
+func (x *NoteDraft) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *Category) GetUpdatedAt() int64 {
+func (x *NoteDraft) GetUpdatedAt() int64 {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return 0
 }
 
-// Tag 标签消息
-type Tag struct {
+// NoteRevision 笔记修订记录消息，每次发布草稿时记录发布的内容
+type NoteRevision struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 资源名称，格式：tags/{tag}
+	// 资源名称，格式：notes/{note}/revisions/{revision}
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// 标签ID
+	// 修订ID
 	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	// 标签名称
-	NameText string `protobuf:"bytes,3,opt,name=name_text,json=nameText,proto3" json:"name_text,omitempty"`
-	// URL友好的标识符
-	Slug string `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
-	// 描述
-	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	// 使用次数
-	Count int32 `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
-	// 创建时间（Unix时间戳，秒）
-	CreatedAt int64 `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// 更新时间（Unix时间戳，秒）
-	UpdatedAt     int64 `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 笔记ID
+	NoteId int64 `protobuf:"varint,3,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// 发布者，格式：users/{user}
+	Author string `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	// 标题
+	Title string `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	// 内容
+	Content string `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	// 摘要
+	Summary string `protobuf:"bytes,7,opt,name=summary,proto3" json:"summary,omitempty"`
+	// 发布时间（Unix时间戳，秒）
+	CreatedAt     int64 `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Tag) Reset() {
-	*x = Tag{}
+func (x *NoteRevision) Reset() {
+	*x = NoteRevision{}
 	mi := &file_store_note_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Tag) String() string {
+func (x *NoteRevision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tag) ProtoMessage() {}
+func (*NoteRevision) ProtoMessage() {}
 
-func (x *Tag) ProtoReflect() protoreflect.Message {
+func (x *NoteRevision) ProtoReflect() protoreflect.Message {
 	mi := &file_store_note_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -496,108 +678,98 @@ func (x *Tag) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
-func (*Tag) Descriptor() ([]byte, []int) {
+// Deprecated: Use NoteRevision.ProtoReflect.Descriptor instead.
+func (*NoteRevision) Descriptor() ([]byte, []int) {
 	return file_store_note_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Tag) GetName() string {
+func (x *NoteRevision) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Tag) GetId() int64 {
+func (x *NoteRevision) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Tag) GetNameText() string {
+func (x *NoteRevision) GetNoteId() int64 {
 	if x != nil {
-		return x.NameText
+		return x.NoteId
 	}
-	return ""
+	return 0
 }
 
-func (x *Tag) GetSlug() string {
+func (x *NoteRevision) GetAuthor() string {
 	if x != nil {
-		return x.Slug
+		return x.Author
 	}
 	return ""
 }
 
-func (x *Tag) GetDescription() string {
+func (x *NoteRevision) GetTitle() string {
 	if x != nil {
-		return x.Description
+		return x.Title
 	}
 	return ""
 }
 
-func (x *Tag) GetCount() int32 {
+func (x *NoteRevision) GetContent() string {
 	if x != nil {
-		return x.Count
+		return x.Content
 	}
-	return 0
+	return ""
 }
 
-func (x *Tag) GetCreatedAt() int64 {
+func (x *NoteRevision) GetSummary() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Summary
 	}
-	return 0
+	return ""
 }
 
-func (x *Tag) GetUpdatedAt() int64 {
+func (x *NoteRevision) GetCreatedAt() int64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CreatedAt
 	}
 	return 0
 }
 
-// User 用户消息
-type User struct {
+// NoteLink 笔记链接消息，表示笔记内容中的 [[链接]]
+type NoteLink struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 资源名称，格式：users/{user}
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// 用户ID
-	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	// 用户名
-	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	// 密码哈希
-	PasswordHash string `protobuf:"bytes,5,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
-	// 昵称
-	Nickname string `protobuf:"bytes,6,opt,name=nickname,proto3" json:"nickname,omitempty"`
-	// 头像URL
-	Avatar string `protobuf:"bytes,7,opt,name=avatar,proto3" json:"avatar,omitempty"`
-	// 个人简介
-	Bio string `protobuf:"bytes,8,opt,name=bio,proto3" json:"bio,omitempty"`
-	// 用户角色
-	Role UserRole `protobuf:"varint,9,opt,name=role,proto3,enum=store.UserRole" json:"role,omitempty"`
-	// 创建时间（Unix时间戳，秒）
-	CreatedAt int64 `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// 更新时间（Unix时间戳，秒）
-	UpdatedAt     int64 `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 链接所在的笔记，格式：notes/{note}
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// 链接所在笔记的标题
+	SourceTitle string `protobuf:"bytes,2,opt,name=source_title,json=sourceTitle,proto3" json:"source_title,omitempty"`
+	// 链接所在笔记的作者，格式：users/{user}
+	SourceAuthor string `protobuf:"bytes,3,opt,name=source_author,json=sourceAuthor,proto3" json:"source_author,omitempty"`
+	// 链接中书写的目标（笔记标题或 notes/{note}）
+	Target string `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	// 链接显示的文本，可选
+	Alias         string `protobuf:"bytes,5,opt,name=alias,proto3" json:"alias,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
+func (x *NoteLink) Reset() {
+	*x = NoteLink{}
 	mi := &file_store_note_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *NoteLink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*NoteLink) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
+func (x *NoteLink) ProtoReflect() protoreflect.Message {
 	mi := &file_store_note_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -609,123 +781,1096 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
+// Deprecated: Use NoteLink.ProtoReflect.Descriptor instead.
+func (*NoteLink) Descriptor() ([]byte, []int) {
 	return file_store_note_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *User) GetName() string {
+func (x *NoteLink) GetSource() string {
 	if x != nil {
-		return x.Name
+		return x.Source
 	}
 	return ""
 }
 
-func (x *User) GetId() int64 {
+func (x *NoteLink) GetSourceTitle() string {
 	if x != nil {
-		return x.Id
+		return x.SourceTitle
 	}
-	return 0
+	return ""
 }
 
-func (x *User) GetUsername() string {
+func (x *NoteLink) GetSourceAuthor() string {
 	if x != nil {
-		return x.Username
+		return x.SourceAuthor
 	}
 	return ""
 }
 
-func (x *User) GetPasswordHash() string {
+func (x *NoteLink) GetTarget() string {
 	if x != nil {
-		return x.PasswordHash
+		return x.Target
 	}
 	return ""
 }
 
-func (x *User) GetNickname() string {
+func (x *NoteLink) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+// ShareLink 笔记分享链接消息，持有令牌的访问者无需登录即可查看私密或未发布的笔记
+// 令牌只在创建时返回，数据库中只保存令牌的哈希
+type ShareLink struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}/shareLinks/{share_link}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 分享链接ID
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 笔记ID
+	NoteId int64 `protobuf:"varint,3,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// 创建者，格式：users/{user}
+	Creator string `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
+	// 分享令牌，只在创建时返回
+	Token string `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	// 分享地址（相对路径），只在创建时返回
+	Url string `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	// 是否需要访问密码
+	HasPassword bool `protobuf:"varint,7,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	// 过期时间（Unix时间戳，秒），0 表示永不过期
+	ExpireAt int64 `protobuf:"varint,8,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	// 最多查看次数，0 表示不限制
+	MaxViews int32 `protobuf:"varint,9,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+	// 已查看次数
+	ViewCount int32 `protobuf:"varint,10,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	// 是否已撤销
+	Revoked bool `protobuf:"varint,11,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt     int64 `protobuf:"varint,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_store_note_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ShareLink) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShareLink) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShareLink) GetNoteId() int64 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *ShareLink) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *ShareLink) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ShareLink) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ShareLink) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *ShareLink) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *ShareLink) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+func (x *ShareLink) GetViewCount() int32 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *ShareLink) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+func (x *ShareLink) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// NotePermission 笔记权限消息，授予用户对笔记或分类的协作者角色
+// 分类上的权限由该分类及其子分类中的笔记继承
+type NotePermission struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：notes/{note}/permissions/{user} 或 categories/{category}/permissions/{user}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 授权的资源，格式：notes/{note} 或 categories/{category}
+	Resource string `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	// 被授权的用户，格式：users/{user}
+	User string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// 角色
+	Role NotePermissionRole `protobuf:"varint,4,opt,name=role,proto3,enum=store.NotePermissionRole" json:"role,omitempty"`
+	// 授权者，格式：users/{user}
+	GrantedBy string `protobuf:"bytes,5,opt,name=granted_by,json=grantedBy,proto3" json:"granted_by,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt int64 `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新时间（Unix时间戳，秒）
+	UpdatedAt     int64 `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotePermission) Reset() {
+	*x = NotePermission{}
+	mi := &file_store_note_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotePermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotePermission) ProtoMessage() {}
+
+func (x *NotePermission) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotePermission.ProtoReflect.Descriptor instead.
+func (*NotePermission) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NotePermission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NotePermission) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *NotePermission) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *NotePermission) GetRole() NotePermissionRole {
+	if x != nil {
+		return x.Role
+	}
+	return NotePermissionRole_NOTE_PERMISSION_ROLE_UNSPECIFIED
+}
+
+func (x *NotePermission) GetGrantedBy() string {
+	if x != nil {
+		return x.GrantedBy
+	}
+	return ""
+}
+
+func (x *NotePermission) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *NotePermission) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Category 分类消息
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：categories/{category}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 分类ID
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 分类名称
+	NameText string `protobuf:"bytes,3,opt,name=name_text,json=nameText,proto3" json:"name_text,omitempty"`
+	// URL友好的标识符
+	Slug string `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
+	// 描述
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// 父分类ID
+	ParentId int64 `protobuf:"varint,6,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// 排序顺序
+	Order int32 `protobuf:"varint,7,opt,name=order,proto3" json:"order,omitempty"`
+	// 是否可见
+	Visible bool `protobuf:"varint,8,opt,name=visible,proto3" json:"visible,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新时间（Unix时间戳，秒）
+	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_store_note_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetNameText() string {
+	if x != nil {
+		return x.NameText
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Category) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *Category) GetVisible() bool {
+	if x != nil {
+		return x.Visible
+	}
+	return false
+}
+
+func (x *Category) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Category) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Tag 标签消息
+type Tag struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：tags/{tag}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 标签ID
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 标签名称
+	NameText string `protobuf:"bytes,3,opt,name=name_text,json=nameText,proto3" json:"name_text,omitempty"`
+	// URL友好的标识符
+	Slug string `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
+	// 描述
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// 使用次数
+	Count int32 `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt int64 `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新时间（Unix时间戳，秒）
+	UpdatedAt int64 `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 别名，创建名称与别名相同的标签时会解析为该标签
+	Aliases       []string `protobuf:"bytes,9,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_store_note_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tag) GetNameText() string {
+	if x != nil {
+		return x.NameText
+	}
+	return ""
+}
+
+func (x *Tag) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Tag) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Tag) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Tag) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Tag) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Tag) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+// User 用户消息
+type User struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：users/{user}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 用户ID
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 用户名
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// 密码哈希
+	PasswordHash string `protobuf:"bytes,5,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	// 昵称
+	Nickname string `protobuf:"bytes,6,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// 头像URL
+	Avatar string `protobuf:"bytes,7,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	// 个人简介
+	Bio string `protobuf:"bytes,8,opt,name=bio,proto3" json:"bio,omitempty"`
+	// 用户角色
+	Role UserRole `protobuf:"varint,9,opt,name=role,proto3,enum=store.UserRole" json:"role,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt int64 `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新时间（Unix时间戳，秒）
+	UpdatedAt     int64 `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_store_note_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *User) GetNickname() string {
 	if x != nil {
 		return x.Nickname
 	}
 	return ""
 }
 
-func (x *User) GetAvatar() string {
+func (x *User) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *User) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *User) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_USER_ROLE_UNSPECIFIED
+}
+
+func (x *User) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *User) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Comment 评论消息
+type Comment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：comments/{comment}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 评论ID
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 笔记ID
+	NoteId string `protobuf:"bytes,3,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// 作者名称
+	Author string `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	// 作者邮箱
+	Email string `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// 评论内容
+	Content string `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	// 父评论ID（用于回复）
+	ParentId int64 `protobuf:"varint,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// 是否已审核
+	Approved bool `protobuf:"varint,8,opt,name=approved,proto3" json:"approved,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新时间（Unix时间戳，秒）
+	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_store_note_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Comment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Comment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Comment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comment) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Comment) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *Comment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Comment) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Page 页面消息
+type Page struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：pages/{page}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 页面ID
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 标题
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// URL友好的标识符
+	Slug string `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
+	// 内容
+	Content string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	// 是否已发布
+	Published bool `protobuf:"varint,6,opt,name=published,proto3" json:"published,omitempty"`
+	// 是否在导航中显示
+	InNavigation bool `protobuf:"varint,7,opt,name=in_navigation,json=inNavigation,proto3" json:"in_navigation,omitempty"`
+	// 排序顺序
+	Order int32 `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新时间（Unix时间戳，秒）
+	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_store_note_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Page) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Page) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Page) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Page) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Page) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Page) GetPublished() bool {
+	if x != nil {
+		return x.Published
+	}
+	return false
+}
+
+func (x *Page) GetInNavigation() bool {
+	if x != nil {
+		return x.InNavigation
+	}
+	return false
+}
+
+func (x *Page) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *Page) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Page) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Attachment 附件消息
+type Attachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 资源名称，格式：attachments/{attachment}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 附件ID
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 文件名
+	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	// MIME类型
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// 文件大小（字节）
+	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// 文件内容（仅用于输入）
+	Content []byte `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	// 关联的笔记ID（可选），格式：notes/{note}
+	NoteId string `protobuf:"bytes,7,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// 作者ID，格式：users/{user}
+	AuthorId string `protobuf:"bytes,8,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// 创建时间（Unix时间戳，秒）
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 更新时间（Unix时间戳，秒）
+	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_store_note_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
 	if x != nil {
-		return x.Avatar
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetBio() string {
+func (x *Attachment) GetContent() []byte {
 	if x != nil {
-		return x.Bio
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Attachment) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
 	}
 	return ""
 }
 
-func (x *User) GetRole() UserRole {
+func (x *Attachment) GetAuthorId() string {
 	if x != nil {
-		return x.Role
+		return x.AuthorId
 	}
-	return UserRole_USER_ROLE_UNSPECIFIED
+	return ""
 }
 
-func (x *User) GetCreatedAt() int64 {
+func (x *Attachment) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *User) GetUpdatedAt() int64 {
+func (x *Attachment) GetUpdatedAt() int64 {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return 0
 }
 
-// Comment 评论消息
-type Comment struct {
+// SavedSearch 保存的搜索（智能集合）
+type SavedSearch struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 资源名称，格式：comments/{comment}
+	// 资源名称，格式：savedSearches/{saved_search}
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// 评论ID
+	// 保存的搜索ID
 	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	// 笔记ID
-	NoteId string `protobuf:"bytes,3,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
-	// 作者名称
-	Author string `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
-	// 作者邮箱
-	Email string `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
-	// 评论内容
-	Content string `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
-	// 父评论ID（用于回复）
-	ParentId int64 `protobuf:"varint,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	// 是否已审核
-	Approved bool `protobuf:"varint,8,opt,name=approved,proto3" json:"approved,omitempty"`
+	// 创建者，格式：users/{user}
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// 名称
+	NameText string `protobuf:"bytes,4,opt,name=name_text,json=nameText,proto3" json:"name_text,omitempty"`
+	// 过滤表达式，语法与 ListNotes 的 filter 相同
+	Filter string `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 排序字段，格式与 ListNotes 的 sort_by 相同
+	SortBy string `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// 未指定方向的排序字段是否降序排序
+	SortDesc bool `protobuf:"varint,7,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	// 是否置顶
+	Pinned bool `protobuf:"varint,8,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// 是否共享给其他登录用户（其他用户只能查看和执行）
+	Shared bool `protobuf:"varint,9,opt,name=shared,proto3" json:"shared,omitempty"`
+	// 是否发布为订阅源（只包含已发布的公开笔记）
+	FeedEnabled bool `protobuf:"varint,10,opt,name=feed_enabled,json=feedEnabled,proto3" json:"feed_enabled,omitempty"`
+	// 当前用户上次查看后新增的笔记数量（仅输出）
+	NewCount int32 `protobuf:"varint,11,opt,name=new_count,json=newCount,proto3" json:"new_count,omitempty"`
+	// 当前用户上次查看的时间（Unix时间戳，秒，仅输出），从未查看时为0
+	LastViewedAt int64 `protobuf:"varint,12,opt,name=last_viewed_at,json=lastViewedAt,proto3" json:"last_viewed_at,omitempty"`
 	// 创建时间（Unix时间戳，秒）
-	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt int64 `protobuf:"varint,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// 更新时间（Unix时间戳，秒）
-	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedAt     int64 `protobuf:"varint,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Comment) Reset() {
-	*x = Comment{}
-	mi := &file_store_note_proto_msgTypes[4]
+func (x *SavedSearch) Reset() {
+	*x = SavedSearch{}
+	mi := &file_store_note_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Comment) String() string {
+func (x *SavedSearch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Comment) ProtoMessage() {}
+func (*SavedSearch) ProtoMessage() {}
 
-func (x *Comment) ProtoReflect() protoreflect.Message {
-	mi := &file_store_note_proto_msgTypes[4]
+func (x *SavedSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -736,123 +1881,149 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
-func (*Comment) Descriptor() ([]byte, []int) {
-	return file_store_note_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SavedSearch.ProtoReflect.Descriptor instead.
+func (*SavedSearch) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Comment) GetName() string {
+func (x *SavedSearch) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Comment) GetId() int64 {
+func (x *SavedSearch) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Comment) GetNoteId() string {
+func (x *SavedSearch) GetOwner() string {
 	if x != nil {
-		return x.NoteId
+		return x.Owner
 	}
 	return ""
 }
 
-func (x *Comment) GetAuthor() string {
+func (x *SavedSearch) GetNameText() string {
 	if x != nil {
-		return x.Author
+		return x.NameText
 	}
 	return ""
 }
 
-func (x *Comment) GetEmail() string {
+func (x *SavedSearch) GetFilter() string {
 	if x != nil {
-		return x.Email
+		return x.Filter
 	}
 	return ""
 }
 
-func (x *Comment) GetContent() string {
+func (x *SavedSearch) GetSortBy() string {
 	if x != nil {
-		return x.Content
+		return x.SortBy
 	}
 	return ""
 }
 
-func (x *Comment) GetParentId() int64 {
+func (x *SavedSearch) GetSortDesc() bool {
 	if x != nil {
-		return x.ParentId
+		return x.SortDesc
 	}
-	return 0
+	return false
 }
 
-func (x *Comment) GetApproved() bool {
+func (x *SavedSearch) GetPinned() bool {
 	if x != nil {
-		return x.Approved
+		return x.Pinned
 	}
 	return false
 }
 
-func (x *Comment) GetCreatedAt() int64 {
+func (x *SavedSearch) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *SavedSearch) GetFeedEnabled() bool {
+	if x != nil {
+		return x.FeedEnabled
+	}
+	return false
+}
+
+func (x *SavedSearch) GetNewCount() int32 {
+	if x != nil {
+		return x.NewCount
+	}
+	return 0
+}
+
+func (x *SavedSearch) GetLastViewedAt() int64 {
+	if x != nil {
+		return x.LastViewedAt
+	}
+	return 0
+}
+
+func (x *SavedSearch) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *Comment) GetUpdatedAt() int64 {
+func (x *SavedSearch) GetUpdatedAt() int64 {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return 0
 }
 
-// Page 页面消息
-type Page struct {
+// Workspace 工作区消息，笔记、分类、标签、页面和附件都属于一个工作区
+type Workspace struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 资源名称，格式：pages/{page}
+	// 资源名称，格式：workspaces/{slug}
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// 页面ID
+	// 工作区ID
 	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	// 标题
-	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	// URL友好的标识符
-	Slug string `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
-	// 内容
-	Content string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
-	// 是否已发布
-	Published bool `protobuf:"varint,6,opt,name=published,proto3" json:"published,omitempty"`
-	// 是否在导航中显示
-	InNavigation bool `protobuf:"varint,7,opt,name=in_navigation,json=inNavigation,proto3" json:"in_navigation,omitempty"`
-	// 排序顺序
-	Order int32 `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`
+	// URL友好的标识符，唯一
+	Slug string `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	// 名称
+	NameText string `protobuf:"bytes,4,opt,name=name_text,json=nameText,proto3" json:"name_text,omitempty"`
+	// 描述
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// 创建者，格式：users/{user}
+	Creator string `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	// 当前用户在工作区中的角色（仅输出），不是成员时为 UNSPECIFIED
+	Role WorkspaceRole `protobuf:"varint,7,opt,name=role,proto3,enum=store.WorkspaceRole" json:"role,omitempty"`
 	// 创建时间（Unix时间戳，秒）
-	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt int64 `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// 更新时间（Unix时间戳，秒）
-	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedAt     int64 `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Page) Reset() {
-	*x = Page{}
-	mi := &file_store_note_proto_msgTypes[5]
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_store_note_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Page) String() string {
+func (x *Workspace) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Page) ProtoMessage() {}
+func (*Workspace) ProtoMessage() {}
 
-func (x *Page) ProtoReflect() protoreflect.Message {
-	mi := &file_store_note_proto_msgTypes[5]
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -863,123 +2034,108 @@ func (x *Page) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Page.ProtoReflect.Descriptor instead.
-func (*Page) Descriptor() ([]byte, []int) {
-	return file_store_note_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Page) GetName() string {
+func (x *Workspace) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Page) GetId() int64 {
+func (x *Workspace) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Page) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
-}
-
-func (x *Page) GetSlug() string {
+func (x *Workspace) GetSlug() string {
 	if x != nil {
 		return x.Slug
 	}
 	return ""
 }
 
-func (x *Page) GetContent() string {
+func (x *Workspace) GetNameText() string {
 	if x != nil {
-		return x.Content
+		return x.NameText
 	}
 	return ""
 }
 
-func (x *Page) GetPublished() bool {
+func (x *Workspace) GetDescription() string {
 	if x != nil {
-		return x.Published
+		return x.Description
 	}
-	return false
+	return ""
 }
 
-func (x *Page) GetInNavigation() bool {
+func (x *Workspace) GetCreator() string {
 	if x != nil {
-		return x.InNavigation
+		return x.Creator
 	}
-	return false
+	return ""
 }
 
-func (x *Page) GetOrder() int32 {
+func (x *Workspace) GetRole() WorkspaceRole {
 	if x != nil {
-		return x.Order
+		return x.Role
 	}
-	return 0
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
 }
 
-func (x *Page) GetCreatedAt() int64 {
+func (x *Workspace) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *Page) GetUpdatedAt() int64 {
+func (x *Workspace) GetUpdatedAt() int64 {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return 0
 }
 
-// Attachment 附件消息
-type Attachment struct {
+// WorkspaceMember 工作区成员消息
+type WorkspaceMember struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 资源名称，格式：attachments/{attachment}
+	// 资源名称，格式：workspaces/{slug}/members/{user}
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// 附件ID
-	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	// 文件名
-	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	// MIME类型
-	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	// 文件大小（字节）
-	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
-	// 文件内容（仅用于输入）
-	Content []byte `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
-	// 关联的笔记ID（可选），格式：notes/{note}
-	NoteId string `protobuf:"bytes,7,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
-	// 作者ID，格式：users/{user}
-	AuthorId string `protobuf:"bytes,8,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// 用户，格式：users/{user}
+	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// 用户名（仅输出）
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// 角色
+	Role WorkspaceRole `protobuf:"varint,4,opt,name=role,proto3,enum=store.WorkspaceRole" json:"role,omitempty"`
 	// 创建时间（Unix时间戳，秒）
-	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt int64 `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// 更新时间（Unix时间戳，秒）
-	UpdatedAt     int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedAt     int64 `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Attachment) Reset() {
-	*x = Attachment{}
-	mi := &file_store_note_proto_msgTypes[6]
+func (x *WorkspaceMember) Reset() {
+	*x = WorkspaceMember{}
+	mi := &file_store_note_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Attachment) String() string {
+func (x *WorkspaceMember) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Attachment) ProtoMessage() {}
+func (*WorkspaceMember) ProtoMessage() {}
 
-func (x *Attachment) ProtoReflect() protoreflect.Message {
-	mi := &file_store_note_proto_msgTypes[6]
+func (x *WorkspaceMember) ProtoReflect() protoreflect.Message {
+	mi := &file_store_note_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -990,75 +2146,47 @@ func (x *Attachment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
-func (*Attachment) Descriptor() ([]byte, []int) {
-	return file_store_note_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use WorkspaceMember.ProtoReflect.Descriptor instead.
+func (*WorkspaceMember) Descriptor() ([]byte, []int) {
+	return file_store_note_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Attachment) GetName() string {
+func (x *WorkspaceMember) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Attachment) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Attachment) GetFilename() string {
-	if x != nil {
-		return x.Filename
-	}
-	return ""
-}
-
-func (x *Attachment) GetType() string {
+func (x *WorkspaceMember) GetUser() string {
 	if x != nil {
-		return x.Type
+		return x.User
 	}
 	return ""
 }
 
-func (x *Attachment) GetSize() int64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
-}
-
-func (x *Attachment) GetContent() []byte {
-	if x != nil {
-		return x.Content
-	}
-	return nil
-}
-
-func (x *Attachment) GetNoteId() string {
+func (x *WorkspaceMember) GetUsername() string {
 	if x != nil {
-		return x.NoteId
+		return x.Username
 	}
 	return ""
 }
 
-func (x *Attachment) GetAuthorId() string {
+func (x *WorkspaceMember) GetRole() WorkspaceRole {
 	if x != nil {
-		return x.AuthorId
+		return x.Role
 	}
-	return ""
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
 }
 
-func (x *Attachment) GetCreatedAt() int64 {
+func (x *WorkspaceMember) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *Attachment) GetUpdatedAt() int64 {
+func (x *WorkspaceMember) GetUpdatedAt() int64 {
 	if x != nil {
 		return x.UpdatedAt
 	}
@@ -1069,7 +2197,7 @@ var File_store_note_proto protoreflect.FileDescriptor
 
 const file_store_note_proto_rawDesc = "" +
 	"\n" +
-	"\x10store/note.proto\x12\x05store\"\xf8\x03\n" +
+	"\x10store/note.proto\x12\x05store\"\x96\x05\n" +
 	"\x04Note\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x14\n" +
@@ -1095,7 +2223,77 @@ const file_store_note_proto_rawDesc = "" +
 	"view_count\x18\x10 \x01(\x05R\tviewCount\x125\n" +
 	"\n" +
 	"visibility\x18\x11 \x01(\x0e2\x15.store.NoteVisibilityR\n" +
-	"visibility\"\x8c\x02\n" +
+	"visibility\x12#\n" +
+	"\rrendered_html\x18\x12 \x01(\tR\frenderedHtml\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x13 \x01(\x05R\twordCount\x12\x1d\n" +
+	"\n" +
+	"char_count\x18\x14 \x01(\x05R\tcharCount\x12\x1c\n" +
+	"\tscheduled\x18\x15 \x01(\bR\tscheduled\x12\x1b\n" +
+	"\texpire_at\x18\x16 \x01(\x03R\bexpireAt\"\xea\x02\n" +
+	"\tNoteDraft\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\anote_id\x18\x02 \x01(\x03R\x06noteId\x12\x16\n" +
+	"\x06editor\x18\x03 \x01(\tR\x06editor\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12\x18\n" +
+	"\asummary\x18\x06 \x01(\tR\asummary\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\tR\n" +
+	"categoryId\x12\x17\n" +
+	"\atag_ids\x18\b \x03(\tR\x06tagIds\x12\x1f\n" +
+	"\vcover_image\x18\t \x01(\tR\n" +
+	"coverImage\x125\n" +
+	"\n" +
+	"visibility\x18\n" +
+	" \x01(\x0e2\x15.store.NoteVisibilityR\n" +
+	"visibility\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\x03R\tupdatedAt\"\xcc\x01\n" +
+	"\fNoteRevision\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x17\n" +
+	"\anote_id\x18\x03 \x01(\x03R\x06noteId\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x06 \x01(\tR\acontent\x12\x18\n" +
+	"\asummary\x18\a \x01(\tR\asummary\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\"\x98\x01\n" +
+	"\bNoteLink\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12!\n" +
+	"\fsource_title\x18\x02 \x01(\tR\vsourceTitle\x12#\n" +
+	"\rsource_author\x18\x03 \x01(\tR\fsourceAuthor\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12\x14\n" +
+	"\x05alias\x18\x05 \x01(\tR\x05alias\"\xbf\x02\n" +
+	"\tShareLink\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x17\n" +
+	"\anote_id\x18\x03 \x01(\x03R\x06noteId\x12\x18\n" +
+	"\acreator\x18\x04 \x01(\tR\acreator\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x12!\n" +
+	"\fhas_password\x18\a \x01(\bR\vhasPassword\x12\x1b\n" +
+	"\texpire_at\x18\b \x01(\x03R\bexpireAt\x12\x1b\n" +
+	"\tmax_views\x18\t \x01(\x05R\bmaxViews\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\n" +
+	" \x01(\x05R\tviewCount\x12\x18\n" +
+	"\arevoked\x18\v \x01(\bR\arevoked\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\x03R\tcreatedAt\"\xe0\x01\n" +
+	"\x0eNotePermission\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bresource\x18\x02 \x01(\tR\bresource\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\x12-\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x19.store.NotePermissionRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"granted_by\x18\x05 \x01(\tR\tgrantedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"\x8c\x02\n" +
 	"\bCategory\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1b\n" +
@@ -1109,7 +2307,7 @@ const file_store_note_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\x03R\tupdatedAt\"\xd0\x01\n" +
+	" \x01(\x03R\tupdatedAt\"\xea\x01\n" +
 	"\x03Tag\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1b\n" +
@@ -1120,7 +2318,8 @@ const file_store_note_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\x03R\tupdatedAt\"\x94\x02\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\x12\x18\n" +
+	"\aaliases\x18\t \x03(\tR\aaliases\"\x94\x02\n" +
 	"\x04User\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1a\n" +
@@ -1177,16 +2376,66 @@ const file_store_note_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\x03R\tupdatedAt*j\n" +
+	" \x01(\x03R\tupdatedAt\"\x86\x03\n" +
+	"\vSavedSearch\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x12\x1b\n" +
+	"\tname_text\x18\x04 \x01(\tR\bnameText\x12\x16\n" +
+	"\x06filter\x18\x05 \x01(\tR\x06filter\x12\x17\n" +
+	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\a \x01(\bR\bsortDesc\x12\x16\n" +
+	"\x06pinned\x18\b \x01(\bR\x06pinned\x12\x16\n" +
+	"\x06shared\x18\t \x01(\bR\x06shared\x12!\n" +
+	"\ffeed_enabled\x18\n" +
+	" \x01(\bR\vfeedEnabled\x12\x1b\n" +
+	"\tnew_count\x18\v \x01(\x05R\bnewCount\x12$\n" +
+	"\x0elast_viewed_at\x18\f \x01(\x03R\flastViewedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\x03R\tupdatedAt\"\x84\x02\n" +
+	"\tWorkspace\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x1b\n" +
+	"\tname_text\x18\x04 \x01(\tR\bnameText\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x18\n" +
+	"\acreator\x18\x06 \x01(\tR\acreator\x12(\n" +
+	"\x04role\x18\a \x01(\x0e2\x14.store.WorkspaceRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\"\xbd\x01\n" +
+	"\x0fWorkspaceMember\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12(\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x14.store.WorkspaceRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt*j\n" +
 	"\x0eNoteVisibility\x12\x1f\n" +
 	"\x1bNOTE_VISIBILITY_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16NOTE_VISIBILITY_PUBLIC\x10\x01\x12\x1b\n" +
-	"\x17NOTE_VISIBILITY_PRIVATE\x10\x02*b\n" +
+	"\x17NOTE_VISIBILITY_PRIVATE\x10\x02*\xa0\x01\n" +
+	"\x12NotePermissionRole\x12$\n" +
+	" NOTE_PERMISSION_ROLE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bNOTE_PERMISSION_ROLE_VIEWER\x10\x01\x12\"\n" +
+	"\x1eNOTE_PERMISSION_ROLE_COMMENTER\x10\x02\x12\x1f\n" +
+	"\x1bNOTE_PERMISSION_ROLE_EDITOR\x10\x03*b\n" +
 	"\bUserRole\x12\x19\n" +
 	"\x15USER_ROLE_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eUSER_ROLE_HOST\x10\x01\x12\x13\n" +
 	"\x0fUSER_ROLE_ADMIN\x10\x02\x12\x12\n" +
-	"\x0eUSER_ROLE_USER\x10\x03B{\n" +
+	"\x0eUSER_ROLE_USER\x10\x03*\x99\x01\n" +
+	"\rWorkspaceRole\x12\x1e\n" +
+	"\x1aWORKSPACE_ROLE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15WORKSPACE_ROLE_VIEWER\x10\x01\x12\x19\n" +
+	"\x15WORKSPACE_ROLE_MEMBER\x10\x02\x12\x18\n" +
+	"\x14WORKSPACE_ROLE_ADMIN\x10\x03\x12\x18\n" +
+	"\x14WORKSPACE_ROLE_OWNER\x10\x04B{\n" +
 	"\tcom.storeB\tNoteProtoP\x01Z/github.com/wdmsyhh/simple-notes/proto/gen/store\xa2\x02\x03SXX\xaa\x02\x05Store\xca\x02\x05Store\xe2\x02\x11Store\\GPBMetadata\xea\x02\x05Storeb\x06proto3"
 
 var (
@@ -1201,27 +2450,41 @@ func file_store_note_proto_rawDescGZIP() []byte {
 	return file_store_note_proto_rawDescData
 }
 
-var file_store_note_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_store_note_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_store_note_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_store_note_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_store_note_proto_goTypes = []any{
-	(NoteVisibility)(0), // 0: store.NoteVisibility
-	(UserRole)(0),       // 1: store.UserRole
-	(*Note)(nil),        // 2: store.Note
-	(*Category)(nil),    // 3: store.Category
-	(*Tag)(nil),         // 4: store.Tag
-	(*User)(nil),        // 5: store.User
-	(*Comment)(nil),     // 6: store.Comment
-	(*Page)(nil),        // 7: store.Page
-	(*Attachment)(nil),  // 8: store.Attachment
+	(NoteVisibility)(0),     // 0: store.NoteVisibility
+	(NotePermissionRole)(0), // 1: store.NotePermissionRole
+	(UserRole)(0),           // 2: store.UserRole
+	(WorkspaceRole)(0),      // 3: store.WorkspaceRole
+	(*Note)(nil),            // 4: store.Note
+	(*NoteDraft)(nil),       // 5: store.NoteDraft
+	(*NoteRevision)(nil),    // 6: store.NoteRevision
+	(*NoteLink)(nil),        // 7: store.NoteLink
+	(*ShareLink)(nil),       // 8: store.ShareLink
+	(*NotePermission)(nil),  // 9: store.NotePermission
+	(*Category)(nil),        // 10: store.Category
+	(*Tag)(nil),             // 11: store.Tag
+	(*User)(nil),            // 12: store.User
+	(*Comment)(nil),         // 13: store.Comment
+	(*Page)(nil),            // 14: store.Page
+	(*Attachment)(nil),      // 15: store.Attachment
+	(*SavedSearch)(nil),     // 16: store.SavedSearch
+	(*Workspace)(nil),       // 17: store.Workspace
+	(*WorkspaceMember)(nil), // 18: store.WorkspaceMember
 }
 var file_store_note_proto_depIdxs = []int32{
 	0, // 0: store.Note.visibility:type_name -> store.NoteVisibility
-	1, // 1: store.User.role:type_name -> store.UserRole
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 1: store.NoteDraft.visibility:type_name -> store.NoteVisibility
+	1, // 2: store.NotePermission.role:type_name -> store.NotePermissionRole
+	2, // 3: store.User.role:type_name -> store.UserRole
+	3, // 4: store.Workspace.role:type_name -> store.WorkspaceRole
+	3, // 5: store.WorkspaceMember.role:type_name -> store.WorkspaceRole
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_store_note_proto_init() }
@@ -1234,8 +2497,8 @@ func file_store_note_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_note_proto_rawDesc), len(file_store_note_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      4,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wdmsyhh/simple-notes/service"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// ShareAccessKeyDuration 附件访问密钥的有效期，过期后需要重新打开分享链接
+const ShareAccessKeyDuration = time.Hour
+
+var (
+	// ErrShareLinkInvalid 分享链接不存在、不属于该笔记、已撤销或已过期
+	ErrShareLinkInvalid = errors.New("share link is invalid or expired")
+	// ErrShareLinkExhausted 分享链接的查看次数已用完
+	ErrShareLinkExhausted = errors.New("share link view limit reached")
+	// ErrShareLinkPasswordRequired 分享链接需要访问密码
+	ErrShareLinkPasswordRequired = errors.New("share link password required")
+	// ErrShareLinkPasswordIncorrect 分享链接的访问密码错误
+	ErrShareLinkPasswordIncorrect = errors.New("incorrect share link password")
+)
+
+// OpenShareLink 使用分享令牌打开笔记，检查链接属于该笔记、未撤销、未过期且密码正确，并消耗一次查看次数
+// 返回访问笔记附件所需的短期密钥，见 AuthorizeShareLink
+func (a *Authenticator) OpenShareLink(ctx context.Context, noteID int64, token, password string) (string, error) {
+	link, err := a.findShareLink(ctx, noteID, token)
+	if err != nil {
+		return "", err
+	}
+	if link.Exhausted() {
+		return "", ErrShareLinkExhausted
+	}
+	if link.PasswordHash != "" {
+		if password == "" {
+			return "", ErrShareLinkPasswordRequired
+		}
+		if !service.CheckPassword(password, link.PasswordHash) {
+			return "", ErrShareLinkPasswordIncorrect
+		}
+	}
+
+	// 检查和增加查看次数在同一条语句中完成，并发访问不会超过上限
+	ok, err := a.store.ConsumeNoteShareLinkView(ctx, link.ID, time.Now())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to record share link view")
+	}
+	if !ok {
+		return "", ErrShareLinkExhausted
+	}
+	expiresAt := time.Now().Add(ShareAccessKeyDuration).Unix()
+	return strconv.FormatInt(expiresAt, 10) + "." + a.shareLinkAccessKey(link, expiresAt), nil
+}
+
+// AuthorizeShareLink 验证加载附件时携带的分享令牌和密钥，不消耗查看次数
+// 密钥只有打开过笔记（通过了密码检查）的访问者才能获得，在 ShareAccessKeyDuration 后过期，链接撤销或过期后立即失效
+func (a *Authenticator) AuthorizeShareLink(ctx context.Context, noteID int64, token, accessKey string) error {
+	expires, mac, ok := strings.Cut(accessKey, ".")
+	if !ok {
+		return ErrShareLinkInvalid
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return ErrShareLinkInvalid
+	}
+	link, err := a.findShareLink(ctx, noteID, token)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(mac), []byte(a.shareLinkAccessKey(link, expiresAt))) {
+		return ErrShareLinkInvalid
+	}
+	return nil
+}
+
+// findShareLink 查找属于笔记且仍然有效的分享链接
+func (a *Authenticator) findShareLink(ctx context.Context, noteID int64, token string) (*store.NoteShareLink, error) {
+	if token == "" {
+		return nil, ErrShareLinkInvalid
+	}
+	link, err := a.store.GetNoteShareLinkByTokenHash(ctx, store.HashShareToken(token))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get share link")
+	}
+	if link == nil || link.NoteID != noteID || !link.Active(time.Now()) {
+		return nil, ErrShareLinkInvalid
+	}
+	return link, nil
+}
+
+// shareLinkAccessKey 计算分享链接在 expiresAt 前有效的附件访问密钥签名
+// 签名与访问密码的哈希和过期时间绑定，没有密码的访问者无法仅凭令牌加载受密码保护的附件，也无法延长有效期
+func (a *Authenticator) shareLinkAccessKey(link *store.NoteShareLink, expiresAt int64) string {
+	mac := hmac.New(sha256.New, []byte(a.secret))
+	mac.Write([]byte("share:" + link.TokenHash + ":" + link.PasswordHash + ":" + strconv.FormatInt(expiresAt, 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
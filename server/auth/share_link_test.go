@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	"github.com/wdmsyhh/simple-notes/service"
+	"github.com/wdmsyhh/simple-notes/store"
+	"github.com/wdmsyhh/simple-notes/store/db"
+)
+
+func TestShareLinkAccessKey(t *testing.T) {
+	dir := t.TempDir()
+	p := &profile.Profile{Driver: "sqlite", DSN: filepath.Join(dir, "test.db"), Data: dir}
+	driver, err := db.NewDBDriver(p)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	s := store.NewStore(driver, p)
+	defer s.Close()
+	if err := s.RunMigrations(); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	passwordHash, err := service.HashPassword("secret")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	const noteID, token = 1, "share-token"
+	link, err := s.CreateNoteShareLink(ctx, &store.NoteShareLink{NoteID: noteID, CreatorID: 1, TokenHash: store.HashShareToken(token), PasswordHash: passwordHash})
+	if err != nil {
+		t.Fatalf("CreateNoteShareLink: %v", err)
+	}
+	a := NewAuthenticator(s, "test-secret")
+
+	if _, err := a.OpenShareLink(ctx, noteID, token, ""); !errors.Is(err, ErrShareLinkPasswordRequired) {
+		t.Errorf("OpenShareLink without password = %v, want %v", err, ErrShareLinkPasswordRequired)
+	}
+	if _, err := a.OpenShareLink(ctx, noteID, token, "wrong"); !errors.Is(err, ErrShareLinkPasswordIncorrect) {
+		t.Errorf("OpenShareLink with a wrong password = %v, want %v", err, ErrShareLinkPasswordIncorrect)
+	}
+	accessKey, err := a.OpenShareLink(ctx, noteID, token, "secret")
+	if err != nil {
+		t.Fatalf("OpenShareLink: %v", err)
+	}
+	if strings.Contains(accessKey, "secret") {
+		t.Errorf("access key %q contains the password", accessKey)
+	}
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	extended := strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10)
+	_, mac, _ := strings.Cut(accessKey, ".")
+	tests := []struct {
+		name      string
+		noteID    int64
+		token     string
+		accessKey string
+		ok        bool
+	}{
+		{name: "valid", noteID: noteID, token: token, accessKey: accessKey, ok: true},
+		{name: "expired", noteID: noteID, token: token, accessKey: strconv.FormatInt(expired, 10) + "." + a.shareLinkAccessKey(link, expired)},
+		{name: "extended expiry", noteID: noteID, token: token, accessKey: extended + "." + mac},
+		{name: "missing expiry", noteID: noteID, token: token, accessKey: mac},
+		{name: "raw password", noteID: noteID, token: token, accessKey: "secret"},
+		{name: "another note", noteID: noteID + 1, token: token, accessKey: accessKey},
+		{name: "another token", noteID: noteID, token: "other", accessKey: accessKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.AuthorizeShareLink(ctx, tt.noteID, tt.token, tt.accessKey)
+			if tt.ok && err != nil {
+				t.Errorf("AuthorizeShareLink: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrShareLinkInvalid) {
+				t.Errorf("AuthorizeShareLink = %v, want %v", err, ErrShareLinkInvalid)
+			}
+		})
+	}
+
+	// 撤销后已经发出的密钥立即失效
+	if err := s.RevokeNoteShareLink(ctx, link.ID); err != nil {
+		t.Fatalf("RevokeNoteShareLink: %v", err)
+	}
+	if err := a.AuthorizeShareLink(ctx, noteID, token, accessKey); !errors.Is(err, ErrShareLinkInvalid) {
+		t.Errorf("AuthorizeShareLink after revocation = %v, want %v", err, ErrShareLinkInvalid)
+	}
+}
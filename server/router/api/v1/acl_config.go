@@ -7,13 +7,20 @@ var PublicMethods = map[string]struct{}{
 	"/api.v1.UserService/LoginUser":    {},
 	"/api.v1.NoteService/ListNotes":    {},
 	"/api.v1.NoteService/GetNote":      {},
+	"/api.v1.NoteService/RenderNote":   {},
+	"/api.v1.NoteService/ListBacklinks": {},
+	"/api.v1.NoteService/GetNoteGraph":  {},
+	"/api.v1.NoteService/ListRelatedNotes": {},
 	"/api.v1.CategoryService/ListCategories": {},
 	"/api.v1.CategoryService/GetCategory":    {},
 	"/api.v1.CategoryService/GetCategoryBySlug": {},
+	"/api.v1.CategoryService/GetCategoryTree":   {},
 	"/api.v1.TagService/ListTags":      {},
 	"/api.v1.TagService/GetTag":        {},
 	"/api.v1.TagService/GetTagBySlug":  {},
+	"/api.v1.TagService/SuggestTags":   {},
 	"/api.v1.AttachmentService/ListAttachments": {},
+	"/api.v1.CommentService/ListComments":       {},
 	// Note: CreateNote, UpdateNote, DeleteNote require authentication
 }
 
@@ -66,6 +66,9 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, req *apiv1.CreateAt
 	if err != nil || currentUser == nil {
 		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
 	}
+	if err := s.checkWorkspaceMember(ctx, currentUser, "upload attachment"); err != nil {
+		return nil, err
+	}
 
 	// 验证必需字段
 	if req.Attachment == nil {
@@ -105,6 +108,9 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, req *apiv1.CreateAt
 	}
 
 	if req.Attachment.NoteId != "" {
+		if err := s.checkAttachmentNote(ctx, req.Attachment.NoteId, currentUser); err != nil {
+			return nil, err
+		}
 		storeAttachment.NoteId = req.Attachment.NoteId
 	}
 
@@ -136,7 +142,7 @@ func (s *APIV1Service) ListAttachments(ctx context.Context, req *apiv1.ListAttac
 	if req.NoteId != "" {
 		// Extract ID from resource name format: notes/{id}
 		var id int64
-		if _, err := fmt.Sscanf(req.NoteId, "notes/%d", &id); err == nil {
+		if _, err := fmt.Sscanf(trimWorkspacePrefix(req.NoteId), "notes/%d", &id); err == nil {
 			noteID = &id
 		}
 	}
@@ -151,7 +157,7 @@ func (s *APIV1Service) ListAttachments(ctx context.Context, req *apiv1.ListAttac
 		}
 
 		// Check if user can see this note
-		if !s.isNoteVisibleToUser(note, currentUser) {
+		if !s.isNoteVisibleToUser(ctx, note, currentUser) {
 			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		}
 		// If they can see the note, we don't need to filter by authorID
@@ -194,7 +200,7 @@ func (s *APIV1Service) GetAttachment(ctx context.Context, req *apiv1.GetAttachme
 
 	// 从资源名称中提取ID
 	var id int64
-	if _, err := fmt.Sscanf(req.Name, "attachments/%d", &id); err != nil {
+	if _, err := fmt.Sscanf(trimWorkspacePrefix(req.Name), "attachments/%d", &id); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid attachment name: %s", req.Name)
 	}
 
@@ -209,9 +215,9 @@ func (s *APIV1Service) GetAttachment(ctx context.Context, req *apiv1.GetAttachme
 	// 1. 检查它是否属于公开笔记
 	if attachment.NoteId != "" {
 		var noteID int64
-		if _, err := fmt.Sscanf(attachment.NoteId, "notes/%d", &noteID); err == nil {
+		if _, err := fmt.Sscanf(trimWorkspacePrefix(attachment.NoteId), "notes/%d", &noteID); err == nil {
 			note, err := s.Store.GetNote(ctx, noteID)
-			if err == nil && s.isNoteVisibleToUser(note, currentUser) {
+			if err == nil && s.isNoteVisibleToUser(ctx, note, currentUser) {
 				allowed = true
 			}
 		}
@@ -221,7 +227,7 @@ func (s *APIV1Service) GetAttachment(ctx context.Context, req *apiv1.GetAttachme
 	if !allowed && currentUser != nil {
 		var authorID uint
 		if _, err := fmt.Sscanf(attachment.AuthorId, "%d", &authorID); err == nil {
-			if currentUser.ID == authorID || currentUser.Role == store.RoleAdmin || currentUser.Role == store.RoleHost {
+			if currentUser.ID == authorID || s.isWorkspaceAdmin(ctx, currentUser) {
 				allowed = true
 			}
 		}
@@ -255,7 +261,7 @@ func (s *APIV1Service) UpdateAttachment(ctx context.Context, req *apiv1.UpdateAt
 
 	// 从资源名称中提取ID
 	var id int64
-	if _, err := fmt.Sscanf(req.Attachment.Name, "attachments/%d", &id); err != nil {
+	if _, err := fmt.Sscanf(trimWorkspacePrefix(req.Attachment.Name), "attachments/%d", &id); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid attachment name: %s", req.Attachment.Name)
 	}
 
@@ -274,6 +280,13 @@ func (s *APIV1Service) UpdateAttachment(ctx context.Context, req *apiv1.UpdateAt
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
+	// 关联到笔记时需要可以编辑该笔记
+	if req.Attachment.NoteId != "" && req.Attachment.NoteId != existingAttachment.NoteId {
+		if err := s.checkAttachmentNote(ctx, req.Attachment.NoteId, currentUser); err != nil {
+			return nil, err
+		}
+	}
+
 	// 转换为存储层附件
 	storeAttachment := &pbstore.Attachment{
 		Name:   req.Attachment.Name,
@@ -299,7 +312,7 @@ func (s *APIV1Service) DeleteAttachment(ctx context.Context, req *apiv1.DeleteAt
 
 	// 从资源名称中提取ID
 	var id int64
-	if _, err := fmt.Sscanf(req.Name, "attachments/%d", &id); err != nil {
+	if _, err := fmt.Sscanf(trimWorkspacePrefix(req.Name), "attachments/%d", &id); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid attachment name: %s", req.Name)
 	}
 
@@ -349,3 +362,16 @@ func convertAttachmentToAPI(storeAttachment *pbstore.Attachment) *apiv1.Attachme
 func timestampToTime(ts int64) time.Time {
 	return time.Unix(ts, 0)
 }
+
+// checkAttachmentNote 检查用户可以将附件关联到笔记：作者、管理员和编辑者可以关联
+func (s *APIV1Service) checkAttachmentNote(ctx context.Context, noteName string, user *store.User) error {
+	var noteID int64
+	if _, err := fmt.Sscanf(trimWorkspacePrefix(noteName), "notes/%d", &noteID); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid note ID: %s", noteName)
+	}
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "note not found")
+	}
+	return s.requireNoteAccess(ctx, note, user, store.NoteAccessEditor, "attach files to note")
+}
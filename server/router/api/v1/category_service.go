@@ -9,6 +9,9 @@ import (
 
 	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
 	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -50,6 +53,10 @@ func (s *APIV1Service) GetCategory(ctx context.Context, req *apiv1.GetCategoryRe
 
 // CreateCategory 创建新分类
 func (s *APIV1Service) CreateCategory(ctx context.Context, req *apiv1.CreateCategoryRequest) (*pbstore.Category, error) {
+	if err := s.requireWorkspaceMember(ctx, "create category"); err != nil {
+		return nil, err
+	}
+
 	// 从请求中获取分类信息
 	category := req.GetCategory()
 	if category == nil {
@@ -78,6 +85,10 @@ func (s *APIV1Service) CreateCategory(ctx context.Context, req *apiv1.CreateCate
 
 // UpdateCategory 更新现有分类
 func (s *APIV1Service) UpdateCategory(ctx context.Context, req *apiv1.UpdateCategoryRequest) (*pbstore.Category, error) {
+	if err := s.requireWorkspaceMember(ctx, "update category"); err != nil {
+		return nil, err
+	}
+
 	// 从请求中获取分类信息
 	category := req.GetCategory()
 	if category == nil {
@@ -111,14 +122,34 @@ func (s *APIV1Service) UpdateCategory(ctx context.Context, req *apiv1.UpdateCate
 
 // DeleteCategory 删除分类
 func (s *APIV1Service) DeleteCategory(ctx context.Context, req *apiv1.DeleteCategoryRequest) (*emptypb.Empty, error) {
+	if err := s.requireWorkspaceMember(ctx, "delete category"); err != nil {
+		return nil, err
+	}
+
 	// 从资源名称中提取分类ID
 	categoryID, err := extractIDFromResourceName(req.GetName(), "categories")
 	if err != nil {
 		return nil, err
 	}
 
+	// 指定了转移目标时，分类下的笔记和子分类转移到目标分类
+	var reassignTo int64
+	if req.GetReassignTo() != "" {
+		reassignTo, err = extractIDFromResourceName(req.GetReassignTo(), "categories")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid category name: %v", err)
+		}
+	}
+
 	// 调用存储层删除分类
-	if err := s.Store.DeleteCategory(ctx, categoryID); err != nil {
+	if err := s.Store.DeleteCategory(ctx, categoryID, reassignTo); err != nil {
+		if strings.Contains(err.Error(), "cannot reassign") {
+			return nil, status.Errorf(codes.InvalidArgument, "不能将笔记和子分类转移到被删除的分类或其子分类")
+		}
+		// 检查是否是"分类下有子分类"的错误
+		if strings.Contains(err.Error(), "subcategor") {
+			return nil, fmt.Errorf("该分类下还有子分类，无法删除")
+		}
 		// 检查是否是"分类下有文章"的错误
 		if strings.Contains(err.Error(), "category has") {
 			return nil, fmt.Errorf("该分类下还有文章，无法删除")
@@ -148,6 +179,73 @@ func (s *APIV1Service) GetCategoryBySlug(ctx context.Context, req *apiv1.GetCate
 	return category, nil
 }
 
+// GetCategoryTree 返回嵌套的分类树，包含每个分类的直接和递归已发布笔记数量
+func (s *APIV1Service) GetCategoryTree(ctx context.Context, req *apiv1.GetCategoryTreeRequest) (*apiv1.GetCategoryTreeResponse, error) {
+	nodes, err := s.Store.GetCategoryTree(ctx, req.GetIncludeHidden())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get category tree: %v", err)
+	}
+	return &apiv1.GetCategoryTreeResponse{Nodes: nodes}, nil
+}
+
+// MoveCategory 将分类（连同其子分类）移动到新的父分类下
+func (s *APIV1Service) MoveCategory(ctx context.Context, req *apiv1.MoveCategoryRequest) (*pbstore.Category, error) {
+	if err := s.requireWorkspaceMember(ctx, "move category"); err != nil {
+		return nil, err
+	}
+
+	categoryID, err := extractIDFromResourceName(req.GetName(), "categories")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category name: %v", err)
+	}
+
+	var parentID int64
+	if req.GetParent() != "" {
+		parentID, err = extractIDFromResourceName(req.GetParent(), "categories")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid parent category name: %v", err)
+		}
+	}
+
+	category, err := s.Store.MoveCategory(ctx, categoryID, parentID, req.GetOrder())
+	if err != nil {
+		if strings.Contains(err.Error(), "own subtree") {
+			return nil, status.Errorf(codes.InvalidArgument, "不能将分类移动到自身或其子分类下")
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to move category: %v", err)
+	}
+
+	category.Name = fmt.Sprintf("categories/%d", category.Id)
+	return category, nil
+}
+
+// ReorderCategories 批量设置分类的排序顺序
+func (s *APIV1Service) ReorderCategories(ctx context.Context, req *apiv1.ReorderCategoriesRequest) (*emptypb.Empty, error) {
+	if err := s.requireWorkspaceMember(ctx, "reorder categories"); err != nil {
+		return nil, err
+	}
+
+	orders := make([]store.CategoryOrder, 0, len(req.GetOrders()))
+	for _, order := range req.GetOrders() {
+		categoryID, err := extractIDFromResourceName(order.GetName(), "categories")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid category name: %v", err)
+		}
+		orders = append(orders, store.CategoryOrder{ID: categoryID, Order: order.GetOrder()})
+	}
+
+	if err := s.Store.ReorderCategories(ctx, orders); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reorder categories: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // generateSlugFromName 从分类名称生成 slug
 // 将中文和特殊字符转换为 URL 友好的格式
 func generateSlugFromName(name string) string {
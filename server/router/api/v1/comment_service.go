@@ -0,0 +1,202 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// 评论遵循笔记的权限：可以查看笔记的用户可以查看已审核的评论，评论者及以上可以发表评论（无需审核），
+// 编辑者、作者和管理员可以查看未审核的评论并修改、审核和删除评论
+
+// ListComments 返回笔记的评论列表，按创建时间升序
+func (s *APIV1Service) ListComments(ctx context.Context, req *apiv1.ListCommentsRequest) (*apiv1.ListCommentsResponse, error) {
+	currentUser, _ := s.fetchCurrentUser(ctx)
+	note, err := s.getCommentNote(ctx, req.GetNoteId())
+	if err != nil {
+		return nil, err
+	}
+	level, err := s.Store.NoteAccessLevelOf(ctx, note, currentUser)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check note permission: %v", err)
+	}
+	if level < store.NoteAccessPublic {
+		return nil, status.Errorf(codes.PermissionDenied, "没有权限访问该笔记")
+	}
+	if req.GetIncludeUnapproved() && level < store.NoteAccessEditor {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: editor role required to view unapproved comments")
+	}
+
+	comments, err := s.Store.ListComments(ctx, note.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取评论失败: %v", err)
+	}
+	visible := []*pbstore.Comment{}
+	for _, comment := range comments {
+		if comment.Approved || req.GetIncludeUnapproved() {
+			visible = append(visible, comment)
+		}
+	}
+
+	page := req.GetPage()
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = 20
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	total := int32(len(visible))
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return &apiv1.ListCommentsResponse{Comments: visible[start:end], Total: total}, nil
+}
+
+// CreateComment 发表评论，需要评论者及以上的权限；作者名称使用当前用户的昵称
+func (s *APIV1Service) CreateComment(ctx context.Context, req *apiv1.CreateCommentRequest) (*pbstore.Comment, error) {
+	comment := req.GetComment()
+	if comment == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "comment is required")
+	}
+	if strings.TrimSpace(comment.Content) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "评论内容不能为空")
+	}
+
+	currentUser, _ := s.fetchCurrentUser(ctx)
+	note, err := s.getCommentNote(ctx, comment.NoteId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireNoteAccess(ctx, note, currentUser, store.NoteAccessCommenter, "comment on note"); err != nil {
+		return nil, err
+	}
+	if comment.ParentId > 0 {
+		parent, err := s.Store.GetComment(ctx, comment.ParentId)
+		if err != nil || parent.NoteId != note.Name {
+			return nil, status.Errorf(codes.InvalidArgument, "parent comment not found: %d", comment.ParentId)
+		}
+	}
+
+	author := currentUser.Nickname
+	if author == "" {
+		author = currentUser.Username
+	}
+	created, err := s.Store.CreateComment(ctx, &pbstore.Comment{
+		NoteId:   note.Name,
+		Author:   author,
+		Content:  comment.Content,
+		ParentId: comment.ParentId,
+		Approved: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "创建评论失败: %v", err)
+	}
+	return created, nil
+}
+
+// UpdateComment 修改评论内容，需要编辑者及以上的权限
+func (s *APIV1Service) UpdateComment(ctx context.Context, req *apiv1.UpdateCommentRequest) (*pbstore.Comment, error) {
+	comment := req.GetComment()
+	if comment == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "comment is required")
+	}
+	if req.GetUpdateMask() != nil {
+		for _, path := range req.GetUpdateMask().GetPaths() {
+			if path != "content" {
+				return nil, status.Errorf(codes.InvalidArgument, "unsupported update mask path: %s", path)
+			}
+		}
+	}
+	if strings.TrimSpace(comment.Content) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "评论内容不能为空")
+	}
+
+	existing, err := s.getModeratedComment(ctx, comment.Name, "edit comments")
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.Store.UpdateCommentContent(ctx, existing.Id, comment.Content)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "更新评论失败: %v", err)
+	}
+	return updated, nil
+}
+
+// DeleteComment 删除评论，需要编辑者及以上的权限
+func (s *APIV1Service) DeleteComment(ctx context.Context, req *apiv1.DeleteCommentRequest) (*emptypb.Empty, error) {
+	existing, err := s.getModeratedComment(ctx, req.GetName(), "delete comments")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteComment(ctx, existing.Id); err != nil {
+		return nil, status.Errorf(codes.Internal, "删除评论失败: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ApproveComment 审核通过评论，需要编辑者及以上的权限
+func (s *APIV1Service) ApproveComment(ctx context.Context, req *apiv1.ApproveCommentRequest) (*pbstore.Comment, error) {
+	existing, err := s.getModeratedComment(ctx, req.GetName(), "approve comments")
+	if err != nil {
+		return nil, err
+	}
+	approved, err := s.Store.ApproveComment(ctx, existing.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "审核评论失败: %v", err)
+	}
+	return approved, nil
+}
+
+// getCommentNote 根据评论中的笔记ID（格式：notes/{note}）获取笔记
+func (s *APIV1Service) getCommentNote(ctx context.Context, noteName string) (*pbstore.Note, error) {
+	noteID, err := extractIDFromResourceName(noteName, "notes")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "获取笔记失败: %v", err)
+	}
+	note.Name = fmt.Sprintf("notes/%d", note.Id)
+	return note, nil
+}
+
+// getModeratedComment 获取评论并检查当前用户可以管理该笔记的评论（编辑者及以上）
+func (s *APIV1Service) getModeratedComment(ctx context.Context, name, action string) (*pbstore.Comment, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	commentID, err := extractIDFromResourceName(name, "comments")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	comment, err := s.Store.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+	note, err := s.getCommentNote(ctx, comment.NoteId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireNoteAccess(ctx, note, currentUser, store.NoteAccessEditor, action); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
@@ -1,14 +1,19 @@
 package v1
 
 import (
+	"context"
 	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
 )
 
-// extractIDFromResourceName 从资源名称中提取ID
+// extractIDFromResourceName 从资源名称中提取ID，资源名称可以带工作区前缀（workspaces/{workspace}/{type}/{id}）
 func extractIDFromResourceName(name, expectedType string) (int64, error) {
 	if name == "" {
 		return 0, fmt.Errorf("resource name is required")
 	}
+	name = trimWorkspacePrefix(name)
 
 	// 分割资源名称
 	parts := []string{}
@@ -48,3 +53,28 @@ func extractIDFromResourceName(name, expectedType string) (int64, error) {
 
 	return id, nil
 }
+
+// getClientInfo 从请求元数据中获取客户端IP和User-Agent
+// Connect 请求由 NewMetadataInterceptor 写入元数据，gRPC-Gateway 请求由网关写入
+func getClientInfo(ctx context.Context) (clientIP string, userAgent string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+
+	// 使用 X-Forwarded-For 的最后一个地址，即拦截器或网关追加的连接对端地址；
+	// 前面的地址由客户端提供，可以随意伪造，不能用于浏览量去重等依赖客户端身份的场景
+	if values := md.Get("x-forwarded-for"); len(values) > 0 {
+		hops := strings.Split(values[len(values)-1], ",")
+		clientIP = strings.TrimSpace(hops[len(hops)-1])
+	}
+
+	for _, key := range []string{"user-agent", "grpcgateway-user-agent"} {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			userAgent = values[0]
+			break
+		}
+	}
+
+	return clientIP, userAgent
+}
@@ -32,6 +32,10 @@ func (s *ConnectServiceHandler) RegisterConnectHandlers(mux *http.ServeMux, opts
 	mux.Handle(apiv1connect.NewTagServiceHandler(s, opts...))
 	mux.Handle(apiv1connect.NewUserServiceHandler(s, opts...))
 	mux.Handle(apiv1connect.NewAttachmentServiceHandler(s, opts...))
+	mux.Handle(apiv1connect.NewSiteServiceHandler(s, opts...))
+	mux.Handle(apiv1connect.NewSavedSearchServiceHandler(s, opts...))
+	mux.Handle(apiv1connect.NewCommentServiceHandler(s, opts...))
+	mux.Handle(apiv1connect.NewWorkspaceServiceHandler(s, opts...))
 }
 
 // wrap 将 (path, handler) 返回值转换为结构体，以便更清晰地迭代
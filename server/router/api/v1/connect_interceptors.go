@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"log"
+	"net"
 	"runtime/debug"
+	"strings"
 
 	"connectrpc.com/connect"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/wdmsyhh/simple-notes/server/auth"
 	"github.com/wdmsyhh/simple-notes/store"
@@ -29,9 +34,33 @@ func NewRecoveryInterceptor(logStacktraces bool) connect.Interceptor {
 }
 
 // NewMetadataInterceptor 创建一个新的元数据拦截器，用于将HTTP头转换为gRPC元数据
+// 与 gRPC-Gateway 的行为保持一致：客户端地址追加到 X-Forwarded-For，User-Agent 原样传递，
+// 使服务实现无论通过哪种协议调用都能从元数据中获取客户端信息
 func NewMetadataInterceptor() connect.Interceptor {
 	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
 		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			header := req.Header()
+			md := metadata.MD{}
+
+			forwardedFor := header.Get("X-Forwarded-For")
+			if peerHost, _, err := net.SplitHostPort(req.Peer().Addr); err == nil && peerHost != "" {
+				if forwardedFor == "" {
+					forwardedFor = peerHost
+				} else {
+					forwardedFor = forwardedFor + ", " + peerHost
+				}
+			}
+			if forwardedFor != "" {
+				md.Set("x-forwarded-for", forwardedFor)
+			}
+			if userAgent := header.Get("User-Agent"); userAgent != "" {
+				md.Set("user-agent", userAgent)
+			}
+
+			if existing, ok := metadata.FromIncomingContext(ctx); ok {
+				md = metadata.Join(existing, md)
+			}
+			ctx = metadata.NewIncomingContext(ctx, md)
 			return next(ctx, req)
 		}
 	})
@@ -103,3 +132,23 @@ func (*AuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) co
 func (*AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
 	return next
 }
+
+// NewWorkspaceInterceptor 创建工作区拦截器，为请求选择工作区并保存到上下文中（见 store.WithWorkspace）
+// 选择规则见 selectWorkspace，非默认工作区只有成员和管理员可以选择（包括公开方法）。WorkspaceService 自己处理工作区，不经过选择
+func NewWorkspaceInterceptor(st *store.Store) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if strings.HasPrefix(req.Spec().Procedure, "/api.v1.WorkspaceService/") {
+				return next(ctx, req)
+			}
+
+			msg, _ := req.Any().(proto.Message)
+			workspaceID, err := selectWorkspace(ctx, st, req.Header().Get(WorkspaceHeader), msg)
+			if err != nil {
+				selectErr := status.Convert(err)
+				return nil, connect.NewError(connect.Code(selectErr.Code()), errors.New(selectErr.Message()))
+			}
+			return next(store.WithWorkspace(ctx, workspaceID), req)
+		}
+	})
+}
@@ -67,6 +67,168 @@ func (s *ConnectServiceHandler) GetNoteBySlug(ctx context.Context, req *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("GetNoteBySlug 已废弃，请使用 GetNote 通过 ID 获取笔记"))
 }
 
+// RenderNote 渲染笔记的 Connect 处理器
+func (s *ConnectServiceHandler) RenderNote(ctx context.Context, req *connect.Request[apiv1.RenderNoteRequest]) (*connect.Response[apiv1.RenderNoteResponse], error) {
+	resp, err := s.APIV1Service.RenderNote(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetNoteStats 获取笔记统计的 Connect 处理器
+func (s *ConnectServiceHandler) GetNoteStats(ctx context.Context, req *connect.Request[apiv1.GetNoteStatsRequest]) (*connect.Response[apiv1.NoteStats], error) {
+	resp, err := s.APIV1Service.GetNoteStats(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ImportNotes 导入笔记的 Connect 处理器
+func (s *ConnectServiceHandler) ImportNotes(ctx context.Context, req *connect.Request[apiv1.ImportNotesRequest]) (*connect.Response[apiv1.ImportNotesResponse], error) {
+	resp, err := s.APIV1Service.ImportNotes(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetNoteDraft 获取笔记草稿的 Connect 处理器
+func (s *ConnectServiceHandler) GetNoteDraft(ctx context.Context, req *connect.Request[apiv1.GetNoteDraftRequest]) (*connect.Response[pbstore.NoteDraft], error) {
+	resp, err := s.APIV1Service.GetNoteDraft(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// SaveNoteDraft 保存笔记草稿的 Connect 处理器
+func (s *ConnectServiceHandler) SaveNoteDraft(ctx context.Context, req *connect.Request[apiv1.SaveNoteDraftRequest]) (*connect.Response[pbstore.NoteDraft], error) {
+	resp, err := s.APIV1Service.SaveNoteDraft(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// PublishDraft 发布笔记草稿的 Connect 处理器
+func (s *ConnectServiceHandler) PublishDraft(ctx context.Context, req *connect.Request[apiv1.PublishDraftRequest]) (*connect.Response[pbstore.Note], error) {
+	resp, err := s.APIV1Service.PublishDraft(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DiscardDraft 删除笔记草稿的 Connect 处理器
+func (s *ConnectServiceHandler) DiscardDraft(ctx context.Context, req *connect.Request[apiv1.DiscardDraftRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.DiscardDraft(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListNoteRevisions 列出笔记修订记录的 Connect 处理器
+func (s *ConnectServiceHandler) ListNoteRevisions(ctx context.Context, req *connect.Request[apiv1.ListNoteRevisionsRequest]) (*connect.Response[apiv1.ListNoteRevisionsResponse], error) {
+	resp, err := s.APIV1Service.ListNoteRevisions(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListBacklinks 列出反向链接的 Connect 处理器
+func (s *ConnectServiceHandler) ListBacklinks(ctx context.Context, req *connect.Request[apiv1.ListBacklinksRequest]) (*connect.Response[apiv1.ListBacklinksResponse], error) {
+	resp, err := s.APIV1Service.ListBacklinks(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListUnresolvedLinks 列出未解析链接的 Connect 处理器
+func (s *ConnectServiceHandler) ListUnresolvedLinks(ctx context.Context, req *connect.Request[apiv1.ListUnresolvedLinksRequest]) (*connect.Response[apiv1.ListUnresolvedLinksResponse], error) {
+	resp, err := s.APIV1Service.ListUnresolvedLinks(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetNoteGraph 获取笔记关系图的 Connect 处理器
+func (s *ConnectServiceHandler) GetNoteGraph(ctx context.Context, req *connect.Request[apiv1.GetNoteGraphRequest]) (*connect.Response[apiv1.NoteGraph], error) {
+	resp, err := s.APIV1Service.GetNoteGraph(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListRelatedNotes 列出相关笔记的 Connect 处理器
+func (s *ConnectServiceHandler) ListRelatedNotes(ctx context.Context, req *connect.Request[apiv1.ListRelatedNotesRequest]) (*connect.Response[apiv1.ListRelatedNotesResponse], error) {
+	resp, err := s.APIV1Service.ListRelatedNotes(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateShareLink 创建分享链接的 Connect 处理器
+func (s *ConnectServiceHandler) CreateShareLink(ctx context.Context, req *connect.Request[apiv1.CreateShareLinkRequest]) (*connect.Response[pbstore.ShareLink], error) {
+	resp, err := s.APIV1Service.CreateShareLink(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListShareLinks 列出分享链接的 Connect 处理器
+func (s *ConnectServiceHandler) ListShareLinks(ctx context.Context, req *connect.Request[apiv1.ListShareLinksRequest]) (*connect.Response[apiv1.ListShareLinksResponse], error) {
+	resp, err := s.APIV1Service.ListShareLinks(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// RevokeShareLink 撤销分享链接的 Connect 处理器
+func (s *ConnectServiceHandler) RevokeShareLink(ctx context.Context, req *connect.Request[apiv1.RevokeShareLinkRequest]) (*connect.Response[pbstore.ShareLink], error) {
+	resp, err := s.APIV1Service.RevokeShareLink(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GrantNotePermission 授予权限的 Connect 处理器
+func (s *ConnectServiceHandler) GrantNotePermission(ctx context.Context, req *connect.Request[apiv1.GrantNotePermissionRequest]) (*connect.Response[pbstore.NotePermission], error) {
+	resp, err := s.APIV1Service.GrantNotePermission(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListNotePermissions 列出权限的 Connect 处理器
+func (s *ConnectServiceHandler) ListNotePermissions(ctx context.Context, req *connect.Request[apiv1.ListNotePermissionsRequest]) (*connect.Response[apiv1.ListNotePermissionsResponse], error) {
+	resp, err := s.APIV1Service.ListNotePermissions(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// RevokeNotePermission 撤销权限的 Connect 处理器
+func (s *ConnectServiceHandler) RevokeNotePermission(ctx context.Context, req *connect.Request[apiv1.RevokeNotePermissionRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.RevokeNotePermission(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // CategoryService
 
 // ListCategories 获取分类列表的 Connect 处理器
@@ -123,6 +285,33 @@ func (s *ConnectServiceHandler) GetCategoryBySlug(ctx context.Context, req *conn
 	return connect.NewResponse(resp), nil
 }
 
+// GetCategoryTree 获取分类树的 Connect 处理器
+func (s *ConnectServiceHandler) GetCategoryTree(ctx context.Context, req *connect.Request[apiv1.GetCategoryTreeRequest]) (*connect.Response[apiv1.GetCategoryTreeResponse], error) {
+	resp, err := s.APIV1Service.GetCategoryTree(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// MoveCategory 移动分类的 Connect 处理器
+func (s *ConnectServiceHandler) MoveCategory(ctx context.Context, req *connect.Request[apiv1.MoveCategoryRequest]) (*connect.Response[pbstore.Category], error) {
+	resp, err := s.APIV1Service.MoveCategory(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ReorderCategories 批量排序分类的 Connect 处理器
+func (s *ConnectServiceHandler) ReorderCategories(ctx context.Context, req *connect.Request[apiv1.ReorderCategoriesRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.ReorderCategories(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // TagService
 
 // ListTags 获取标签列表的 Connect 处理器
@@ -179,6 +368,51 @@ func (s *ConnectServiceHandler) GetTagBySlug(ctx context.Context, req *connect.R
 	return connect.NewResponse(resp), nil
 }
 
+// RecountTags 重新计算标签计数的 Connect 处理器
+func (s *ConnectServiceHandler) RecountTags(ctx context.Context, req *connect.Request[apiv1.RecountTagsRequest]) (*connect.Response[apiv1.RecountTagsResponse], error) {
+	resp, err := s.APIV1Service.RecountTags(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// MergeTags 合并标签的 Connect 处理器
+func (s *ConnectServiceHandler) MergeTags(ctx context.Context, req *connect.Request[apiv1.MergeTagsRequest]) (*connect.Response[pbstore.Tag], error) {
+	resp, err := s.APIV1Service.MergeTags(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateTagAlias 添加标签别名的 Connect 处理器
+func (s *ConnectServiceHandler) CreateTagAlias(ctx context.Context, req *connect.Request[apiv1.CreateTagAliasRequest]) (*connect.Response[pbstore.Tag], error) {
+	resp, err := s.APIV1Service.CreateTagAlias(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteTagAlias 删除标签别名的 Connect 处理器
+func (s *ConnectServiceHandler) DeleteTagAlias(ctx context.Context, req *connect.Request[apiv1.DeleteTagAliasRequest]) (*connect.Response[pbstore.Tag], error) {
+	resp, err := s.APIV1Service.DeleteTagAlias(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// SuggestTags 标签自动补全的 Connect 处理器
+func (s *ConnectServiceHandler) SuggestTags(ctx context.Context, req *connect.Request[apiv1.SuggestTagsRequest]) (*connect.Response[apiv1.SuggestTagsResponse], error) {
+	resp, err := s.APIV1Service.SuggestTags(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // UserService
 
 // RegisterUser 注册新用户
@@ -244,6 +478,15 @@ func (s *ConnectServiceHandler) ListUsers(ctx context.Context, req *connect.Requ
 	return connect.NewResponse(resp), nil
 }
 
+// ExportMyData 导出当前用户（或整个实例）的数据
+func (s *ConnectServiceHandler) ExportMyData(ctx context.Context, req *connect.Request[apiv1.ExportMyDataRequest]) (*connect.Response[apiv1.ExportMyDataResponse], error) {
+	resp, err := s.APIV1Service.ExportMyData(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // AttachmentService 附件服务
 
 // CreateAttachment 创建新附件
@@ -290,3 +533,209 @@ func (s *ConnectServiceHandler) DeleteAttachment(ctx context.Context, req *conne
 	}
 	return connect.NewResponse(resp), nil
 }
+
+// SiteService
+
+// ExportStaticSite 导出静态网站
+func (s *ConnectServiceHandler) ExportStaticSite(ctx context.Context, req *connect.Request[apiv1.ExportStaticSiteRequest]) (*connect.Response[apiv1.ExportStaticSiteResponse], error) {
+	resp, err := s.APIV1Service.ExportStaticSite(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateBackup 创建数据库备份
+func (s *ConnectServiceHandler) CreateBackup(ctx context.Context, req *connect.Request[apiv1.CreateBackupRequest]) (*connect.Response[apiv1.Backup], error) {
+	resp, err := s.APIV1Service.CreateBackup(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListBackups 列出数据库备份
+func (s *ConnectServiceHandler) ListBackups(ctx context.Context, req *connect.Request[apiv1.ListBackupsRequest]) (*connect.Response[apiv1.ListBackupsResponse], error) {
+	resp, err := s.APIV1Service.ListBackups(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// SavedSearchService
+
+// ListSavedSearches 列出保存的搜索
+func (s *ConnectServiceHandler) ListSavedSearches(ctx context.Context, req *connect.Request[apiv1.ListSavedSearchesRequest]) (*connect.Response[apiv1.ListSavedSearchesResponse], error) {
+	resp, err := s.APIV1Service.ListSavedSearches(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetSavedSearch 获取保存的搜索
+func (s *ConnectServiceHandler) GetSavedSearch(ctx context.Context, req *connect.Request[apiv1.GetSavedSearchRequest]) (*connect.Response[pbstore.SavedSearch], error) {
+	resp, err := s.APIV1Service.GetSavedSearch(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateSavedSearch 创建保存的搜索
+func (s *ConnectServiceHandler) CreateSavedSearch(ctx context.Context, req *connect.Request[apiv1.CreateSavedSearchRequest]) (*connect.Response[pbstore.SavedSearch], error) {
+	resp, err := s.APIV1Service.CreateSavedSearch(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateSavedSearch 更新保存的搜索
+func (s *ConnectServiceHandler) UpdateSavedSearch(ctx context.Context, req *connect.Request[apiv1.UpdateSavedSearchRequest]) (*connect.Response[pbstore.SavedSearch], error) {
+	resp, err := s.APIV1Service.UpdateSavedSearch(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteSavedSearch 删除保存的搜索
+func (s *ConnectServiceHandler) DeleteSavedSearch(ctx context.Context, req *connect.Request[apiv1.DeleteSavedSearchRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.DeleteSavedSearch(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListSavedSearchNotes 列出保存的搜索匹配的笔记
+func (s *ConnectServiceHandler) ListSavedSearchNotes(ctx context.Context, req *connect.Request[apiv1.ListSavedSearchNotesRequest]) (*connect.Response[apiv1.ListNotesResponse], error) {
+	resp, err := s.APIV1Service.ListSavedSearchNotes(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CommentService
+
+// ListComments 获取评论列表的 Connect 处理器
+func (s *ConnectServiceHandler) ListComments(ctx context.Context, req *connect.Request[apiv1.ListCommentsRequest]) (*connect.Response[apiv1.ListCommentsResponse], error) {
+	resp, err := s.APIV1Service.ListComments(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateComment 发表评论的 Connect 处理器
+func (s *ConnectServiceHandler) CreateComment(ctx context.Context, req *connect.Request[apiv1.CreateCommentRequest]) (*connect.Response[pbstore.Comment], error) {
+	resp, err := s.APIV1Service.CreateComment(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateComment 修改评论的 Connect 处理器
+func (s *ConnectServiceHandler) UpdateComment(ctx context.Context, req *connect.Request[apiv1.UpdateCommentRequest]) (*connect.Response[pbstore.Comment], error) {
+	resp, err := s.APIV1Service.UpdateComment(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteComment 删除评论的 Connect 处理器
+func (s *ConnectServiceHandler) DeleteComment(ctx context.Context, req *connect.Request[apiv1.DeleteCommentRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.DeleteComment(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ApproveComment 审核评论的 Connect 处理器
+func (s *ConnectServiceHandler) ApproveComment(ctx context.Context, req *connect.Request[apiv1.ApproveCommentRequest]) (*connect.Response[pbstore.Comment], error) {
+	resp, err := s.APIV1Service.ApproveComment(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// WorkspaceService
+
+// ListWorkspaces 获取工作区列表的 Connect 处理器
+func (s *ConnectServiceHandler) ListWorkspaces(ctx context.Context, req *connect.Request[apiv1.ListWorkspacesRequest]) (*connect.Response[apiv1.ListWorkspacesResponse], error) {
+	resp, err := s.APIV1Service.ListWorkspaces(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetWorkspace 获取工作区的 Connect 处理器
+func (s *ConnectServiceHandler) GetWorkspace(ctx context.Context, req *connect.Request[apiv1.GetWorkspaceRequest]) (*connect.Response[pbstore.Workspace], error) {
+	resp, err := s.APIV1Service.GetWorkspace(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateWorkspace 创建工作区的 Connect 处理器
+func (s *ConnectServiceHandler) CreateWorkspace(ctx context.Context, req *connect.Request[apiv1.CreateWorkspaceRequest]) (*connect.Response[pbstore.Workspace], error) {
+	resp, err := s.APIV1Service.CreateWorkspace(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateWorkspace 更新工作区的 Connect 处理器
+func (s *ConnectServiceHandler) UpdateWorkspace(ctx context.Context, req *connect.Request[apiv1.UpdateWorkspaceRequest]) (*connect.Response[pbstore.Workspace], error) {
+	resp, err := s.APIV1Service.UpdateWorkspace(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteWorkspace 删除工作区的 Connect 处理器
+func (s *ConnectServiceHandler) DeleteWorkspace(ctx context.Context, req *connect.Request[apiv1.DeleteWorkspaceRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.DeleteWorkspace(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListWorkspaceMembers 获取工作区成员列表的 Connect 处理器
+func (s *ConnectServiceHandler) ListWorkspaceMembers(ctx context.Context, req *connect.Request[apiv1.ListWorkspaceMembersRequest]) (*connect.Response[apiv1.ListWorkspaceMembersResponse], error) {
+	resp, err := s.APIV1Service.ListWorkspaceMembers(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// SetWorkspaceMember 设置工作区成员的 Connect 处理器
+func (s *ConnectServiceHandler) SetWorkspaceMember(ctx context.Context, req *connect.Request[apiv1.SetWorkspaceMemberRequest]) (*connect.Response[pbstore.WorkspaceMember], error) {
+	resp, err := s.APIV1Service.SetWorkspaceMember(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// RemoveWorkspaceMember 移除工作区成员的 Connect 处理器
+func (s *ConnectServiceHandler) RemoveWorkspaceMember(ctx context.Context, req *connect.Request[apiv1.RemoveWorkspaceMemberRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.RemoveWorkspaceMember(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
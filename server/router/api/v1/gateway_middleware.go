@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/wdmsyhh/simple-notes/server/auth"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// gatewayMiddleware 为 gRPC-Gateway 请求执行与 Connect 拦截器相同的认证和工作区选择
+// 网关直接调用服务实现，不经过 Connect 拦截器，因此在 HTTP 层完成：
+// 根据 Authorization 头设置用户声明（非公开方法要求认证），再根据 X-Workspace 请求头和请求体中的资源名称选择工作区（见 selectWorkspace）
+func (s *APIV1Service) gatewayMiddleware(gwMux *runtime.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		procedure := gatewayProcedure(r.URL.Path)
+		msg := newGatewayRequestMessage(procedure)
+		if msg == nil {
+			// 未知方法交给网关返回 404
+			gwMux.ServeHTTP(w, r)
+			return
+		}
+		ctx := r.Context()
+		_, outbound := runtime.MarshalerForRequest(gwMux, r)
+
+		result := s.authenticator.Authenticate(ctx, r.Header.Get("Authorization"))
+		if result == nil && !IsPublicMethod(procedure) {
+			runtime.HTTPError(ctx, gwMux, outbound, w, r, status.Error(codes.Unauthenticated, "authentication required"))
+			return
+		}
+		if result != nil && result.Claims != nil {
+			ctx = auth.SetUserClaimsInContext(ctx, result.Claims)
+		}
+
+		// WorkspaceService 自己处理工作区，不经过选择
+		if !strings.HasPrefix(procedure, "/api.v1.WorkspaceService/") {
+			if err := readGatewayRequestMessage(gwMux, w, r, msg); err != nil {
+				runtime.HTTPError(ctx, gwMux, outbound, w, r, err)
+				return
+			}
+			workspaceID, err := selectWorkspace(ctx, s.Store, r.Header.Get(WorkspaceHeader), msg)
+			if err != nil {
+				runtime.HTTPError(ctx, gwMux, outbound, w, r, err)
+				return
+			}
+			ctx = store.WithWorkspace(ctx, workspaceID)
+		}
+
+		gwMux.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// gatewayProcedure 返回网关请求路径对应的过程名称（/api.v1.{Service}/{Method}），与 Connect 的过程名称格式一致
+func gatewayProcedure(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 {
+		return path
+	}
+	return "/" + segments[len(segments)-2] + "/" + segments[len(segments)-1]
+}
+
+// readGatewayRequestMessage 将网关请求体解析到 msg，并把请求体放回请求中供网关再次解析
+// 请求体无法解析时返回 InvalidArgument
+func readGatewayRequestMessage(gwMux *runtime.ServeMux, w http.ResponseWriter, r *http.Request, msg proto.Message) error {
+	if r.Body == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	inbound, _ := runtime.MarshalerForRequest(gwMux, r)
+	if err := inbound.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return nil
+}
+
+// newGatewayRequestMessage 根据过程名称查找方法，创建空的请求消息，未知方法返回 nil
+func newGatewayRequestMessage(procedure string) proto.Message {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	if !ok {
+		return nil
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+	if err != nil {
+		return nil
+	}
+	return messageType.New().Interface()
+}
@@ -0,0 +1,267 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// defaultNoteGraphMaxNodes 关系图默认最多返回的笔记节点数量
+	defaultNoteGraphMaxNodes = 500
+	// maxNoteGraphMaxNodes 关系图最多返回的笔记节点数量上限
+	maxNoteGraphMaxNodes = 2000
+	// maxNoteGraphDepth 从中心笔记出发的最大链接跳数
+	maxNoteGraphDepth = 5
+	// noteGraphCacheTTL 关系图基础数据的最长缓存时间，超过后重新读取以更新浏览次数
+	noteGraphCacheTTL = time.Minute
+)
+
+// noteGraphCache 关系图基础数据的缓存
+// 每次请求检查数据版本（见 store.NoteGraphVersion），笔记、链接、标签或分类变化后重新读取
+type noteGraphCache struct {
+	// mu 保护以下字段的并发访问，同一时间只有一个请求重新读取数据
+	mu sync.Mutex
+	// version 缓存数据的版本
+	version string
+	// loadedAt 读取数据的时间
+	loadedAt time.Time
+	// graph 缓存的基础数据
+	graph *store.NoteGraph
+}
+
+// get 返回关系图基础数据，缓存失效时重新读取
+func (c *noteGraphCache) get(ctx context.Context, s *store.Store) (*store.NoteGraph, error) {
+	version, err := s.NoteGraphVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.graph != nil && c.version == version && time.Since(c.loadedAt) < noteGraphCacheTTL {
+		return c.graph, nil
+	}
+
+	graph, err := s.LoadNoteGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.graph, c.version, c.loadedAt = graph, version, time.Now()
+	return graph, nil
+}
+
+// GetNoteGraph 返回笔记之间的关系图
+// 笔记的范围与笔记列表一致（可见性、标签和过滤表达式在数据库查询中过滤），指定中心笔记时沿链接（不区分方向）向外扩展 depth 跳
+func (s *APIV1Service) GetNoteGraph(ctx context.Context, req *apiv1.GetNoteGraphRequest) (*apiv1.NoteGraph, error) {
+	currentUser, _ := s.fetchCurrentUser(ctx)
+
+	depth := int(req.GetDepth())
+	if depth <= 0 {
+		depth = 1
+	} else if depth > maxNoteGraphDepth {
+		depth = maxNoteGraphDepth
+	}
+	maxNodes := int(req.GetMaxNodes())
+	if maxNodes <= 0 {
+		maxNodes = defaultNoteGraphMaxNodes
+	} else if maxNodes > maxNoteGraphMaxNodes {
+		maxNodes = maxNoteGraphMaxNodes
+	}
+
+	storeReq := &store.ListNotesRequest{Filter: req.GetFilter()}
+	s.applyNoteListVisibility(ctx, storeReq, currentUser)
+	if req.GetTag() != "" {
+		tagID, err := extractIDFromResourceName(req.GetTag(), "tags")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		storeReq.TagID = fmt.Sprintf("%d", tagID)
+	}
+	var centerID int64
+	if req.GetNote() != "" {
+		noteID, err := extractIDFromResourceName(req.GetNote(), "notes")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		centerID = noteID
+	}
+
+	ids, err := s.Store.ListNoteIDs(ctx, storeReq)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidFilter) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "获取笔记失败: %v", err)
+	}
+	graph, err := s.noteGraphCache.get(ctx, s.Store)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取关系图失败: %v", err)
+	}
+
+	// 访问者可以看到的笔记及其之间的链接
+	visible := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if _, ok := graph.Notes[id]; ok {
+			visible[id] = true
+		}
+	}
+	var links [][2]int64
+	linkDegree := make(map[int64]int)
+	neighbours := make(map[int64][]int64)
+	for _, link := range graph.Links {
+		if !visible[link[0]] || !visible[link[1]] {
+			continue
+		}
+		links = append(links, link)
+		linkDegree[link[0]]++
+		linkDegree[link[1]]++
+		neighbours[link[0]] = append(neighbours[link[0]], link[1])
+		neighbours[link[1]] = append(neighbours[link[1]], link[0])
+	}
+
+	// 选取笔记：指定中心笔记时按链接广度优先扩展
+	selected := visible
+	if centerID > 0 {
+		if !visible[centerID] {
+			return nil, status.Errorf(codes.NotFound, "note not found: %d", centerID)
+		}
+		selected = map[int64]bool{centerID: true}
+		frontier := []int64{centerID}
+		for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+			var next []int64
+			for _, id := range frontier {
+				for _, neighbour := range neighbours[id] {
+					if !selected[neighbour] {
+						selected[neighbour] = true
+						next = append(next, neighbour)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+
+	noteWeight := func(note *store.NoteGraphNote) float64 {
+		return 1 + float64(linkDegree[note.ID]) + math.Log1p(float64(note.ViewCount))
+	}
+
+	// 按权重从高到低保留最多 maxNodes 篇笔记，中心笔记始终保留
+	noteIDs := make([]int64, 0, len(selected))
+	for id := range selected {
+		noteIDs = append(noteIDs, id)
+	}
+	sort.Slice(noteIDs, func(i, j int) bool {
+		a, b := noteIDs[i], noteIDs[j]
+		if (a == centerID) != (b == centerID) {
+			return a == centerID
+		}
+		wa, wb := noteWeight(graph.Notes[a]), noteWeight(graph.Notes[b])
+		if wa != wb {
+			return wa > wb
+		}
+		return a < b
+	})
+	response := &apiv1.NoteGraph{Nodes: []*apiv1.NoteGraphNode{}, Edges: []*apiv1.NoteGraphEdge{}}
+	if len(noteIDs) > maxNodes {
+		noteIDs = noteIDs[:maxNodes]
+		response.Truncated = true
+	}
+	sort.Slice(noteIDs, func(i, j int) bool { return noteIDs[i] < noteIDs[j] })
+	included := make(map[int64]bool, len(noteIDs))
+	for _, id := range noteIDs {
+		included[id] = true
+	}
+
+	degree := make(map[string]int32)
+	addEdge := func(source, target string, edgeType apiv1.NoteGraphEdgeType) {
+		response.Edges = append(response.Edges, &apiv1.NoteGraphEdge{Source: source, Target: target, Type: edgeType})
+		degree[source]++
+		degree[target]++
+	}
+	for _, link := range links {
+		if included[link[0]] && included[link[1]] {
+			addEdge(fmt.Sprintf("notes/%d", link[0]), fmt.Sprintf("notes/%d", link[1]), apiv1.NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_LINK)
+		}
+	}
+
+	// 标签和分类节点
+	var tagIDs, categoryIDs []int64
+	seenTags := make(map[int64]bool)
+	seenCategories := make(map[int64]bool)
+	isAdmin := s.isWorkspaceAdmin(ctx, currentUser)
+	for _, id := range noteIDs {
+		note := graph.Notes[id]
+		if req.GetIncludeTags() {
+			for _, tagID := range note.TagIDs {
+				if _, ok := graph.Tags[tagID]; !ok {
+					continue
+				}
+				if !seenTags[tagID] {
+					seenTags[tagID] = true
+					tagIDs = append(tagIDs, tagID)
+				}
+				addEdge(fmt.Sprintf("notes/%d", id), fmt.Sprintf("tags/%d", tagID), apiv1.NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_TAG)
+			}
+		}
+		if req.GetIncludeCategories() && note.CategoryID > 0 {
+			// 隐藏的分类只对管理员显示
+			category, ok := graph.Categories[note.CategoryID]
+			if !ok || (!category.Visible && !isAdmin) {
+				continue
+			}
+			if !seenCategories[note.CategoryID] {
+				seenCategories[note.CategoryID] = true
+				categoryIDs = append(categoryIDs, note.CategoryID)
+			}
+			addEdge(fmt.Sprintf("notes/%d", id), fmt.Sprintf("categories/%d", note.CategoryID), apiv1.NoteGraphEdgeType_NOTE_GRAPH_EDGE_TYPE_CATEGORY)
+		}
+	}
+
+	for _, id := range noteIDs {
+		note := graph.Notes[id]
+		name := fmt.Sprintf("notes/%d", id)
+		response.Nodes = append(response.Nodes, &apiv1.NoteGraphNode{
+			Name:      name,
+			Type:      apiv1.NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_NOTE,
+			Title:     note.Title,
+			ViewCount: note.ViewCount,
+			Degree:    degree[name],
+			Weight:    noteWeight(note),
+		})
+	}
+	sort.Slice(tagIDs, func(i, j int) bool { return tagIDs[i] < tagIDs[j] })
+	for _, id := range tagIDs {
+		name := fmt.Sprintf("tags/%d", id)
+		response.Nodes = append(response.Nodes, &apiv1.NoteGraphNode{
+			Name:   name,
+			Type:   apiv1.NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_TAG,
+			Title:  graph.Tags[id],
+			Degree: degree[name],
+			Weight: 1 + float64(degree[name]),
+		})
+	}
+	sort.Slice(categoryIDs, func(i, j int) bool { return categoryIDs[i] < categoryIDs[j] })
+	for _, id := range categoryIDs {
+		name := fmt.Sprintf("categories/%d", id)
+		response.Nodes = append(response.Nodes, &apiv1.NoteGraphNode{
+			Name:   name,
+			Type:   apiv1.NoteGraphNodeType_NOTE_GRAPH_NODE_TYPE_CATEGORY,
+			Title:  graph.Categories[id].Name,
+			Degree: degree[name],
+			Weight: 1 + float64(degree[name]),
+		})
+	}
+
+	return response, nil
+}
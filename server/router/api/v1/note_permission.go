@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// requireNoteAccess 检查用户对笔记的访问级别不低于 minLevel，action 为错误信息中描述的操作
+func (s *APIV1Service) requireNoteAccess(ctx context.Context, note *pbstore.Note, user *store.User, minLevel store.NoteAccessLevel, action string) error {
+	level, err := s.Store.NoteAccessLevelOf(ctx, note, user)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check note permission: %v", err)
+	}
+	if level >= minLevel {
+		return nil
+	}
+	if user == nil {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	switch minLevel {
+	case store.NoteAccessOwner:
+		return status.Errorf(codes.PermissionDenied, "permission denied: only author or admin can %s", action)
+	case store.NoteAccessEditor:
+		return status.Errorf(codes.PermissionDenied, "permission denied: editor role required to %s", action)
+	case store.NoteAccessCommenter:
+		return status.Errorf(codes.PermissionDenied, "permission denied: commenter role required to %s", action)
+	default:
+		return status.Errorf(codes.PermissionDenied, "permission denied: cannot %s", action)
+	}
+}
+
+// GrantNotePermission 授予用户对笔记或分类的协作者角色
+func (s *APIV1Service) GrantNotePermission(ctx context.Context, req *apiv1.GrantNotePermissionRequest) (*pbstore.NotePermission, error) {
+	noteID, categoryID, currentUser, err := s.getPermissionResource(ctx, req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRole() == pbstore.NotePermissionRole_NOTE_PERMISSION_ROLE_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "role is required")
+	}
+	if _, ok := pbstore.NotePermissionRole_name[int32(req.GetRole())]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid role: %v", req.GetRole())
+	}
+
+	userID, err := extractIDFromResourceName(req.GetUser(), "users")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if user, err := s.Store.GetUserByID(ctx, uint(userID)); err != nil || user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found: %s", req.GetUser())
+	}
+
+	permission, err := s.Store.UpsertNotePermission(ctx, userID, noteID, categoryID, req.GetRole(), int64(currentUser.ID))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "授予权限失败: %v", err)
+	}
+	return permission, nil
+}
+
+// ListNotePermissions 返回笔记或分类的权限
+func (s *APIV1Service) ListNotePermissions(ctx context.Context, req *apiv1.ListNotePermissionsRequest) (*apiv1.ListNotePermissionsResponse, error) {
+	noteID, categoryID, _, err := s.getPermissionResource(ctx, req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []*pbstore.NotePermission
+	if noteID > 0 {
+		note, err := s.Store.GetNote(ctx, noteID)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "获取笔记失败: %v", err)
+		}
+		noteCategoryID, _ := strconv.ParseInt(note.CategoryId, 10, 64)
+		permissions, err = s.Store.ListNotePermissions(ctx, noteID, noteCategoryID)
+	} else {
+		permissions, err = s.Store.ListCategoryPermissions(ctx, categoryID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取权限失败: %v", err)
+	}
+	if permissions == nil {
+		permissions = []*pbstore.NotePermission{}
+	}
+	return &apiv1.ListNotePermissionsResponse{Permissions: permissions}, nil
+}
+
+// RevokeNotePermission 撤销用户对笔记或分类的权限
+func (s *APIV1Service) RevokeNotePermission(ctx context.Context, req *apiv1.RevokeNotePermissionRequest) (*emptypb.Empty, error) {
+	resource, userName, ok := strings.Cut(trimWorkspacePrefix(req.GetName()), "/permissions/")
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid permission name: %s", req.GetName())
+	}
+	userID, err := strconv.ParseInt(userName, 10, 64)
+	if err != nil || userID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid permission name: %s", req.GetName())
+	}
+	noteID, categoryID, _, err := s.getPermissionResource(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.Store.DeleteNotePermission(ctx, userID, noteID, categoryID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "撤销权限失败: %v", err)
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "permission not found: %s", req.GetName())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// getPermissionResource 解析权限的资源并检查当前用户可以管理其权限：笔记由作者和管理员管理，分类只有管理员可以管理
+// 返回笔记ID和分类ID（只有一个不为 0）
+func (s *APIV1Service) getPermissionResource(ctx context.Context, resource string) (int64, int64, *store.User, error) {
+	resource = trimWorkspacePrefix(resource)
+	if strings.HasPrefix(resource, "categories/") {
+		if err := s.checkWorkspaceAdmin(ctx, "manage category permissions"); err != nil {
+			return 0, 0, nil, err
+		}
+		currentUser, err := s.fetchCurrentUser(ctx)
+		if err != nil {
+			return 0, 0, nil, status.Errorf(codes.Unauthenticated, "authentication required")
+		}
+		categoryID, err := extractIDFromResourceName(resource, "categories")
+		if err != nil {
+			return 0, 0, nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if _, err := s.Store.GetCategory(ctx, categoryID); err != nil {
+			return 0, 0, nil, status.Errorf(codes.NotFound, "category not found: %s", resource)
+		}
+		return 0, categoryID, currentUser, nil
+	}
+
+	if !strings.HasPrefix(resource, "notes/") {
+		return 0, 0, nil, status.Errorf(codes.InvalidArgument, "invalid resource: %s", resource)
+	}
+	note, currentUser, err := s.getOwnedNote(ctx, resource)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return note.Id, 0, currentUser, nil
+}
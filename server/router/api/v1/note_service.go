@@ -1,15 +1,20 @@
 package v1
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/wdmsyhh/simple-notes/internal/importer"
 	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
 	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
 	"github.com/wdmsyhh/simple-notes/store"
@@ -31,78 +36,81 @@ func (s *APIV1Service) ListNotes(ctx context.Context, req *apiv1.ListNotesReques
 		pageSize = 100 // 将分页大小限制为100
 	}
 
+	// 获取当前用户，可见性在存储层过滤，保证总数与返回的笔记一致
+	currentUser, _ := s.fetchCurrentUser(ctx)
+
 	// 创建存储层请求
 	storeReq := &store.ListNotesRequest{
 		Page:               page,
 		PageSize:           pageSize,
 		CategoryID:         req.GetCategoryId(),
+		IncludeDescendants: req.GetIncludeDescendants(),
 		TagID:              req.GetTagId(),
 		Search:             req.GetSearch(),
 		SortBy:             req.GetSortBy(),
 		SortDesc:           req.GetSortDesc(),
+		PageToken:          req.GetPageToken(),
+		Filter:             req.GetFilter(),
 		IncludeUnpublished: false, // API只返回已发布的帖子
 	}
+	s.applyNoteListVisibility(ctx, storeReq, currentUser)
 
 	// 调用存储层获取笔记列表
-	notes, totalCount, err := s.Store.ListNotes(ctx, storeReq)
+	notePage, err := s.Store.ListNotesPage(ctx, storeReq)
 	if err != nil {
+		if errors.Is(err, store.ErrInvalidSortField) || errors.Is(err, store.ErrInvalidPageToken) || errors.Is(err, store.ErrInvalidFilter) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, fmt.Errorf("获取笔记列表失败: %w", err)
 	}
 
-	// 获取当前用户
-	currentUser, _ := s.fetchCurrentUser(ctx)
-
-	// 根据可见性过滤笔记并设置资源名称
-	visibleNotes := []*pbstore.Note{}
-	for _, note := range notes {
-		// 检查笔记可见性
-		if s.isNoteVisibleToUser(note, currentUser) {
-			// 设置资源名称
-			note.Name = fmt.Sprintf("notes/%d", note.Id)
-			visibleNotes = append(visibleNotes, note)
-		}
+	// 设置资源名称
+	for _, note := range notePage.Notes {
+		note.Name = fmt.Sprintf("notes/%d", note.Id)
+	}
+	notes := notePage.Notes
+	if notes == nil {
+		notes = []*pbstore.Note{}
 	}
 
-	// 计算总页数（使用数据库返回的总记录数）
-	// 注意：totalCount 是数据库查询的总记录数，可能包含不可见的笔记
-	// 但对于首页和分类页面，通常都是公开笔记，所以可以使用 totalCount
-	totalPages := int32(math.Ceil(float64(totalCount) / float64(pageSize)))
+	// 计算总页数
+	totalPages := int32(math.Ceil(float64(notePage.Total) / float64(pageSize)))
 	if totalPages < 1 {
 		totalPages = 1
 	}
 
 	// 创建响应对象
 	response := &apiv1.ListNotesResponse{
-		Notes:      visibleNotes,
-		Total:      int32(totalCount), // 使用数据库返回的总记录数，而不是当前页的数量
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
+		Notes:         notes,
+		Total:         int32(notePage.Total),
+		Page:          page,
+		PageSize:      pageSize,
+		TotalPages:    totalPages,
+		NextPageToken: notePage.NextPageToken,
 	}
 
 	return response, nil
 }
 
-// isNoteVisibleToUser 检查用户是否有权访问指定笔记
-func (s *APIV1Service) isNoteVisibleToUser(note *pbstore.Note, user *store.User) bool {
-	// 公共笔记对所有人可见
-	if note.Visibility == pbstore.NoteVisibility_NOTE_VISIBILITY_PUBLIC {
-		return true
+// applyNoteListVisibility 按访问者设置笔记列表的可见性条件：未登录时只返回公开笔记，管理员和工作区的所有者、管理员可以看到全部笔记，
+// 其他用户还能看到自己的和被授予了权限的私有笔记
+func (s *APIV1Service) applyNoteListVisibility(ctx context.Context, storeReq *store.ListNotesRequest, currentUser *store.User) {
+	switch {
+	case currentUser == nil:
+		storeReq.Visibility = "PUBLIC"
+	case !s.isWorkspaceAdmin(ctx, currentUser):
+		storeReq.VisibleToUserID = strconv.FormatUint(uint64(currentUser.ID), 10)
 	}
-
-	// 私有笔记仅对作者和管理员可见
-	if user == nil {
-		return false
-	}
-
-	// 检查用户是否为笔记作者
-	authorID, _ := strconv.ParseUint(note.AuthorId, 10, 32)
-	if user.ID == uint(authorID) {
-		return true
+	if currentUser != nil {
+		storeReq.ViewerID = int64(currentUser.ID)
 	}
+}
 
-	// 检查用户是否为管理员或主机
-	return user.Role == store.RoleAdmin || user.Role == store.RoleHost
+// isNoteVisibleToUser 检查用户是否有权访问指定笔记：公开笔记对所有人可见，私有笔记对作者、管理员和被授予了权限的用户可见
+// 查询权限失败时视为不可见
+func (s *APIV1Service) isNoteVisibleToUser(ctx context.Context, note *pbstore.Note, user *store.User) bool {
+	level, err := s.Store.NoteAccessLevelOf(ctx, note, user)
+	return err == nil && level >= store.NoteAccessPublic
 }
 
 // GetNote 根据ID获取笔记
@@ -114,16 +122,48 @@ func (s *APIV1Service) GetNote(ctx context.Context, req *apiv1.GetNoteRequest) (
 		return nil, err
 	}
 
+	// 分享链接不限定工作区：提供了分享令牌时在全部工作区中查找笔记，访问级别按笔记所属的工作区计算（见 store.NoteAccessLevelOf）
+	lookupCtx := ctx
+	if req.GetShareToken() != "" {
+		lookupCtx = store.AllWorkspaces(ctx)
+	}
+
 	// 调用存储层获取笔记
-	note, err := s.Store.GetNote(ctx, noteID)
+	note, err := s.Store.GetNote(lookupCtx, noteID)
 	if err != nil {
 		return nil, fmt.Errorf("获取笔记失败: %w", err)
 	}
 
-	// 检查可见性权限
+	// 检查可见性权限，无权查看时可以使用分享链接访问
 	currentUser, _ := s.fetchCurrentUser(ctx)
-	if !s.isNoteVisibleToUser(note, currentUser) {
-		return nil, fmt.Errorf("没有权限访问该笔记")
+	var shareQuery string
+	if !s.isNoteVisibleToUser(lookupCtx, note, currentUser) {
+		if req.GetShareToken() == "" {
+			return nil, fmt.Errorf("没有权限访问该笔记")
+		}
+		shareQuery, err = s.openNoteShareLink(ctx, note.Id, req.GetShareToken(), req.GetSharePassword())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 记录浏览（同一访客在去重窗口内只计一次，作者本人的浏览不计入）
+	s.recordNoteView(ctx, note, currentUser)
+
+	// 按需返回服务端渲染的 HTML
+	if req.GetRenderedHtml() {
+		renderedHTML, err := s.MarkdownRenderer.RenderNote(note.Id, note.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "渲染笔记失败: %v", err)
+		}
+		note.RenderedHtml = renderedHTML
+	}
+
+	// 通过分享链接访问时，为附件地址附加访问附件所需的参数（渲染结果按笔记缓存，在缓存之外修改）
+	if shareQuery != "" {
+		note.Content = shareAttachmentURLs(note.Content, shareQuery, "&")
+		note.CoverImage = shareAttachmentURLs(note.CoverImage, shareQuery, "&")
+		note.RenderedHtml = shareAttachmentURLs(note.RenderedHtml, shareQuery, "&amp;")
 	}
 
 	// 设置资源名称
@@ -132,6 +172,141 @@ func (s *APIV1Service) GetNote(ctx context.Context, req *apiv1.GetNoteRequest) (
 	return note, nil
 }
 
+// RenderNote 在服务端将笔记内容渲染为经过安全过滤的 HTML
+// 提供 name 时渲染已保存的笔记（结果按笔记ID缓存，内容摘要变化时重新渲染），否则渲染请求中的 content
+func (s *APIV1Service) RenderNote(ctx context.Context, req *apiv1.RenderNoteRequest) (*apiv1.RenderNoteResponse, error) {
+	currentUser, _ := s.fetchCurrentUser(ctx)
+
+	// 渲染请求中的内容，仅对已登录用户开放（用于编辑器预览）
+	if req.GetName() == "" {
+		if currentUser == nil {
+			return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+		}
+		renderedHTML, err := s.MarkdownRenderer.Render(req.GetContent())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "渲染内容失败: %v", err)
+		}
+		return &apiv1.RenderNoteResponse{Html: renderedHTML}, nil
+	}
+
+	noteID, err := extractIDFromResourceName(req.GetName(), "notes")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "获取笔记失败: %v", err)
+	}
+	if !s.isNoteVisibleToUser(ctx, note, currentUser) {
+		return nil, status.Errorf(codes.PermissionDenied, "没有权限访问该笔记")
+	}
+
+	renderedHTML, err := s.MarkdownRenderer.RenderNote(note.Id, note.Content)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "渲染笔记失败: %v", err)
+	}
+
+	return &apiv1.RenderNoteResponse{Html: renderedHTML}, nil
+}
+
+// GetNoteStats 获取笔记的阅读统计，仅作者和管理员可查看
+func (s *APIV1Service) GetNoteStats(ctx context.Context, req *apiv1.GetNoteStatsRequest) (*apiv1.NoteStats, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	noteID, err := extractIDFromResourceName(req.GetName(), "notes")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "获取笔记失败: %v", err)
+	}
+
+	if err := s.requireNoteAccess(ctx, note, currentUser, store.NoteAccessOwner, "view note stats"); err != nil {
+		return nil, err
+	}
+
+	days := req.GetDays()
+	if days <= 0 {
+		days = 30
+	} else if days > 365 {
+		days = 365
+	}
+
+	endDate := time.Now().UTC()
+	startDate := endDate.AddDate(0, 0, -int(days-1))
+	views, err := s.Store.ListNoteViews(ctx, noteID, startDate.Format(store.NoteViewDateLayout), endDate.Format(store.NoteViewDateLayout))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取浏览统计失败: %v", err)
+	}
+
+	// 合并数据库中的统计和尚未写入的浏览量
+	viewsByDate := make(map[string]int64, len(views))
+	for _, view := range views {
+		viewsByDate[view.Date] = view.ViewCount
+	}
+	viewCount := int64(note.ViewCount)
+	if s.ViewRecorder != nil {
+		for date, count := range s.ViewRecorder.PendingByDate(noteID) {
+			viewsByDate[date] += count
+			viewCount += count
+		}
+	}
+
+	// 补齐没有浏览的日期
+	dailyViews := make([]*apiv1.DailyViewCount, 0, days)
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		key := date.Format(store.NoteViewDateLayout)
+		dailyViews = append(dailyViews, &apiv1.DailyViewCount{
+			Date:      key,
+			ViewCount: viewsByDate[key],
+		})
+	}
+
+	return &apiv1.NoteStats{
+		Name:        fmt.Sprintf("notes/%d", note.Id),
+		ViewCount:   viewCount,
+		WordCount:   note.WordCount,
+		ReadingTime: note.ReadingTime,
+		DailyViews:  dailyViews,
+	}, nil
+}
+
+// recordNoteView 记录一次笔记浏览，并让返回的浏览量包含尚未写入数据库的部分
+// 只统计已发布笔记；已登录用户按用户ID去重，匿名访客按客户端IP和User-Agent去重，作者本人的浏览不计入
+func (s *APIV1Service) recordNoteView(ctx context.Context, note *pbstore.Note, user *store.User) {
+	if s.ViewRecorder == nil || !note.Published {
+		return
+	}
+
+	if visitor := noteVisitorID(ctx, note, user); visitor != "" {
+		s.ViewRecorder.Record(note.Id, visitor)
+	}
+	note.ViewCount += int32(s.ViewRecorder.Pending(note.Id))
+}
+
+// noteVisitorID 生成用于浏览去重的访客标识，返回空字符串表示不计入浏览
+func noteVisitorID(ctx context.Context, note *pbstore.Note, user *store.User) string {
+	if user != nil {
+		authorID, _ := strconv.ParseUint(note.AuthorId, 10, 32)
+		if user.ID == uint(authorID) {
+			return ""
+		}
+		return fmt.Sprintf("user:%d", user.ID)
+	}
+
+	clientIP, userAgent := getClientInfo(ctx)
+	if clientIP == "" && userAgent == "" {
+		return ""
+	}
+	return "anon:" + clientIP + "|" + userAgent
+}
+
 // CreateNote 创建新笔记
 func (s *APIV1Service) CreateNote(ctx context.Context, req *apiv1.CreateNoteRequest) (*pbstore.Note, error) {
 	// 检查认证
@@ -139,6 +314,9 @@ func (s *APIV1Service) CreateNote(ctx context.Context, req *apiv1.CreateNoteRequ
 	if err != nil || currentUser == nil {
 		return nil, fmt.Errorf("authentication required")
 	}
+	if err := s.checkWorkspaceMember(ctx, currentUser, "create note"); err != nil {
+		return nil, err
+	}
 
 	// 从请求中获取笔记信息
 	note := req.GetNote()
@@ -146,13 +324,10 @@ func (s *APIV1Service) CreateNote(ctx context.Context, req *apiv1.CreateNoteRequ
 		return nil, fmt.Errorf("笔记信息不能为空")
 	}
 
-	// 验证笔记数据：标题、描述、内容都是必填
+	// 验证笔记数据：标题、内容是必填，描述为空时由存储层根据内容自动生成
 	if note.Title == "" {
 		return nil, fmt.Errorf("标题不能为空")
 	}
-	if note.Summary == "" {
-		return nil, fmt.Errorf("描述不能为空")
-	}
 	if note.Content == "" {
 		return nil, fmt.Errorf("内容不能为空")
 	}
@@ -163,9 +338,13 @@ func (s *APIV1Service) CreateNote(ctx context.Context, req *apiv1.CreateNoteRequ
 	// 设置作者ID
 	note.AuthorId = fmt.Sprintf("%d", currentUser.ID)
 
-	// 如果设置为发布，设置发布时间
-	if note.Published {
-		note.PublishedAt = time.Now().Unix()
+	// 如果设置为发布且未指定发布时间，设置发布时间；发布时间在未来时等待定时发布
+	now := time.Now().Unix()
+	if (note.Published || note.Scheduled) && note.PublishedAt <= 0 {
+		note.PublishedAt = now
+	}
+	if err := validateNoteSchedule(note, now); err != nil {
+		return nil, err
 	}
 
 	// 调用存储层创建笔记
@@ -173,6 +352,8 @@ func (s *APIV1Service) CreateNote(ctx context.Context, req *apiv1.CreateNoteRequ
 	if err != nil {
 		return nil, fmt.Errorf("创建笔记失败: %w", err)
 	}
+	s.notifyNoteScheduler(createdNote)
+	s.notifyRelatedIndexer(createdNote.Id)
 
 	// 设置资源名称
 	createdNote.Name = fmt.Sprintf("notes/%d", createdNote.Id)
@@ -213,26 +394,36 @@ func (s *APIV1Service) UpdateNote(ctx context.Context, req *apiv1.UpdateNoteRequ
 		return nil, fmt.Errorf("获取笔记失败: %w", err)
 	}
 
-	// 检查权限：只有作者或管理员可以更新
-	authorID, _ := strconv.ParseUint(existingNote.AuthorId, 10, 32)
-	if currentUser.ID != uint(authorID) && currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied: only author or admin can update note")
+	// 检查权限：作者、管理员和编辑者可以更新，编辑者不能修改作者和可见性
+	level, err := s.Store.NoteAccessLevelOf(ctx, existingNote, currentUser)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check note permission: %v", err)
+	}
+	if level < store.NoteAccessEditor {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: only author, admin or editor can update note")
+	}
+	if level < store.NoteAccessOwner {
+		if err := checkEditorNoteChanges(existingNote, note.AuthorId, note.Visibility); err != nil {
+			return nil, err
+		}
+		note.AuthorId = existingNote.AuthorId
 	}
 
-	// 验证笔记数据：标题、描述、内容都是必填
+	// 验证笔记数据：标题、内容是必填，描述为空时由存储层根据内容自动生成
 	if note.Title == "" {
 		return nil, fmt.Errorf("标题不能为空")
 	}
-	if note.Summary == "" {
-		return nil, fmt.Errorf("描述不能为空")
-	}
 	if note.Content == "" {
 		return nil, fmt.Errorf("内容不能为空")
 	}
 
-	// 如果从未发布变为发布，设置发布时间
-	if !existingNote.Published && note.Published {
-		note.PublishedAt = time.Now().Unix()
+	// 如果从未发布变为发布，设置发布时间；指定了未来的发布时间时等待定时发布
+	now := time.Now().Unix()
+	if !existingNote.Published && (note.Published || note.Scheduled) && note.PublishedAt <= now {
+		note.PublishedAt = now
+	}
+	if err := validateNoteSchedule(note, now); err != nil {
+		return nil, err
 	}
 
 	// 调用存储层更新笔记
@@ -240,6 +431,8 @@ func (s *APIV1Service) UpdateNote(ctx context.Context, req *apiv1.UpdateNoteRequ
 	if err != nil {
 		return nil, fmt.Errorf("更新笔记失败: %w", err)
 	}
+	s.notifyNoteScheduler(updatedNote)
+	s.notifyRelatedIndexer(updatedNote.Id)
 
 	// 设置资源名称
 	updatedNote.Name = fmt.Sprintf("notes/%d", updatedNote.Id)
@@ -247,6 +440,36 @@ func (s *APIV1Service) UpdateNote(ctx context.Context, req *apiv1.UpdateNoteRequ
 	return updatedNote, nil
 }
 
+// validateNoteSchedule 检查笔记的过期时间：必须晚于发布时间，发布时必须在未来
+func validateNoteSchedule(note *pbstore.Note, now int64) error {
+	if note.ExpireAt <= 0 {
+		return nil
+	}
+	if note.PublishedAt > 0 && note.ExpireAt <= note.PublishedAt {
+		return status.Errorf(codes.InvalidArgument, "expire_at must be after published_at")
+	}
+	if (note.Published || note.Scheduled) && note.ExpireAt <= now {
+		return status.Errorf(codes.InvalidArgument, "expire_at must be in the future")
+	}
+	return nil
+}
+
+// notifyNoteScheduler 笔记设置了定时发布或过期时间时通知定时任务重新计算下一次检查的时间
+func (s *APIV1Service) notifyNoteScheduler(note *pbstore.Note) {
+	if s.NoteScheduler == nil || (!note.Scheduled && note.ExpireAt <= 0) {
+		return
+	}
+	s.NoteScheduler.Notify()
+}
+
+// notifyRelatedIndexer 通知相关笔记索引笔记已创建、更新或删除
+func (s *APIV1Service) notifyRelatedIndexer(noteIDs ...int64) {
+	if s.RelatedIndexer == nil {
+		return
+	}
+	s.RelatedIndexer.Notify(noteIDs...)
+}
+
 // DeleteNote 删除笔记
 func (s *APIV1Service) DeleteNote(ctx context.Context, req *apiv1.DeleteNoteRequest) (*emptypb.Empty, error) {
 	// 检查认证
@@ -267,10 +490,9 @@ func (s *APIV1Service) DeleteNote(ctx context.Context, req *apiv1.DeleteNoteRequ
 		return nil, fmt.Errorf("获取笔记失败: %w", err)
 	}
 
-	// 检查权限：只有作者或管理员可以删除
-	authorID, _ := strconv.ParseUint(existingNote.AuthorId, 10, 32)
-	if currentUser.ID != uint(authorID) && currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied: only author or admin can delete note")
+	// 检查权限：只有作者或管理员可以删除，编辑者不能删除
+	if err := s.requireNoteAccess(ctx, existingNote, currentUser, store.NoteAccessOwner, "delete note"); err != nil {
+		return nil, err
 	}
 
 	// 调用存储层删除笔记
@@ -278,7 +500,334 @@ func (s *APIV1Service) DeleteNote(ctx context.Context, req *apiv1.DeleteNoteRequ
 		return nil, fmt.Errorf("删除笔记失败: %w", err)
 	}
 
+	// 清除渲染缓存
+	s.MarkdownRenderer.Invalidate(noteID)
+	s.notifyRelatedIndexer(noteID)
+
 	return &emptypb.Empty{}, nil
 }
 
 // GetNoteBySlug 已移除，请使用 GetNote 通过 ID 获取笔记
+
+// ImportNotes 从 zip 压缩包批量导入 Markdown 笔记，导入的笔记属于当前用户
+func (s *APIV1Service) ImportNotes(ctx context.Context, req *apiv1.ImportNotesRequest) (*apiv1.ImportNotesResponse, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	if err := s.checkWorkspaceMember(ctx, currentUser, "import notes"); err != nil {
+		return nil, err
+	}
+
+	if len(req.GetZip()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "zip is required")
+	}
+	reader, err := zip.NewReader(bytes.NewReader(req.GetZip()), int64(len(req.GetZip())))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid zip: %v", err)
+	}
+
+	report, err := importer.NewImporter(s.Store).ImportMarkdown(ctx, reader, importer.Options{
+		AuthorID:   currentUser.ID,
+		DryRun:     req.GetDryRun(),
+		Visibility: req.GetVisibility(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "导入笔记失败: %v", err)
+	}
+
+	response := &apiv1.ImportNotesResponse{
+		CreatedTags:         report.CreatedTags,
+		CreatedCategories:   report.CreatedCategories,
+		AttachmentsUploaded: int32(report.AttachmentsUploaded),
+		DryRun:              req.GetDryRun(),
+	}
+	for _, result := range report.Notes {
+		note := &apiv1.ImportedNote{
+			Path:    result.Path,
+			Title:   result.Title,
+			Status:  string(result.Status),
+			Message: result.Message,
+		}
+		if result.NoteID > 0 {
+			note.Note = fmt.Sprintf("notes/%d", result.NoteID)
+			s.notifyRelatedIndexer(result.NoteID)
+		}
+		response.Notes = append(response.Notes, note)
+	}
+	for _, issue := range report.Issues {
+		response.Issues = append(response.Issues, &apiv1.ImportIssue{
+			Path:    issue.Path,
+			Kind:    string(issue.Kind),
+			Message: issue.Message,
+		})
+	}
+	return response, nil
+}
+
+// GetNoteDraft 返回当前用户对笔记的工作草稿，作者、管理员和编辑者可以编辑笔记
+func (s *APIV1Service) GetNoteDraft(ctx context.Context, req *apiv1.GetNoteDraftRequest) (*pbstore.NoteDraft, error) {
+	note, currentUser, err := s.getEditableNote(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := s.Store.GetNoteDraft(ctx, note.Id, int64(currentUser.ID))
+	if err != nil {
+		return nil, noteDraftError("获取草稿失败", err)
+	}
+	return draft, nil
+}
+
+// SaveNoteDraft 自动保存当前用户的工作草稿，读者看到的仍然是笔记已发布的内容
+func (s *APIV1Service) SaveNoteDraft(ctx context.Context, req *apiv1.SaveNoteDraftRequest) (*pbstore.NoteDraft, error) {
+	draft := req.GetDraft()
+	if draft == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "草稿信息不能为空")
+	}
+
+	// 草稿资源名称格式: "notes/{note}/draft"
+	name := strings.TrimSuffix(draft.Name, "/draft")
+	if name == "" && draft.NoteId > 0 {
+		name = fmt.Sprintf("notes/%d", draft.NoteId)
+	}
+	note, currentUser, err := s.getEditableNote(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	draft.NoteId = note.Id
+
+	// 草稿允许内容暂时为空（自动保存），标题为空时沿用笔记的标题
+	if strings.TrimSpace(draft.Title) == "" {
+		draft.Title = note.Title
+	}
+
+	saved, err := s.Store.SaveNoteDraft(ctx, draft, int64(currentUser.ID))
+	if err != nil {
+		return nil, noteDraftError("保存草稿失败", err)
+	}
+	return saved, nil
+}
+
+// PublishDraft 将当前用户的工作草稿发布为笔记内容，同时记录修订并删除草稿
+func (s *APIV1Service) PublishDraft(ctx context.Context, req *apiv1.PublishDraftRequest) (*pbstore.Note, error) {
+	note, currentUser, err := s.getEditableNote(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	// 发布的内容需要满足与 UpdateNote 相同的校验
+	draft, err := s.Store.GetNoteDraft(ctx, note.Id, int64(currentUser.ID))
+	if err != nil {
+		return nil, noteDraftError("获取草稿失败", err)
+	}
+	if draft.Title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "标题不能为空")
+	}
+	if draft.Content == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "内容不能为空")
+	}
+	level, err := s.Store.NoteAccessLevelOf(ctx, note, currentUser)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check note permission: %v", err)
+	}
+	if level < store.NoteAccessOwner {
+		if err := checkEditorNoteChanges(note, "", draft.Visibility); err != nil {
+			return nil, err
+		}
+	}
+
+	publishedNote, err := s.Store.PublishNoteDraft(ctx, note.Id, int64(currentUser.ID))
+	if err != nil {
+		return nil, noteDraftError("发布草稿失败", err)
+	}
+	s.notifyRelatedIndexer(publishedNote.Id)
+
+	// 设置资源名称
+	publishedNote.Name = fmt.Sprintf("notes/%d", publishedNote.Id)
+
+	return publishedNote, nil
+}
+
+// DiscardDraft 删除当前用户的工作草稿，笔记的已发布内容不变
+func (s *APIV1Service) DiscardDraft(ctx context.Context, req *apiv1.DiscardDraftRequest) (*emptypb.Empty, error) {
+	note, currentUser, err := s.getEditableNote(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Store.DeleteNoteDraft(ctx, note.Id, int64(currentUser.ID)); err != nil {
+		return nil, noteDraftError("删除草稿失败", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListNoteRevisions 返回笔记的修订记录，作者、管理员和编辑者可以查看
+func (s *APIV1Service) ListNoteRevisions(ctx context.Context, req *apiv1.ListNoteRevisionsRequest) (*apiv1.ListNoteRevisionsResponse, error) {
+	note, _, err := s.getEditableNote(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.Store.ListNoteRevisions(ctx, note.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取修订记录失败: %v", err)
+	}
+	return &apiv1.ListNoteRevisionsResponse{Revisions: revisions}, nil
+}
+
+// isNoteListedForUser 检查笔记是否可以出现在访问者看到的关联笔记中：需要可以访问，未发布的笔记只对作者、管理员和被授予了权限的用户显示
+func (s *APIV1Service) isNoteListedForUser(ctx context.Context, note *pbstore.Note, user *store.User) bool {
+	level, err := s.Store.NoteAccessLevelOf(ctx, note, user)
+	if err != nil || level < store.NoteAccessPublic {
+		return false
+	}
+	return note.Published || level >= store.NoteAccessViewer
+}
+
+// ListBacklinks 返回链接到该笔记的笔记，只返回访问者可以查看的笔记
+func (s *APIV1Service) ListBacklinks(ctx context.Context, req *apiv1.ListBacklinksRequest) (*apiv1.ListBacklinksResponse, error) {
+	noteID, err := extractIDFromResourceName(req.GetName(), "notes")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "获取笔记失败: %v", err)
+	}
+	currentUser, _ := s.fetchCurrentUser(ctx)
+	if !s.isNoteVisibleToUser(ctx, note, currentUser) {
+		return nil, status.Errorf(codes.PermissionDenied, "没有权限访问该笔记")
+	}
+
+	sources, err := s.Store.ListBacklinkNotes(ctx, noteID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取反向链接失败: %v", err)
+	}
+	notes := []*pbstore.Note{}
+	for _, source := range sources {
+		if !s.isNoteListedForUser(ctx, source, currentUser) {
+			continue
+		}
+		source.Name = fmt.Sprintf("notes/%d", source.Id)
+		notes = append(notes, source)
+	}
+	return &apiv1.ListBacklinksResponse{Notes: notes}, nil
+}
+
+// ListRelatedNotes 返回与笔记内容相似的笔记，只返回访问者可以查看的笔记
+func (s *APIV1Service) ListRelatedNotes(ctx context.Context, req *apiv1.ListRelatedNotesRequest) (*apiv1.ListRelatedNotesResponse, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 5
+	} else if pageSize > 20 {
+		pageSize = 20
+	}
+
+	noteID, err := extractIDFromResourceName(req.GetName(), "notes")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "获取笔记失败: %v", err)
+	}
+	currentUser, _ := s.fetchCurrentUser(ctx)
+	if !s.isNoteVisibleToUser(ctx, note, currentUser) {
+		return nil, status.Errorf(codes.PermissionDenied, "没有权限访问该笔记")
+	}
+
+	response := &apiv1.ListRelatedNotesResponse{RelatedNotes: []*apiv1.RelatedNote{}}
+	if s.RelatedIndexer == nil {
+		return response, nil
+	}
+	candidates, _ := s.RelatedIndexer.Related(noteID)
+	for _, candidate := range candidates {
+		if len(response.RelatedNotes) >= pageSize {
+			break
+		}
+		// 索引更新前笔记可能已被删除
+		relatedNote, err := s.Store.GetNote(ctx, candidate.NoteID)
+		if err != nil || !s.isNoteListedForUser(ctx, relatedNote, currentUser) {
+			continue
+		}
+		relatedNote.Name = fmt.Sprintf("notes/%d", relatedNote.Id)
+		response.RelatedNotes = append(response.RelatedNotes, &apiv1.RelatedNote{Note: relatedNote, Score: candidate.Score})
+	}
+	return response, nil
+}
+
+// ListUnresolvedLinks 返回没有找到目标笔记的链接，管理员可以查看全部，其他用户只能查看自己笔记中的链接
+func (s *APIV1Service) ListUnresolvedLinks(ctx context.Context, _ *apiv1.ListUnresolvedLinksRequest) (*apiv1.ListUnresolvedLinksResponse, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	authorID := int64(currentUser.ID)
+	if s.isWorkspaceAdmin(ctx, currentUser) {
+		authorID = 0
+	}
+	links, err := s.Store.ListUnresolvedNoteLinks(ctx, authorID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取未解析的链接失败: %v", err)
+	}
+	return &apiv1.ListUnresolvedLinksResponse{Links: links}, nil
+}
+
+// getEditableNote 获取当前用户可以编辑的笔记：需要登录，作者、管理员和被授予编辑者角色的用户可以编辑
+func (s *APIV1Service) getEditableNote(ctx context.Context, name string) (*pbstore.Note, *store.User, error) {
+	return s.getNoteWithAccess(ctx, name, store.NoteAccessEditor, "edit note")
+}
+
+// getOwnedNote 获取当前用户管理的笔记：需要登录，只有作者或管理员可以管理分享链接和权限
+func (s *APIV1Service) getOwnedNote(ctx context.Context, name string) (*pbstore.Note, *store.User, error) {
+	return s.getNoteWithAccess(ctx, name, store.NoteAccessOwner, "manage note")
+}
+
+// getNoteWithAccess 获取笔记并检查当前用户的访问级别不低于 minLevel
+func (s *APIV1Service) getNoteWithAccess(ctx context.Context, name string, minLevel store.NoteAccessLevel, action string) (*pbstore.Note, *store.User, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	noteID, err := extractIDFromResourceName(name, "notes")
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil {
+		return nil, nil, noteDraftError("获取笔记失败", err)
+	}
+
+	if err := s.requireNoteAccess(ctx, note, currentUser, minLevel, action); err != nil {
+		return nil, nil, err
+	}
+	return note, currentUser, nil
+}
+
+// checkEditorNoteChanges 检查编辑者提交的修改：作者和可见性只能由作者或管理员修改
+// authorID 为空时表示不修改作者
+func checkEditorNoteChanges(existing *pbstore.Note, authorID string, visibility pbstore.NoteVisibility) error {
+	if authorID != "" && authorID != existing.AuthorId {
+		return status.Errorf(codes.PermissionDenied, "permission denied: only author or admin can change note author")
+	}
+	isPrivate := func(v pbstore.NoteVisibility) bool { return v == pbstore.NoteVisibility_NOTE_VISIBILITY_PRIVATE }
+	if isPrivate(visibility) != isPrivate(existing.Visibility) {
+		return status.Errorf(codes.PermissionDenied, "permission denied: only author or admin can change note visibility")
+	}
+	return nil
+}
+
+// noteDraftError 将存储层错误转换为 gRPC 错误
+func noteDraftError(message string, err error) error {
+	switch {
+	case strings.Contains(err.Error(), "invalid tag id"), strings.Contains(err.Error(), "tag not found"):
+		// 草稿引用的标签无效或已被删除
+		return status.Errorf(codes.InvalidArgument, "%s: %v", message, err)
+	case strings.Contains(err.Error(), "not found"):
+		return status.Errorf(codes.NotFound, "%s: %v", message, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", message, err)
+}
@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/server/auth"
+	"github.com/wdmsyhh/simple-notes/service"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// shareTokenLength 分享令牌的长度
+	shareTokenLength = 32
+	// maxSharePasswordLength 访问密码的最大长度（bcrypt 只使用前 72 字节）
+	maxSharePasswordLength = 72
+)
+
+// attachmentURLPattern 匹配笔记内容和渲染结果中的附件地址
+var attachmentURLPattern = regexp.MustCompile(`/file/attachments/\d+/[^\s"'<>()?#]*`)
+
+// CreateShareLink 为笔记创建分享链接，只有作者和管理员可以创建
+// 令牌只在本次响应中返回，之后无法再次查看
+func (s *APIV1Service) CreateShareLink(ctx context.Context, req *apiv1.CreateShareLinkRequest) (*pbstore.ShareLink, error) {
+	note, currentUser, err := s.getOwnedNote(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetMaxViews() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max_views must not be negative")
+	}
+	link := &store.NoteShareLink{
+		NoteID:    note.Id,
+		CreatorID: int64(currentUser.ID),
+		MaxViews:  req.GetMaxViews(),
+	}
+	if req.GetExpireAt() != 0 {
+		if req.GetExpireAt() <= time.Now().Unix() {
+			return nil, status.Errorf(codes.InvalidArgument, "expire_at must be in the future")
+		}
+		link.ExpireAt = time.Unix(req.GetExpireAt(), 0)
+	}
+	if password := req.GetPassword(); password != "" {
+		if len(password) > maxSharePasswordLength {
+			return nil, status.Errorf(codes.InvalidArgument, "password must be at most %d bytes", maxSharePasswordLength)
+		}
+		passwordHash, err := service.HashPassword(password)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+		}
+		link.PasswordHash = passwordHash
+	}
+
+	token, err := auth.RandomString(shareTokenLength)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate share token: %v", err)
+	}
+	link.TokenHash = store.HashShareToken(token)
+
+	created, err := s.Store.CreateNoteShareLink(ctx, link)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "创建分享链接失败: %v", err)
+	}
+	shareLink := convertShareLinkFromStore(created)
+	shareLink.Token = token
+	shareLink.Url = fmt.Sprintf("/note/%d?share=%s", note.Id, token)
+	return shareLink, nil
+}
+
+// ListShareLinks 返回笔记的分享链接，只有作者和管理员可以查看
+func (s *APIV1Service) ListShareLinks(ctx context.Context, req *apiv1.ListShareLinksRequest) (*apiv1.ListShareLinksResponse, error) {
+	note, _, err := s.getOwnedNote(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.Store.ListNoteShareLinks(ctx, note.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取分享链接失败: %v", err)
+	}
+	response := &apiv1.ListShareLinksResponse{ShareLinks: []*pbstore.ShareLink{}}
+	for _, link := range links {
+		response.ShareLinks = append(response.ShareLinks, convertShareLinkFromStore(link))
+	}
+	return response, nil
+}
+
+// RevokeShareLink 撤销分享链接，只有作者和管理员可以撤销
+func (s *APIV1Service) RevokeShareLink(ctx context.Context, req *apiv1.RevokeShareLinkRequest) (*pbstore.ShareLink, error) {
+	noteName, linkID, err := parseShareLinkName(req.GetName())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	note, _, err := s.getOwnedNote(ctx, noteName)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.Store.GetNoteShareLink(ctx, linkID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取分享链接失败: %v", err)
+	}
+	if link == nil || link.NoteID != note.Id {
+		return nil, status.Errorf(codes.NotFound, "share link not found: %s", req.GetName())
+	}
+	if !link.Revoked {
+		if err := s.Store.RevokeNoteShareLink(ctx, link.ID); err != nil {
+			return nil, status.Errorf(codes.Internal, "撤销分享链接失败: %v", err)
+		}
+		link.Revoked = true
+	}
+	return convertShareLinkFromStore(link), nil
+}
+
+// openNoteShareLink 使用请求中的分享令牌打开访问者无权查看的笔记，并消耗一次查看次数
+// 返回加载附件时需要附加到附件地址的查询参数：分享令牌和短期有效的附件访问密钥（不包含访问密码）
+func (s *APIV1Service) openNoteShareLink(ctx context.Context, noteID int64, token, password string) (string, error) {
+	accessKey, err := s.authenticator.OpenShareLink(ctx, noteID, token, password)
+	switch {
+	case err == nil:
+		return url.Values{"share": {token}, "key": {accessKey}}.Encode(), nil
+	case errors.Is(err, auth.ErrShareLinkPasswordRequired), errors.Is(err, auth.ErrShareLinkPasswordIncorrect):
+		return "", status.Errorf(codes.Unauthenticated, "%v", err)
+	case errors.Is(err, auth.ErrShareLinkInvalid), errors.Is(err, auth.ErrShareLinkExhausted):
+		return "", status.Errorf(codes.PermissionDenied, "%v", err)
+	default:
+		return "", status.Errorf(codes.Internal, "%v", err)
+	}
+}
+
+// shareAttachmentURLs 为文本中的附件地址附加分享链接的查询参数，使通过分享链接打开笔记的访问者可以加载附件
+// separator 为参数之间的分隔符，HTML 中使用 "&amp;"
+func shareAttachmentURLs(text, query, separator string) string {
+	query = strings.ReplaceAll(query, "&", separator)
+	return attachmentURLPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return match + "?" + query
+	})
+}
+
+// parseShareLinkName 解析分享链接资源名称 notes/{note}/shareLinks/{share_link}（可以带工作区前缀）
+func parseShareLinkName(name string) (string, int64, error) {
+	parts := strings.Split(trimWorkspacePrefix(name), "/")
+	if len(parts) != 4 || parts[0] != "notes" || parts[2] != "shareLinks" {
+		return "", 0, fmt.Errorf("invalid share link name: %s", name)
+	}
+	id, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("invalid share link id: %s", parts[3])
+	}
+	return parts[0] + "/" + parts[1], id, nil
+}
+
+// convertShareLinkFromStore 将存储层的分享链接转换为 API 消息（不包含令牌）
+func convertShareLinkFromStore(link *store.NoteShareLink) *pbstore.ShareLink {
+	shareLink := &pbstore.ShareLink{
+		Name:        fmt.Sprintf("notes/%d/shareLinks/%d", link.NoteID, link.ID),
+		Id:          link.ID,
+		NoteId:      link.NoteID,
+		Creator:     fmt.Sprintf("users/%d", link.CreatorID),
+		HasPassword: link.PasswordHash != "",
+		MaxViews:    link.MaxViews,
+		ViewCount:   link.ViewCount,
+		Revoked:     link.Revoked,
+		CreatedAt:   link.CreatedAt.Unix(),
+	}
+	if !link.ExpireAt.IsZero() {
+		shareLink.ExpireAt = link.ExpireAt.Unix()
+	}
+	return shareLink
+}
@@ -0,0 +1,292 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// ListSavedSearches 返回当前用户的和其他用户共享的保存的搜索，包含上次查看后新增的笔记数量
+func (s *APIV1Service) ListSavedSearches(ctx context.Context, _ *apiv1.ListSavedSearchesRequest) (*apiv1.ListSavedSearchesResponse, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	searches, err := s.Store.ListSavedSearches(ctx, int64(currentUser.ID))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list saved searches: %v", err)
+	}
+	for _, search := range searches {
+		if err := s.fillSavedSearchNewCount(ctx, search, currentUser); err != nil {
+			return nil, err
+		}
+	}
+	return &apiv1.ListSavedSearchesResponse{SavedSearches: searches}, nil
+}
+
+// GetSavedSearch 根据ID返回保存的搜索
+func (s *APIV1Service) GetSavedSearch(ctx context.Context, req *apiv1.GetSavedSearchRequest) (*pbstore.SavedSearch, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	search, err := s.getSavedSearch(ctx, req.GetName(), currentUser, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillSavedSearchNewCount(ctx, search, currentUser); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// CreateSavedSearch 创建保存的搜索
+func (s *APIV1Service) CreateSavedSearch(ctx context.Context, req *apiv1.CreateSavedSearchRequest) (*pbstore.SavedSearch, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSavedSearch() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "saved search is required")
+	}
+
+	search, err := s.Store.CreateSavedSearch(ctx, req.GetSavedSearch(), int64(currentUser.ID))
+	if err != nil {
+		return nil, savedSearchError("create", err)
+	}
+	return search, nil
+}
+
+// UpdateSavedSearch 更新保存的搜索，只有创建者可以更新
+func (s *APIV1Service) UpdateSavedSearch(ctx context.Context, req *apiv1.UpdateSavedSearchRequest) (*pbstore.SavedSearch, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	update := req.GetSavedSearch()
+	if update == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "saved search is required")
+	}
+	search, err := s.getSavedSearch(ctx, update.GetName(), currentUser, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按字段掩码合并，掩码为空时更新全部字段
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"name_text", "filter", "sort_by", "sort_desc", "pinned", "shared", "feed_enabled"}
+	}
+	for _, path := range paths {
+		switch path {
+		case "name_text":
+			search.NameText = update.GetNameText()
+		case "filter":
+			search.Filter = update.GetFilter()
+		case "sort_by":
+			search.SortBy = update.GetSortBy()
+		case "sort_desc":
+			search.SortDesc = update.GetSortDesc()
+		case "pinned":
+			search.Pinned = update.GetPinned()
+		case "shared":
+			search.Shared = update.GetShared()
+		case "feed_enabled":
+			search.FeedEnabled = update.GetFeedEnabled()
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported update mask path: %s", path)
+		}
+	}
+
+	updated, err := s.Store.UpdateSavedSearch(ctx, search, int64(currentUser.ID))
+	if err != nil {
+		return nil, savedSearchError("update", err)
+	}
+	return updated, nil
+}
+
+// DeleteSavedSearch 删除保存的搜索，只有创建者可以删除
+func (s *APIV1Service) DeleteSavedSearch(ctx context.Context, req *apiv1.DeleteSavedSearchRequest) (*emptypb.Empty, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	search, err := s.getSavedSearch(ctx, req.GetName(), currentUser, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteSavedSearch(ctx, search.Id); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete saved search: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListSavedSearchNotes 执行保存的搜索，返回的笔记与 ListNotes 使用相同的查询和可见性规则
+// 保存的搜索还包含当前用户自己未发布的笔记（例如 published == false && author == "me" 查找自己的草稿）
+// 读取第一页时记录当前用户的查看时间
+func (s *APIV1Service) ListSavedSearchNotes(ctx context.Context, req *apiv1.ListSavedSearchNotesRequest) (*apiv1.ListNotesResponse, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	search, err := s.getSavedSearch(ctx, req.GetName(), currentUser, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+
+	viewedAt := time.Now()
+	storeReq := s.savedSearchNotesRequest(ctx, search, currentUser)
+	storeReq.Page = 1
+	storeReq.PageSize = pageSize
+	storeReq.PageToken = req.GetPageToken()
+	notePage, err := s.Store.ListNotesPage(ctx, storeReq)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list saved search notes: %v", err)
+	}
+
+	if req.GetPageToken() == "" {
+		if err := s.Store.MarkSavedSearchViewed(ctx, search.Id, int64(currentUser.ID), viewedAt); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to mark saved search viewed: %v", err)
+		}
+	}
+
+	notes := notePage.Notes
+	if notes == nil {
+		notes = []*pbstore.Note{}
+	}
+	for _, note := range notes {
+		note.Name = fmt.Sprintf("notes/%d", note.Id)
+	}
+	totalPages := int32(math.Ceil(float64(notePage.Total) / float64(pageSize)))
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &apiv1.ListNotesResponse{
+		Notes:         notes,
+		Total:         int32(notePage.Total),
+		Page:          1,
+		PageSize:      pageSize,
+		TotalPages:    totalPages,
+		NextPageToken: notePage.NextPageToken,
+	}, nil
+}
+
+// savedSearchNotesRequest 根据保存的搜索和当前用户构建笔记查询请求（不含分页参数）
+func (s *APIV1Service) savedSearchNotesRequest(ctx context.Context, search *pbstore.SavedSearch, currentUser *store.User) *store.ListNotesRequest {
+	userID := strconv.FormatUint(uint64(currentUser.ID), 10)
+	req := &store.ListNotesRequest{
+		Filter:              search.Filter,
+		SortBy:              search.SortBy,
+		SortDesc:            search.SortDesc,
+		ViewerID:            int64(currentUser.ID),
+		UnpublishedAuthorID: userID,
+	}
+	if !s.isWorkspaceAdmin(ctx, currentUser) {
+		req.VisibleToUserID = userID
+	}
+	return req
+}
+
+// fillSavedSearchNewCount 填充当前用户上次查看后新增的笔记数量，从未查看时为全部笔记数量
+func (s *APIV1Service) fillSavedSearchNewCount(ctx context.Context, search *pbstore.SavedSearch, currentUser *store.User) error {
+	lastViewedAt, err := s.Store.GetSavedSearchLastViewed(ctx, search.Id, int64(currentUser.ID))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get saved search last viewed time: %v", err)
+	}
+
+	req := s.savedSearchNotesRequest(ctx, search, currentUser)
+	req.Page = 1
+	req.PageSize = 1
+	if !lastViewedAt.IsZero() {
+		search.LastViewedAt = lastViewedAt.Unix()
+		since := fmt.Sprintf("created_at > %q", lastViewedAt.Format(time.RFC3339Nano))
+		if strings.TrimSpace(req.Filter) != "" {
+			req.Filter = "(" + req.Filter + ") && " + since
+		} else {
+			req.Filter = since
+		}
+	}
+
+	notePage, err := s.Store.ListNotesPage(ctx, req)
+	if err != nil {
+		// 保存后字段被删除等原因导致过滤表达式不再有效时，不影响列表的其他内容
+		if errors.Is(err, store.ErrInvalidFilter) || errors.Is(err, store.ErrInvalidSortField) {
+			return nil
+		}
+		return status.Errorf(codes.Internal, "failed to count saved search notes: %v", err)
+	}
+	search.NewCount = int32(notePage.Total)
+	return nil
+}
+
+// getSavedSearch 根据资源名称获取保存的搜索并检查权限：
+// 创建者可以读写，共享的保存的搜索其他用户只能读取；ownerOnly 为 true 时只允许创建者
+func (s *APIV1Service) getSavedSearch(ctx context.Context, name string, currentUser *store.User, ownerOnly bool) (*pbstore.SavedSearch, error) {
+	id, err := extractIDFromResourceName(name, "savedSearches")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid saved search name: %v", err)
+	}
+	search, err := s.Store.GetSavedSearch(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Errorf(codes.NotFound, "saved search not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get saved search: %v", err)
+	}
+
+	isOwner := search.Owner == fmt.Sprintf("users/%d", currentUser.ID)
+	switch {
+	case isOwner:
+		return search, nil
+	case !search.Shared:
+		// 未共享的保存的搜索对其他用户不可见
+		return nil, status.Errorf(codes.NotFound, "saved search not found")
+	case ownerOnly:
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: only the owner can modify this saved search")
+	}
+	return search, nil
+}
+
+// requireCurrentUser 返回当前登录用户，未登录时返回 Unauthenticated 错误
+func (s *APIV1Service) requireCurrentUser(ctx context.Context) (*store.User, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	return currentUser, nil
+}
+
+// savedSearchError 将存储层错误转换为 gRPC 错误
+func savedSearchError(action string, err error) error {
+	if errors.Is(err, store.ErrInvalidFilter) || errors.Is(err, store.ErrInvalidSortField) || strings.Contains(err.Error(), "name is required") {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if strings.Contains(err.Error(), "not found") {
+		return status.Errorf(codes.NotFound, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "failed to %s saved search: %v", action, err)
+}
@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wdmsyhh/simple-notes/internal/backup"
+	"github.com/wdmsyhh/simple-notes/internal/staticsite"
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// staticSiteDir 静态网站在数据目录中的导出目录
+const staticSiteDir = "static"
+
+// ExportStaticSite 将已发布的公开笔记导出为静态网站，仅管理员可操作
+// 导出目录固定为数据目录下的 static 目录，重复导出时只重新生成有变化的内容
+func (s *APIV1Service) ExportStaticSite(ctx context.Context, req *apiv1.ExportStaticSiteRequest) (*apiv1.ExportStaticSiteResponse, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	if currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: only admin can export static site")
+	}
+
+	if req.GetBaseUrl() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "base_url is required")
+	}
+	if s.StaticSiteExporter == nil || s.Profile == nil || s.Profile.Data == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "data directory is not configured")
+	}
+
+	outputDir := filepath.Join(s.Profile.Data, staticSiteDir)
+	result, err := s.StaticSiteExporter.Export(ctx, staticsite.Options{
+		OutputDir: outputDir,
+		BaseURL:   req.GetBaseUrl(),
+		Force:     req.GetForce(),
+	})
+	if err != nil {
+		if errors.Is(err, staticsite.ErrExportInProgress) {
+			return nil, status.Errorf(codes.Aborted, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "导出静态网站失败: %v", err)
+	}
+
+	return &apiv1.ExportStaticSiteResponse{
+		OutputDir:         outputDir,
+		NotesRendered:     int32(result.NotesRendered),
+		NotesSkipped:      int32(result.NotesSkipped),
+		NotesRemoved:      int32(result.NotesRemoved),
+		PagesRendered:     int32(result.PagesRendered),
+		AttachmentsCopied: int32(result.AttachmentsCopied),
+	}, nil
+}
+
+// CreateBackup 在数据目录的 backups 目录中创建数据库备份，仅系统管理员可操作
+func (s *APIV1Service) CreateBackup(ctx context.Context, _ *apiv1.CreateBackupRequest) (*apiv1.Backup, error) {
+	if err := s.checkHost(ctx); err != nil {
+		return nil, err
+	}
+	if s.BackupManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "data directory is not configured")
+	}
+
+	info, err := s.BackupManager.Create(ctx)
+	if err != nil {
+		if errors.Is(err, backup.ErrBackupInProgress) {
+			return nil, status.Errorf(codes.Aborted, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "创建备份失败: %v", err)
+	}
+	return convertBackupFromInfo(info), nil
+}
+
+// ListBackups 列出数据目录中的数据库备份，仅系统管理员可操作
+func (s *APIV1Service) ListBackups(ctx context.Context, _ *apiv1.ListBackupsRequest) (*apiv1.ListBackupsResponse, error) {
+	if err := s.checkHost(ctx); err != nil {
+		return nil, err
+	}
+	if s.BackupManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "data directory is not configured")
+	}
+
+	infos, err := s.BackupManager.List()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取备份列表失败: %v", err)
+	}
+	response := &apiv1.ListBackupsResponse{}
+	for _, info := range infos {
+		response.Backups = append(response.Backups, convertBackupFromInfo(info))
+	}
+	return response, nil
+}
+
+// checkHost 检查当前用户是否为系统管理员（HOST），备份包含全部用户的数据和密码哈希
+func (s *APIV1Service) checkHost(ctx context.Context) error {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	if currentUser.Role != store.RoleHost {
+		return status.Errorf(codes.PermissionDenied, "permission denied: only host can manage backups")
+	}
+	return nil
+}
+
+// convertBackupFromInfo 将备份文件信息转换为 API 消息
+func convertBackupFromInfo(info *backup.Info) *apiv1.Backup {
+	return &apiv1.Backup{
+		Filename:    info.Filename,
+		Size:        info.Size,
+		CreatedAt:   info.CreatedAt.Unix(),
+		DownloadUrl: "/file/backups/" + info.Filename,
+	}
+}
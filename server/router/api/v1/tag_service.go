@@ -2,13 +2,17 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"connectrpc.com/connect"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
 	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -51,6 +55,10 @@ func (s *APIV1Service) GetTag(ctx context.Context, req *apiv1.GetTagRequest) (*p
 
 // CreateTag 创建新标签
 func (s *APIV1Service) CreateTag(ctx context.Context, req *apiv1.CreateTagRequest) (*pbstore.Tag, error) {
+	if err := s.requireWorkspaceMember(ctx, "create tag"); err != nil {
+		return nil, err
+	}
+
 	// 从请求中获取标签信息
 	tag := req.GetTag()
 	if tag == nil {
@@ -65,7 +73,7 @@ func (s *APIV1Service) CreateTag(ctx context.Context, req *apiv1.CreateTagReques
 	// slug 字段不需要，使用主键 id 即可，设置为空字符串
 	tag.Slug = ""
 
-	// 调用存储层创建标签
+	// 调用存储层创建标签，名称与已有标签或别名相同时返回已有的标签
 	createdTag, err := s.Store.CreateTag(ctx, tag)
 	if err != nil {
 		return nil, fmt.Errorf("创建标签失败: %w", err)
@@ -79,6 +87,10 @@ func (s *APIV1Service) CreateTag(ctx context.Context, req *apiv1.CreateTagReques
 
 // UpdateTag 更新现有标签
 func (s *APIV1Service) UpdateTag(ctx context.Context, req *apiv1.UpdateTagRequest) (*pbstore.Tag, error) {
+	if err := s.requireWorkspaceMember(ctx, "update tag"); err != nil {
+		return nil, err
+	}
+
 	// 从请求中获取标签信息
 	tag := req.GetTag()
 	if tag == nil {
@@ -101,6 +113,9 @@ func (s *APIV1Service) UpdateTag(ctx context.Context, req *apiv1.UpdateTagReques
 	// 调用存储层更新标签
 	updatedTag, err := s.Store.UpdateTag(ctx, tag)
 	if err != nil {
+		if errors.Is(err, store.ErrTagExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "标签名称已被其他标签或别名使用: %s", tag.NameText)
+		}
 		return nil, fmt.Errorf("更新标签失败: %w", err)
 	}
 
@@ -112,6 +127,10 @@ func (s *APIV1Service) UpdateTag(ctx context.Context, req *apiv1.UpdateTagReques
 
 // DeleteTag 删除标签
 func (s *APIV1Service) DeleteTag(ctx context.Context, req *apiv1.DeleteTagRequest) (*emptypb.Empty, error) {
+	if err := s.requireWorkspaceMember(ctx, "delete tag"); err != nil {
+		return nil, err
+	}
+
 	// 从资源名称中提取标签ID
 	tagID, err := extractIDFromResourceName(req.GetName(), "tags")
 	if err != nil {
@@ -149,6 +168,120 @@ func (s *APIV1Service) GetTagBySlug(ctx context.Context, req *apiv1.GetTagBySlug
 	return tag, nil
 }
 
+// RecountTags 根据笔记标签关系重新计算所选工作区中标签的使用次数，并清理无效的关联，仅工作区管理员可操作
+func (s *APIV1Service) RecountTags(ctx context.Context, _ *apiv1.RecountTagsRequest) (*apiv1.RecountTagsResponse, error) {
+	if err := s.checkWorkspaceAdmin(ctx, "recount tags"); err != nil {
+		return nil, err
+	}
+
+	result, err := s.Store.RecountTags(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to recount tags: %v", err)
+	}
+
+	return &apiv1.RecountTagsResponse{
+		UpdatedTags:    int32(result.UpdatedTags),
+		RemovedOrphans: int32(result.RemovedOrphans),
+	}, nil
+}
+
+// MergeTags 将多个标签合并到目标标签，仅管理员可操作
+func (s *APIV1Service) MergeTags(ctx context.Context, req *apiv1.MergeTagsRequest) (*pbstore.Tag, error) {
+	if err := s.checkWorkspaceAdmin(ctx, "merge tags"); err != nil {
+		return nil, err
+	}
+
+	targetID, err := extractIDFromResourceName(req.GetName(), "tags")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag name: %v", err)
+	}
+	if len(req.GetSourceNames()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "source tags are required")
+	}
+	sourceIDs := make([]int64, 0, len(req.GetSourceNames()))
+	for _, name := range req.GetSourceNames() {
+		sourceID, err := extractIDFromResourceName(name, "tags")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid tag name: %v", err)
+		}
+		if sourceID == targetID {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot merge a tag into itself")
+		}
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+
+	tag, err := s.Store.MergeTags(ctx, targetID, sourceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("合并标签失败: %w", err)
+	}
+	tag.Name = fmt.Sprintf("tags/%d", tag.Id)
+	return tag, nil
+}
+
+// CreateTagAlias 为标签添加别名，仅管理员可操作
+func (s *APIV1Service) CreateTagAlias(ctx context.Context, req *apiv1.CreateTagAliasRequest) (*pbstore.Tag, error) {
+	if err := s.checkWorkspaceAdmin(ctx, "manage tag aliases"); err != nil {
+		return nil, err
+	}
+
+	tagID, err := extractIDFromResourceName(req.GetName(), "tags")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag name: %v", err)
+	}
+	if strings.TrimSpace(req.GetAlias()) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "alias is required")
+	}
+
+	tag, err := s.Store.CreateTagAlias(ctx, tagID, req.GetAlias())
+	if err != nil {
+		if errors.Is(err, store.ErrTagExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "别名已被其他标签使用: %s", req.GetAlias())
+		}
+		return nil, fmt.Errorf("添加标签别名失败: %w", err)
+	}
+	tag.Name = fmt.Sprintf("tags/%d", tag.Id)
+	return tag, nil
+}
+
+// DeleteTagAlias 删除标签的别名，仅管理员可操作
+func (s *APIV1Service) DeleteTagAlias(ctx context.Context, req *apiv1.DeleteTagAliasRequest) (*pbstore.Tag, error) {
+	if err := s.checkWorkspaceAdmin(ctx, "manage tag aliases"); err != nil {
+		return nil, err
+	}
+
+	tagID, err := extractIDFromResourceName(req.GetName(), "tags")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag name: %v", err)
+	}
+
+	tag, err := s.Store.DeleteTagAlias(ctx, tagID, req.GetAlias())
+	if err != nil {
+		return nil, fmt.Errorf("删除标签别名失败: %w", err)
+	}
+	tag.Name = fmt.Sprintf("tags/%d", tag.Id)
+	return tag, nil
+}
+
+// SuggestTags 返回名称或别名以指定前缀开头的标签，按使用次数排序，用于编辑器的标签输入
+func (s *APIV1Service) SuggestTags(ctx context.Context, req *apiv1.SuggestTagsRequest) (*apiv1.SuggestTagsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	tags, err := s.Store.SuggestTags(ctx, req.GetPrefix(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("获取标签建议失败: %w", err)
+	}
+	for _, tag := range tags {
+		tag.Name = fmt.Sprintf("tags/%d", tag.Id)
+	}
+	return &apiv1.SuggestTagsResponse{Tags: tags}, nil
+}
+
 // TagService 的 Connect 处理器实现
 
 // ListTagsHandler 实现 ListTags 方法的 Connect 处理器
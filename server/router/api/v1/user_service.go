@@ -1,15 +1,19 @@
 package v1
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/wdmsyhh/simple-notes/internal/dataexport"
 	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
 	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
 	"github.com/wdmsyhh/simple-notes/server/auth"
@@ -226,6 +230,57 @@ func (s *APIV1Service) ListUsers(ctx context.Context, request *apiv1.ListUsersRe
 	}, nil
 }
 
+// maxExportDataBytes ExportMyData 返回的压缩包大小上限，为响应消息的其他字段预留少量空间
+const maxExportDataBytes = MaxUploadBufferSizeBytes - 64<<10
+
+// errExportTooLarge 导出的压缩包超过响应消息大小限制
+var errExportTooLarge = errors.New("export too large")
+
+// ExportMyData 将当前用户的笔记、附件、评论导出为 zip 压缩包，管理员可以导出整个实例
+// 压缩包较大时应使用支持流式下载的 /file/export 接口
+func (s *APIV1Service) ExportMyData(ctx context.Context, request *apiv1.ExportMyDataRequest) (*apiv1.ExportMyDataResponse, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	if request.Instance && currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: only admin can export the whole instance")
+	}
+
+	// 导出包含用户在全部工作区中的数据，与 GET /file/export 一致
+	buf := &limitedBuffer{limit: maxExportDataBytes}
+	err = dataexport.NewExporter(s.Store).Export(ctx, buf, dataexport.Options{
+		UserID:   currentUser.ID,
+		Instance: request.Instance,
+	})
+	if err != nil {
+		if errors.Is(err, errExportTooLarge) {
+			return nil, status.Errorf(codes.ResourceExhausted, "export exceeds %d MB, use GET /file/export to download it", maxExportDataBytes>>20)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to export data: %v", err)
+	}
+
+	return &apiv1.ExportMyDataResponse{
+		Filename: dataexport.Filename(currentUser, request.Instance, time.Now()),
+		Content:  buf.Bytes(),
+	}, nil
+}
+
+// limitedBuffer 限制写入大小的缓冲区，超过上限时返回 errExportTooLarge
+type limitedBuffer struct {
+	bytes.Buffer
+	// limit 最大字节数
+	limit int
+}
+
+// Write 实现 io.Writer
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errExportTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
 // 辅助函数
 
 // extractUserIDFromName 从资源名称中提取用户ID
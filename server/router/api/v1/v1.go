@@ -12,11 +12,22 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/wdmsyhh/simple-notes/internal/backup"
+	"github.com/wdmsyhh/simple-notes/internal/markdown"
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	"github.com/wdmsyhh/simple-notes/internal/staticsite"
 	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	"github.com/wdmsyhh/simple-notes/server/auth"
+	"github.com/wdmsyhh/simple-notes/server/runner/related"
+	"github.com/wdmsyhh/simple-notes/server/runner/schedule"
+	"github.com/wdmsyhh/simple-notes/server/runner/viewcount"
 	"github.com/wdmsyhh/simple-notes/service"
 	"github.com/wdmsyhh/simple-notes/store"
 )
 
+// maxMessageSize 请求和响应消息的大小上限，支持大文件上传（32MB）
+const maxMessageSize = 32 << 20
+
 // APIV1Service 是 API V1 版本的服务实现结构体
 // 实现了 gRPC 服务接口和 Connect 服务接口
 
@@ -31,24 +42,59 @@ type APIV1Service struct {
 	apiv1.UnimplementedUserServiceServer
 	// 未实现的 AttachmentService 服务器（用于 gRPC 兼容性）
 	apiv1.UnimplementedAttachmentServiceServer
+	// 未实现的 SiteService 服务器（用于 gRPC 兼容性）
+	apiv1.UnimplementedSiteServiceServer
+	// 未实现的 SavedSearchService 服务器（用于 gRPC 兼容性）
+	apiv1.UnimplementedSavedSearchServiceServer
+	// 未实现的 CommentService 服务器（用于 gRPC 兼容性）
+	apiv1.UnimplementedCommentServiceServer
+	// 未实现的 WorkspaceService 服务器（用于 gRPC 兼容性）
+	apiv1.UnimplementedWorkspaceServiceServer
 
 	// 数据存储实例，用于数据库操作
 	Store *store.Store
+	// Profile 服务器配置（数据目录等）
+	Profile *profile.Profile
 	// 用户服务实例，用于处理用户相关业务逻辑
 	userService *service.UserService
 	// Secret 用于 JWT token 签名
 	Secret string
+	// MarkdownRenderer 服务端 Markdown 渲染器
+	MarkdownRenderer *markdown.Renderer
+	// ViewRecorder 笔记浏览量记录器，负责去重和批量写入
+	ViewRecorder *viewcount.Recorder
+	// NoteScheduler 笔记定时发布和过期任务，定时设置变化时通知它重新计算
+	NoteScheduler *schedule.Scheduler
+	// RelatedIndexer 相关笔记索引，笔记变化时通知它更新
+	RelatedIndexer *related.Indexer
+	// StaticSiteExporter 静态网站导出器
+	StaticSiteExporter *staticsite.Exporter
+	// BackupManager 数据库备份管理器
+	BackupManager *backup.Manager
+	// noteGraphCache 笔记关系图基础数据的缓存
+	noteGraphCache *noteGraphCache
+	// authenticator 认证器实例，用于验证分享链接
+	authenticator *auth.Authenticator
 }
 
 // NewAPIV1Service 创建一个新的 APIV1Service 实例
-func NewAPIV1Service(store *store.Store, secret string) *APIV1Service {
+func NewAPIV1Service(store *store.Store, profile *profile.Profile, secret string, markdownRenderer *markdown.Renderer, viewRecorder *viewcount.Recorder, noteScheduler *schedule.Scheduler, relatedIndexer *related.Indexer, staticSiteExporter *staticsite.Exporter, backupManager *backup.Manager) *APIV1Service {
 	// 创建用户服务实例
 	userService := service.NewUserService(store)
 
 	return &APIV1Service{
-		Store:       store,
-		userService: userService,
-		Secret:      secret,
+		Store:              store,
+		Profile:            profile,
+		userService:        userService,
+		Secret:             secret,
+		MarkdownRenderer:   markdownRenderer,
+		ViewRecorder:       viewRecorder,
+		NoteScheduler:      noteScheduler,
+		RelatedIndexer:     relatedIndexer,
+		StaticSiteExporter: staticSiteExporter,
+		BackupManager:      backupManager,
+		noteGraphCache:     &noteGraphCache{},
+		authenticator:      auth.NewAuthenticator(store, secret),
 	}
 }
 
@@ -82,12 +128,32 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 		return err
 	}
 
+	// 注册 SiteService 处理服务器
+	if err := apiv1.RegisterSiteServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
+
+	// 注册 SavedSearchService 处理服务器
+	if err := apiv1.RegisterSavedSearchServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
+
+	// 注册 CommentService 处理服务器
+	if err := apiv1.RegisterCommentServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
+
+	// 注册 WorkspaceService 处理服务器
+	if err := apiv1.RegisterWorkspaceServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
+
 	// 创建 API 网关路由组
 	gwGroup := echoServer.Group("")
 	// 添加 CORS 中间件
 	gwGroup.Use(middleware.CORS())
-	// 将 gRPC-Gateway 多路复用器包装为 Echo 处理器
-	handler := echo.WrapHandler(gwMux)
+	// 将 gRPC-Gateway 多路复用器包装为 Echo 处理器，网关请求与 Connect 请求一样经过认证和工作区选择
+	handler := echo.WrapHandler(s.gatewayMiddleware(gwMux))
 
 	// 注册所有 API V1 路径
 	gwGroup.Any("/api/v1/*", handler)
@@ -97,10 +163,10 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 		NewMetadataInterceptor(),
 		NewLoggingInterceptor(true), // 启用日志记录以进行调试
 		NewAuthInterceptor(s.Store, s.Secret),
+		NewWorkspaceInterceptor(s.Store),
 	)
 
 	// 配置 Connect 处理器选项，支持大文件上传（32MB）
-	const maxMessageSize = 32 << 20 // 32MB
 	connectHandlerOptions := []connect.HandlerOption{
 		connectInterceptors,
 		connect.WithReadMaxBytes(maxMessageSize),
@@ -0,0 +1,501 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/server/auth"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// WorkspaceHeader 选择工作区的请求头，值为工作区标识符
+const WorkspaceHeader = "X-Workspace"
+
+// errWorkspaceNotFound 请求选择的工作区不存在
+var errWorkspaceNotFound = errors.New("workspace not found")
+
+// ListWorkspaces 返回当前用户所属的工作区，管理员返回全部工作区
+func (s *APIV1Service) ListWorkspaces(ctx context.Context, _ *apiv1.ListWorkspacesRequest) (*apiv1.ListWorkspacesResponse, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.Store.ListWorkspaces(ctx, int64(currentUser.ID), !isInstanceAdmin(currentUser))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workspaces: %v", err)
+	}
+	return &apiv1.ListWorkspacesResponse{Workspaces: workspaces}, nil
+}
+
+// GetWorkspace 返回工作区，需要是工作区成员或管理员
+func (s *APIV1Service) GetWorkspace(ctx context.Context, req *apiv1.GetWorkspaceRequest) (*pbstore.Workspace, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.getWorkspace(ctx, req.GetName(), currentUser, pbstore.WorkspaceRole_WORKSPACE_ROLE_VIEWER, "view workspace")
+}
+
+// CreateWorkspace 创建工作区，创建者成为所有者
+func (s *APIV1Service) CreateWorkspace(ctx context.Context, req *apiv1.CreateWorkspaceRequest) (*pbstore.Workspace, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspace := req.GetWorkspace()
+	if workspace == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "workspace is required")
+	}
+	if !isValidWorkspaceSlug(workspace.Slug) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid workspace slug: only lowercase letters, digits and '-' are allowed")
+	}
+	if strings.TrimSpace(workspace.NameText) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "workspace name is required")
+	}
+
+	created, err := s.Store.CreateWorkspace(ctx, workspace, int64(currentUser.ID))
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create workspace: %v", err)
+	}
+	return created, nil
+}
+
+// UpdateWorkspace 更新工作区的名称和描述，需要是工作区所有者或管理员
+func (s *APIV1Service) UpdateWorkspace(ctx context.Context, req *apiv1.UpdateWorkspaceRequest) (*pbstore.Workspace, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	update := req.GetWorkspace()
+	if update == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "workspace is required")
+	}
+	workspace, err := s.getWorkspace(ctx, update.GetName(), currentUser, pbstore.WorkspaceRole_WORKSPACE_ROLE_OWNER, "update workspace")
+	if err != nil {
+		return nil, err
+	}
+
+	// 按字段掩码合并，掩码为空时更新全部字段
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"name_text", "description"}
+	}
+	for _, path := range paths {
+		switch path {
+		case "name_text":
+			workspace.NameText = update.GetNameText()
+		case "description":
+			workspace.Description = update.GetDescription()
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported update mask path: %s", path)
+		}
+	}
+	if strings.TrimSpace(workspace.NameText) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "workspace name is required")
+	}
+
+	if err := s.Store.UpdateWorkspace(ctx, workspace); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update workspace: %v", err)
+	}
+	return s.Store.GetWorkspaceBySlug(ctx, workspace.Slug, int64(currentUser.ID))
+}
+
+// DeleteWorkspace 删除没有内容的工作区，需要是工作区所有者或管理员，默认工作区不能删除
+func (s *APIV1Service) DeleteWorkspace(ctx context.Context, req *apiv1.DeleteWorkspaceRequest) (*emptypb.Empty, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.getWorkspace(ctx, req.GetName(), currentUser, pbstore.WorkspaceRole_WORKSPACE_ROLE_OWNER, "delete workspace")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Store.DeleteWorkspace(ctx, workspace.Id); err != nil {
+		if errors.Is(err, store.ErrWorkspaceNotEmpty) || strings.Contains(err.Error(), "default workspace") {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete workspace: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListWorkspaceMembers 返回工作区成员，需要是工作区成员或管理员
+func (s *APIV1Service) ListWorkspaceMembers(ctx context.Context, req *apiv1.ListWorkspaceMembersRequest) (*apiv1.ListWorkspaceMembersResponse, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.getWorkspace(ctx, req.GetParent(), currentUser, pbstore.WorkspaceRole_WORKSPACE_ROLE_VIEWER, "view workspace members")
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.Store.ListWorkspaceMembers(ctx, workspace)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workspace members: %v", err)
+	}
+	return &apiv1.ListWorkspaceMembersResponse{Members: members}, nil
+}
+
+// SetWorkspaceMember 添加成员或修改成员角色，需要是工作区所有者或管理员
+func (s *APIV1Service) SetWorkspaceMember(ctx context.Context, req *apiv1.SetWorkspaceMemberRequest) (*pbstore.WorkspaceMember, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.getWorkspace(ctx, req.GetParent(), currentUser, pbstore.WorkspaceRole_WORKSPACE_ROLE_OWNER, "manage workspace members")
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRole() == pbstore.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "role is required")
+	}
+	userID, err := extractUserIDFromName(req.GetUser())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	user, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	if err := s.Store.SetWorkspaceMember(ctx, workspace.Id, int64(userID), req.GetRole()); err != nil {
+		return nil, workspaceMemberError(err)
+	}
+
+	members, err := s.Store.ListWorkspaceMembers(ctx, workspace)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workspace members: %v", err)
+	}
+	for _, member := range members {
+		if member.User == fmt.Sprintf("users/%d", userID) {
+			return member, nil
+		}
+	}
+	return nil, status.Errorf(codes.Internal, "workspace member not found after update")
+}
+
+// RemoveWorkspaceMember 移除成员，需要是工作区所有者或管理员，成员也可以自己退出工作区
+func (s *APIV1Service) RemoveWorkspaceMember(ctx context.Context, req *apiv1.RemoveWorkspaceMemberRequest) (*emptypb.Empty, error) {
+	currentUser, err := s.requireCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parent, userName, ok := strings.Cut(req.GetName(), "/members/")
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid member name: expected workspaces/{workspace}/members/{user}")
+	}
+	userID, err := extractUserIDFromName("users/" + userName)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid member name: %v", err)
+	}
+
+	minRole := pbstore.WorkspaceRole_WORKSPACE_ROLE_OWNER
+	if userID == currentUser.ID {
+		minRole = pbstore.WorkspaceRole_WORKSPACE_ROLE_VIEWER
+	}
+	workspace, err := s.getWorkspace(ctx, parent, currentUser, minRole, "manage workspace members")
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.Store.RemoveWorkspaceMember(ctx, workspace.Id, int64(userID))
+	if err != nil {
+		return nil, workspaceMemberError(err)
+	}
+	if !removed {
+		return nil, status.Errorf(codes.NotFound, "workspace member not found")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// getWorkspace 根据资源名称获取工作区，并检查当前用户在其中的角色不低于 minRole（管理员不受限制）
+func (s *APIV1Service) getWorkspace(ctx context.Context, name string, user *store.User, minRole pbstore.WorkspaceRole, action string) (*pbstore.Workspace, error) {
+	slug, ok := strings.CutPrefix(name, "workspaces/")
+	if !ok || slug == "" || strings.Contains(slug, "/") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid workspace name: expected workspaces/{workspace}")
+	}
+	workspace, err := s.Store.GetWorkspaceBySlug(ctx, slug, int64(user.ID))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get workspace: %v", err)
+	}
+	if isInstanceAdmin(user) {
+		return workspace, nil
+	}
+	if workspace.Role == pbstore.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED {
+		// 不是成员时不暴露工作区是否存在
+		return nil, status.Errorf(codes.NotFound, "workspace not found: %s", slug)
+	}
+	if workspace.Role < minRole {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: only workspace owner can %s", action)
+	}
+	return workspace, nil
+}
+
+// workspaceMemberError 将修改工作区成员时的存储层错误转换为 gRPC 错误
+func workspaceMemberError(err error) error {
+	if errors.Is(err, store.ErrLastWorkspaceOwner) {
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if strings.Contains(err.Error(), "invalid workspace role") {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "failed to update workspace member: %v", err)
+}
+
+// isValidWorkspaceSlug 检查工作区标识符：小写字母、数字和连字符，长度不超过 64
+func isValidWorkspaceSlug(slug string) bool {
+	if slug == "" || len(slug) > 64 {
+		return false
+	}
+	for _, r := range slug {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isInstanceAdmin 检查用户是否为系统管理员（HOST）或管理员，管理员可以管理所有工作区
+func isInstanceAdmin(user *store.User) bool {
+	return user != nil && (user.Role == store.RoleAdmin || user.Role == store.RoleHost)
+}
+
+// workspaceRoleOf 返回用户在请求所选工作区（未选择时为默认工作区）中的角色，
+// 未登录、不是成员或上下文不按工作区过滤（见 store.AllWorkspaces）时返回 UNSPECIFIED
+func (s *APIV1Service) workspaceRoleOf(ctx context.Context, user *store.User) (pbstore.WorkspaceRole, error) {
+	if user == nil {
+		return pbstore.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED, nil
+	}
+	workspaceID, err := s.Store.ContextWorkspaceID(ctx)
+	if err != nil {
+		return pbstore.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED, err
+	}
+	if workspaceID == 0 {
+		return pbstore.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED, nil
+	}
+	return s.Store.GetWorkspaceRole(ctx, workspaceID, int64(user.ID))
+}
+
+// isWorkspaceAdmin 检查用户是否可以管理所选工作区中的全部内容：管理员，或工作区的所有者和管理员
+// 查询角色失败时视为不是管理员
+func (s *APIV1Service) isWorkspaceAdmin(ctx context.Context, user *store.User) bool {
+	if isInstanceAdmin(user) {
+		return true
+	}
+	role, err := s.workspaceRoleOf(ctx, user)
+	return err == nil && role >= pbstore.WorkspaceRole_WORKSPACE_ROLE_ADMIN
+}
+
+// checkWorkspaceAdmin 检查当前用户是否可以管理所选工作区中的全部内容，action 用于错误信息
+func (s *APIV1Service) checkWorkspaceAdmin(ctx context.Context, action string) error {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil || currentUser == nil {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	if !s.isWorkspaceAdmin(ctx, currentUser) {
+		return status.Errorf(codes.PermissionDenied, "permission denied: only workspace admin can %s", action)
+	}
+	return nil
+}
+
+// checkWorkspaceMember 检查用户可以在所选工作区（未选择时为默认工作区）中创建内容：
+// 需要登录，并且是管理员或工作区的成员、管理员、所有者（查看者只读）
+func (s *APIV1Service) checkWorkspaceMember(ctx context.Context, user *store.User, action string) error {
+	if user == nil {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	if isInstanceAdmin(user) {
+		return nil
+	}
+	role, err := s.workspaceRoleOf(ctx, user)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check workspace role: %v", err)
+	}
+	if role < pbstore.WorkspaceRole_WORKSPACE_ROLE_MEMBER {
+		return status.Errorf(codes.PermissionDenied, "permission denied: workspace member role required to %s", action)
+	}
+	return nil
+}
+
+// requireWorkspaceMember 检查当前用户可以在所选工作区中创建和修改内容，见 checkWorkspaceMember
+func (s *APIV1Service) requireWorkspaceMember(ctx context.Context, action string) error {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	return s.checkWorkspaceMember(ctx, currentUser, action)
+}
+
+// selectWorkspace 为请求选择工作区：来自请求消息中资源名称的工作区前缀（见 workspaceFromMessage）或 X-Workspace 请求头，
+// 都未指定时选择默认工作区。Connect 拦截器和 gRPC-Gateway 中间件共用这一逻辑，两种协议的工作区选择保持一致；
+// 两者指定了不同的工作区时返回 InvalidArgument，工作区不存在或当前用户不能访问时返回 NotFound（见 checkWorkspaceAccess）
+func selectWorkspace(ctx context.Context, st *store.Store, header string, msg proto.Message) (int64, error) {
+	slug := strings.TrimSpace(header)
+	if msg != nil {
+		prefixed, err := workspaceFromMessage(msg)
+		if err != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if prefixed != "" {
+			if slug != "" && slug != prefixed {
+				return 0, status.Errorf(codes.InvalidArgument, "workspace header %q does not match resource name workspace %q", slug, prefixed)
+			}
+			slug = prefixed
+		}
+	}
+
+	workspaceID, err := resolveWorkspace(ctx, st, slug)
+	if err != nil {
+		if errors.Is(err, errWorkspaceNotFound) {
+			return 0, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return 0, status.Errorf(codes.Internal, "failed to resolve workspace: %v", err)
+	}
+	if err := checkWorkspaceAccess(ctx, st, workspaceID, slug); err != nil {
+		return 0, err
+	}
+	return workspaceID, nil
+}
+
+// checkWorkspaceAccess 检查当前用户可以访问所选的工作区：默认工作区对所有人开放（包括访客），
+// 其他工作区中的数据（包括公开笔记）只对工作区成员（包括查看者）和管理员开放。
+// 不能访问时与工作区不存在一样返回 NotFound，不透露工作区是否存在
+func checkWorkspaceAccess(ctx context.Context, st *store.Store, workspaceID int64, slug string) error {
+	defaultID, err := st.DefaultWorkspaceID(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to resolve workspace: %v", err)
+	}
+	if workspaceID == defaultID {
+		return nil
+	}
+	if claims := auth.GetUserClaims(ctx); claims != nil {
+		user, err := st.GetUserByID(ctx, uint(claims.UserID))
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "user not found")
+		}
+		if isInstanceAdmin(user) {
+			return nil
+		}
+		role, err := st.GetWorkspaceRole(ctx, workspaceID, int64(user.ID))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check workspace role: %v", err)
+		}
+		if role != pbstore.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED {
+			return nil
+		}
+	}
+	return status.Errorf(codes.NotFound, "%v: %s", errWorkspaceNotFound, slug)
+}
+
+// resolveWorkspace 根据工作区标识符返回工作区ID，标识符为空时返回默认工作区，工作区不存在时返回 errWorkspaceNotFound
+func resolveWorkspace(ctx context.Context, st *store.Store, slug string) (int64, error) {
+	if slug == "" {
+		return st.DefaultWorkspaceID(ctx)
+	}
+	workspace, err := st.GetWorkspaceBySlug(ctx, slug, 0)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return 0, fmt.Errorf("%w: %s", errWorkspaceNotFound, slug)
+		}
+		return 0, err
+	}
+	return workspace.Id, nil
+}
+
+// workspaceResourceNamePattern 带工作区前缀的资源名称，例如 workspaces/team/notes/12、workspaces/team/notes/12/shareLinks/3
+var workspaceResourceNamePattern = regexp.MustCompile(`^workspaces/([a-z0-9-]+)/(notes|categories|tags|attachments|pages)/\d+(/[^\s]*)?$`)
+
+// workspaceFromResourceName 返回资源名称中工作区前缀的标识符，不是带工作区前缀的资源名称时返回空字符串
+// 只识别完整的资源名称，避免把以 workspaces/ 开头的正文等普通字符串当作工作区前缀
+func workspaceFromResourceName(name string) string {
+	match := workspaceResourceNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// trimWorkspacePrefix 去掉资源名称的工作区前缀，例如 workspaces/team/notes/1 返回 notes/1
+// 工作区由拦截器根据前缀选择，服务实现只需要处理不带前缀的资源名称
+func trimWorkspacePrefix(name string) string {
+	if rest, ok := strings.CutPrefix(name, "workspaces/"); ok {
+		if _, resource, ok := strings.Cut(rest, "/"); ok {
+			return resource
+		}
+	}
+	return name
+}
+
+// workspaceResourceNameFields 保存资源名称的请求字段，只有这些字段中的工作区前缀用于选择工作区，正文等其他字段不检查
+var workspaceResourceNameFields = map[protoreflect.Name]bool{
+	"name":        true,
+	"parent":      true,
+	"resource":    true,
+	"note":        true,
+	"note_id":     true,
+	"tag":         true,
+	"reassign_to": true,
+}
+
+// workspaceFromMessage 查找请求消息中带工作区前缀的资源名称（顶层字段以及顶层消息字段中的字段，见 workspaceResourceNameFields），
+// 返回其中的工作区标识符；多个资源名称指定了不同的工作区时返回错误
+func workspaceFromMessage(msg proto.Message) (string, error) {
+	var slug string
+	var conflict error
+	var visit func(m protoreflect.Message, depth int)
+	visit = func(m protoreflect.Message, depth int) {
+		m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if fd.IsList() || fd.IsMap() {
+				return true
+			}
+			switch fd.Kind() {
+			case protoreflect.StringKind:
+				if !workspaceResourceNameFields[fd.Name()] {
+					return true
+				}
+				found := workspaceFromResourceName(v.String())
+				if found == "" {
+					return true
+				}
+				if slug != "" && slug != found {
+					conflict = fmt.Errorf("resource names refer to different workspaces: %s and %s", slug, found)
+					return false
+				}
+				slug = found
+			case protoreflect.MessageKind:
+				if depth == 0 {
+					visit(v.Message(), depth+1)
+				}
+			}
+			return conflict == nil
+		})
+	}
+	visit(msg.ProtoReflect(), 0)
+	if conflict != nil {
+		return "", conflict
+	}
+	return slug, nil
+}
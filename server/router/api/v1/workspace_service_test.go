@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/server/auth"
+	"github.com/wdmsyhh/simple-notes/store"
+	"github.com/wdmsyhh/simple-notes/store/db"
+)
+
+func TestSelectWorkspaceRequiresMembership(t *testing.T) {
+	dir := t.TempDir()
+	p := &profile.Profile{Driver: "sqlite", DSN: filepath.Join(dir, "test.db"), Data: dir}
+	driver, err := db.NewDBDriver(p)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	st := store.NewStore(driver, p)
+	defer st.Close()
+	if err := st.RunMigrations(); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	createUser := func(username string, role store.UserRole) *store.User {
+		t.Helper()
+		user, err := st.CreateUser(ctx, &store.User{Username: username, PasswordHash: "x", Role: role})
+		if err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+		return user
+	}
+	owner := createUser("owner", store.RoleUser)
+	viewer := createUser("viewer", store.RoleUser)
+	outsider := createUser("outsider", store.RoleUser)
+	admin := createUser("admin", store.RoleAdmin)
+
+	team, err := st.CreateWorkspace(ctx, &pbstore.Workspace{Slug: "team", NameText: "team"}, int64(owner.ID))
+	if err != nil {
+		t.Fatalf("CreateWorkspace: %v", err)
+	}
+	if err := st.SetWorkspaceMember(ctx, team.Id, int64(viewer.ID), pbstore.WorkspaceRole_WORKSPACE_ROLE_VIEWER); err != nil {
+		t.Fatalf("SetWorkspaceMember: %v", err)
+	}
+	defaultID, err := st.DefaultWorkspaceID(ctx)
+	if err != nil {
+		t.Fatalf("DefaultWorkspaceID: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		user   *store.User
+		header string
+		msg    *apiv1.GetNoteRequest
+		want   int64
+		code   codes.Code
+	}{
+		{name: "guest in the default workspace", want: defaultID},
+		{name: "outsider in the default workspace", user: outsider, header: store.DefaultWorkspaceSlug, want: defaultID},
+		{name: "member by header", user: owner, header: "team", want: team.Id},
+		{name: "viewer by resource name", user: viewer, msg: &apiv1.GetNoteRequest{Name: "workspaces/team/notes/1"}, want: team.Id},
+		{name: "instance admin", user: admin, header: "team", want: team.Id},
+		// 非成员与工作区不存在时的错误相同
+		{name: "guest by header", header: "team", code: codes.NotFound},
+		{name: "guest by resource name", msg: &apiv1.GetNoteRequest{Name: "workspaces/team/notes/1"}, code: codes.NotFound},
+		{name: "outsider", user: outsider, header: "team", code: codes.NotFound},
+		{name: "unknown workspace", user: admin, header: "missing", code: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ctx
+			if tt.user != nil {
+				ctx = auth.SetUserClaimsInContext(ctx, &auth.UserClaims{UserID: int32(tt.user.ID), Username: tt.user.Username, Role: string(tt.user.Role)})
+			}
+			msg := tt.msg
+			if msg == nil {
+				msg = &apiv1.GetNoteRequest{Name: "notes/1"}
+			}
+			got, err := selectWorkspace(ctx, st, tt.header, msg)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("selectWorkspace: code = %v (%v), want %v", code, err, tt.code)
+			}
+			if got != tt.want {
+				t.Errorf("selectWorkspace = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package fileserver
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/wdmsyhh/simple-notes/internal/backup"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// serveBackup 下载备份目录中的数据库备份，仅系统管理员可下载
+func (s *FileServerService) serveBackup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user, err := s.getCurrentUser(ctx, c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get current user").SetInternal(err)
+	}
+	if user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	if user.Role != store.RoleHost {
+		return echo.NewHTTPError(http.StatusForbidden, "only host can download backups")
+	}
+
+	filename := c.Param("filename")
+	if s.BackupManager == nil || !backup.IsBackupFilename(filename) {
+		return echo.NewHTTPError(http.StatusNotFound, "backup not found")
+	}
+	f, err := os.Open(filepath.Join(s.BackupManager.Dir(), filename))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "backup not found")
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to read backup").SetInternal(err)
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, "application/zip")
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	header.Set("Cache-Control", "no-store")
+	http.ServeContent(c.Response(), c.Request(), filename, info.ModTime(), f)
+	return nil
+}
@@ -0,0 +1,56 @@
+package fileserver
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/wdmsyhh/simple-notes/internal/dataexport"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+// serveDataExport 以 zip 压缩包流式下载当前用户的全部数据
+// 管理员可以通过 ?instance=true 导出整个实例
+func (s *FileServerService) serveDataExport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user, err := s.getCurrentUser(ctx, c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get current user").SetInternal(err)
+	}
+	if user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+
+	instance := false
+	if value := c.QueryParam("instance"); value != "" {
+		if instance, err = strconv.ParseBool(value); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid instance parameter")
+		}
+	}
+	if instance && user.Role != store.RoleAdmin && user.Role != store.RoleHost {
+		return echo.NewHTTPError(http.StatusForbidden, "only admin can export the whole instance")
+	}
+
+	filename := dataexport.Filename(user, instance, time.Now())
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, "application/zip")
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	header.Set("Cache-Control", "no-store")
+	c.Response().WriteHeader(http.StatusOK)
+
+	// 响应头已发送，出错时只能中断连接，客户端会得到不完整的压缩包
+	err = dataexport.NewExporter(s.Store).Export(ctx, c.Response(), dataexport.Options{
+		UserID:   user.ID,
+		Instance: instance,
+	})
+	if err != nil {
+		log.Printf("Failed to export data for user %d (instance: %t): %v", user.ID, instance, err)
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
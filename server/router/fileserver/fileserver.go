@@ -9,25 +9,33 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/wdmsyhh/simple-notes/internal/backup"
 	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
 	"github.com/wdmsyhh/simple-notes/server/auth"
 	"github.com/wdmsyhh/simple-notes/store"
 )
 
+// ShareKeyHeader 通过分享链接加载附件时携带附件访问密钥的请求头
+const ShareKeyHeader = "X-Share-Key"
+
 // FileServerService 处理带有正确范围请求支持的 HTTP 文件服务
 // 此服务绕过 gRPC-Gateway，使用原生 HTTP 服务通过 http.ServeContent()，
 // 这对于 Safari 视频/音频播放是必需的
 type FileServerService struct {
 	// Store 数据存储实例
 	Store *store.Store
+	// BackupManager 数据库备份管理器
+	BackupManager *backup.Manager
 	// authenticator 认证器实例
 	authenticator *auth.Authenticator
 }
 
 // NewFileServerService 创建新的文件服务器服务实例
-func NewFileServerService(store *store.Store, secret string) *FileServerService {
+func NewFileServerService(store *store.Store, secret string, backupManager *backup.Manager) *FileServerService {
 	return &FileServerService{
 		Store:         store,
+		BackupManager: backupManager,
 		authenticator: auth.NewAuthenticator(store, secret),
 	}
 }
@@ -38,6 +46,12 @@ func (s *FileServerService) RegisterRoutes(echoServer *echo.Echo) {
 
 	// 提供附件二进制文件服务
 	fileGroup.GET("/attachments/:id/:filename", s.serveAttachmentFile)
+
+	// 流式下载数据导出压缩包
+	fileGroup.GET("/export", s.serveDataExport)
+
+	// 下载数据库备份（仅系统管理员）
+	fileGroup.GET("/backups/:filename", s.serveBackup)
 }
 
 // serveAttachmentFile 使用原生 HTTP 提供附件二进制内容服务
@@ -51,6 +65,9 @@ func (s *FileServerService) serveAttachmentFile(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid attachment ID")
 	}
 
+	// 附件地址只包含ID，不区分工作区：在全部工作区中查找附件和所属的笔记，是否可以访问由 checkAttachmentPermission 决定
+	ctx = store.AllWorkspaces(ctx)
+
 	// 从数据库获取附件
 	attachment, err := s.Store.GetAttachment(ctx, attachmentID)
 	if err != nil {
@@ -97,7 +114,12 @@ func (s *FileServerService) serveAttachmentFile(c echo.Context) error {
 
 	// 设置通用头部
 	c.Response().Header().Set("Content-Type", contentType)
-	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+	if c.QueryParam("share") != "" {
+		// 分享链接撤销或过期后附件应当立即无法访问，不允许共享缓存保存
+		c.Response().Header().Set("Cache-Control", "private, no-cache")
+	} else {
+		c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+	}
 	// 防止 MIME 类型嗅探，这可能导致 XSS
 	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
 	// 深度防御：防止嵌入到框架中并限制内容加载
@@ -167,32 +189,35 @@ func (s *FileServerService) checkAttachmentPermission(ctx context.Context, c ech
 		return echo.NewHTTPError(http.StatusNotFound, "note not found")
 	}
 
-	// 公开笔记所有人都可以访问
-	if note.Visibility == storepb.NoteVisibility_NOTE_VISIBILITY_PUBLIC {
-		return nil
-	}
-
-	// 对于非公开笔记，检查认证
+	// 与笔记接口使用相同的访问规则（见 store.NoteAccessLevelOf）：可以查看笔记的用户都可以访问笔记的附件
 	user, err := s.getCurrentUser(ctx, c)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get current user").SetInternal(err)
 	}
-	if user == nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	level, err := s.Store.NoteAccessLevelOf(ctx, note, user)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to check note permission").SetInternal(err)
+	}
+	if level >= store.NoteAccessPublic {
+		return nil
 	}
 
-	// 私有笔记只能由创建者访问
-	if note.Visibility == storepb.NoteVisibility_NOTE_VISIBILITY_PRIVATE {
-		var authorID uint
-		if _, err := fmt.Sscanf(note.AuthorId, "%d", &authorID); err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "invalid author ID format")
+	// 通过分享链接打开笔记的访问者使用链接令牌和短期密钥加载附件，不计入查看次数
+	// 密钥优先从 X-Share-Key 请求头读取，<img> 等无法设置请求头的场景使用附件地址中的 key 参数
+	if token := c.QueryParam("share"); token != "" {
+		accessKey := c.Request().Header.Get(ShareKeyHeader)
+		if accessKey == "" {
+			accessKey = c.QueryParam("key")
 		}
-		if user.ID != authorID {
-			return echo.NewHTTPError(http.StatusForbidden, "forbidden access")
+		if err := s.authenticator.AuthorizeShareLink(ctx, noteID, token, accessKey); err == nil {
+			return nil
 		}
 	}
 
-	return nil
+	if user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	return echo.NewHTTPError(http.StatusForbidden, "forbidden access")
 }
 
 // getCurrentUser 从 Echo 上下文检索当前已认证的用户
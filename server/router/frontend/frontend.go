@@ -34,7 +34,11 @@ func NewFrontendService(profile *profile.Profile, store *store.Store) *FrontendS
 }
 
 // Serve 启动前端静态文件服务
-func (*FrontendService) Serve(_ context.Context, e *echo.Echo) {
+func (s *FrontendService) Serve(_ context.Context, e *echo.Echo) {
+	// 注册由服务端注入 SEO 元信息的页面路由，以及 sitemap.xml 和 robots.txt
+	s.registerSEORoutes(e)
+	s.registerSitemapRoutes(e)
+
 	skipper := func(c echo.Context) bool {
 		// 跳过 API 路由
 		if util.HasPrefixes(c.Path(), "/api", "/api.v1") {
@@ -44,9 +48,13 @@ func (*FrontendService) Serve(_ context.Context, e *echo.Echo) {
 		if util.HasPrefixes(c.Path(), "/attachments") {
 			return true
 		}
-		// 对于 index.html 和根路径，设置不缓存头部以防止浏览器缓存
+		// 跳过由 SEO 路由处理的页面
+		if seoRoutes[c.Path()] {
+			return true
+		}
+		// 对于 index.html，设置不缓存头部以防止浏览器缓存
 		// 这可以防止敏感数据在登出后通过浏览器后退按钮访问
-		if c.Path() == "/" || c.Path() == "/index.html" {
+		if c.Path() == "/index.html" {
 			c.Response().Header().Set(echo.HeaderCacheControl, "no-cache, no-store, must-revalidate")
 			c.Response().Header().Set("Pragma", "no-cache")
 			c.Response().Header().Set("Expires", "0")
@@ -61,6 +69,13 @@ func (*FrontendService) Serve(_ context.Context, e *echo.Echo) {
 		return false
 	}
 
+	// 旧地址重定向需要在 SPA 回退之前处理
+	dist, err := fs.Sub(embeddedFiles, "dist")
+	if err != nil {
+		panic(err)
+	}
+	e.Use(s.redirectMiddleware(dist))
+
 	// 使用 HTML5 fallback 为 SPA 行为提供主应用路由
 	e.Use(middleware.StaticWithConfig(middleware.StaticConfig{
 		Filesystem: getFileSystem("dist"),
@@ -0,0 +1,57 @@
+package frontend
+
+import (
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/wdmsyhh/simple-notes/internal/util"
+)
+
+// redirectMiddleware 将导入时记录的旧地址（例如 WordPress 固定链接）永久重定向到新地址
+// 只处理没有匹配到任何路由、也不是静态文件的 GET/HEAD 请求，其余请求交给 SPA 回退处理
+func (s *FrontendService) redirectMiddleware(dist fs.FS) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+			// 已匹配到路由的请求，未匹配时路由为空或 "/*"
+			// 首页带查询参数时可能是 ?p=123 形式的旧地址
+			if route := c.Path(); route != "" && route != "/*" && (route != "/" || req.URL.RawQuery == "") {
+				return next(c)
+			}
+			p := req.URL.Path
+			if util.HasPrefixes(p, "/api", "/file", "/attachments") {
+				return next(c)
+			}
+			// 前端构建产物中存在的文件
+			if name := strings.TrimPrefix(p, "/"); name != "" {
+				if _, err := fs.Stat(dist, name); err == nil {
+					return next(c)
+				}
+			}
+
+			candidates := []string{req.URL.RequestURI()}
+			if trimmed := strings.TrimSuffix(req.URL.EscapedPath(), "/"); trimmed != "" && trimmed != candidates[0] {
+				candidates = append(candidates, trimmed)
+				if req.URL.RawQuery != "" {
+					candidates = append(candidates, trimmed+"?"+req.URL.RawQuery)
+				}
+			}
+			for _, source := range candidates {
+				target, err := s.Store.GetRedirect(req.Context(), source)
+				if err != nil {
+					return err
+				}
+				if target != "" {
+					return c.Redirect(http.StatusMovedPermanently, target)
+				}
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/wdmsyhh/simple-notes/internal/markdown"
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+const (
+	// siteName 站点名称
+	siteName = "Simple Notes"
+	// siteDescription 站点默认描述
+	siteDescription = "一个简洁优雅的笔记管理系统，支持 Markdown 编辑、分类管理、标签系统等功能。"
+	// maxDescriptionLength meta description 的最大字符数
+	maxDescriptionLength = 160
+)
+
+// titlePattern 匹配 index.html 中原有的 <title> 标签
+var titlePattern = regexp.MustCompile(`(?is)<title>.*?</title>`)
+
+// seoRoutes 由服务端注入 SEO 元信息的前端路由
+var seoRoutes = map[string]bool{
+	"/":             true,
+	"/note/:id":     true,
+	"/notes/:id":    true,
+	"/category/:id": true,
+	"/tag/:slug":    true,
+	"/page/:slug":   true,
+	"/sitemap.xml":  true,
+	"/robots.txt":   true,
+}
+
+// seoMeta 页面的 SEO 元信息
+type seoMeta struct {
+	// title 页面标题
+	title string
+	// description 页面描述
+	description string
+	// canonical 规范地址
+	canonical string
+	// image 分享图片地址（可选）
+	image string
+	// ogType OpenGraph 类型（website/article）
+	ogType string
+	// publishedTime 发布时间（仅文章）
+	publishedTime time.Time
+	// modifiedTime 更新时间（仅文章）
+	modifiedTime time.Time
+	// noIndex 是否禁止搜索引擎收录
+	noIndex bool
+}
+
+// seoResolver 根据路由参数查询页面的 SEO 元信息，返回 nil 表示使用站点默认信息
+type seoResolver func(ctx context.Context, c echo.Context, baseURL string) *seoMeta
+
+// indexTemplate 缓存的 index.html 内容
+type indexTemplate struct {
+	// once 保证只读取一次
+	once sync.Once
+	// content index.html 内容
+	content string
+	// err 读取错误
+	err error
+}
+
+// index 全局的 index.html 缓存（内容来自编译时嵌入的文件，运行期间不会变化）
+var index indexTemplate
+
+// getIndexHTML 读取嵌入的 index.html
+func getIndexHTML() (string, error) {
+	index.once.Do(func() {
+		data, err := embeddedFiles.ReadFile("dist/index.html")
+		index.content, index.err = string(data), err
+	})
+	return index.content, index.err
+}
+
+// registerSEORoutes 注册需要注入 SEO 元信息的前端路由
+func (s *FrontendService) registerSEORoutes(e *echo.Echo) {
+	e.GET("/", s.serveIndex(func(_ context.Context, _ echo.Context, baseURL string) *seoMeta {
+		return &seoMeta{title: siteName, description: siteDescription, canonical: baseURL + "/", ogType: "website"}
+	}))
+	e.GET("/note/:id", s.serveIndex(s.resolveNoteMeta))
+	e.GET("/notes/:id", s.serveIndex(s.resolveNoteMeta))
+	e.GET("/category/:id", s.serveIndex(s.resolveCategoryMeta))
+	e.GET("/tag/:slug", s.serveIndex(s.resolveTagMeta))
+	e.GET("/page/:slug", s.serveIndex(s.resolvePageMeta))
+}
+
+// serveIndex 返回注入了 SEO 元信息的 index.html
+func (s *FrontendService) serveIndex(resolve seoResolver) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		content, err := getIndexHTML()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusNotFound, "index.html not found").SetInternal(err)
+		}
+
+		baseURL := getBaseURL(c)
+		meta := resolve(c.Request().Context(), c, baseURL)
+		if meta == nil {
+			// 不存在或不公开的内容使用站点默认信息，并禁止收录
+			meta = &seoMeta{
+				title:       siteName,
+				description: siteDescription,
+				canonical:   baseURL + c.Request().URL.Path,
+				ogType:      "website",
+				noIndex:     true,
+			}
+		}
+
+		// 与 index.html 一致，不缓存页面
+		header := c.Response().Header()
+		header.Set(echo.HeaderCacheControl, "no-cache, no-store, must-revalidate")
+		header.Set("Pragma", "no-cache")
+		header.Set("Expires", "0")
+		return c.HTML(http.StatusOK, injectMeta(content, meta, baseURL))
+	}
+}
+
+// resolveNoteMeta 查询笔记页面的 SEO 元信息，只处理已发布的公开笔记
+func (s *FrontendService) resolveNoteMeta(ctx context.Context, c echo.Context, baseURL string) *seoMeta {
+	noteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || noteID <= 0 {
+		return nil
+	}
+	note, err := s.Store.GetNote(ctx, noteID)
+	if err != nil || !note.Published || note.Visibility != storepb.NoteVisibility_NOTE_VISIBILITY_PUBLIC {
+		return nil
+	}
+
+	description := note.Summary
+	if description == "" {
+		description = markdown.Summarize(note.Content, maxDescriptionLength)
+	}
+	meta := &seoMeta{
+		title:        note.Title + " - " + siteName,
+		description:  description,
+		canonical:    fmt.Sprintf("%s/note/%d", baseURL, note.Id),
+		image:        absoluteURL(baseURL, note.CoverImage),
+		ogType:       "article",
+		modifiedTime: time.Unix(note.UpdatedAt, 0),
+	}
+	if note.PublishedAt > 0 {
+		meta.publishedTime = time.Unix(note.PublishedAt, 0)
+	}
+	return meta
+}
+
+// resolveCategoryMeta 查询分类页面的 SEO 元信息
+func (s *FrontendService) resolveCategoryMeta(ctx context.Context, c echo.Context, baseURL string) *seoMeta {
+	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || categoryID <= 0 {
+		return nil
+	}
+	category, err := s.Store.GetCategory(ctx, categoryID)
+	if err != nil || !category.Visible {
+		return nil
+	}
+
+	description := category.Description
+	if description == "" {
+		description = fmt.Sprintf("分类「%s」下的全部笔记", category.NameText)
+	}
+	return &seoMeta{
+		title:       category.NameText + " - " + siteName,
+		description: description,
+		canonical:   fmt.Sprintf("%s/category/%d", baseURL, category.Id),
+		ogType:      "website",
+	}
+}
+
+// resolveTagMeta 查询标签页面的 SEO 元信息（路由参数为标签ID）
+func (s *FrontendService) resolveTagMeta(ctx context.Context, c echo.Context, baseURL string) *seoMeta {
+	tagID, err := strconv.ParseInt(c.Param("slug"), 10, 64)
+	if err != nil || tagID <= 0 {
+		return nil
+	}
+	tag, err := s.Store.GetTag(ctx, tagID)
+	if err != nil {
+		return nil
+	}
+
+	description := tag.Description
+	if description == "" {
+		description = fmt.Sprintf("标签「%s」下的全部笔记", tag.NameText)
+	}
+	return &seoMeta{
+		title:       "#" + tag.NameText + " - " + siteName,
+		description: description,
+		canonical:   fmt.Sprintf("%s/tag/%d", baseURL, tag.Id),
+		ogType:      "website",
+	}
+}
+
+// resolvePageMeta 查询独立页面的 SEO 元信息，只处理已发布的页面
+func (s *FrontendService) resolvePageMeta(ctx context.Context, c echo.Context, baseURL string) *seoMeta {
+	page, err := s.Store.GetPageBySlug(ctx, c.Param("slug"))
+	if err != nil || !page.Published {
+		return nil
+	}
+
+	return &seoMeta{
+		title:        page.Title + " - " + siteName,
+		description:  markdown.Summarize(page.Content, maxDescriptionLength),
+		canonical:    baseURL + "/page/" + url.PathEscape(page.Slug),
+		ogType:       "article",
+		modifiedTime: time.Unix(page.UpdatedAt, 0),
+	}
+}
+
+// injectMeta 将 SEO 元信息注入 index.html，替换原有的 <title>
+func injectMeta(content string, meta *seoMeta, baseURL string) string {
+	description := truncate(strings.Join(strings.Fields(meta.description), " "), maxDescriptionLength)
+
+	var sb strings.Builder
+	writeTag := func(format string, args ...string) {
+		escaped := make([]any, len(args))
+		for i, arg := range args {
+			escaped[i] = html.EscapeString(arg)
+		}
+		sb.WriteString(fmt.Sprintf(format, escaped...))
+		sb.WriteString("\n    ")
+	}
+
+	writeTag(`<title>%s</title>`, meta.title)
+	writeTag(`<meta name="description" content="%s" />`, description)
+	writeTag(`<link rel="canonical" href="%s" />`, meta.canonical)
+	if meta.noIndex {
+		writeTag(`<meta name="robots" content="%s" />`, "noindex")
+	}
+	writeTag(`<link rel="alternate" type="application/rss+xml" title="%s" href="%s" />`, siteName, baseURL+"/feed.xml")
+
+	// OpenGraph
+	writeTag(`<meta property="og:site_name" content="%s" />`, siteName)
+	writeTag(`<meta property="og:type" content="%s" />`, meta.ogType)
+	writeTag(`<meta property="og:title" content="%s" />`, meta.title)
+	writeTag(`<meta property="og:description" content="%s" />`, description)
+	writeTag(`<meta property="og:url" content="%s" />`, meta.canonical)
+	if meta.image != "" {
+		writeTag(`<meta property="og:image" content="%s" />`, meta.image)
+	}
+	if !meta.publishedTime.IsZero() {
+		writeTag(`<meta property="article:published_time" content="%s" />`, meta.publishedTime.UTC().Format(time.RFC3339))
+	}
+	if !meta.modifiedTime.IsZero() {
+		writeTag(`<meta property="article:modified_time" content="%s" />`, meta.modifiedTime.UTC().Format(time.RFC3339))
+	}
+
+	// Twitter 卡片
+	twitterCard := "summary"
+	if meta.image != "" {
+		twitterCard = "summary_large_image"
+	}
+	writeTag(`<meta name="twitter:card" content="%s" />`, twitterCard)
+	writeTag(`<meta name="twitter:title" content="%s" />`, meta.title)
+	writeTag(`<meta name="twitter:description" content="%s" />`, description)
+	if meta.image != "" {
+		writeTag(`<meta name="twitter:image" content="%s" />`, meta.image)
+	}
+
+	tags := strings.TrimRight(sb.String(), " \n")
+	if titlePattern.MatchString(content) {
+		replaced := false
+		return titlePattern.ReplaceAllStringFunc(content, func(match string) string {
+			// 只替换第一个 <title>
+			if replaced {
+				return match
+			}
+			replaced = true
+			return tags
+		})
+	}
+	return strings.Replace(content, "</head>", tags+"\n  </head>", 1)
+}
+
+// absoluteURL 将站内相对地址转换为绝对地址
+func absoluteURL(baseURL, rawURL string) string {
+	if rawURL == "" || strings.HasPrefix(rawURL, "http://") || strings.HasPrefix(rawURL, "https://") {
+		return rawURL
+	}
+	if strings.HasPrefix(rawURL, "//") {
+		return "https:" + rawURL
+	}
+	if !strings.HasPrefix(rawURL, "/") {
+		rawURL = "/" + rawURL
+	}
+	return baseURL + rawURL
+}
+
+// truncate 按字符截断字符串，截断时追加省略号
+func truncate(s string, maxLength int) string {
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:maxLength-1])) + "…"
+}
+
+// getBaseURL 根据请求获取站点的根地址（支持反向代理的 X-Forwarded-Proto）
+func getBaseURL(c echo.Context) string {
+	return c.Scheme() + "://" + c.Request().Host
+}
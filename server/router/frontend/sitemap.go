@@ -0,0 +1,165 @@
+package frontend
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// maxSitemapURLs 单个 sitemap 允许的最大地址数量（sitemaps.org 协议限制）
+	maxSitemapURLs = 50000
+	// sitemapPageSize 分批查询笔记的数量
+	sitemapPageSize = 100
+	// sitemapCacheMaxAge sitemap.xml 和 robots.txt 的缓存时间（秒）
+	sitemapCacheMaxAge = 3600
+)
+
+// sitemapURLSet sitemap 根节点
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL sitemap 中的一个地址
+type sitemapURL struct {
+	// Loc 页面地址
+	Loc string `xml:"loc"`
+	// LastMod 最后修改时间（可选）
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// registerSitemapRoutes 注册 sitemap.xml 和 robots.txt 路由
+func (s *FrontendService) registerSitemapRoutes(e *echo.Echo) {
+	e.GET("/sitemap.xml", s.serveSitemap)
+	e.GET("/robots.txt", s.serveRobots)
+}
+
+// serveSitemap 生成包含首页、公开笔记、分类、标签和已发布页面的 sitemap.xml
+func (s *FrontendService) serveSitemap(c echo.Context) error {
+	urls, err := s.listSitemapURLs(c.Request().Context(), getBaseURL(c))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate sitemap").SetInternal(err)
+	}
+
+	data, err := xml.MarshalIndent(&sitemapURLSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  urls,
+	}, "", "  ")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to encode sitemap").SetInternal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", sitemapCacheMaxAge))
+	return c.Blob(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), data...))
+}
+
+// serveRobots 返回 robots.txt，禁止收录后台和接口路径，并声明 sitemap 地址
+func (s *FrontendService) serveRobots(c echo.Context) error {
+	var sb strings.Builder
+	sb.WriteString("User-agent: *\n")
+	sb.WriteString("Allow: /\n")
+	for _, path := range []string{"/dashboard", "/login", "/signup", "/api/", "/api.v1."} {
+		sb.WriteString("Disallow: " + path + "\n")
+	}
+	sb.WriteString("\nSitemap: " + getBaseURL(c) + "/sitemap.xml\n")
+
+	c.Response().Header().Set(echo.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", sitemapCacheMaxAge))
+	return c.String(http.StatusOK, sb.String())
+}
+
+// listSitemapURLs 收集 sitemap 中的全部地址，站点页面展示默认工作区的内容，因此只包含默认工作区
+func (s *FrontendService) listSitemapURLs(ctx context.Context, baseURL string) ([]sitemapURL, error) {
+	workspaceID, err := s.Store.DefaultWorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = store.WithWorkspace(ctx, workspaceID)
+
+	urls := []sitemapURL{{Loc: baseURL + "/"}}
+
+	// 已发布的公开笔记，按发布时间倒序分批查询
+	for page := int32(1); len(urls) < maxSitemapURLs; page++ {
+		notes, _, err := s.Store.ListNotes(ctx, &store.ListNotesRequest{
+			Page:       page,
+			PageSize:   sitemapPageSize,
+			SortBy:     "published_at",
+			SortDesc:   true,
+			Visibility: "PUBLIC",
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, note := range notes {
+			urls = append(urls, sitemapURL{
+				Loc:     fmt.Sprintf("%s/note/%d", baseURL, note.Id),
+				LastMod: formatLastMod(note.UpdatedAt),
+			})
+		}
+		if len(notes) < sitemapPageSize {
+			break
+		}
+	}
+
+	// 可见的分类
+	categories, err := s.Store.ListCategories(ctx, &apiv1.ListCategoriesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		urls = append(urls, sitemapURL{
+			Loc:     fmt.Sprintf("%s/category/%d", baseURL, category.Id),
+			LastMod: formatLastMod(category.UpdatedAt),
+		})
+	}
+
+	// 有笔记的标签
+	tags, _, err := s.Store.ListTags(ctx, &apiv1.ListTagsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if tag.Count <= 0 {
+			continue
+		}
+		urls = append(urls, sitemapURL{
+			Loc:     fmt.Sprintf("%s/tag/%d", baseURL, tag.Id),
+			LastMod: formatLastMod(tag.UpdatedAt),
+		})
+	}
+
+	// 已发布的页面
+	pages, err := s.Store.ListPages(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, page := range pages {
+		urls = append(urls, sitemapURL{
+			Loc:     baseURL + "/page/" + url.PathEscape(page.Slug),
+			LastMod: formatLastMod(page.UpdatedAt),
+		})
+	}
+
+	if len(urls) > maxSitemapURLs {
+		urls = urls[:maxSitemapURLs]
+	}
+	return urls, nil
+}
+
+// formatLastMod 将 Unix 时间戳格式化为 sitemap 的 lastmod（W3C 日期时间格式）
+func formatLastMod(timestamp int64) string {
+	if timestamp <= 0 {
+		return ""
+	}
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
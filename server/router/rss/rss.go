@@ -0,0 +1,487 @@
+package rss
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/feeds"
+	"github.com/labstack/echo/v4"
+
+	"github.com/wdmsyhh/simple-notes/internal/markdown"
+	storepb "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// siteTitle 订阅源标题
+	siteTitle = "Simple Notes"
+	// siteDescription 订阅源描述
+	siteDescription = "Simple Notes 最新发布的笔记"
+	// maxFeedItems 订阅源中最多包含的笔记数量
+	maxFeedItems = 20
+	// feedCacheMaxAge 订阅源的缓存时间（秒）
+	feedCacheMaxAge = 300
+)
+
+// feedFormat 订阅源格式
+type feedFormat string
+
+const (
+	// formatRSS RSS 2.0
+	formatRSS feedFormat = "rss"
+	// formatAtom Atom 1.0
+	formatAtom feedFormat = "atom"
+	// formatJSON JSON Feed 1.1
+	formatJSON feedFormat = "json"
+)
+
+// RSSService 提供已发布笔记的订阅源（RSS、Atom、JSON Feed）
+// 支持全站订阅以及按分类（?category={id}）、按标签（?tag={id}）、
+// 按开启了订阅的保存的搜索（?search={id}）订阅，只包含已发布且公开的笔记
+// 订阅源只包含默认工作区的笔记：订阅源不需要登录，其他工作区的内容只对成员开放，不提供订阅源
+type RSSService struct {
+	// Store 数据存储实例
+	Store *store.Store
+	// MarkdownRenderer 服务端 Markdown 渲染器，用于生成条目正文
+	MarkdownRenderer *markdown.Renderer
+}
+
+// NewRSSService 创建新的订阅源服务实例
+func NewRSSService(store *store.Store, markdownRenderer *markdown.Renderer) *RSSService {
+	return &RSSService{
+		Store:            store,
+		MarkdownRenderer: markdownRenderer,
+	}
+}
+
+// RegisterRoutes 注册订阅源路由
+func (s *RSSService) RegisterRoutes(echoServer *echo.Echo) {
+	echoServer.GET("/feed.xml", func(c echo.Context) error {
+		return s.serveFeed(c, formatRSS)
+	})
+	echoServer.GET("/atom.xml", func(c echo.Context) error {
+		return s.serveFeed(c, formatAtom)
+	})
+	echoServer.GET("/feed.json", func(c echo.Context) error {
+		return s.serveFeed(c, formatJSON)
+	})
+}
+
+// feedFilter 订阅源的过滤条件
+type feedFilter struct {
+	// workspace 请求中的工作区标识符，只能为空或默认工作区
+	workspace string
+	// categoryID 分类ID（可选）
+	categoryID int64
+	// tagID 标签ID（可选）
+	tagID int64
+	// searchID 保存的搜索ID（可选）
+	searchID int64
+	// search 保存的搜索，由 getFeedInfo 加载
+	search *storepb.SavedSearch
+}
+
+// feedEntry 订阅源中的一篇笔记及其附件
+type feedEntry struct {
+	// note 笔记
+	note *storepb.Note
+	// attachments 笔记附件
+	attachments []*storepb.Attachment
+}
+
+// serveFeed 生成并返回指定格式的订阅源，支持条件请求（ETag / Last-Modified）
+func (s *RSSService) serveFeed(c echo.Context, format feedFormat) error {
+	ctx := c.Request().Context()
+
+	filter, err := parseFeedFilter(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx, err = s.withFeedWorkspace(ctx, filter.workspace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "workspace not found").SetInternal(err)
+	}
+
+	baseURL := getBaseURL(c)
+	info, err := s.getFeedInfo(ctx, baseURL, filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "category, tag or saved search not found").SetInternal(err)
+	}
+
+	entries, err := s.listFeedEntries(ctx, filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list notes").SetInternal(err)
+	}
+
+	// 条件请求：内容未变化时返回 304
+	etag, lastModified := feedValidators(format, filter, entries)
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	header.Set(echo.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", feedCacheMaxAge))
+	if notModified(c.Request(), etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	feed, err := s.buildFeed(ctx, baseURL, info, entries, lastModified)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to build feed").SetInternal(err)
+	}
+
+	var buf bytes.Buffer
+	var contentType string
+	switch format {
+	case formatAtom:
+		err = feeds.WriteXML(atomFeed(feed, entries, baseURL), &buf)
+		contentType = "application/atom+xml; charset=utf-8"
+	case formatJSON:
+		err = writeJSONFeed(jsonFeed(feed, entries, baseURL, c.Request().URL), &buf)
+		contentType = "application/feed+json; charset=utf-8"
+	default:
+		err = feed.WriteRss(&buf)
+		contentType = "application/rss+xml; charset=utf-8"
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to encode feed").SetInternal(err)
+	}
+
+	return c.Blob(http.StatusOK, contentType, buf.Bytes())
+}
+
+// parseFeedFilter 解析订阅源的过滤参数
+func parseFeedFilter(c echo.Context) (*feedFilter, error) {
+	filter := &feedFilter{workspace: strings.TrimSpace(c.QueryParam("workspace"))}
+	if category := c.QueryParam("category"); category != "" {
+		id, err := strconv.ParseInt(category, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid category: %s", category)
+		}
+		filter.categoryID = id
+	}
+	if tag := c.QueryParam("tag"); tag != "" {
+		id, err := strconv.ParseInt(tag, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid tag: %s", tag)
+		}
+		filter.tagID = id
+	}
+	if search := c.QueryParam("search"); search != "" {
+		id, err := strconv.ParseInt(search, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid search: %s", search)
+		}
+		filter.searchID = id
+	}
+	return filter, nil
+}
+
+// withFeedWorkspace 返回选择了默认工作区的上下文，slug 为其他工作区时返回错误
+func (s *RSSService) withFeedWorkspace(ctx context.Context, slug string) (context.Context, error) {
+	if slug != "" && slug != store.DefaultWorkspaceSlug {
+		return nil, fmt.Errorf("feeds are only available for the default workspace: %s", slug)
+	}
+	workspaceID, err := s.Store.DefaultWorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.WithWorkspace(ctx, workspaceID), nil
+}
+
+// listFeedEntries 获取订阅源包含的笔记及其附件，按发布时间倒序（保存的搜索使用其排序方式）
+func (s *RSSService) listFeedEntries(ctx context.Context, filter *feedFilter) ([]*feedEntry, error) {
+	req := &store.ListNotesRequest{
+		Page:               1,
+		PageSize:           maxFeedItems,
+		SortBy:             "published_at",
+		SortDesc:           true,
+		IncludeUnpublished: false,
+		Visibility:         "PUBLIC",
+	}
+	if filter.categoryID > 0 {
+		req.CategoryID = strconv.FormatInt(filter.categoryID, 10)
+	}
+	if filter.tagID > 0 {
+		req.TagID = strconv.FormatInt(filter.tagID, 10)
+	}
+	if search := filter.search; search != nil {
+		// 使用保存的搜索的过滤表达式和排序，author == "me" 按创建者解析
+		req.Filter = search.Filter
+		req.SortBy = search.SortBy
+		req.SortDesc = search.SortDesc
+		if ownerID, err := strconv.ParseInt(strings.TrimPrefix(search.Owner, "users/"), 10, 64); err == nil {
+			req.ViewerID = ownerID
+		}
+	}
+
+	notes, _, err := s.Store.ListNotes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*feedEntry, 0, len(notes))
+	for _, note := range notes {
+		noteID := note.Id
+		attachments, err := s.Store.ListAttachments(ctx, &noteID, nil)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &feedEntry{note: note, attachments: attachments})
+	}
+	return entries, nil
+}
+
+// feedInfo 订阅源的标题、描述和对应的站点页面
+type feedInfo struct {
+	// title 标题
+	title string
+	// description 描述
+	description string
+	// link 对应的站点页面地址
+	link string
+}
+
+// getFeedInfo 根据过滤条件生成订阅源信息，分类、标签或保存的搜索不存在时返回错误
+// 保存的搜索必须由创建者开启订阅（feed_enabled）
+func (s *RSSService) getFeedInfo(ctx context.Context, baseURL string, filter *feedFilter) (*feedInfo, error) {
+	info := &feedInfo{
+		title:       siteTitle,
+		description: siteDescription,
+		link:        baseURL + "/",
+	}
+	if filter.categoryID > 0 {
+		category, err := s.Store.GetCategory(ctx, filter.categoryID)
+		if err != nil {
+			return nil, err
+		}
+		info.title = fmt.Sprintf("%s - %s", siteTitle, category.NameText)
+		info.description = fmt.Sprintf("分类「%s」下最新发布的笔记", category.NameText)
+		info.link = fmt.Sprintf("%s/category/%d", baseURL, category.Id)
+	}
+	if filter.tagID > 0 {
+		tag, err := s.Store.GetTag(ctx, filter.tagID)
+		if err != nil {
+			return nil, err
+		}
+		info.title = fmt.Sprintf("%s - #%s", info.title, tag.NameText)
+		info.description = fmt.Sprintf("标签「%s」下最新发布的笔记", tag.NameText)
+		if filter.categoryID == 0 {
+			info.link = fmt.Sprintf("%s/tag/%d", baseURL, tag.Id)
+		}
+	}
+	if filter.searchID > 0 {
+		search, err := s.Store.GetSavedSearch(ctx, filter.searchID)
+		if err != nil {
+			return nil, err
+		}
+		if !search.FeedEnabled {
+			return nil, fmt.Errorf("saved search feed is not enabled: %d", search.Id)
+		}
+		filter.search = search
+		info.title = fmt.Sprintf("%s - %s", info.title, search.NameText)
+		info.description = fmt.Sprintf("保存的搜索「%s」匹配的笔记", search.NameText)
+	}
+	return info, nil
+}
+
+// buildFeed 根据笔记列表构建通用的订阅源结构
+func (s *RSSService) buildFeed(ctx context.Context, baseURL string, info *feedInfo, entries []*feedEntry, lastModified time.Time) (*feeds.Feed, error) {
+	if lastModified.IsZero() {
+		lastModified = time.Now()
+	}
+	feed := &feeds.Feed{
+		Title:       info.title,
+		Link:        &feeds.Link{Href: info.link},
+		Description: info.description,
+		Id:          info.link,
+		Updated:     lastModified,
+	}
+
+	// 作者信息按用户ID缓存，避免重复查询
+	authors := map[string]*feeds.Author{}
+	for _, entry := range entries {
+		note := entry.note
+		noteURL := fmt.Sprintf("%s/note/%d", baseURL, note.Id)
+
+		content, err := s.MarkdownRenderer.RenderNote(note.Id, note.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &feeds.Item{
+			Title:       note.Title,
+			Link:        &feeds.Link{Href: noteURL},
+			Id:          noteURL,
+			IsPermaLink: "true",
+			Description: html.EscapeString(note.Summary),
+			Content:     content,
+			Created:     time.Unix(note.PublishedAt, 0),
+			Updated:     time.Unix(note.UpdatedAt, 0),
+			Author:      s.getAuthor(ctx, authors, note.AuthorId),
+		}
+		if note.PublishedAt <= 0 {
+			item.Created = time.Unix(note.CreatedAt, 0)
+		}
+		// RSS 每个条目只允许一个 enclosure，使用第一个附件
+		if len(entry.attachments) > 0 {
+			item.Enclosure = attachmentEnclosure(baseURL, entry.attachments[0])
+		}
+		feed.Add(item)
+	}
+
+	return feed, nil
+}
+
+// getAuthor 获取笔记作者信息，用户不存在时返回 nil
+func (s *RSSService) getAuthor(ctx context.Context, cache map[string]*feeds.Author, authorID string) *feeds.Author {
+	if author, ok := cache[authorID]; ok {
+		return author
+	}
+
+	var author *feeds.Author
+	if id, err := strconv.ParseUint(authorID, 10, 32); err == nil {
+		if user, err := s.Store.GetUserByID(ctx, uint(id)); err == nil && user != nil {
+			name := user.Nickname
+			if name == "" {
+				name = user.Username
+			}
+			author = &feeds.Author{Name: name}
+		}
+	}
+	cache[authorID] = author
+	return author
+}
+
+// atomFeed 构建 Atom 订阅源，并为每个附件添加 rel="enclosure" 链接
+func atomFeed(feed *feeds.Feed, entries []*feedEntry, baseURL string) *feeds.AtomFeed {
+	atom := (&feeds.Atom{Feed: feed}).AtomFeed()
+	for i, entry := range atom.Entries {
+		if i >= len(entries) {
+			break
+		}
+		attachments := entries[i].attachments
+		// 第一个附件已由 Enclosure 生成
+		for j := 1; j < len(attachments); j++ {
+			enclosure := attachmentEnclosure(baseURL, attachments[j])
+			entry.Links = append(entry.Links, feeds.AtomLink{
+				Href:   enclosure.Url,
+				Rel:    "enclosure",
+				Type:   enclosure.Type,
+				Length: enclosure.Length,
+			})
+		}
+	}
+	return atom
+}
+
+// jsonFeed 构建 JSON Feed，并将附件写入 attachments 字段
+func jsonFeed(feed *feeds.Feed, entries []*feedEntry, baseURL string, requestURL *url.URL) *feeds.JSONFeed {
+	jsonFeed := (&feeds.JSON{Feed: feed}).JSONFeed()
+	jsonFeed.FeedUrl = baseURL + requestURL.RequestURI()
+	for i, item := range jsonFeed.Items {
+		if i >= len(entries) {
+			break
+		}
+		for _, attachment := range entries[i].attachments {
+			item.Attachments = append(item.Attachments, feeds.JSONAttachment{
+				Url:      attachmentURL(baseURL, attachment),
+				MIMEType: attachment.Type,
+				Title:    attachment.Filename,
+				Size:     int32(attachment.Size),
+			})
+		}
+		// JSON Feed 的 summary 为纯文本，不需要 HTML 转义
+		item.Summary = entries[i].note.Summary
+	}
+	return jsonFeed
+}
+
+// writeJSONFeed 将 JSON Feed 编码后写入缓冲区
+func writeJSONFeed(feed *feeds.JSONFeed, buf *bytes.Buffer) error {
+	data, err := feed.ToJSON()
+	if err != nil {
+		return err
+	}
+	buf.WriteString(data)
+	return nil
+}
+
+// attachmentEnclosure 将附件转换为订阅源的 enclosure
+func attachmentEnclosure(baseURL string, attachment *storepb.Attachment) *feeds.Enclosure {
+	contentType := attachment.Type
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &feeds.Enclosure{
+		Url:    attachmentURL(baseURL, attachment),
+		Length: strconv.FormatInt(attachment.Size, 10),
+		Type:   contentType,
+	}
+}
+
+// attachmentURL 返回附件的绝对访问地址（由文件服务器提供）
+func attachmentURL(baseURL string, attachment *storepb.Attachment) string {
+	return fmt.Sprintf("%s/file/attachments/%d/%s", baseURL, attachment.Id, url.PathEscape(attachment.Filename))
+}
+
+// feedValidators 计算订阅源的 ETag 和最后修改时间
+// ETag 由格式、过滤条件（包括保存的搜索的更新时间）以及各笔记的ID和更新时间决定，任一笔记变化都会改变 ETag
+func feedValidators(format feedFormat, filter *feedFilter, entries []*feedEntry) (string, time.Time) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%s|%d|%d", format, filter.workspace, filter.categoryID, filter.tagID)
+	if filter.search != nil {
+		// 保存的搜索修改后（例如过滤条件变化）即使笔记相同也需要更新
+		fmt.Fprintf(hash, "|search:%d:%d", filter.search.Id, filter.search.UpdatedAt)
+	}
+
+	var lastModified time.Time
+	for _, entry := range entries {
+		note := entry.note
+		fmt.Fprintf(hash, "|%d:%d", note.Id, note.UpdatedAt)
+		for _, attachment := range entry.attachments {
+			fmt.Fprintf(hash, ",%d:%d", attachment.Id, attachment.UpdatedAt)
+		}
+		if updatedAt := time.Unix(note.UpdatedAt, 0); updatedAt.After(lastModified) {
+			lastModified = updatedAt
+		}
+	}
+
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`, lastModified
+}
+
+// notModified 判断条件请求的内容是否未变化
+// 优先使用 If-None-Match，未提供时再比较 If-Modified-Since
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil {
+			return !lastModified.Truncate(time.Second).After(t)
+		}
+	}
+	return false
+}
+
+// getBaseURL 根据请求获取站点的根地址（支持反向代理的 X-Forwarded-Proto）
+func getBaseURL(c echo.Context) string {
+	return c.Scheme() + "://" + c.Request().Host
+}
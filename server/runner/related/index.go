@@ -0,0 +1,193 @@
+package related
+
+import (
+	"math"
+	"sort"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// 各字段的词频权重：标题和标签最能代表笔记的主题
+	titleWeight   = 3
+	tagWeight     = 3
+	summaryWeight = 2
+	contentWeight = 1
+
+	// maxQueryTerms 计算相似度时每篇笔记使用的词项数量（按 TF-IDF 权重取最高的部分）
+	maxQueryTerms = 64
+	// maxDocumentFrequencyRatio 出现在超过该比例的笔记中的词项区分度很低，不用于查找候选笔记
+	maxDocumentFrequencyRatio = 0.5
+	// minDocumentsForFrequencyLimit 笔记数量达到该值后才按 maxDocumentFrequencyRatio 忽略常见词项
+	minDocumentsForFrequencyLimit = 20
+)
+
+// Related 相关笔记
+type Related struct {
+	// NoteID 笔记ID
+	NoteID int64
+	// Score 余弦相似度，范围 (0, 1]
+	Score float64
+}
+
+// index TF-IDF 倒排索引，不是并发安全的，由 Indexer 加锁访问
+type index struct {
+	// docs 笔记ID到加权词频的映射
+	docs map[int64]map[string]float64
+	// postings 词项到包含该词项的笔记集合的映射，集合大小即文档频率
+	postings map[string]map[int64]struct{}
+}
+
+// newIndex 创建空索引
+func newIndex() *index {
+	return &index{
+		docs:     make(map[int64]map[string]float64),
+		postings: make(map[string]map[int64]struct{}),
+	}
+}
+
+// termFrequencies 计算笔记各字段加权后的词频
+func termFrequencies(document *store.NoteDocument) map[string]float64 {
+	terms := make(map[string]float64)
+	add := func(text string, weight float64) {
+		for _, token := range tokenize(text) {
+			terms[token] += weight
+		}
+	}
+	add(document.Title, titleWeight)
+	add(document.Summary, summaryWeight)
+	add(document.Content, contentWeight)
+	for _, tag := range document.Tags {
+		add(tag, tagWeight)
+	}
+	return terms
+}
+
+// put 写入或替换笔记
+func (x *index) put(document *store.NoteDocument) {
+	x.remove(document.ID)
+	terms := termFrequencies(document)
+	x.docs[document.ID] = terms
+	for term := range terms {
+		if x.postings[term] == nil {
+			x.postings[term] = make(map[int64]struct{})
+		}
+		x.postings[term][document.ID] = struct{}{}
+	}
+}
+
+// remove 删除笔记
+func (x *index) remove(noteID int64) {
+	for term := range x.docs[noteID] {
+		delete(x.postings[term], noteID)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	delete(x.docs, noteID)
+}
+
+// idf 词项的逆文档频率
+func (x *index) idf(term string) float64 {
+	return math.Log(1 + float64(len(x.docs))/float64(len(x.postings[term])))
+}
+
+// selective 判断词项是否有足够的区分度用于查找候选笔记
+func (x *index) selective(term string) bool {
+	if len(x.docs) < minDocumentsForFrequencyLimit {
+		return true
+	}
+	return float64(len(x.postings[term])) <= maxDocumentFrequencyRatio*float64(len(x.docs))
+}
+
+// weight 词项在笔记中的 TF-IDF 权重，词频取对数以减弱长文重复词的影响
+func (x *index) weight(tf float64, term string) float64 {
+	return (1 + math.Log(tf)) * x.idf(term)
+}
+
+// norms 计算全部笔记向量的长度，用于余弦相似度归一化
+func (x *index) norms() map[int64]float64 {
+	norms := make(map[int64]float64, len(x.docs))
+	for id, terms := range x.docs {
+		var sum float64
+		for term, tf := range terms {
+			w := x.weight(tf, term)
+			sum += w * w
+		}
+		norms[id] = math.Sqrt(sum)
+	}
+	return norms
+}
+
+// neighbours 返回与笔记共享有区分度的词项的其他笔记
+func (x *index) neighbours(noteID int64) map[int64]struct{} {
+	neighbours := make(map[int64]struct{})
+	for term := range x.docs[noteID] {
+		if !x.selective(term) {
+			continue
+		}
+		for id := range x.postings[term] {
+			if id != noteID {
+				neighbours[id] = struct{}{}
+			}
+		}
+	}
+	return neighbours
+}
+
+// similar 计算与笔记最相似的 k 篇笔记，norms 为 norms() 的结果
+func (x *index) similar(noteID int64, k int, norms map[int64]float64) []Related {
+	terms := x.docs[noteID]
+	norm := norms[noteID]
+	if len(terms) == 0 || norm == 0 {
+		return nil
+	}
+
+	// 使用权重最高且有区分度的词项作为查询
+	type weightedTerm struct {
+		term   string
+		weight float64
+	}
+	query := make([]weightedTerm, 0, len(terms))
+	for term, tf := range terms {
+		if x.selective(term) {
+			query = append(query, weightedTerm{term: term, weight: x.weight(tf, term)})
+		}
+	}
+	sort.Slice(query, func(i, j int) bool {
+		if query[i].weight != query[j].weight {
+			return query[i].weight > query[j].weight
+		}
+		return query[i].term < query[j].term
+	})
+	if len(query) > maxQueryTerms {
+		query = query[:maxQueryTerms]
+	}
+
+	scores := make(map[int64]float64)
+	for _, q := range query {
+		for id := range x.postings[q.term] {
+			if id == noteID {
+				continue
+			}
+			scores[id] += q.weight * x.weight(x.docs[id][q.term], q.term)
+		}
+	}
+
+	related := make([]Related, 0, len(scores))
+	for id, score := range scores {
+		if otherNorm := norms[id]; otherNorm > 0 {
+			related = append(related, Related{NoteID: id, Score: score / (norm * otherNorm)})
+		}
+	}
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].NoteID < related[j].NoteID
+	})
+	if len(related) > k {
+		related = related[:k]
+	}
+	return related
+}
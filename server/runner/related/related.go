@@ -0,0 +1,241 @@
+// related 包负责计算相关笔记
+// 后台任务根据笔记的标题、摘要、内容和标签为每个工作区分别建立 TF-IDF 索引（中日韩文字按二元词切分），为每篇笔记预先计算同一工作区中最相似的笔记；
+// 笔记创建、更新或删除后只更新该笔记以及与其共享词项的笔记，并定期完整重建以修正逆文档频率的变化
+package related
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// DefaultTopK 每篇笔记保存的相关笔记数量，需要大于接口返回的数量以便按可见性过滤
+	DefaultTopK = 30
+	// DefaultRebuildInterval 完整重建索引的间隔，期间没有笔记变化时跳过
+	DefaultRebuildInterval = time.Hour
+	// updateDelay 收到变化通知后等待的时间，将短时间内的多次修改合并为一次更新
+	updateDelay = 2 * time.Second
+)
+
+// Indexer 相关笔记索引
+type Indexer struct {
+	// store 数据存储实例
+	store *store.Store
+	// topK 每篇笔记保存的相关笔记数量
+	topK int
+	// rebuildInterval 完整重建索引的间隔
+	rebuildInterval time.Duration
+	// wake 有笔记变化时唤醒后台任务
+	wake chan struct{}
+
+	// mu 保护以下字段的并发访问
+	mu sync.RWMutex
+	// indexes 工作区ID到 TF-IDF 索引的映射，每个工作区的逆文档频率独立计算
+	indexes map[int64]*index
+	// workspaces 已索引的笔记ID到所属工作区ID的映射
+	workspaces map[int64]int64
+	// related 笔记ID到相关笔记的映射
+	related map[int64][]Related
+	// ready 首次建立索引后为 true
+	ready bool
+	// pending 等待更新的笔记ID
+	pending map[int64]struct{}
+	// dirty 上次完整重建后是否有笔记变化
+	dirty bool
+}
+
+// NewIndexer 创建新的相关笔记索引
+func NewIndexer(s *store.Store, topK int, rebuildInterval time.Duration) *Indexer {
+	if topK <= 0 {
+		topK = DefaultTopK
+	}
+	if rebuildInterval <= 0 {
+		rebuildInterval = DefaultRebuildInterval
+	}
+	return &Indexer{
+		store:           s,
+		topK:            topK,
+		rebuildInterval: rebuildInterval,
+		wake:            make(chan struct{}, 1),
+		indexes:         make(map[int64]*index),
+		workspaces:      make(map[int64]int64),
+		related:         make(map[int64][]Related),
+		pending:         make(map[int64]struct{}),
+	}
+}
+
+// Notify 通知笔记已创建、更新或删除，后台任务稍后更新索引
+func (x *Indexer) Notify(noteIDs ...int64) {
+	x.mu.Lock()
+	for _, id := range noteIDs {
+		x.pending[id] = struct{}{}
+	}
+	x.mu.Unlock()
+
+	select {
+	case x.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Related 返回同一工作区中与笔记最相似的笔记，按相似度从高到低排列
+// 索引尚未建立时 ready 为 false
+func (x *Indexer) Related(noteID int64) (related []Related, ready bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return append([]Related(nil), x.related[noteID]...), x.ready
+}
+
+// Rebuild 读取全部笔记完整重建索引
+func (x *Indexer) Rebuild(ctx context.Context) error {
+	x.mu.Lock()
+	// 重建会读取所有笔记的最新内容，之前的变化通知不再需要单独处理
+	x.pending = make(map[int64]struct{})
+	x.dirty = false
+	x.mu.Unlock()
+
+	documents, err := x.store.ListNoteDocuments(ctx, nil)
+	if err != nil {
+		return err
+	}
+	indexes := make(map[int64]*index)
+	workspaces := make(map[int64]int64, len(documents))
+	for _, document := range documents {
+		idx := indexes[document.WorkspaceID]
+		if idx == nil {
+			idx = newIndex()
+			indexes[document.WorkspaceID] = idx
+		}
+		idx.put(document)
+		workspaces[document.ID] = document.WorkspaceID
+	}
+	related := make(map[int64][]Related, len(documents))
+	for _, idx := range indexes {
+		norms := idx.norms()
+		for id := range idx.docs {
+			if similar := idx.similar(id, x.topK, norms); len(similar) > 0 {
+				related[id] = similar
+			}
+		}
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.indexes, x.workspaces, x.related, x.ready = indexes, workspaces, related, true
+	return nil
+}
+
+// update 更新发生变化的笔记，并重新计算与这些笔记共享词项的笔记的相关笔记
+func (x *Indexer) update(ctx context.Context) error {
+	x.mu.Lock()
+	noteIDs := make([]int64, 0, len(x.pending))
+	for id := range x.pending {
+		noteIDs = append(noteIDs, id)
+	}
+	x.pending = make(map[int64]struct{})
+	x.mu.Unlock()
+	if len(noteIDs) == 0 {
+		return nil
+	}
+
+	documents, err := x.store.ListNoteDocuments(ctx, noteIDs)
+	if err != nil {
+		// 保留变化通知，下次重试
+		x.Notify(noteIDs...)
+		return err
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	// 修改前后与这些笔记共享词项的笔记都可能受影响，笔记移动到其他工作区时两个工作区都受影响
+	affected := make(map[int64]struct{})
+	for _, id := range noteIDs {
+		workspaceID, ok := x.workspaces[id]
+		if !ok {
+			continue
+		}
+		idx := x.indexes[workspaceID]
+		for neighbour := range idx.neighbours(id) {
+			affected[neighbour] = struct{}{}
+		}
+		idx.remove(id)
+		if len(idx.docs) == 0 {
+			delete(x.indexes, workspaceID)
+		}
+		delete(x.workspaces, id)
+		delete(x.related, id)
+	}
+	for _, document := range documents {
+		idx := x.indexes[document.WorkspaceID]
+		if idx == nil {
+			idx = newIndex()
+			x.indexes[document.WorkspaceID] = idx
+		}
+		idx.put(document)
+		x.workspaces[document.ID] = document.WorkspaceID
+		affected[document.ID] = struct{}{}
+		for neighbour := range idx.neighbours(document.ID) {
+			affected[neighbour] = struct{}{}
+		}
+	}
+
+	norms := make(map[int64]map[int64]float64)
+	for id := range affected {
+		workspaceID, ok := x.workspaces[id]
+		if !ok {
+			continue
+		}
+		idx := x.indexes[workspaceID]
+		if norms[workspaceID] == nil {
+			norms[workspaceID] = idx.norms()
+		}
+		if similar := idx.similar(id, x.topK, norms[workspaceID]); len(similar) > 0 {
+			x.related[id] = similar
+		} else {
+			delete(x.related, id)
+		}
+	}
+	x.dirty = true
+	return nil
+}
+
+// Run 启动后台任务，直到 ctx 取消
+// 启动时建立索引，之后处理变化通知，并按 rebuildInterval 完整重建
+func (x *Indexer) Run(ctx context.Context) {
+	if err := x.Rebuild(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("Failed to build related notes index: %v", err)
+	}
+
+	ticker := time.NewTicker(x.rebuildInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			x.mu.RLock()
+			rebuild := x.dirty || !x.ready
+			x.mu.RUnlock()
+			if rebuild {
+				if err := x.Rebuild(ctx); err != nil && ctx.Err() == nil {
+					log.Printf("Failed to rebuild related notes index: %v", err)
+				}
+			}
+		case <-x.wake:
+			// 等待一段时间，合并短时间内的多次修改
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(updateDelay):
+			}
+			if err := x.update(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to update related notes index: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package related
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+	"github.com/wdmsyhh/simple-notes/store/db"
+)
+
+func TestIndexerKeepsWorkspacesApart(t *testing.T) {
+	dir := t.TempDir()
+	p := &profile.Profile{Driver: "sqlite", DSN: filepath.Join(dir, "test.db"), Data: dir}
+	driver, err := db.NewDBDriver(p)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	s := store.NewStore(driver, p)
+	defer s.Close()
+	if err := s.RunMigrations(); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	owner, err := s.CreateUser(ctx, &store.User{Username: "owner", PasswordHash: "x", Role: store.RoleUser})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	workspace, err := s.CreateWorkspace(ctx, &pbstore.Workspace{Slug: "team", NameText: "team"}, int64(owner.ID))
+	if err != nil {
+		t.Fatalf("CreateWorkspace: %v", err)
+	}
+	teamCtx := store.WithWorkspace(ctx, workspace.Id)
+	createNote := func(ctx context.Context, title string) int64 {
+		t.Helper()
+		note, err := s.CreateNote(ctx, &pbstore.Note{Title: title, Content: title, Published: true})
+		if err != nil {
+			t.Fatalf("CreateNote: %v", err)
+		}
+		return note.Id
+	}
+
+	first := createNote(ctx, "postgres replication tuning")
+	second := createNote(ctx, "postgres replication backup")
+	team := createNote(teamCtx, "postgres replication tuning guide")
+
+	x := NewIndexer(s, 0, 0)
+	if err := x.Rebuild(ctx); err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	assertRelated(t, x, first, second)
+	assertRelated(t, x, team)
+
+	// 增量更新同样只在笔记所属的工作区中计算
+	another := createNote(teamCtx, "postgres replication tuning notes")
+	x.Notify(another)
+	if err := x.update(ctx); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	assertRelated(t, x, first, second)
+	assertRelated(t, x, team, another)
+	assertRelated(t, x, another, team)
+}
+
+// assertRelated 检查笔记的相关笔记（不考虑顺序）
+func assertRelated(t *testing.T, x *Indexer, noteID int64, want ...int64) {
+	t.Helper()
+	related, ready := x.Related(noteID)
+	if !ready {
+		t.Fatalf("index is not ready")
+	}
+	got := make(map[int64]bool, len(related))
+	for _, r := range related {
+		got[r.NoteID] = true
+	}
+	if len(got) != len(want) {
+		t.Errorf("Related(%d) = %v, want %v", noteID, related, want)
+		return
+	}
+	for _, id := range want {
+		if !got[id] {
+			t.Errorf("Related(%d) = %v, want %v", noteID, related, want)
+			return
+		}
+	}
+}
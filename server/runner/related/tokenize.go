@@ -0,0 +1,79 @@
+package related
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// urlPattern 匹配文本中的网址，网址中的片段（https、com 等）不作为词项
+var urlPattern = regexp.MustCompile(`https?://\S+`)
+
+// stopWords 英文常见停用词
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "for": true, "from": true, "has": true, "have": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "no": true, "not": true, "of": true, "on": true, "or": true,
+	"so": true, "that": true, "the": true, "their": true, "then": true, "there": true, "these": true,
+	"this": true, "to": true, "was": true, "we": true, "were": true, "will": true, "with": true, "you": true,
+}
+
+// tokenize 将文本切分为词项
+// 连续的字母和数字组成一个词（转换为小写，全角转换为半角，忽略单个字母、纯数字和停用词）；
+// 中日韩文字之间没有空格，使用相邻两个字组成的二元词（bigram）切分，只有一个字时使用单字
+func tokenize(text string) []string {
+	text = strings.ToLower(width.Fold.String(urlPattern.ReplaceAllString(text, " ")))
+
+	var tokens []string
+	var word []rune
+	var cjk []rune
+	flushWord := func() {
+		if len(word) > 1 && !isNumber(word) && !stopWords[string(word)] {
+			tokens = append(tokens, string(word))
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		if len(cjk) == 1 {
+			tokens = append(tokens, string(cjk))
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, string(cjk[i:i+2]))
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// isCJK 判断字符是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// isNumber 判断词是否只包含数字
+func isNumber(word []rune) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+// schedule 包负责笔记的定时发布和过期取消发布
+// 状态保存在数据库中（notes.scheduled、notes.published_at、notes.expire_at），服务重启后会立即处理停机期间到期的笔记；
+// 状态切换使用带条件的更新，多个实例同时运行时每个到期事件只由一个实例触发
+package schedule
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// DefaultPollInterval 检查到期笔记的最长间隔，用于发现其他实例修改的定时设置
+	DefaultPollInterval = time.Minute
+	// minWait 两次检查之间的最短间隔，避免处理失败时连续重试
+	minWait = time.Second
+)
+
+// EventKind 定时事件的类型
+type EventKind string
+
+const (
+	// EventPublished 定时笔记已发布
+	EventPublished EventKind = "published"
+	// EventExpired 笔记已过期并取消发布
+	EventExpired EventKind = "expired"
+)
+
+// Event 定时事件
+type Event struct {
+	// Kind 事件类型
+	Kind EventKind
+	// NoteID 笔记ID
+	NoteID int64
+	// At 事件发生的时间
+	At time.Time
+}
+
+// Handler 定时事件的处理函数
+type Handler func(ctx context.Context, event Event)
+
+// Scheduler 笔记定时任务
+type Scheduler struct {
+	// store 数据存储实例
+	store *store.Store
+	// pollInterval 检查到期笔记的最长间隔
+	pollInterval time.Duration
+	// wake 定时设置变化时唤醒后台任务
+	wake chan struct{}
+
+	// mu 保护 handlers 的并发访问
+	mu sync.Mutex
+	// handlers 事件处理函数
+	handlers []Handler
+}
+
+// NewScheduler 创建新的笔记定时任务
+func NewScheduler(s *store.Store, pollInterval time.Duration) *Scheduler {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	return &Scheduler{
+		store:        s,
+		pollInterval: pollInterval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Subscribe 注册事件处理函数，笔记发布或过期时调用
+func (s *Scheduler) Subscribe(handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+// Notify 通知后台任务定时设置已变化，重新计算下一次检查的时间
+func (s *Scheduler) Notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RunOnce 发布到达发布时间的笔记、取消发布到达过期时间的笔记，并触发对应的事件
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	now := time.Now()
+
+	published, err := s.store.PublishDueNotes(ctx, now)
+	s.fire(ctx, EventPublished, published, now)
+	if err != nil {
+		return err
+	}
+
+	expired, err := s.store.ExpireDueNotes(ctx, now)
+	s.fire(ctx, EventExpired, expired, now)
+	return err
+}
+
+// Run 启动后台定时任务，直到 ctx 取消
+// 在下一个到期时间（最长 pollInterval）或收到 Notify 时检查到期的笔记
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		if err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to run note schedules: %v", err)
+		}
+
+		wait := s.pollInterval
+		if next, err := s.store.NextNoteScheduleTime(ctx); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to get next note schedule time: %v", err)
+			}
+		} else if !next.IsZero() && time.Until(next) < wait {
+			wait = time.Until(next)
+		}
+		if wait < minWait {
+			wait = minWait
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// fire 为每篇笔记触发事件
+func (s *Scheduler) fire(ctx context.Context, kind EventKind, noteIDs []int64, at time.Time) {
+	if len(noteIDs) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	handlers := append([]Handler(nil), s.handlers...)
+	s.mu.Unlock()
+
+	for _, noteID := range noteIDs {
+		event := Event{Kind: kind, NoteID: noteID, At: at}
+		for _, handler := range handlers {
+			handler(ctx, event)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// viewcount 包负责笔记浏览量的去重统计和批量写入
+// 浏览先在内存中累加，由后台任务定期批量写入数据库，避免每次阅读都产生一次写操作
+package viewcount
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+const (
+	// DefaultDedupWindow 同一访客重复浏览同一笔记的去重时间窗口
+	DefaultDedupWindow = 30 * time.Minute
+	// DefaultFlushInterval 批量写入数据库的时间间隔
+	DefaultFlushInterval = 30 * time.Second
+	// maxVisitors 去重记录的最大条目数，超出时提前清理过期记录，防止内存无限增长
+	maxVisitors = 100000
+)
+
+// Recorder 浏览量记录器
+type Recorder struct {
+	// store 数据存储实例
+	store *store.Store
+	// dedupWindow 去重时间窗口
+	dedupWindow time.Duration
+	// flushInterval 批量写入间隔
+	flushInterval time.Duration
+
+	// mu 保护以下字段的并发访问
+	mu sync.Mutex
+	// seen 访客最近一次被计入浏览的时间，键为 笔记ID + 访客标识
+	seen map[visitKey]time.Time
+	// pending 尚未写入数据库的浏览量
+	pending map[store.NoteViewKey]int64
+}
+
+// visitKey 去重记录的键
+type visitKey struct {
+	// noteID 笔记ID
+	noteID int64
+	// visitor 访客标识
+	visitor string
+}
+
+// NewRecorder 创建新的浏览量记录器
+func NewRecorder(s *store.Store, dedupWindow, flushInterval time.Duration) *Recorder {
+	if dedupWindow <= 0 {
+		dedupWindow = DefaultDedupWindow
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	return &Recorder{
+		store:         s,
+		dedupWindow:   dedupWindow,
+		flushInterval: flushInterval,
+		seen:          make(map[visitKey]time.Time),
+		pending:       make(map[store.NoteViewKey]int64),
+	}
+}
+
+// Record 记录一次浏览，返回是否被计入（同一访客在去重窗口内的重复浏览不计入）
+func (r *Recorder) Record(noteID int64, visitor string) bool {
+	if noteID <= 0 || visitor == "" {
+		return false
+	}
+
+	now := time.Now()
+	key := visitKey{noteID: noteID, visitor: visitor}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if last, ok := r.seen[key]; ok && now.Sub(last) < r.dedupWindow {
+		return false
+	}
+	if len(r.seen) >= maxVisitors {
+		r.pruneLocked(now)
+	}
+	r.seen[key] = now
+	r.pending[store.NoteViewKey{NoteID: noteID, Date: now.UTC().Format(store.NoteViewDateLayout)}]++
+	return true
+}
+
+// Pending 返回指定笔记尚未写入数据库的浏览量
+func (r *Recorder) Pending(noteID int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for key, n := range r.pending {
+		if key.NoteID == noteID {
+			count += n
+		}
+	}
+	return count
+}
+
+// PendingByDate 返回指定笔记尚未写入数据库的每日浏览量
+func (r *Recorder) PendingByDate(noteID int64) map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := make(map[string]int64)
+	for key, n := range r.pending {
+		if key.NoteID == noteID {
+			counts[key.Date] += n
+		}
+	}
+	return counts
+}
+
+// Flush 将内存中的浏览量批量写入数据库
+// 写入失败时浏览量会放回缓冲区，等待下一次写入
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[store.NoteViewKey]int64)
+	r.pruneLocked(time.Now())
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := r.store.IncrementNoteViews(ctx, pending); err != nil {
+		r.mu.Lock()
+		for key, n := range pending {
+			r.pending[key] += n
+		}
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run 启动后台批量写入任务，直到 ctx 取消；退出前会写入剩余的浏览量
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				log.Printf("Failed to flush note views: %v", err)
+			}
+		case <-ctx.Done():
+			// ctx 已取消，使用新的上下文完成最后一次写入
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := r.Flush(flushCtx); err != nil {
+				log.Printf("Failed to flush note views on shutdown: %v", err)
+			}
+			cancel()
+			return
+		}
+	}
+}
+
+// pruneLocked 清理已超出去重窗口的访客记录，调用方需持有锁
+func (r *Recorder) pruneLocked(now time.Time) {
+	for key, last := range r.seen {
+		if now.Sub(last) >= r.dedupWindow {
+			delete(r.seen, key)
+		}
+	}
+}
@@ -6,14 +6,22 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/wdmsyhh/simple-notes/internal/backup"
+	"github.com/wdmsyhh/simple-notes/internal/markdown"
 	"github.com/wdmsyhh/simple-notes/internal/profile"
+	"github.com/wdmsyhh/simple-notes/internal/staticsite"
 	apiv1 "github.com/wdmsyhh/simple-notes/server/router/api/v1"
 	"github.com/wdmsyhh/simple-notes/server/router/fileserver"
 	"github.com/wdmsyhh/simple-notes/server/router/frontend"
+	"github.com/wdmsyhh/simple-notes/server/router/rss"
+	"github.com/wdmsyhh/simple-notes/server/runner/related"
+	"github.com/wdmsyhh/simple-notes/server/runner/schedule"
+	"github.com/wdmsyhh/simple-notes/server/runner/viewcount"
 	"github.com/wdmsyhh/simple-notes/store"
 )
 
@@ -27,6 +35,14 @@ type Server struct {
 	Profile *profile.Profile
 	// Port - 服务器监听端口
 	Port int
+	// markdownRenderer - 服务端 Markdown 渲染器，各路由共享渲染缓存
+	markdownRenderer *markdown.Renderer
+	// viewRecorder - 笔记浏览量记录器，在后台批量写入浏览量
+	viewRecorder *viewcount.Recorder
+	// noteScheduler - 笔记定时发布和过期任务
+	noteScheduler *schedule.Scheduler
+	// relatedIndexer - 相关笔记索引
+	relatedIndexer *related.Indexer
 	// echoServer - Echo框架实例，处理HTTP请求
 	echoServer *echo.Echo
 }
@@ -43,15 +59,31 @@ func NewServer(store *store.Store, profile *profile.Profile, port int) *Server {
 	echoServer.Use(middleware.BodyLimit("32M"))
 
 	return &Server{
-		Store:      store,
-		Profile:    profile,
-		Port:       port,
-		echoServer: echoServer,
+		Store:            store,
+		Profile:          profile,
+		Port:             port,
+		echoServer:       echoServer,
+		markdownRenderer: markdown.NewRenderer(markdown.DefaultCacheSize),
+		viewRecorder:     viewcount.NewRecorder(store, viewcount.DefaultDedupWindow, viewcount.DefaultFlushInterval),
+		noteScheduler:    schedule.NewScheduler(store, schedule.DefaultPollInterval),
+		relatedIndexer:   related.NewIndexer(store, related.DefaultTopK, related.DefaultRebuildInterval),
 	}
 }
 
 // SetupRoutes 配置所有路由和服务
 func (s *Server) SetupRoutes(ctx context.Context) error {
+	// 启动浏览量批量写入任务，ctx 取消时写入剩余的浏览量
+	go s.viewRecorder.Run(ctx)
+
+	// 启动笔记定时发布和过期任务，启动时立即处理停机期间到期的笔记
+	s.noteScheduler.Subscribe(func(_ context.Context, event schedule.Event) {
+		log.Printf("Note %d %s by schedule", event.NoteID, event.Kind)
+	})
+	go s.noteScheduler.Run(ctx)
+
+	// 启动相关笔记索引，启动时读取全部笔记建立索引
+	go s.relatedIndexer.Run(ctx)
+
 	// 注册健康检查端点
 	s.echoServer.GET("/healthz", func(c echo.Context) error {
 		return c.String(http.StatusOK, "Service ready.")
@@ -63,15 +95,28 @@ func (s *Server) SetupRoutes(ctx context.Context) error {
 		secret = "simple-notes-secret-key-change-in-production"
 	}
 
+	// 数据库备份管理器，配置了备份间隔时启动定时备份
+	backupManager := backup.NewManager(s.Store, filepath.Join(s.Profile.Data, backup.DirName))
+	if s.Profile.BackupInterval > 0 {
+		go backupManager.Run(ctx, s.Profile.BackupInterval, s.Profile.BackupRetention)
+	}
+
 	// Serve frontend static files.
 	frontend.NewFrontendService(s.Profile, s.Store).Serve(ctx, s.echoServer)
 
 	// Register HTTP file server routes BEFORE gRPC-Gateway to ensure proper range request handling for Safari.
-	fileServerService := fileserver.NewFileServerService(s.Store, secret)
+	fileServerService := fileserver.NewFileServerService(s.Store, secret, backupManager)
 	fileServerService.RegisterRoutes(s.echoServer)
 
+	// 注册订阅源路由（RSS / Atom / JSON Feed）
+	rss.NewRSSService(s.Store, s.markdownRenderer).RegisterRoutes(s.echoServer)
+
 	// 注册API v1服务
-	apiV1Service := apiv1.NewAPIV1Service(s.Store, secret)
+	staticSiteExporter, err := staticsite.NewExporter(s.Store, s.markdownRenderer)
+	if err != nil {
+		return fmt.Errorf("failed to create static site exporter: %w", err)
+	}
+	apiV1Service := apiv1.NewAPIV1Service(s.Store, s.Profile, secret, s.markdownRenderer, s.viewRecorder, s.noteScheduler, s.relatedIndexer, staticSiteExporter, backupManager)
 	if err := apiV1Service.RegisterGateway(ctx, s.echoServer); err != nil {
 		return fmt.Errorf("failed to register API v1 gateway: %w", err)
 	}
@@ -90,5 +135,9 @@ func (s *Server) Start() error {
 // Shutdown 优雅关闭服务器
 func (s *Server) Shutdown(ctx context.Context) error {
 	log.Println("Server shutting down")
-	return s.echoServer.Shutdown(ctx)
+	if err := s.echoServer.Shutdown(ctx); err != nil {
+		return err
+	}
+	// 写入内存中尚未保存的浏览量
+	return s.viewRecorder.Flush(ctx)
 }
@@ -134,8 +134,16 @@ func (s *CategoryService) DeleteCategory(ctx context.Context, req *apiv1.DeleteC
 		return nil, err
 	}
 
+	var reassignTo int64
+	if req.GetReassignTo() != "" {
+		reassignTo, err = extractIDFromResourceName(req.GetReassignTo(), "categories")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// 调用存储层
-	if err := s.store.DeleteCategory(ctx, categoryID); err != nil {
+	if err := s.store.DeleteCategory(ctx, categoryID, reassignTo); err != nil {
 		return nil, fmt.Errorf("failed to delete category: %w", err)
 	}
 
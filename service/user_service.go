@@ -127,7 +127,16 @@ func (s *UserService) RegisterUser(ctx context.Context, req *UserRegistrationReq
 		Role:         role,
 	}
 
-	return s.store.CreateUser(ctx, user)
+	created, err := s.store.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// 新用户加入默认工作区，其他工作区需要由所有者添加
+	if err := s.store.JoinDefaultWorkspace(ctx, int64(created.ID), created.Role); err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
 // LoginUser 认证用户
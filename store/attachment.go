@@ -23,11 +23,16 @@ func (s *Store) CreateAttachment(ctx context.Context, attachment *store.Attachme
 		}
 	}
 
+	workspaceID, err := s.writeWorkspaceID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		INSERT INTO attachments (
 			filename, type, size, blob, note_id, author_id,
-			created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, workspace_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	now := time.Now()
@@ -40,6 +45,7 @@ func (s *Store) CreateAttachment(ctx context.Context, attachment *store.Attachme
 		authorID,
 		now,
 		now,
+		workspaceID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create attachment: %w", err)
@@ -53,10 +59,13 @@ func (s *Store) CreateAttachment(ctx context.Context, attachment *store.Attachme
 	return s.GetAttachment(ctx, id)
 }
 
+// attachmentColumns 附件表的查询字段，顺序与 scanAttachment 保持一致
+const attachmentColumns = `id, created_at, updated_at, deleted_at, filename, type, size, blob, note_id, author_id`
+
 // GetAttachment 根据ID获取附件
 func (s *Store) GetAttachment(ctx context.Context, id int64) (*store.Attachment, error) {
-	query := `SELECT * FROM attachments WHERE id = ? AND deleted_at IS NULL`
-	row := s.db.QueryRowContext(ctx, query, id)
+	query, params := andWorkspace(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE id = ? AND deleted_at IS NULL`, "workspace_id", id)
+	row := s.db.QueryRowContext(ctx, query, params...)
 
 	attachment, err := scanAttachment(row)
 	if err != nil {
@@ -71,8 +80,7 @@ func (s *Store) GetAttachment(ctx context.Context, id int64) (*store.Attachment,
 
 // ListAttachments 获取附件列表
 func (s *Store) ListAttachments(ctx context.Context, noteID *int64, authorID *uint) ([]*store.Attachment, error) {
-	query := `SELECT * FROM attachments WHERE deleted_at IS NULL`
-	params := []interface{}{}
+	query, params := andWorkspace(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE deleted_at IS NULL`, "workspace_id")
 
 	if noteID != nil {
 		query += ` AND note_id = ?`
@@ -127,8 +135,8 @@ func (s *Store) UpdateAttachment(ctx context.Context, attachment *store.Attachme
 		}
 	}
 
-	query := `UPDATE attachments SET note_id = ?, updated_at = ? WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, noteID, time.Now(), id)
+	query, params := andWorkspace(ctx, `UPDATE attachments SET note_id = ?, updated_at = ? WHERE id = ?`, "workspace_id", noteID, time.Now(), id)
+	_, err := s.db.ExecContext(ctx, query, params...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update attachment: %w", err)
 	}
@@ -139,8 +147,8 @@ func (s *Store) UpdateAttachment(ctx context.Context, attachment *store.Attachme
 
 // DeleteAttachment 删除附件
 func (s *Store) DeleteAttachment(ctx context.Context, id int64) error {
-	query := `UPDATE attachments SET deleted_at = ? WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, time.Now(), id)
+	query, params := andWorkspace(ctx, `UPDATE attachments SET deleted_at = ? WHERE id = ?`, "workspace_id", time.Now(), id)
+	_, err := s.db.ExecContext(ctx, query, params...)
 	if err != nil {
 		return fmt.Errorf("failed to delete attachment: %w", err)
 	}
@@ -12,15 +12,23 @@ import (
 	"github.com/wdmsyhh/simple-notes/proto/gen/store"
 )
 
+// categoryColumns 分类表的查询字段，顺序与 scanCategory 保持一致
+const categoryColumns = `id, created_at, updated_at, deleted_at, name_text, description, parent_id, "order", visible`
+
 // ListCategories 获取分类列表，支持可选的过滤条件
 func (s *Store) ListCategories(ctx context.Context, req *apiv1.ListCategoriesRequest) ([]*store.Category, error) {
 	// 构建查询语句
-	query := `SELECT * FROM categories`
+	query := `SELECT ` + categoryColumns + ` FROM categories`
 	params := []interface{}{}
 
 	// 构建WHERE条件
 	whereConditions := []string{}
 
+	if condition, workspaceParams := workspaceCondition(ctx, "workspace_id"); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		params = append(params, workspaceParams...)
+	}
+
 	if !req.IncludeHidden {
 		whereConditions = append(whereConditions, "visible = ?")
 		params = append(params, true)
@@ -40,7 +48,7 @@ func (s *Store) ListCategories(ctx context.Context, req *apiv1.ListCategoriesReq
 	query += ` ORDER BY "order" asc, created_at desc`
 
 	// 执行查询
-	rows, err := s.db.QueryContext(ctx, query, params...)
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), params...)
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +74,8 @@ func (s *Store) ListCategories(ctx context.Context, req *apiv1.ListCategoriesReq
 // GetCategory 根据ID获取分类
 func (s *Store) GetCategory(ctx context.Context, categoryID int64) (*store.Category, error) {
 	// 根据ID查询分类
-	query := `SELECT * FROM categories WHERE id = ?`
-	row := s.db.QueryRowContext(ctx, query, categoryID)
+	query, params := andWorkspace(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id = ?`, "workspace_id", categoryID)
+	row := s.db.QueryRowContext(ctx, s.rebind(query), params...)
 
 	category, err := scanCategory(row)
 	if err != nil {
@@ -88,14 +96,19 @@ func (s *Store) CreateCategory(ctx context.Context, category *store.Category) (*
 		parentID = uint(category.ParentId)
 	}
 
+	workspaceID, err := s.writeWorkspaceID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
 	// 插入分类（不包含 slug 字段）
 	query := `
 		INSERT INTO categories (
-			name_text, description, parent_id, "order", visible, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
+			name_text, description, parent_id, "order", visible, created_at, updated_at, workspace_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := s.db.ExecContext(ctx, query,
+	result, err := s.db.ExecContext(ctx, s.rebind(query),
 		category.NameText,
 		category.Description,
 		parentID,
@@ -103,6 +116,7 @@ func (s *Store) CreateCategory(ctx context.Context, category *store.Category) (*
 		category.Visible,
 		now,
 		now,
+		workspaceID,
 	)
 	if err != nil {
 		return nil, err
@@ -117,13 +131,24 @@ func (s *Store) CreateCategory(ctx context.Context, category *store.Category) (*
 	return s.GetCategory(ctx, id)
 }
 
-// UpdateCategory 更新现有分类
+// UpdateCategory 更新现有分类，新的父分类不能是分类本身或其子分类（与 MoveCategory 相同）
 func (s *Store) UpdateCategory(ctx context.Context, category *store.Category) (*store.Category, error) {
 	parentID := uint(0)
 	if category.ParentId > 0 {
 		parentID = uint(category.ParentId)
 	}
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 修改父分类与 MoveCategory 一样不能形成环路
+	if err := s.checkCategoryParent(ctx, tx, category.Id, int64(parentID)); err != nil {
+		return nil, err
+	}
+
 	// 更新分类（不包含 slug 字段）
 	query := `
 		UPDATE categories SET 
@@ -132,7 +157,7 @@ func (s *Store) UpdateCategory(ctx context.Context, category *store.Category) (*
 		WHERE id = ?
 	`
 
-	result, err := s.db.ExecContext(ctx, query,
+	query, params := andWorkspace(ctx, query, "workspace_id",
 		category.NameText,
 		category.Description,
 		parentID,
@@ -141,39 +166,78 @@ func (s *Store) UpdateCategory(ctx context.Context, category *store.Category) (*
 		time.Now(),
 		category.Id,
 	)
-	if err != nil {
+	if _, err := tx.ExecContext(ctx, s.rebind(query), params...); err != nil {
 		return nil, err
 	}
-
-	// 检查分类是否存在并已更新
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
-	if rowsAffected == 0 {
-		return nil, fmt.Errorf("category not found: %d", category.Id)
-	}
 
 	return s.GetCategory(ctx, category.Id)
 }
 
 // DeleteCategory 删除分类
-func (s *Store) DeleteCategory(ctx context.Context, categoryID int64) error {
-	// 检查分类下是否有文章
-	var noteCount int64
-	countQuery := `SELECT COUNT(*) FROM notes WHERE category_id = ?`
-	err := s.db.QueryRowContext(ctx, countQuery, categoryID).Scan(&noteCount)
+// reassignTo 大于 0 时，分类下的笔记和子分类转移到该分类后再删除；否则分类下有笔记或子分类时不能删除
+func (s *Store) DeleteCategory(ctx context.Context, categoryID int64, reassignTo int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to check notes count: %w", err)
+		return err
 	}
+	defer tx.Rollback()
+
+	if reassignTo > 0 {
+		if reassignTo == categoryID {
+			return fmt.Errorf("cannot reassign to the deleted category")
+		}
+		parents, err := s.categoryParents(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if _, ok := parents[reassignTo]; !ok {
+			return fmt.Errorf("category not found: %d", reassignTo)
+		}
+		if isCategoryDescendant(parents, reassignTo, categoryID) {
+			return fmt.Errorf("cannot reassign to a subcategory of the deleted category")
+		}
 
-	if noteCount > 0 {
-		return fmt.Errorf("cannot delete category: category has %d note(s)", noteCount)
+		now := time.Now()
+		if _, err := tx.ExecContext(ctx, s.rebind(`UPDATE notes SET category_id = ?, updated_at = ? WHERE category_id = ?`), reassignTo, now, categoryID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, s.rebind(`UPDATE categories SET parent_id = ?, updated_at = ? WHERE parent_id = ?`), reassignTo, now, categoryID); err != nil {
+			return err
+		}
+	} else {
+		// 检查分类下是否有文章
+		var noteCount int64
+		countQuery := `SELECT COUNT(*) FROM notes WHERE category_id = ?`
+		err := tx.QueryRowContext(ctx, s.rebind(countQuery), categoryID).Scan(&noteCount)
+		if err != nil {
+			return fmt.Errorf("failed to check notes count: %w", err)
+		}
+
+		if noteCount > 0 {
+			return fmt.Errorf("cannot delete category: category has %d note(s)", noteCount)
+		}
+
+		// 检查分类下是否有子分类
+		var childCount int64
+		if err := tx.QueryRowContext(ctx, s.rebind(`SELECT COUNT(*) FROM categories WHERE parent_id = ? AND id <> ?`), categoryID, categoryID).Scan(&childCount); err != nil {
+			return fmt.Errorf("failed to check subcategories count: %w", err)
+		}
+		if childCount > 0 {
+			return fmt.Errorf("cannot delete category: category has %d subcategory(ies)", childCount)
+		}
+	}
+
+	// 删除分类上的协作者权限，重新分配的笔记使用新分类的权限
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM note_permissions WHERE category_id = ?`), categoryID); err != nil {
+		return err
 	}
 
 	// 删除分类
-	query := `DELETE FROM categories WHERE id = ?`
-	result, err := s.db.ExecContext(ctx, query, categoryID)
+	query, params := andWorkspace(ctx, `DELETE FROM categories WHERE id = ?`, "workspace_id", categoryID)
+	result, err := tx.ExecContext(ctx, s.rebind(query), params...)
 	if err != nil {
 		return err
 	}
@@ -187,14 +251,14 @@ func (s *Store) DeleteCategory(ctx context.Context, categoryID int64) error {
 		return fmt.Errorf("category not found: %d", categoryID)
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 // GetCategoryBySlug 通过slug获取分类
 func (s *Store) GetCategoryBySlug(ctx context.Context, slug string) (*store.Category, error) {
 	// 根据slug查询分类
-	query := `SELECT * FROM categories WHERE slug = ?`
-	row := s.db.QueryRowContext(ctx, query, slug)
+	query, params := andWorkspace(ctx, `SELECT `+categoryColumns+` FROM categories WHERE slug = ?`, "workspace_id", slug)
+	row := s.db.QueryRowContext(ctx, s.rebind(query), params...)
 
 	category, err := scanCategory(row)
 	if err != nil {
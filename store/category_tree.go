@@ -0,0 +1,294 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// CategoryOrder 分类的排序顺序
+type CategoryOrder struct {
+	// ID 分类ID
+	ID int64
+	// Order 排序顺序
+	Order int32
+}
+
+// queryer 可以执行查询的数据库连接或事务
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// GetCategoryTree 返回嵌套的分类树，每个节点包含直接和递归的已发布公开笔记数量
+// 父分类不存在的分类作为顶级分类；不包含隐藏分类时，隐藏分类的整个子树都不返回
+func (s *Store) GetCategoryTree(ctx context.Context, includeHidden bool) ([]*apiv1.CategoryNode, error) {
+	categories, err := s.ListCategories(ctx, &apiv1.ListCategoriesRequest{IncludeHidden: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// 分类树是公开接口，只统计所有访问者都可以看到的笔记（已发布、公开且未过期），不透露私有和未发布笔记的存在
+	counts := map[int64]int32{}
+	published, publishedParams := s.publishedNoteCondition("")
+	query, params := andWorkspace(ctx,
+		`SELECT category_id, COUNT(*) FROM notes WHERE `+published+` AND visibility = 'PUBLIC' AND category_id IS NOT NULL`,
+		"workspace_id", publishedParams...)
+	rows, err := s.db.QueryContext(ctx, s.rebind(query+` GROUP BY category_id`), params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var categoryID int64
+		var count int32
+		if err := rows.Scan(&categoryID, &count); err != nil {
+			return nil, err
+		}
+		counts[categoryID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// ListCategories 已按排序顺序返回，子节点保持相同顺序
+	nodes := make(map[int64]*apiv1.CategoryNode, len(categories))
+	for _, category := range categories {
+		category.Name = fmt.Sprintf("categories/%d", category.Id)
+		nodes[category.Id] = &apiv1.CategoryNode{
+			Category:        category,
+			DirectNoteCount: counts[category.Id],
+			Children:        []*apiv1.CategoryNode{},
+		}
+	}
+
+	roots := []*apiv1.CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.Id]
+		parent, ok := nodes[category.ParentId]
+		if category.ParentId == 0 || !ok || category.ParentId == category.Id {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	// 数据库中已有的环路不会连接到顶级分类，将环路中的分类也作为顶级分类返回
+	reached := map[int64]bool{}
+	var mark func(node *apiv1.CategoryNode)
+	mark = func(node *apiv1.CategoryNode) {
+		reached[node.Category.Id] = true
+		for _, child := range node.Children {
+			if !reached[child.Category.Id] {
+				mark(child)
+			}
+		}
+	}
+	for _, root := range roots {
+		mark(root)
+	}
+	for _, category := range categories {
+		if reached[category.Id] {
+			continue
+		}
+		node := nodes[category.Id]
+		if parent := nodes[category.ParentId]; parent != nil {
+			children := parent.Children[:0]
+			for _, child := range parent.Children {
+				if child != node {
+					children = append(children, child)
+				}
+			}
+			parent.Children = children
+		}
+		roots = append(roots, node)
+		mark(node)
+	}
+
+	var sum func(node *apiv1.CategoryNode) int32
+	sum = func(node *apiv1.CategoryNode) int32 {
+		node.TotalNoteCount = node.DirectNoteCount
+		for _, child := range node.Children {
+			node.TotalNoteCount += sum(child)
+		}
+		return node.TotalNoteCount
+	}
+	for _, root := range roots {
+		sum(root)
+	}
+
+	if !includeHidden {
+		roots = visibleCategoryNodes(roots)
+	}
+	return roots, nil
+}
+
+// visibleCategoryNodes 移除隐藏的分类节点及其子树，笔记数量保持不变
+func visibleCategoryNodes(nodes []*apiv1.CategoryNode) []*apiv1.CategoryNode {
+	visible := []*apiv1.CategoryNode{}
+	for _, node := range nodes {
+		if !node.Category.Visible {
+			continue
+		}
+		node.Children = visibleCategoryNodes(node.Children)
+		visible = append(visible, node)
+	}
+	return visible
+}
+
+// categoryParents 返回上下文所选工作区中全部分类的父分类ID
+func (s *Store) categoryParents(ctx context.Context, q queryer) (map[int64]int64, error) {
+	query := `SELECT id, parent_id FROM categories`
+	condition, params := workspaceCondition(ctx, "workspace_id")
+	if condition != "" {
+		query += " WHERE " + condition
+	}
+	rows, err := q.QueryContext(ctx, s.rebind(query), params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parents := map[int64]int64{}
+	for rows.Next() {
+		var id int64
+		var parentID sql.NullInt64
+		if err := rows.Scan(&id, &parentID); err != nil {
+			return nil, err
+		}
+		parents[id] = parentID.Int64
+	}
+	return parents, rows.Err()
+}
+
+// descendantCategoryIDs 返回分类及其所有子分类的ID
+func (s *Store) descendantCategoryIDs(ctx context.Context, q queryer, categoryID int64) ([]int64, error) {
+	parents, err := s.categoryParents(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[int64][]int64{}
+	for id, parentID := range parents {
+		if parentID > 0 && parentID != id {
+			children[parentID] = append(children[parentID], id)
+		}
+	}
+
+	ids := []int64{categoryID}
+	seen := map[int64]bool{categoryID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// isCategoryDescendant 判断 categoryID 是否是 ancestorID 本身或其子分类
+func isCategoryDescendant(parents map[int64]int64, categoryID, ancestorID int64) bool {
+	seen := map[int64]bool{}
+	for id := categoryID; id > 0 && !seen[id]; id = parents[id] {
+		if id == ancestorID {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
+
+// checkCategoryParent 检查分类和新的父分类（parentID 为 0 表示顶级）都在所选工作区中存在，
+// 并且新的父分类不是分类本身或其子分类，避免形成环路；MoveCategory 和 UpdateCategory 修改父分类前都需要检查
+func (s *Store) checkCategoryParent(ctx context.Context, q queryer, categoryID, parentID int64) error {
+	parents, err := s.categoryParents(ctx, q)
+	if err != nil {
+		return err
+	}
+	if _, ok := parents[categoryID]; !ok {
+		return fmt.Errorf("category not found: %d", categoryID)
+	}
+	if parentID > 0 {
+		if _, ok := parents[parentID]; !ok {
+			return fmt.Errorf("category not found: %d", parentID)
+		}
+		if isCategoryDescendant(parents, parentID, categoryID) {
+			return fmt.Errorf("cannot move category into its own subtree")
+		}
+	}
+	return nil
+}
+
+// MoveCategory 将分类移动到新的父分类下（parentID 为 0 时移动到顶级），子分类随之移动
+// 新的父分类不能是分类本身或其子分类
+func (s *Store) MoveCategory(ctx context.Context, categoryID, parentID int64, order int32) (*store.Category, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.checkCategoryParent(ctx, tx, categoryID, parentID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		s.rebind(`UPDATE categories SET parent_id = ?, "order" = ?, updated_at = ? WHERE id = ?`),
+		parentID, order, time.Now(), categoryID,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetCategory(ctx, categoryID)
+}
+
+// ReorderCategories 在一个事务中批量设置分类的排序顺序，任一分类不存在时全部不修改
+func (s *Store) ReorderCategories(ctx context.Context, orders []CategoryOrder) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, order := range orders {
+		query, params := andWorkspace(ctx, `UPDATE categories SET "order" = ?, updated_at = ? WHERE id = ?`, "workspace_id", order.Order, now, order.ID)
+		result, err := tx.ExecContext(ctx, s.rebind(query), params...)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("category not found: %d", order.ID)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// categoryIDsCondition 返回匹配分类及其所有子分类的条件
+func (s *Store) categoryIDsCondition(ctx context.Context, column string, categoryID int64) (string, []interface{}, error) {
+	ids, err := s.descendantCategoryIDs(ctx, s.db, categoryID)
+	if err != nil {
+		return "", nil, err
+	}
+	placeholders := make([]string, len(ids))
+	params := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		params[i] = id
+	}
+	return column + " IN (" + strings.Join(placeholders, ", ") + ")", params, nil
+}
@@ -0,0 +1,160 @@
+package store_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestGetCategoryTreeCountsPublicNotes(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+
+	parent, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "parent", Visible: true})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	child, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "child", ParentId: parent.Id, Visible: true})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	parentID := strconv.FormatInt(parent.Id, 10)
+	childID := strconv.FormatInt(child.Id, 10)
+
+	createTestNote(t, ctx, s, &pbstore.Note{Title: "public", AuthorId: userID(author), CategoryId: parentID})
+	createTestNote(t, ctx, s, &pbstore.Note{Title: "public child", AuthorId: userID(author), CategoryId: childID})
+	createTestNote(t, ctx, s, &pbstore.Note{
+		Title:      "private",
+		AuthorId:   userID(author),
+		CategoryId: parentID,
+		Visibility: pbstore.NoteVisibility_NOTE_VISIBILITY_PRIVATE,
+	})
+	if _, err := s.CreateNote(ctx, &pbstore.Note{Title: "draft", AuthorId: userID(author), CategoryId: childID}); err != nil {
+		t.Fatalf("CreateNote: %v", err)
+	}
+	// 已过期但定时任务尚未取消发布的笔记
+	expired := createTestNote(t, ctx, s, &pbstore.Note{
+		Title:      "expired",
+		AuthorId:   userID(author),
+		CategoryId: childID,
+		ExpireAt:   time.Now().Add(time.Hour).Unix(),
+	})
+	if _, err := s.GetDB().ExecContext(ctx, `UPDATE notes SET expire_at = ? WHERE id = ?`, time.Now().Add(-time.Hour), expired.Id); err != nil {
+		t.Fatalf("failed to expire note: %v", err)
+	}
+
+	roots, err := s.GetCategoryTree(ctx, false)
+	if err != nil {
+		t.Fatalf("GetCategoryTree: %v", err)
+	}
+	if len(roots) != 1 || len(roots[0].Children) != 1 {
+		t.Fatalf("GetCategoryTree = %v, want parent with one child", roots)
+	}
+	if got := roots[0]; got.DirectNoteCount != 1 || got.TotalNoteCount != 2 {
+		t.Errorf("parent counts = %d direct, %d total, want 1 and 2", got.DirectNoteCount, got.TotalNoteCount)
+	}
+	if got := roots[0].Children[0]; got.DirectNoteCount != 1 || got.TotalNoteCount != 1 {
+		t.Errorf("child counts = %d direct, %d total, want 1 and 1", got.DirectNoteCount, got.TotalNoteCount)
+	}
+}
+
+func TestCategoryParentCycles(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	parent, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "parent"})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	child, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "child", ParentId: parent.Id})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	grandchild, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "grandchild", ParentId: child.Id})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+
+	for _, parentID := range []int64{parent.Id, grandchild.Id} {
+		if _, err := s.MoveCategory(ctx, parent.Id, parentID, 0); err == nil {
+			t.Errorf("MoveCategory(parent under %d) succeeded, want error", parentID)
+		}
+		update := &pbstore.Category{Id: parent.Id, NameText: "parent", ParentId: parentID}
+		if _, err := s.UpdateCategory(ctx, update); err == nil {
+			t.Errorf("UpdateCategory(parent under %d) succeeded, want error", parentID)
+		}
+	}
+	if _, err := s.UpdateCategory(ctx, &pbstore.Category{Id: parent.Id, NameText: "parent", ParentId: 999}); err == nil {
+		t.Errorf("UpdateCategory with a missing parent succeeded, want error")
+	}
+
+	// 移动到其他分支和顶级仍然可以
+	updated, err := s.UpdateCategory(ctx, &pbstore.Category{Id: grandchild.Id, NameText: "grandchild", ParentId: parent.Id})
+	if err != nil {
+		t.Fatalf("UpdateCategory: %v", err)
+	}
+	if updated.ParentId != parent.Id {
+		t.Errorf("UpdateCategory parent = %d, want %d", updated.ParentId, parent.Id)
+	}
+	if _, err := s.UpdateCategory(ctx, &pbstore.Category{Id: child.Id, NameText: "child"}); err != nil {
+		t.Errorf("UpdateCategory to top level: %v", err)
+	}
+	if _, err := s.UpdateCategory(ctx, &pbstore.Category{Id: 999, NameText: "missing"}); err == nil {
+		t.Errorf("UpdateCategory of a missing category succeeded, want error")
+	}
+}
+
+func TestReorderAndDeleteCategories(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	createCategory := func(name string, parentID int64) *pbstore.Category {
+		t.Helper()
+		category, err := s.CreateCategory(ctx, &pbstore.Category{NameText: name, ParentId: parentID})
+		if err != nil {
+			t.Fatalf("CreateCategory: %v", err)
+		}
+		return category
+	}
+	old := createCategory("old", 0)
+	child := createCategory("child", old.Id)
+	target := createCategory("target", 0)
+	note := createTestNote(t, ctx, s, &pbstore.Note{Title: "note", AuthorId: userID(author), CategoryId: strconv.FormatInt(old.Id, 10)})
+
+	if err := s.ReorderCategories(ctx, []store.CategoryOrder{{ID: target.Id, Order: 1}, {ID: old.Id, Order: 2}}); err != nil {
+		t.Fatalf("ReorderCategories: %v", err)
+	}
+	if got, err := s.GetCategory(ctx, old.Id); err != nil || got.Order != 2 {
+		t.Errorf("GetCategory order = %v (%v), want 2", got, err)
+	}
+	// 任一分类不存在时全部不修改
+	if err := s.ReorderCategories(ctx, []store.CategoryOrder{{ID: old.Id, Order: 5}, {ID: 999, Order: 6}}); err == nil {
+		t.Errorf("ReorderCategories with a missing category succeeded, want error")
+	}
+	if got, err := s.GetCategory(ctx, old.Id); err != nil || got.Order != 2 {
+		t.Errorf("GetCategory order after failed reorder = %v (%v), want 2", got, err)
+	}
+
+	if err := s.DeleteCategory(ctx, old.Id, 0); err == nil {
+		t.Errorf("DeleteCategory of a non-empty category succeeded, want error")
+	}
+	if err := s.DeleteCategory(ctx, old.Id, child.Id); err == nil {
+		t.Errorf("DeleteCategory reassigning to a subcategory succeeded, want error")
+	}
+	if err := s.DeleteCategory(ctx, old.Id, target.Id); err != nil {
+		t.Fatalf("DeleteCategory: %v", err)
+	}
+	if got, err := s.GetNote(ctx, note.Id); err != nil || got.CategoryId != strconv.FormatInt(target.Id, 10) {
+		t.Errorf("GetNote category = %v (%v), want %d", got, err, target.Id)
+	}
+	if got, err := s.GetCategory(ctx, child.Id); err != nil || got.ParentId != target.Id {
+		t.Errorf("GetCategory parent = %v (%v), want %d", got, err, target.Id)
+	}
+	if _, err := s.GetCategory(ctx, old.Id); err == nil {
+		t.Errorf("GetCategory of the deleted category succeeded, want error")
+	}
+}
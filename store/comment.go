@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// ListComments 获取笔记的评论列表（包含未审核的评论），按创建时间升序
+func (s *Store) ListComments(ctx context.Context, noteID int64) ([]*store.Comment, error) {
+	query := `SELECT id, created_at, updated_at, note_id, author, email, content, parent_id, approved
+		FROM comments WHERE note_id = ? AND deleted_at IS NULL ORDER BY created_at ASC, id ASC`
+
+	rows, err := s.db.QueryContext(ctx, query, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*store.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// CreateComment 创建评论，CreatedAt 不为 0 时使用指定的创建时间（用于导入）
+func (s *Store) CreateComment(ctx context.Context, comment *store.Comment) (*store.Comment, error) {
+	var noteID int64
+	if _, err := fmt.Sscanf(comment.NoteId, "notes/%d", &noteID); err != nil {
+		return nil, fmt.Errorf("invalid note ID: %s", comment.NoteId)
+	}
+	var parentID sql.NullInt64
+	if comment.ParentId > 0 {
+		parentID = sql.NullInt64{Int64: comment.ParentId, Valid: true}
+	}
+	now := time.Now()
+	if comment.CreatedAt > 0 {
+		now = time.Unix(comment.CreatedAt, 0)
+	}
+
+	query := `INSERT INTO comments (note_id, author, email, content, parent_id, approved, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, query,
+		noteID, comment.Author, comment.Email, comment.Content, parentID, comment.Approved, now, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetComment(ctx, id)
+}
+
+// GetComment 根据ID获取评论
+func (s *Store) GetComment(ctx context.Context, id int64) (*store.Comment, error) {
+	query := `SELECT id, created_at, updated_at, note_id, author, email, content, parent_id, approved
+		FROM comments WHERE id = ? AND deleted_at IS NULL`
+	comment, err := scanComment(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("comment not found: %d", id)
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// UpdateCommentContent 更新评论内容
+func (s *Store) UpdateCommentContent(ctx context.Context, id int64, content string) (*store.Comment, error) {
+	if err := s.execComment(ctx, `UPDATE comments SET content = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`, content, time.Now(), id); err != nil {
+		return nil, err
+	}
+	return s.GetComment(ctx, id)
+}
+
+// ApproveComment 将评论标记为已审核
+func (s *Store) ApproveComment(ctx context.Context, id int64) (*store.Comment, error) {
+	if err := s.execComment(ctx, `UPDATE comments SET approved = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`, true, time.Now(), id); err != nil {
+		return nil, err
+	}
+	return s.GetComment(ctx, id)
+}
+
+// DeleteComment 软删除评论
+func (s *Store) DeleteComment(ctx context.Context, id int64) error {
+	now := time.Now()
+	return s.execComment(ctx, `UPDATE comments SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`, now, now, id)
+}
+
+// execComment 执行修改单条评论的语句，评论不存在时返回错误，最后一个参数为评论ID
+func (s *Store) execComment(ctx context.Context, query string, args ...interface{}) error {
+	result, err := s.db.ExecContext(ctx, s.rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("comment not found: %v", args[len(args)-1])
+	}
+	return nil
+}
+
+// scanComment 扫描评论数据
+// 参数可以是 *sql.Row 或 *sql.Rows
+func scanComment(row interface{ Scan(dest ...any) error }) (*store.Comment, error) {
+	var (
+		id        int64
+		createdAt time.Time
+		updatedAt time.Time
+		noteID    int64
+		author    string
+		email     string
+		content   string
+		parentID  sql.NullInt64
+		approved  bool
+	)
+	if err := row.Scan(&id, &createdAt, &updatedAt, &noteID, &author, &email, &content, &parentID, &approved); err != nil {
+		return nil, err
+	}
+
+	return &store.Comment{
+		Name:      fmt.Sprintf("comments/%d", id),
+		Id:        id,
+		NoteId:    fmt.Sprintf("notes/%d", noteID),
+		Author:    author,
+		Email:     email,
+		Content:   content,
+		ParentId:  parentID.Int64,
+		Approved:  approved,
+		CreatedAt: createdAt.Unix(),
+		UpdatedAt: updatedAt.Unix(),
+	}, nil
+}
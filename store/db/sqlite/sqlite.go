@@ -8,8 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 
-	// Import the SQLite driver.
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
 
 	"github.com/wdmsyhh/simple-notes/internal/profile"
 	"github.com/wdmsyhh/simple-notes/store"
@@ -50,10 +49,21 @@ func NewDB(profile *profile.Profile) (store.Driver, error) {
 	// - https://pkg.go.dev/modernc.org/sqlite#Driver.Open
 	// - https://www.sqlite.org/sharedcache.html
 	// - https://www.sqlite.org/pragma.html
-	sqliteDB, err := sql.Open("sqlite", profile.DSN+"?_pragma=foreign_keys(0)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)")
+	//
+	// 连接经过 utcConnector 包装，时间参数以 UTC 保存和比较（见 utc.go）
+	registered, err := sql.Open("sqlite", "")
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to open db with dsn: %s", profile.DSN)
+		return nil, errors.Wrap(err, "failed to get sqlite driver")
+	}
+	defer registered.Close()
+	sqliteDriver, ok := registered.Driver().(*sqlite.Driver)
+	if !ok {
+		return nil, errors.Errorf("unexpected sqlite driver: %T", registered.Driver())
 	}
+	sqliteDB := sql.OpenDB(&utcConnector{
+		dsn:    profile.DSN + "?_pragma=foreign_keys(0)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)",
+		driver: sqliteDriver,
+	})
 
 	driver := DB{db: sqliteDB, profile: profile}
 
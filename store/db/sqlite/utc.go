@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// SQLite 没有时间类型，驱动将 time.Time 以 Time.String() 的文本保存，比较时按文本比较。
+// 文本包含写入时的时区，不同时区（包括夏令时切换前后）写入的时间按文本比较的结果不正确，
+// 因此所有时间参数在写入和绑定前统一转换为 UTC，与 store 中 timeParam 的格式一致
+
+// utcConnector 打开将时间参数转换为 UTC 的连接
+type utcConnector struct {
+	// dsn 数据源名称
+	dsn string
+	// driver SQLite 驱动
+	driver *sqlite.Driver
+}
+
+// Connect 实现 driver.Connector
+func (c *utcConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &utcConn{Conn: conn}, nil
+}
+
+// Driver 实现 driver.Connector
+func (c *utcConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// utcConn 在 SQLite 连接上将时间参数转换为 UTC，其余方法交给原连接
+type utcConn struct {
+	driver.Conn
+}
+
+// CheckNamedValue 实现 driver.NamedValueChecker，database/sql 在调用驱动前对每个参数调用
+// 时间参数转换为 UTC，其他参数使用默认的转换
+func (c *utcConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch value := nv.Value.(type) {
+	case time.Time:
+		nv.Value = value.UTC()
+		return nil
+	case sql.NullTime:
+		if value.Valid {
+			nv.Value = value.Time.UTC()
+		} else {
+			nv.Value = nil
+		}
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+// BeginTx 实现 driver.ConnBeginTx
+func (c *utcConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+// PrepareContext 实现 driver.ConnPrepareContext
+func (c *utcConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+// ExecContext 实现 driver.ExecerContext
+func (c *utcConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+// QueryContext 实现 driver.QueryerContext
+func (c *utcConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+// Ping 实现 driver.Pinger
+func (c *utcConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+// ResetSession 实现 driver.SessionResetter
+func (c *utcConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+// IsValid 实现 driver.Validator
+func (c *utcConn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
@@ -0,0 +1,670 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType 列的数据类型，用于在不同数据库之间转换数据
+type ColumnType string
+
+const (
+	// ColumnInteger 整数
+	ColumnInteger ColumnType = "integer"
+	// ColumnText 字符串
+	ColumnText ColumnType = "text"
+	// ColumnBool 布尔值
+	ColumnBool ColumnType = "bool"
+	// ColumnTime 时间（精确到秒，UTC）
+	ColumnTime ColumnType = "time"
+	// ColumnBlob 二进制数据
+	ColumnBlob ColumnType = "blob"
+)
+
+// Column 数据表的列
+type Column struct {
+	// Name 列名
+	Name string `json:"name"`
+	// Type 数据类型
+	Type ColumnType `json:"type"`
+}
+
+// Table 数据表的结构描述，用于备份、恢复和跨数据库复制
+type Table struct {
+	// Name 表名
+	Name string
+	// Columns 全部列
+	Columns []Column
+	// PrimaryKey 主键列，导出时按主键排序
+	PrimaryKey []string
+	// AutoIncrement 主键是否为自增的 id 列，导入后需要修正自增计数器
+	AutoIncrement bool
+	// SeedRows 新数据库启动时由迁移自动写入的行数（例如默认工作区），恢复时不视为已有数据
+	SeedRows int64
+}
+
+// Tables 全部数据表，按依赖顺序排列（被引用的表在前）
+// 新增数据表或修改列时需要同步更新
+var Tables = []*Table{
+	{
+		Name: "users",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"deleted_at", ColumnTime},
+			{"username", ColumnText}, {"password_hash", ColumnText}, {"nickname", ColumnText}, {"avatar", ColumnText},
+			{"bio", ColumnText}, {"role", ColumnText},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "workspaces",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"slug", ColumnText},
+			{"name_text", ColumnText}, {"description", ColumnText}, {"creator_id", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+		SeedRows:      1,
+	},
+	{
+		Name: "workspace_members",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"workspace_id", ColumnInteger},
+			{"user_id", ColumnInteger}, {"role", ColumnText},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "categories",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"deleted_at", ColumnTime},
+			{"name_text", ColumnText}, {"description", ColumnText}, {"parent_id", ColumnInteger}, {"order", ColumnInteger},
+			{"visible", ColumnBool}, {"workspace_id", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "tags",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"deleted_at", ColumnTime},
+			{"name_text", ColumnText}, {"description", ColumnText}, {"count", ColumnInteger}, {"name_key", ColumnText},
+			{"workspace_id", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "tag_aliases",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"tag_id", ColumnInteger},
+			{"alias", ColumnText}, {"alias_key", ColumnText}, {"workspace_id", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "notes",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"deleted_at", ColumnTime},
+			{"title", ColumnText}, {"content", ColumnText}, {"summary", ColumnText}, {"category_id", ColumnInteger},
+			{"published", ColumnBool}, {"author_id", ColumnInteger}, {"published_at", ColumnTime},
+			{"cover_image", ColumnText}, {"reading_time", ColumnInteger}, {"view_count", ColumnInteger},
+			{"visibility", ColumnText}, {"word_count", ColumnInteger}, {"char_count", ColumnInteger},
+			{"scheduled", ColumnBool}, {"expire_at", ColumnTime}, {"workspace_id", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name:       "note_tags",
+		Columns:    []Column{{"note_id", ColumnInteger}, {"tag_id", ColumnInteger}},
+		PrimaryKey: []string{"note_id", "tag_id"},
+	},
+	{
+		Name: "comments",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"deleted_at", ColumnTime},
+			{"note_id", ColumnInteger}, {"author", ColumnText}, {"email", ColumnText}, {"content", ColumnText},
+			{"parent_id", ColumnInteger}, {"approved", ColumnBool},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "pages",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"deleted_at", ColumnTime},
+			{"title", ColumnText}, {"slug", ColumnText}, {"content", ColumnText}, {"published", ColumnBool},
+			{"in_navigation", ColumnBool}, {"order", ColumnInteger}, {"workspace_id", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "attachments",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"deleted_at", ColumnTime},
+			{"filename", ColumnText}, {"type", ColumnText}, {"size", ColumnInteger}, {"blob", ColumnBlob},
+			{"note_id", ColumnInteger}, {"author_id", ColumnInteger}, {"workspace_id", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name:       "note_views",
+		Columns:    []Column{{"note_id", ColumnInteger}, {"view_date", ColumnText}, {"view_count", ColumnInteger}},
+		PrimaryKey: []string{"note_id", "view_date"},
+	},
+	{
+		Name: "redirects",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"source_path", ColumnText}, {"target_path", ColumnText},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "saved_searches",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"owner_id", ColumnInteger},
+			{"name_text", ColumnText}, {"filter", ColumnText}, {"sort_by", ColumnText}, {"sort_desc", ColumnBool},
+			{"pinned", ColumnBool}, {"shared", ColumnBool}, {"feed_enabled", ColumnBool},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name:       "saved_search_views",
+		Columns:    []Column{{"saved_search_id", ColumnInteger}, {"user_id", ColumnInteger}, {"last_viewed_at", ColumnTime}},
+		PrimaryKey: []string{"saved_search_id", "user_id"},
+	},
+	{
+		Name: "note_drafts",
+		Columns: []Column{
+			{"note_id", ColumnInteger}, {"user_id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime},
+			{"title", ColumnText}, {"content", ColumnText}, {"summary", ColumnText}, {"category_id", ColumnInteger},
+			{"tag_ids", ColumnText}, {"cover_image", ColumnText}, {"visibility", ColumnText},
+		},
+		PrimaryKey: []string{"note_id", "user_id"},
+	},
+	{
+		Name: "note_revisions",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"note_id", ColumnInteger}, {"author_id", ColumnInteger},
+			{"title", ColumnText}, {"content", ColumnText}, {"summary", ColumnText},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "note_links",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"source_note_id", ColumnInteger}, {"target_note_id", ColumnInteger},
+			{"target", ColumnText}, {"target_key", ColumnText}, {"alias", ColumnText},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "note_share_links",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"note_id", ColumnInteger}, {"creator_id", ColumnInteger},
+			{"token_hash", ColumnText}, {"password_hash", ColumnText}, {"expire_at", ColumnTime},
+			{"max_views", ColumnInteger}, {"view_count", ColumnInteger}, {"revoked", ColumnBool},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+	{
+		Name: "note_permissions",
+		Columns: []Column{
+			{"id", ColumnInteger}, {"created_at", ColumnTime}, {"updated_at", ColumnTime}, {"user_id", ColumnInteger},
+			{"note_id", ColumnInteger}, {"category_id", ColumnInteger}, {"role", ColumnText}, {"granted_by", ColumnInteger},
+		},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: true,
+	},
+}
+
+// FindTable 根据表名查找数据表，不存在时返回 nil
+func FindTable(name string) *Table {
+	for _, table := range Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// Queryer 数据库连接或事务
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// DriverName 返回数据库驱动类型（sqlite/mysql/postgres）
+func (s *Store) DriverName() string {
+	return s.profile.Driver
+}
+
+// quoteIdentifier 按数据库类型引用表名或列名（例如 "order" 是保留字）
+func (s *Store) quoteIdentifier(name string) string {
+	if s.profile.Driver == "mysql" {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+// placeholder 返回第 n 个（从 1 开始）参数占位符
+func (s *Store) placeholder(n int) string {
+	if s.profile.Driver == "postgres" {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// BeginSnapshot 开始一个只读事务，事务内的多次查询看到的是同一时刻的一致快照
+func (s *Store) BeginSnapshot(ctx context.Context) (*sql.Tx, error) {
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	if s.profile.Driver == "sqlite" {
+		// SQLite 不支持设置隔离级别，WAL 模式下读事务本身就是一致快照
+		opts = nil
+	}
+	tx, err := s.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin snapshot: %w", err)
+	}
+	return tx, nil
+}
+
+// VacuumInto 将 SQLite 数据库的一致快照写入新文件，写入期间不阻塞其他读写
+func (s *Store) VacuumInto(ctx context.Context, path string) error {
+	if s.profile.Driver != "sqlite" {
+		return fmt.Errorf("VACUUM INTO is only supported by sqlite, current driver: %s", s.profile.Driver)
+	}
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to vacuum into %s: %w", path, err)
+	}
+	return nil
+}
+
+// ExistingColumns 返回数据表在当前数据库中实际存在的列（按 table.Columns 的顺序），表不存在时返回空
+// 用于读取尚未执行迁移的旧版本数据库
+func (s *Store) ExistingColumns(ctx context.Context, table *Table) ([]Column, error) {
+	var columns []Column
+	for _, column := range table.Columns {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		exists, err := s.columnExists(table.Name, column.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check column %s.%s: %w", table.Name, column.Name, err)
+		}
+		if exists {
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+// ScanRows 按主键顺序读取数据表中的全部行（包含软删除的行）
+// 每一行的值已按列类型转换为 int64、string、bool、time.Time、[]byte 或 nil
+func (s *Store) ScanRows(ctx context.Context, q Queryer, table *Table, columns []Column, fn func(row []any) error) error {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = s.quoteIdentifier(column.Name)
+	}
+	orderBy := make([]string, len(table.PrimaryKey))
+	for i, key := range table.PrimaryKey {
+		orderBy[i] = s.quoteIdentifier(key)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY %s",
+		strings.Join(names, ", "), s.quoteIdentifier(table.Name), strings.Join(orderBy, ", "))
+
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to read table %s: %w", table.Name, err)
+	}
+	defer rows.Close()
+
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan table %s: %w", table.Name, err)
+		}
+		row := make([]any, len(columns))
+		for i, column := range columns {
+			if row[i], err = NormalizeValue(column.Type, values[i]); err != nil {
+				return fmt.Errorf("invalid value in %s.%s: %w", table.Name, column.Name, err)
+			}
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// PrepareInsert 准备向数据表插入完整行（包含主键）的语句
+func (s *Store) PrepareInsert(ctx context.Context, q Queryer, table *Table, columns []Column) (*sql.Stmt, error) {
+	names := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = s.quoteIdentifier(column.Name)
+		placeholders[i] = s.placeholder(i + 1)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		s.quoteIdentifier(table.Name), strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	stmt, err := q.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare insert into %s: %w", table.Name, err)
+	}
+	return stmt, nil
+}
+
+// CountRows 返回数据表的行数
+func (s *Store) CountRows(ctx context.Context, q Queryer, table *Table) (int64, error) {
+	rows, err := q.QueryContext(ctx, "SELECT COUNT(*) FROM "+s.quoteIdentifier(table.Name))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count table %s: %w", table.Name, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, err
+		}
+	}
+	return count, rows.Err()
+}
+
+// ClearTables 删除全部数据表中的数据（按依赖的逆序）
+func (s *Store) ClearTables(ctx context.Context, q Queryer) error {
+	for i := len(Tables) - 1; i >= 0; i-- {
+		if _, err := q.ExecContext(ctx, "DELETE FROM "+s.quoteIdentifier(Tables[i].Name)); err != nil {
+			return fmt.Errorf("failed to clear table %s: %w", Tables[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// ResetSequences 将自增计数器设置为当前最大ID，插入指定ID的行后调用
+// SQLite 插入指定ID时会自动更新计数器，无需处理
+// MySQL 的 ALTER TABLE 会隐式提交事务，不能在事务中调用
+func (s *Store) ResetSequences(ctx context.Context, q Queryer) error {
+	for _, table := range Tables {
+		if !table.AutoIncrement {
+			continue
+		}
+		name := s.quoteIdentifier(table.Name)
+		switch s.profile.Driver {
+		case "mysql":
+			var maxID sql.NullInt64
+			rows, err := q.QueryContext(ctx, "SELECT MAX(id) FROM "+name)
+			if err != nil {
+				return fmt.Errorf("failed to reset sequence of %s: %w", table.Name, err)
+			}
+			if rows.Next() {
+				err = rows.Scan(&maxID)
+			}
+			rows.Close()
+			if err != nil {
+				return fmt.Errorf("failed to reset sequence of %s: %w", table.Name, err)
+			}
+			if _, err := q.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s AUTO_INCREMENT = %d", name, maxID.Int64+1)); err != nil {
+				return fmt.Errorf("failed to reset sequence of %s: %w", table.Name, err)
+			}
+		case "postgres":
+			query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 1), MAX(id) IS NOT NULL) FROM %s", table.Name, name)
+			if _, err := q.ExecContext(ctx, query); err != nil {
+				return fmt.Errorf("failed to reset sequence of %s: %w", table.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DisableForeignKeys 在指定连接上关闭外键检查，以便按任意顺序插入带有自引用的行
+// 返回的函数用于恢复外键检查；PostgreSQL 需要超级用户权限，没有权限时保持外键检查
+func (s *Store) DisableForeignKeys(ctx context.Context, conn *sql.Conn) func() {
+	var disable, enable string
+	switch s.profile.Driver {
+	case "sqlite":
+		disable, enable = "PRAGMA foreign_keys = OFF", "PRAGMA foreign_keys = OFF"
+	case "mysql":
+		disable, enable = "SET FOREIGN_KEY_CHECKS = 0", "SET FOREIGN_KEY_CHECKS = 1"
+	case "postgres":
+		disable, enable = "SET session_replication_role = replica", "SET session_replication_role = DEFAULT"
+	default:
+		return func() {}
+	}
+	if _, err := conn.ExecContext(ctx, disable); err != nil {
+		return func() {}
+	}
+	return func() {
+		_, _ = conn.ExecContext(context.Background(), enable)
+	}
+}
+
+// timeLayouts 以字符串形式读取到的时间的格式
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// parseTimeString 解析以字符串形式读取到的时间
+func parseTimeString(value string) (time.Time, error) {
+	// time.Time.String() 输出中的单调时钟部分，例如 " m=+0.005889304"
+	if index := strings.Index(value, " m="); index >= 0 {
+		value = value[:index]
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q", value)
+}
+
+// NormalizeValue 将从数据库读取到的值转换为列类型对应的 Go 类型
+// 时间统一转换为 UTC 并截断到秒，保证在不同数据库之间复制后校验和一致
+func NormalizeValue(columnType ColumnType, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if b, ok := value.([]byte); ok && columnType != ColumnBlob {
+		value = string(b)
+	}
+
+	switch columnType {
+	case ColumnInteger:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			if v == "" {
+				return nil, nil
+			}
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case ColumnText:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case time.Time:
+			return v.UTC().Format(time.RFC3339), nil
+		}
+	case ColumnBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case ColumnTime:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UTC().Truncate(time.Second), nil
+		case string:
+			if v == "" {
+				return nil, nil
+			}
+			t, err := parseTimeString(v)
+			if err != nil {
+				return nil, err
+			}
+			return t.UTC().Truncate(time.Second), nil
+		case int64:
+			return time.Unix(v, 0).UTC(), nil
+		}
+	case ColumnBlob:
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %T for %s column", value, columnType)
+}
+
+// EncodeRow 将一行数据编码为 JSON 数组，时间使用 RFC3339 格式，二进制数据使用 base64
+func EncodeRow(row []any) ([]byte, error) {
+	encoded := make([]any, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case time.Time:
+			encoded[i] = v.Format(time.RFC3339)
+		case []byte:
+			encoded[i] = base64.StdEncoding.EncodeToString(v)
+		default:
+			encoded[i] = v
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// DecodeRow 解码 EncodeRow 编码的一行数据
+func DecodeRow(columns []Column, data []byte) ([]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var values []any
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	if len(values) != len(columns) {
+		return nil, fmt.Errorf("expected %d values, got %d", len(columns), len(values))
+	}
+
+	row := make([]any, len(columns))
+	for i, column := range columns {
+		value := values[i]
+		if value == nil {
+			continue
+		}
+		var err error
+		switch column.Type {
+		case ColumnInteger:
+			n, ok := value.(json.Number)
+			if !ok {
+				return nil, fmt.Errorf("column %s: expected number, got %T", column.Name, value)
+			}
+			row[i], err = n.Int64()
+		case ColumnBlob:
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("column %s: expected base64 string, got %T", column.Name, value)
+			}
+			row[i], err = base64.StdEncoding.DecodeString(str)
+		default:
+			if n, ok := value.(json.Number); ok {
+				value = n.String()
+			}
+			row[i], err = NormalizeValue(column.Type, value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", column.Name, err)
+		}
+	}
+	return row, nil
+}
+
+// Checksum 计算数据表内容的校验和（行数和全部行编码后的 SHA-256）
+type Checksum struct {
+	// rows 已写入的行数
+	rows int64
+	// sum 累计的摘要
+	sum hash.Hash
+}
+
+// NewChecksum 创建新的校验和
+func NewChecksum() *Checksum {
+	return &Checksum{sum: sha256.New()}
+}
+
+// Add 追加一行编码后的数据
+func (c *Checksum) Add(encoded []byte) {
+	c.rows++
+	_, _ = c.sum.Write(encoded)
+	_, _ = c.sum.Write([]byte{'\n'})
+}
+
+// Rows 返回已追加的行数
+func (c *Checksum) Rows() int64 {
+	return c.rows
+}
+
+// String 返回十六进制的摘要
+func (c *Checksum) String() string {
+	return hex.EncodeToString(c.sum.Sum(nil))
+}
+
+// TableChecksum 计算数据表指定列的校验和
+func (s *Store) TableChecksum(ctx context.Context, q Queryer, table *Table, columns []Column) (*Checksum, error) {
+	checksum := NewChecksum()
+	err := s.ScanRows(ctx, q, table, columns, func(row []any) error {
+		encoded, err := EncodeRow(row)
+		if err != nil {
+			return err
+		}
+		checksum.Add(encoded)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checksum, nil
+}
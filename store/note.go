@@ -9,94 +9,263 @@ import (
 	"strings"
 	"time"
 
+	"github.com/wdmsyhh/simple-notes/internal/markdown"
 	"github.com/wdmsyhh/simple-notes/proto/gen/store"
 )
 
+// noteColumns 笔记表的查询字段，顺序与 scanNote 保持一致
+// 使用显式字段列表而不是 SELECT *，避免迁移新增字段后扫描错位
+var noteColumns = []string{
+	"id", "created_at", "updated_at", "deleted_at", "title", "content", "summary",
+	"category_id", "published", "author_id", "published_at", "cover_image",
+	"reading_time", "view_count", "visibility", "word_count", "char_count",
+	"scheduled", "expire_at",
+}
+
+// noteSelectColumns 返回笔记查询字段列表，alias 为表别名（可为空）
+func noteSelectColumns(alias string) string {
+	if alias == "" {
+		return strings.Join(noteColumns, ", ")
+	}
+	columns := make([]string, len(noteColumns))
+	for i, column := range noteColumns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
+
 // ListNotes 获取笔记列表，支持分页和过滤
 func (s *Store) ListNotes(ctx context.Context, req *ListNotesRequest) ([]*store.Note, int64, error) {
-	// 构建基础查询
-	query := `SELECT DISTINCT p.* FROM notes p`
-	countQuery := `SELECT COUNT(DISTINCT p.id) FROM notes p`
-	params := []interface{}{}
+	page, err := s.ListNotesPage(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	return page.Notes, page.Total, nil
+}
 
-	// 构建WHERE条件
-	whereConditions := []string{}
+// NotePage 一页笔记
+type NotePage struct {
+	// Notes 笔记列表
+	Notes []*store.Note
+	// Total 符合过滤条件的笔记总数
+	Total int64
+	// NextPageToken 下一页的游标，没有更多笔记时为空
+	NextPageToken string
+}
 
-	if req.CategoryID != "" {
-		whereConditions = append(whereConditions, "p.category_id = ?")
-		params = append(params, req.CategoryID)
+// ListNotesPage 获取一页笔记
+// 指定 PageToken 时从游标之后继续读取（keyset 分页），否则按 Page 计算偏移量；两种方式都以笔记ID作为排序的最后一个字段，保证顺序稳定
+func (s *Store) ListNotesPage(ctx context.Context, req *ListNotesRequest) (*NotePage, error) {
+	if req.PageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive: %d", req.PageSize)
 	}
 
-	if req.TagID != "" {
-		query += ` JOIN note_tags pt ON p.id = pt.note_id`
-		countQuery += ` JOIN note_tags pt ON p.id = pt.note_id`
-		whereConditions = append(whereConditions, "pt.tag_id = ?")
-		params = append(params, req.TagID)
+	sortKeys, err := parseNoteSort(req.SortBy, req.SortDesc)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.Search != "" {
-		whereConditions = append(whereConditions, "p.title LIKE ?")
-		params = append(params, "%"+req.Search+"%")
+	// 构建基础查询，同时读取各排序字段的值用于生成下一页的游标
+	cursorColumns := make([]string, len(sortKeys))
+	for i, key := range sortKeys {
+		cursorColumns[i] = key.cursorExpr(s.profile.Driver)
 	}
-
-	if !req.IncludeUnpublished {
-		whereConditions = append(whereConditions, "p.published = 1")
+	join, whereConditions, params, err := s.noteListConditions(ctx, req)
+	if err != nil {
+		return nil, err
 	}
+	query := `SELECT DISTINCT ` + noteSelectColumns("p") + `, ` + strings.Join(cursorColumns, ", ") + ` FROM notes p` + join
+	countQuery := `SELECT COUNT(DISTINCT p.id) FROM notes p` + join
 
 	// 为查询添加WHERE子句
 	if len(whereConditions) > 0 {
-		query += " WHERE " + strings.Join(whereConditions, " AND ")
 		countQuery += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	// 计算总数
+	// 计算总数（不受游标影响）
 	var total int64
-	if err := s.db.QueryRowContext(ctx, countQuery, params...).Scan(&total); err != nil {
-		return nil, 0, err
+	if err := s.db.QueryRowContext(ctx, s.rebind(countQuery), params...).Scan(&total); err != nil {
+		return nil, err
 	}
 
-	// 应用排序
-	sortBy := req.SortBy
-	if sortBy == "" {
-		sortBy = "p.published_at"
-	} else {
-		sortBy = "p." + sortBy
+	// 从游标之后继续读取
+	if req.PageToken != "" {
+		cursorValues, err := decodeNoteCursor(req.PageToken, sortKeys)
+		if err != nil {
+			return nil, err
+		}
+		condition, cursorParams := noteKeysetCondition(sortKeys, cursorValues)
+		whereConditions = append(whereConditions, condition)
+		params = append(params, cursorParams...)
 	}
 
-	sortOrder := "DESC"
-	if !req.SortDesc {
-		sortOrder = "ASC"
+	if len(whereConditions) > 0 {
+		query += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	query += fmt.Sprintf(" ORDER BY %s %s", sortBy, sortOrder)
+	// 应用排序，笔记ID作为最后的排序字段
+	query += " ORDER BY " + noteOrderBy(sortKeys)
 
-	// 应用分页
-	offset := (req.Page - 1) * req.PageSize
-	query += " LIMIT ? OFFSET ?"
-	params = append(params, req.PageSize, offset)
+	// 应用分页，多读取一条用于判断是否还有下一页
+	query += " LIMIT ?"
+	params = append(params, req.PageSize+1)
+	if req.PageToken == "" && req.Page > 1 {
+		query += " OFFSET ?"
+		params = append(params, (req.Page-1)*req.PageSize)
+	}
 
 	// 执行查询
-	rows, err := s.db.QueryContext(ctx, query, params...)
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), params...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
 	// 扫描笔记数据
 	var notes []*store.Note
+	var sortValues [][]any
 	for rows.Next() {
-		note, err := scanNote(rows)
-		if err != nil {
-			return nil, 0, err
+		var row noteRow
+		values := make([]any, len(sortKeys))
+		dest := row.scanDest()
+		for i := range values {
+			dest = append(dest, &values[i])
 		}
-		notes = append(notes, note)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		notes = append(notes, row.toNote())
+		sortValues = append(sortValues, values)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	rows.Close()
+
+	page := &NotePage{Total: total}
+	if len(notes) > int(req.PageSize) {
+		notes = notes[:req.PageSize]
+		page.NextPageToken, err = encodeNoteCursor(sortKeys, sortValues[len(notes)-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.loadNoteTagIDs(ctx, s.db, notes); err != nil {
+		return nil, err
 	}
+	page.Notes = notes
 
-	return notes, total, nil
+	return page, nil
+}
+
+// publishedNoteCondition 返回已发布且尚未过期的笔记的条件（已过期但定时任务尚未取消发布的笔记不算），prefix 为列名前缀（例如 "p."）
+// 布尔值和时间都使用参数绑定，PostgreSQL 的 BOOLEAN 列不能与整数比较
+func (s *Store) publishedNoteCondition(prefix string) (string, []interface{}) {
+	condition := "(" + prefix + "published = ? AND (" + prefix + "expire_at IS NULL OR " + prefix + "expire_at > ?))"
+	return condition, []interface{}{true, timeParam(s.profile.Driver, time.Now())}
+}
+
+// noteListConditions 根据列表请求构建查询笔记（别名 p）需要的 JOIN 和 WHERE 条件，不包含分页和排序
+func (s *Store) noteListConditions(ctx context.Context, req *ListNotesRequest) (string, []string, []interface{}, error) {
+	// 构建WHERE条件
+	join := ""
+	whereConditions := []string{}
+	params := []interface{}{}
+
+	if condition, workspaceParams := workspaceCondition(ctx, "p.workspace_id"); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		params = append(params, workspaceParams...)
+	}
+
+	if req.CategoryID != "" {
+		categoryID, err := strconv.ParseInt(req.CategoryID, 10, 64)
+		if req.IncludeDescendants && err == nil {
+			condition, categoryParams, err := s.categoryIDsCondition(ctx, "p.category_id", categoryID)
+			if err != nil {
+				return "", nil, nil, err
+			}
+			whereConditions = append(whereConditions, condition)
+			params = append(params, categoryParams...)
+		} else {
+			whereConditions = append(whereConditions, "p.category_id = ?")
+			params = append(params, req.CategoryID)
+		}
+	}
+
+	if req.TagID != "" {
+		join = ` JOIN note_tags pt ON p.id = pt.note_id`
+		whereConditions = append(whereConditions, "pt.tag_id = ?")
+		params = append(params, req.TagID)
+	}
+
+	if req.Search != "" {
+		whereConditions = append(whereConditions, "p.title LIKE ?")
+		params = append(params, "%"+req.Search+"%")
+	}
+
+	if !req.IncludeUnpublished {
+		// 已过期但定时任务尚未取消发布的笔记同样不显示
+		published, publishedParams := s.publishedNoteCondition("p.")
+		params = append(params, publishedParams...)
+		if req.UnpublishedAuthorID != "" {
+			// 同时包含该用户被授予了权限的未发布笔记
+			authorID, _ := strconv.ParseInt(req.UnpublishedAuthorID, 10, 64)
+			permitted, permittedParams, err := s.notePermissionCondition(ctx, authorID)
+			if err != nil {
+				return "", nil, nil, err
+			}
+			condition := "(" + published + " OR p.author_id = ?"
+			params = append(params, req.UnpublishedAuthorID)
+			if permitted != "" {
+				condition += " OR " + permitted
+				params = append(params, permittedParams...)
+			}
+			whereConditions = append(whereConditions, condition+")")
+		} else {
+			whereConditions = append(whereConditions, published)
+		}
+	}
+
+	if req.Visibility != "" {
+		whereConditions = append(whereConditions, "p.visibility = ?")
+		params = append(params, req.Visibility)
+	}
+
+	if req.VisibleToUserID != "" {
+		// 被授予了权限（直接授予或从分类继承）的私有笔记同样可见
+		userID, _ := strconv.ParseInt(req.VisibleToUserID, 10, 64)
+		permitted, permittedParams, err := s.notePermissionCondition(ctx, userID)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		condition := "(p.visibility = 'PUBLIC' OR p.author_id = ?"
+		params = append(params, req.VisibleToUserID)
+		if permitted != "" {
+			condition += " OR " + permitted
+			params = append(params, permittedParams...)
+		}
+		whereConditions = append(whereConditions, condition+")")
+	}
+
+	if req.AuthorID != "" {
+		whereConditions = append(whereConditions, "p.author_id = ?")
+		params = append(params, req.AuthorID)
+	}
+
+	if req.Filter != "" {
+		condition, filterParams, err := s.compileNoteFilter(req.Filter, req.ViewerID)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if condition != "" {
+			whereConditions = append(whereConditions, condition)
+			params = append(params, filterParams...)
+		}
+	}
+
+	return join, whereConditions, params, nil
 }
 
 // noteRow 用于扫描数据库行的临时结构体
@@ -117,8 +286,6 @@ type noteRow struct {
 	summary sql.NullString
 	// categoryID 分类ID
 	categoryID uint
-	// tagIDs 标签ID列表（逗号分隔）
-	tagIDs string
 	// published 是否已发布
 	published bool
 	// authorID 作者ID
@@ -133,6 +300,39 @@ type noteRow struct {
 	viewCount int
 	// visibility 可见性
 	visibility string
+	// wordCount 字数
+	wordCount int
+	// charCount 字符数
+	charCount int
+	// scheduled 是否等待定时发布
+	scheduled sql.NullBool
+	// expireAt 过期时间
+	expireAt sql.NullTime
+}
+
+// scanDest 返回扫描笔记字段的目标，顺序与 noteColumns 一致
+func (row *noteRow) scanDest() []any {
+	return []any{
+		&row.id,
+		&row.createdAt,
+		&row.updatedAt,
+		&row.deletedAt,
+		&row.title,
+		&row.content,
+		&row.summary,
+		&row.categoryID,
+		&row.published,
+		&row.authorID,
+		&row.publishedAt,
+		&row.coverImage,
+		&row.readingTime,
+		&row.viewCount,
+		&row.visibility,
+		&row.wordCount,
+		&row.charCount,
+		&row.scheduled,
+		&row.expireAt,
+	}
 }
 
 // scanNote 将数据库行扫描到store.Note
@@ -141,57 +341,22 @@ func scanNote(rows interface{}) (*store.Note, error) {
 
 	switch v := rows.(type) {
 	case *sql.Row:
-		if err := v.Scan(
-			&row.id,
-			&row.createdAt,
-			&row.updatedAt,
-			&row.deletedAt,
-			&row.title,
-			&row.content,
-			&row.summary,
-			&row.categoryID,
-			&row.tagIDs,
-			&row.published,
-			&row.authorID,
-			&row.publishedAt,
-			&row.coverImage,
-			&row.readingTime,
-			&row.viewCount,
-			&row.visibility,
-		); err != nil {
+		if err := v.Scan(row.scanDest()...); err != nil {
 			return nil, err
 		}
 	case *sql.Rows:
-		if err := v.Scan(
-			&row.id,
-			&row.createdAt,
-			&row.updatedAt,
-			&row.deletedAt,
-			&row.title,
-			&row.content,
-			&row.summary,
-			&row.categoryID,
-			&row.tagIDs,
-			&row.published,
-			&row.authorID,
-			&row.publishedAt,
-			&row.coverImage,
-			&row.readingTime,
-			&row.viewCount,
-			&row.visibility,
-		); err != nil {
+		if err := v.Scan(row.scanDest()...); err != nil {
 			return nil, err
 		}
 	default:
 		return nil, fmt.Errorf("unsupported rows type: %T", rows)
 	}
 
-	// 从逗号分隔的字符串解析标签ID
-	tagIDs := []string{}
-	if row.tagIDs != "" {
-		tagIDs = strings.Split(row.tagIDs, ",")
-	}
+	return row.toNote(), nil
+}
 
+// toNote 将扫描到的数据库行转换为store.Note
+func (row *noteRow) toNote() *store.Note {
 	// 将数据库中的可见性转换为protobuf枚举
 	visibility := store.NoteVisibility_NOTE_VISIBILITY_PUBLIC
 	if row.visibility == "PRIVATE" {
@@ -219,7 +384,7 @@ func scanNote(rows interface{}) (*store.Note, error) {
 		Content:     content,
 		Summary:     summary,
 		CategoryId:  fmt.Sprintf("%d", row.categoryID),
-		TagIds:      tagIDs,
+		TagIds:      []string{}, // 标签ID由 loadNoteTagIDs 从 note_tags 加载
 		Published:   row.published,
 		AuthorId:    fmt.Sprintf("%d", row.authorID),
 		CreatedAt:   row.createdAt.Unix(),
@@ -229,16 +394,22 @@ func scanNote(rows interface{}) (*store.Note, error) {
 		ReadingTime: int32(row.readingTime),
 		ViewCount:   int32(row.viewCount),
 		Visibility:  visibility,
+		WordCount:   int32(row.wordCount),
+		CharCount:   int32(row.charCount),
+		Scheduled:   row.scheduled.Bool,
+	}
+	if row.expireAt.Valid {
+		note.ExpireAt = row.expireAt.Time.Unix()
 	}
 
-	return note, nil
+	return note
 }
 
 // GetNote 根据ID获取笔记
 func (s *Store) GetNote(ctx context.Context, id int64) (*store.Note, error) {
 	// 查询笔记
-	query := `SELECT * FROM notes WHERE id = ?`
-	row := s.db.QueryRowContext(ctx, query, id)
+	query, params := andWorkspace(ctx, `SELECT `+noteSelectColumns("")+` FROM notes WHERE id = ?`, "workspace_id", id)
+	row := s.db.QueryRowContext(ctx, query, params...)
 
 	note, err := scanNote(row)
 	if err != nil {
@@ -248,6 +419,10 @@ func (s *Store) GetNote(ctx context.Context, id int64) (*store.Note, error) {
 		return nil, err
 	}
 
+	if err := s.loadNoteTagIDs(ctx, s.db, []*store.Note{note}); err != nil {
+		return nil, err
+	}
+
 	return note, nil
 }
 
@@ -271,31 +446,38 @@ func (s *Store) CreateNote(ctx context.Context, note *store.Note) (*store.Note,
 		authorID = parseUint(note.AuthorId)
 	}
 
-	// 将标签ID转换为逗号分隔的字符串
-	tagIDs := ""
-	if len(note.TagIds) > 0 {
-		tagIDs = strings.Join(note.TagIds, ",")
-	}
-
 	// 将protobuf枚举转换为数据库中的可见性
 	visibility := "PUBLIC"
 	if note.Visibility == store.NoteVisibility_NOTE_VISIBILITY_PRIVATE {
 		visibility = "PRIVATE"
 	}
 
+	// 根据内容计算字数和阅读时间，未填写摘要时自动生成
+	populateNoteStats(note)
+
 	now := time.Now()
 	publishedAt := now
 	if note.PublishedAt > 0 {
 		publishedAt = time.Unix(note.PublishedAt, 0)
 	}
+	published, scheduled := notePublishState(note, publishedAt, now)
+
+	// 笔记属于上下文所选的工作区，分类必须属于同一工作区
+	workspaceID, err := s.writeWorkspaceID(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCategoryWorkspace(ctx, tx, categoryID, workspaceID); err != nil {
+		return nil, err
+	}
 
 	// 插入笔记
 	query := `
 		INSERT INTO notes (
-			title, content, summary, category_id, tag_ids, published, 
+			title, content, summary, category_id, published, 
 			author_id, published_at, cover_image, reading_time, view_count, visibility,
-			created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			word_count, char_count, scheduled, expire_at, created_at, updated_at, workspace_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := tx.ExecContext(ctx, query,
@@ -303,16 +485,20 @@ func (s *Store) CreateNote(ctx context.Context, note *store.Note) (*store.Note,
 		note.Content,
 		note.Summary,
 		categoryID,
-		tagIDs,
-		note.Published,
+		published,
 		authorID,
 		publishedAt,
 		note.CoverImage,
 		note.ReadingTime,
 		note.ViewCount,
 		visibility,
+		note.WordCount,
+		note.CharCount,
+		scheduled,
+		noteExpireAt(note),
 		now,
 		now,
+		workspaceID,
 	)
 	if err != nil {
 		return nil, err
@@ -324,32 +510,17 @@ func (s *Store) CreateNote(ctx context.Context, note *store.Note) (*store.Note,
 		return nil, err
 	}
 
-	// 处理标签
-	if len(note.TagIds) > 0 {
-		for _, tagIDStr := range note.TagIds {
-			tagID, err := strconv.ParseUint(tagIDStr, 10, 32)
-			if err != nil {
-				return nil, err
-			}
-
-			// 将标签添加到笔记（插入到note_tags）
-			_, err = tx.ExecContext(ctx,
-				"INSERT INTO note_tags (note_id, tag_id) VALUES (?, ?)",
-				id, tagID,
-			)
-			if err != nil {
-				return nil, err
-			}
+	// 写入笔记标签并更新标签计数
+	if err := s.setNoteTags(ctx, tx, id, note.TagIds); err != nil {
+		return nil, err
+	}
 
-			// 增加标签计数
-			_, err = tx.ExecContext(ctx,
-				"UPDATE tags SET count = count + 1 WHERE id = ?",
-				tagID,
-			)
-			if err != nil {
-				return nil, err
-			}
-		}
+	// 解析笔记内容中的链接，并将指向该标题的未解析链接解析到新笔记
+	if err := s.setNoteLinks(ctx, tx, id, note.Content); err != nil {
+		return nil, err
+	}
+	if err := s.resolveNoteLinks(ctx, tx, id, note.Title); err != nil {
+		return nil, err
 	}
 
 	// 提交事务
@@ -362,6 +533,7 @@ func (s *Store) CreateNote(ctx context.Context, note *store.Note) (*store.Note,
 }
 
 // UpdateNote 更新现有笔记
+// 浏览次数由浏览统计单独维护（见 IncrementNoteViews），这里不会覆盖
 func (s *Store) UpdateNote(ctx context.Context, note *store.Note) (*store.Note, error) {
 	// 开始事务
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -371,64 +543,50 @@ func (s *Store) UpdateNote(ctx context.Context, note *store.Note) (*store.Note,
 	defer tx.Rollback()
 
 	// 检查笔记是否存在
-	_, err = scanNote(tx.QueryRowContext(ctx, `SELECT * FROM notes WHERE id = ?`, note.Id))
-	if err != nil {
+	var workspaceID int64
+	query, params := andWorkspace(ctx, `SELECT workspace_id FROM notes WHERE id = ?`, "workspace_id", note.Id)
+	if err := tx.QueryRowContext(ctx, query, params...).Scan(&workspaceID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("note not found: %d", note.Id)
 		}
 		return nil, err
 	}
 
-	// 加载现有标签
-	var existingTagIDs []string
-	tagQuery := `SELECT t.id FROM tags t JOIN note_tags pt ON t.id = pt.tag_id WHERE pt.note_id = ?`
-	tagRows, err := tx.QueryContext(ctx, tagQuery, note.Id)
-	if err != nil {
-		return nil, err
-	}
-	defer tagRows.Close()
-
-	for tagRows.Next() {
-		var tagID uint
-		if err := tagRows.Scan(&tagID); err != nil {
-			return nil, err
-		}
-		existingTagIDs = append(existingTagIDs, fmt.Sprintf("%d", tagID))
-	}
-
-	// 解析分类ID和作者ID
+	// 解析分类ID和作者ID，分类必须与笔记属于同一工作区
 	categoryID := uint(0)
 	if note.CategoryId != "" {
 		categoryID = parseUint(note.CategoryId)
 	}
+	if err := s.checkCategoryWorkspace(ctx, tx, categoryID, workspaceID); err != nil {
+		return nil, err
+	}
 
 	authorID := uint(0)
 	if note.AuthorId != "" {
 		authorID = parseUint(note.AuthorId)
 	}
 
-	// 将标签ID转换为逗号分隔的字符串
-	tagIDs := ""
-	if len(note.TagIds) > 0 {
-		tagIDs = strings.Join(note.TagIds, ",")
-	}
-
 	// 将protobuf枚举转换为数据库中的可见性
 	visibility := "PUBLIC"
 	if note.Visibility == store.NoteVisibility_NOTE_VISIBILITY_PRIVATE {
 		visibility = "PRIVATE"
 	}
 
-	publishedAt := time.Now()
+	now := time.Now()
+	publishedAt := now
 	if note.PublishedAt > 0 {
 		publishedAt = time.Unix(note.PublishedAt, 0)
 	}
+	published, scheduled := notePublishState(note, publishedAt, now)
+
+	// 根据内容重新计算字数和阅读时间，未填写摘要时自动生成
+	populateNoteStats(note)
 
 	updateQuery := `
 		UPDATE notes SET 
-			title = ?, content = ?, summary = ?, category_id = ?, tag_ids = ?, 
+			title = ?, content = ?, summary = ?, category_id = ?, 
 			published = ?, author_id = ?, published_at = ?, cover_image = ?, reading_time = ?, 
-			view_count = ?, visibility = ?, updated_at = ?
+			visibility = ?, word_count = ?, char_count = ?, scheduled = ?, expire_at = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -437,109 +595,35 @@ func (s *Store) UpdateNote(ctx context.Context, note *store.Note) (*store.Note,
 		note.Content,
 		note.Summary,
 		categoryID,
-		tagIDs,
-		note.Published,
+		published,
 		authorID,
 		publishedAt,
 		note.CoverImage,
 		note.ReadingTime,
-		note.ViewCount,
 		visibility,
-		time.Now(),
+		note.WordCount,
+		note.CharCount,
+		scheduled,
+		noteExpireAt(note),
+		now,
 		note.Id,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// 处理标签（如果提供）
-	if len(note.TagIds) > 0 {
-		// 新标签ID
-		newTagIDs := note.TagIds
-
-		// 找出需要添加和删除的标签
-		tagsToAdd := make([]string, 0)
-		tagsToRemove := make([]string, 0)
-
-		// 需要添加的标签
-		for _, newID := range newTagIDs {
-			found := false
-			for _, existingID := range existingTagIDs {
-				if newID == existingID {
-					found = true
-					break
-				}
-			}
-			if !found {
-				tagsToAdd = append(tagsToAdd, newID)
-			}
-		}
-
-		// 需要删除的标签
-		for _, existingID := range existingTagIDs {
-			found := false
-			for _, newID := range newTagIDs {
-				if existingID == newID {
-					found = true
-					break
-				}
-			}
-			if !found {
-				tagsToRemove = append(tagsToRemove, existingID)
-			}
-		}
-
-		// 删除标签
-		for _, tagID := range tagsToRemove {
-			tagIDUint, err := strconv.ParseUint(tagID, 10, 32)
-			if err != nil {
-				return nil, err
-			}
-
-			// 从note_tags中删除标签
-			_, err = tx.ExecContext(ctx,
-				"DELETE FROM note_tags WHERE note_id = ? AND tag_id = ?",
-				note.Id, tagIDUint,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			// 减少标签计数
-			_, err = tx.ExecContext(ctx,
-				"UPDATE tags SET count = GREATEST(count - 1, 0) WHERE id = ?",
-				tagIDUint,
-			)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		// 添加标签
-		for _, tagID := range tagsToAdd {
-			tagIDUint, err := strconv.ParseUint(tagID, 10, 32)
-			if err != nil {
-				return nil, err
-			}
-
-			// 将标签添加到note_tags
-			_, err = tx.ExecContext(ctx,
-				"INSERT INTO note_tags (note_id, tag_id) VALUES (?, ?)",
-				note.Id, tagIDUint,
-			)
-			if err != nil {
-				return nil, err
-			}
+	// 同步笔记标签（TagIds 为完整的标签列表，为空时移除全部标签）并更新标签计数
+	if err := s.setNoteTags(ctx, tx, note.Id, note.TagIds); err != nil {
+		return nil, err
+	}
 
-			// 增加标签计数
-			_, err = tx.ExecContext(ctx,
-				"UPDATE tags SET count = count + 1 WHERE id = ?",
-				tagIDUint,
-			)
-			if err != nil {
-				return nil, err
-			}
-		}
+	// 重新解析笔记内容中的链接；指向其他笔记的链接按ID记录，笔记改名后仍然有效，
+	// 同时将指向新标题的未解析链接解析到该笔记
+	if err := s.setNoteLinks(ctx, tx, note.Id, note.Content); err != nil {
+		return nil, err
+	}
+	if err := s.resolveNoteLinks(ctx, tx, note.Id, note.Title); err != nil {
+		return nil, err
 	}
 
 	// 提交事务
@@ -560,40 +644,48 @@ func (s *Store) DeleteNote(ctx context.Context, id int64) error {
 	}
 	defer tx.Rollback()
 
-	// 获取笔记标签ID
-	tagQuery := `SELECT tag_id FROM note_tags WHERE note_id = ?`
-	tagRows, err := tx.QueryContext(ctx, tagQuery, id)
-	if err != nil {
+	// 检查笔记属于上下文所选的工作区
+	var count int
+	query, params := andWorkspace(ctx, "SELECT COUNT(*) FROM notes WHERE id = ?", "workspace_id", id)
+	if err := tx.QueryRowContext(ctx, query, params...).Scan(&count); err != nil {
 		return err
 	}
-	defer tagRows.Close()
+	if count == 0 {
+		return fmt.Errorf("note not found: %d", id)
+	}
 
-	var tagIDs []uint
-	for tagRows.Next() {
-		var tagID uint
-		if err := tagRows.Scan(&tagID); err != nil {
-			return err
-		}
-		tagIDs = append(tagIDs, tagID)
+	// 移除笔记标签并更新标签计数
+	if err := s.setNoteTags(ctx, tx, id, nil); err != nil {
+		return err
 	}
 
-	if err := tagRows.Err(); err != nil {
+	// 删除每日浏览量统计
+	_, err = tx.ExecContext(ctx, s.rebind("DELETE FROM note_views WHERE note_id = ?"), id)
+	if err != nil {
 		return err
 	}
 
-	// 减少标签计数
-	for _, tagID := range tagIDs {
-		_, err = tx.ExecContext(ctx,
-			"UPDATE tags SET count = GREATEST(count - 1, 0) WHERE id = ?",
-			tagID,
-		)
-		if err != nil {
-			return err
-		}
+	// 删除笔记中的链接，指向该笔记的链接变为未解析
+	if err := s.deleteNoteLinks(ctx, tx, id); err != nil {
+		return err
 	}
 
-	// 删除note_tags条目
-	_, err = tx.ExecContext(ctx, "DELETE FROM note_tags WHERE note_id = ?", id)
+	// 删除草稿和修订记录
+	_, err = tx.ExecContext(ctx, "DELETE FROM note_drafts WHERE note_id = ?", id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM note_revisions WHERE note_id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	// 删除分享链接和协作者权限
+	_, err = tx.ExecContext(ctx, "DELETE FROM note_share_links WHERE note_id = ?", id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM note_permissions WHERE note_id = ?", id)
 	if err != nil {
 		return err
 	}
@@ -618,16 +710,44 @@ type ListNotesRequest struct {
 	PageSize int32
 	// CategoryID - 分类ID
 	CategoryID string
+	// IncludeDescendants - 按分类过滤时是否包含子分类下的笔记
+	IncludeDescendants bool
 	// TagID - 标签ID
 	TagID string
 	// Search - 搜索关键词
 	Search string
-	// SortBy - 排序字段
+	// SortBy - 排序字段，可以是逗号分隔的多个字段，每个字段后可以跟 asc 或 desc
 	SortBy string
-	// SortDesc - 是否降序排序
+	// SortDesc - 未指定方向的排序字段是否降序排序
 	SortDesc bool
+	// PageToken - 上一页返回的游标，指定时忽略 Page
+	PageToken string
 	// IncludeUnpublished - 是否包含未发布的笔记
 	IncludeUnpublished bool
+	// UnpublishedAuthorID - 不包含未发布的笔记时，仍然包含该用户自己的以及被授予了权限的未发布笔记
+	UnpublishedAuthorID string
+	// Visibility - 可见性过滤（PUBLIC/PRIVATE），为空时不过滤
+	Visibility string
+	// AuthorID - 作者ID过滤，为空时不过滤
+	AuthorID string
+	// Filter - 过滤表达式，语法见 internal/filter 包
+	Filter string
+	// ViewerID - 当前用户ID，过滤表达式中的 author == "me" 表示该用户
+	ViewerID int64
+	// VisibleToUserID - 访问者的用户ID，不为空时只返回公开笔记、该用户自己的笔记和被授予了权限的笔记
+	VisibleToUserID string
+}
+
+// populateNoteStats 根据笔记内容计算字数、字符数和阅读时间，并在摘要为空时自动生成摘要
+func populateNoteStats(note *store.Note) {
+	stats := markdown.ComputeStats(note.Content)
+	note.WordCount = int32(stats.WordCount)
+	note.CharCount = int32(stats.CharCount)
+	note.ReadingTime = int32(stats.ReadingTime)
+
+	if strings.TrimSpace(note.Summary) == "" {
+		note.Summary = markdown.Summarize(note.Content, markdown.DefaultSummaryLength)
+	}
 }
 
 // parseUint 将字符串转换为uint
@@ -0,0 +1,234 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSortField 不支持的排序字段
+var ErrInvalidSortField = errors.New("invalid sort field")
+
+// ErrInvalidPageToken 无效的分页游标，或游标与当前的排序方式不一致
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// noteSortField 笔记列表可以使用的排序字段
+type noteSortField struct {
+	// name 排序字段名称
+	name string
+	// expr 排序使用的SQL表达式
+	expr string
+	// timestamp 是否为时间字段
+	timestamp bool
+}
+
+// noteSortFields 笔记列表允许的排序字段，键为请求中的字段名称
+var noteSortFields = map[string]noteSortField{
+	"published_at": {name: "published_at", expr: "p.published_at", timestamp: true},
+	"created_at":   {name: "created_at", expr: "p.created_at", timestamp: true},
+	"updated_at":   {name: "updated_at", expr: "p.updated_at", timestamp: true},
+	"title":        {name: "title", expr: "p.title"},
+	"view_count":   {name: "view_count", expr: "p.view_count"},
+	"word_count":   {name: "word_count", expr: "p.word_count"},
+	"reading_time": {name: "reading_time", expr: "p.reading_time"},
+	"id":           {name: "id", expr: "p.id"},
+}
+
+// cursorExpr 返回读取游标排序值的SQL表达式
+// SQLite 中时间以文本保存，驱动读取时会解析为时间，再作为参数写回时格式与保存的文本不一致，因此直接读取原始文本
+func (f noteSortField) cursorExpr(driver string) string {
+	if f.timestamp && driver == "sqlite" {
+		return "CAST(" + f.expr + " AS TEXT)"
+	}
+	return f.expr
+}
+
+// noteSortKey 排序字段及方向
+type noteSortKey struct {
+	noteSortField
+	// desc 是否降序
+	desc bool
+}
+
+// parseNoteSort 解析排序参数，格式为逗号分隔的字段列表，每个字段后可以跟 asc 或 desc，
+// 例如 "published_at desc, title"；未指定方向的字段使用 defaultDesc。为空时按发布时间排序
+// 笔记ID总是作为最后的排序字段（方向与第一个字段相同），保证顺序稳定
+func parseNoteSort(sortBy string, defaultDesc bool) ([]noteSortKey, error) {
+	if strings.TrimSpace(sortBy) == "" {
+		sortBy = "published_at"
+	}
+
+	var keys []noteSortKey
+	seen := map[string]bool{}
+	for _, part := range strings.Split(sortBy, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSortField, strings.TrimSpace(part))
+		}
+		field, ok := noteSortFields[fields[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSortField, fields[0])
+		}
+		if seen[field.name] {
+			return nil, fmt.Errorf("%w: duplicate field %s", ErrInvalidSortField, field.name)
+		}
+		seen[field.name] = true
+
+		desc := defaultDesc
+		if len(fields) == 2 {
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+				desc = false
+			case "desc":
+				desc = true
+			default:
+				return nil, fmt.Errorf("%w: invalid direction %q", ErrInvalidSortField, fields[1])
+			}
+		}
+		keys = append(keys, noteSortKey{noteSortField: field, desc: desc})
+	}
+
+	if !seen["id"] {
+		keys = append(keys, noteSortKey{noteSortField: noteSortFields["id"], desc: keys[0].desc})
+	}
+	return keys, nil
+}
+
+// noteSortSpec 返回排序的规范化描述，记录在游标中用于检查翻页时排序方式没有改变
+func noteSortSpec(keys []noteSortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		direction := "asc"
+		if key.desc {
+			direction = "desc"
+		}
+		parts[i] = key.name + " " + direction
+	}
+	return strings.Join(parts, ",")
+}
+
+// noteOrderBy 返回 ORDER BY 子句的内容
+func noteOrderBy(keys []noteSortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+		parts[i] = key.expr + " " + direction
+	}
+	return strings.Join(parts, ", ")
+}
+
+// noteKeysetCondition 返回读取游标之后的笔记的条件，例如两个排序字段时为
+// (a < ? OR (a = ? AND b > ?))
+func noteKeysetCondition(keys []noteSortKey, values []any) (string, []interface{}) {
+	var disjuncts []string
+	var params []interface{}
+	for i, key := range keys {
+		var conjuncts []string
+		for j := 0; j < i; j++ {
+			conjuncts = append(conjuncts, keys[j].expr+" = ?")
+			params = append(params, values[j])
+		}
+		operator := ">"
+		if key.desc {
+			operator = "<"
+		}
+		conjuncts = append(conjuncts, key.expr+" "+operator+" ?")
+		params = append(params, values[i])
+		disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")", params
+}
+
+// noteCursor 分页游标，记录上一页最后一篇笔记的各排序字段的值
+type noteCursor struct {
+	// Sort 排序的规范化描述
+	Sort string `json:"s"`
+	// Values 各排序字段的值
+	Values []cursorValue `json:"v"`
+}
+
+// cursorValue 游标中的排序值
+type cursorValue struct {
+	// Kind 排序值的类型：s 字符串，t 时间，i 整数，f 浮点数
+	Kind string `json:"k"`
+	// Value 排序值
+	Value string `json:"v"`
+}
+
+// encodeNoteCursor 将上一页最后一篇笔记的排序值编码为不透明的游标
+// 排序值保持数据库返回的原始精度，避免时间被截断到秒后跳过或重复同一秒内的笔记
+func encodeNoteCursor(keys []noteSortKey, values []any) (string, error) {
+	cursor := noteCursor{Sort: noteSortSpec(keys)}
+	for _, value := range values {
+		var v cursorValue
+		switch value := value.(type) {
+		case time.Time:
+			v = cursorValue{Kind: "t", Value: value.Format(time.RFC3339Nano)}
+		case []byte:
+			v = cursorValue{Kind: "s", Value: string(value)}
+		case string:
+			v = cursorValue{Kind: "s", Value: value}
+		case int64:
+			v = cursorValue{Kind: "i", Value: strconv.FormatInt(value, 10)}
+		case float64:
+			v = cursorValue{Kind: "f", Value: strconv.FormatFloat(value, 'g', -1, 64)}
+		default:
+			return "", fmt.Errorf("unsupported sort value type: %T", value)
+		}
+		cursor.Values = append(cursor.Values, v)
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeNoteCursor 解析分页游标，检查游标与当前的排序方式一致，返回各排序字段的值
+func decodeNoteCursor(token string, keys []noteSortKey) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var cursor noteCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if cursor.Sort != noteSortSpec(keys) || len(cursor.Values) != len(keys) {
+		return nil, fmt.Errorf("%w: sort order does not match", ErrInvalidPageToken)
+	}
+
+	values := make([]any, len(cursor.Values))
+	for i, v := range cursor.Values {
+		value, err := v.parse()
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// parse 将游标中的排序值转换为查询参数
+func (v cursorValue) parse() (any, error) {
+	switch v.Kind {
+	case "t":
+		return time.Parse(time.RFC3339Nano, v.Value)
+	case "s":
+		return v.Value, nil
+	case "i":
+		return strconv.ParseInt(v.Value, 10, 64)
+	case "f":
+		return strconv.ParseFloat(v.Value, 64)
+	default:
+		return nil, fmt.Errorf("unknown sort value kind: %s", v.Kind)
+	}
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNoteKeysetCondition(t *testing.T) {
+	keys, err := parseNoteSort("published_at desc, title asc", false)
+	if err != nil {
+		t.Fatalf("parseNoteSort: %v", err)
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	condition, params := noteKeysetCondition(keys, []any{at, "b", int64(7)})
+
+	want := "((p.published_at < ?) OR (p.published_at = ? AND p.title > ?) OR (p.published_at = ? AND p.title = ? AND p.id < ?))"
+	if condition != want {
+		t.Errorf("condition = %s, want %s", condition, want)
+	}
+	if wantParams := []interface{}{at, at, "b", at, "b", int64(7)}; !reflect.DeepEqual(params, wantParams) {
+		t.Errorf("params = %v, want %v", params, wantParams)
+	}
+}
+
+func TestNoteCursorRoundTrip(t *testing.T) {
+	keys, err := parseNoteSort("created_at, title, view_count, word_count", true)
+	if err != nil {
+		t.Fatalf("parseNoteSort: %v", err)
+	}
+	// 时间保留纳秒精度，同一秒内的笔记不会被跳过
+	at := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("CST", 8*3600))
+	token, err := encodeNoteCursor(keys, []any{at, []byte("title"), int64(42), 1.5, int64(9)})
+	if err != nil {
+		t.Fatalf("encodeNoteCursor: %v", err)
+	}
+
+	values, err := decodeNoteCursor(token, keys)
+	if err != nil {
+		t.Fatalf("decodeNoteCursor: %v", err)
+	}
+	if len(values) != 5 {
+		t.Fatalf("decodeNoteCursor = %v, want 5 values", values)
+	}
+	if got, ok := values[0].(time.Time); !ok || !got.Equal(at) {
+		t.Errorf("time value = %v, want %v", values[0], at)
+	}
+	if want := []any{"title", int64(42), 1.5, int64(9)}; !reflect.DeepEqual(values[1:], want) {
+		t.Errorf("values = %v, want %v", values[1:], want)
+	}
+
+	if _, err := encodeNoteCursor(keys, []any{struct{}{}}); err == nil {
+		t.Errorf("encodeNoteCursor with an unsupported value succeeded, want error")
+	}
+}
+
+func TestDecodeInvalidNoteCursor(t *testing.T) {
+	keys, err := parseNoteSort("title", false)
+	if err != nil {
+		t.Fatalf("parseNoteSort: %v", err)
+	}
+	valid, err := encodeNoteCursor(keys, []any{"a", int64(1)})
+	if err != nil {
+		t.Fatalf("encodeNoteCursor: %v", err)
+	}
+	otherSort, err := parseNoteSort("title desc", false)
+	if err != nil {
+		t.Fatalf("parseNoteSort: %v", err)
+	}
+	encode := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		keys  []noteSortKey
+	}{
+		{name: "not base64", token: "!!!", keys: keys},
+		{name: "truncated", token: valid[:len(valid)-4], keys: keys},
+		{name: "not json", token: encode("cursor"), keys: keys},
+		{name: "different sort", token: valid, keys: otherSort},
+		{name: "missing values", token: encode(`{"s":"title asc,id asc","v":[{"k":"s","v":"a"}]}`), keys: keys},
+		{name: "unknown kind", token: encode(`{"s":"title asc,id asc","v":[{"k":"s","v":"a"},{"k":"x","v":"1"}]}`), keys: keys},
+		{name: "invalid integer", token: encode(`{"s":"title asc,id asc","v":[{"k":"s","v":"a"},{"k":"i","v":"one"}]}`), keys: keys},
+		{name: "invalid time", token: encode(`{"s":"title asc,id asc","v":[{"k":"t","v":"yesterday"},{"k":"i","v":"1"}]}`), keys: keys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeNoteCursor(tt.token, tt.keys); !errors.Is(err, ErrInvalidPageToken) {
+				t.Errorf("decodeNoteCursor = %v, want %v", err, ErrInvalidPageToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// NoteDocument 用于建立相关笔记索引的笔记文本
+type NoteDocument struct {
+	// ID 笔记ID
+	ID int64
+	// WorkspaceID 所属工作区ID，相关笔记只在同一工作区中计算
+	WorkspaceID int64
+	// Title 标题
+	Title string
+	// Summary 摘要
+	Summary string
+	// Content 内容
+	Content string
+	// Tags 标签名称
+	Tags []string
+}
+
+// ListNoteDocuments 获取笔记的所属工作区、标题、摘要、内容和标签名称，noteIDs 为空时返回全部工作区的全部笔记
+// 不存在的笔记不会出现在结果中
+func (s *Store) ListNoteDocuments(ctx context.Context, noteIDs []int64) ([]*NoteDocument, error) {
+	query := `SELECT id, workspace_id, title, summary, content FROM notes`
+	tagQuery := `SELECT nt.note_id, t.name_text FROM note_tags nt JOIN tags t ON t.id = nt.tag_id`
+	var params []any
+	if len(noteIDs) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(noteIDs)), ", ")
+		query += ` WHERE id IN (` + placeholders + `)`
+		tagQuery += ` WHERE nt.note_id IN (` + placeholders + `)`
+		for _, id := range noteIDs {
+			params = append(params, id)
+		}
+	}
+	query += ` ORDER BY id`
+	tagQuery += ` ORDER BY nt.note_id, t.id`
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []*NoteDocument
+	byID := make(map[int64]*NoteDocument)
+	for rows.Next() {
+		var (
+			document NoteDocument
+			summary  sql.NullString
+			content  sql.NullString
+		)
+		if err := rows.Scan(&document.ID, &document.WorkspaceID, &document.Title, &summary, &content); err != nil {
+			return nil, err
+		}
+		document.Summary = summary.String
+		document.Content = content.String
+		documents = append(documents, &document)
+		byID[document.ID] = &document
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	tagRows, err := s.db.QueryContext(ctx, s.rebind(tagQuery), params...)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var noteID int64
+		var name string
+		if err := tagRows.Scan(&noteID, &name); err != nil {
+			return nil, err
+		}
+		if document, ok := byID[noteID]; ok {
+			document.Tags = append(document.Tags, name)
+		}
+	}
+	return documents, tagRows.Err()
+}
@@ -0,0 +1,304 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// 笔记草稿是每个用户对每篇笔记的工作副本，自动保存只写入 note_drafts，
+// 读者看到的始终是 notes 中已发布的内容；发布草稿时在一个事务中更新笔记、记录修订并删除草稿
+
+// noteDraftColumns 笔记草稿表的查询字段，顺序与 scanNoteDraft 保持一致
+const noteDraftColumns = `note_id, user_id, created_at, updated_at, title, content, summary, category_id, tag_ids, cover_image, visibility`
+
+// GetNoteDraft 获取用户对笔记的工作草稿
+func (s *Store) GetNoteDraft(ctx context.Context, noteID, userID int64) (*store.NoteDraft, error) {
+	return s.getNoteDraft(ctx, s.db, noteID, userID)
+}
+
+// SaveNoteDraft 保存用户对笔记的工作草稿（不存在时创建），不修改笔记本身
+func (s *Store) SaveNoteDraft(ctx context.Context, draft *store.NoteDraft, userID int64) (*store.NoteDraft, error) {
+	tagIDs, err := normalizeDraftTagIDs(draft.TagIds)
+	if err != nil {
+		return nil, err
+	}
+	visibility := "PUBLIC"
+	if draft.Visibility == store.NoteVisibility_NOTE_VISIBILITY_PRIVATE {
+		visibility = "PRIVATE"
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query, params := andWorkspace(ctx, `SELECT COUNT(*) FROM notes WHERE id = ?`, "workspace_id", draft.NoteId)
+	noteExists, err := s.rowExists(ctx, tx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	if !noteExists {
+		return nil, fmt.Errorf("note not found: %d", draft.NoteId)
+	}
+
+	draftExists, err := s.rowExists(ctx, tx,
+		`SELECT COUNT(*) FROM note_drafts WHERE note_id = ? AND user_id = ?`, draft.NoteId, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	categoryID := parseUint(draft.CategoryId)
+	if draftExists {
+		_, err = tx.ExecContext(ctx, s.rebind(
+			`UPDATE note_drafts SET title = ?, content = ?, summary = ?, category_id = ?, tag_ids = ?, cover_image = ?, visibility = ?, updated_at = ?
+			WHERE note_id = ? AND user_id = ?`),
+			draft.Title, draft.Content, draft.Summary, categoryID, tagIDs, draft.CoverImage, visibility, now,
+			draft.NoteId, userID,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, s.rebind(
+			`INSERT INTO note_drafts (note_id, user_id, title, content, summary, category_id, tag_ids, cover_image, visibility, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			draft.NoteId, userID, draft.Title, draft.Content, draft.Summary, categoryID, tagIDs, draft.CoverImage, visibility, now, now,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetNoteDraft(ctx, draft.NoteId, userID)
+}
+
+// DeleteNoteDraft 删除用户对笔记的工作草稿
+func (s *Store) DeleteNoteDraft(ctx context.Context, noteID, userID int64) error {
+	result, err := s.db.ExecContext(ctx, s.rebind(`DELETE FROM note_drafts WHERE note_id = ? AND user_id = ?`), noteID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("note draft not found: %d", noteID)
+	}
+	return nil
+}
+
+// PublishNoteDraft 将用户的工作草稿发布为笔记内容：在一个事务中更新笔记和标签、记录修订并删除草稿
+// 笔记此前未发布时设置为已发布并记录发布时间，已发布的笔记保留原发布时间；
+// 等待定时发布的笔记只更新内容，仍然在原定的发布时间发布
+func (s *Store) PublishNoteDraft(ctx context.Context, noteID, userID int64) (*store.Note, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	draft, err := s.getNoteDraft(ctx, tx, noteID, userID)
+	if err != nil {
+		return nil, err
+	}
+	query, params := andWorkspace(ctx, `SELECT `+noteSelectColumns("")+` FROM notes WHERE id = ?`, "workspace_id", noteID)
+	existing, err := scanNote(tx.QueryRowContext(ctx, s.rebind(query), params...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("note not found: %d", noteID)
+		}
+		return nil, err
+	}
+	// 草稿中的分类必须与笔记属于同一工作区
+	var workspaceID int64
+	if err := tx.QueryRowContext(ctx, s.rebind(`SELECT workspace_id FROM notes WHERE id = ?`), noteID).Scan(&workspaceID); err != nil {
+		return nil, err
+	}
+	if err := s.checkCategoryWorkspace(ctx, tx, parseUint(draft.CategoryId), workspaceID); err != nil {
+		return nil, err
+	}
+
+	note := &store.Note{
+		Id:         noteID,
+		Title:      draft.Title,
+		Content:    draft.Content,
+		Summary:    draft.Summary,
+		CategoryId: draft.CategoryId,
+		TagIds:     draft.TagIds,
+		CoverImage: draft.CoverImage,
+		Visibility: draft.Visibility,
+	}
+	// 根据内容计算字数和阅读时间，未填写摘要时自动生成
+	populateNoteStats(note)
+
+	visibility := "PUBLIC"
+	if note.Visibility == store.NoteVisibility_NOTE_VISIBILITY_PRIVATE {
+		visibility = "PRIVATE"
+	}
+	now := time.Now()
+	publishedAt := now
+	if (existing.Published || existing.Scheduled) && existing.PublishedAt > 0 {
+		publishedAt = time.Unix(existing.PublishedAt, 0)
+	}
+
+	_, err = tx.ExecContext(ctx, s.rebind(`
+		UPDATE notes SET
+			title = ?, content = ?, summary = ?, category_id = ?, published = ?, published_at = ?,
+			cover_image = ?, reading_time = ?, visibility = ?, word_count = ?, char_count = ?, updated_at = ?
+		WHERE id = ?`),
+		note.Title, note.Content, note.Summary, parseUint(note.CategoryId), !existing.Scheduled, publishedAt,
+		note.CoverImage, note.ReadingTime, visibility, note.WordCount, note.CharCount, now,
+		noteID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.setNoteTags(ctx, tx, noteID, note.TagIds); err != nil {
+		return nil, err
+	}
+	if err := s.setNoteLinks(ctx, tx, noteID, note.Content); err != nil {
+		return nil, err
+	}
+	if err := s.resolveNoteLinks(ctx, tx, noteID, note.Title); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, s.rebind(
+		`INSERT INTO note_revisions (note_id, author_id, title, content, summary, created_at) VALUES (?, ?, ?, ?, ?, ?)`),
+		noteID, userID, note.Title, note.Content, note.Summary, now,
+	); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM note_drafts WHERE note_id = ? AND user_id = ?`), noteID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetNote(ctx, noteID)
+}
+
+// ListNoteRevisions 获取笔记的修订记录，按发布时间倒序
+func (s *Store) ListNoteRevisions(ctx context.Context, noteID int64) ([]*store.NoteRevision, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(
+		`SELECT id, created_at, note_id, author_id, title, content, summary FROM note_revisions
+		WHERE note_id = ? ORDER BY id DESC`), noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*store.NoteRevision{}
+	for rows.Next() {
+		var (
+			revision  store.NoteRevision
+			createdAt time.Time
+			authorID  int64
+			content   sql.NullString
+			summary   sql.NullString
+		)
+		if err := rows.Scan(&revision.Id, &createdAt, &revision.NoteId, &authorID, &revision.Title, &content, &summary); err != nil {
+			return nil, err
+		}
+		revision.Name = fmt.Sprintf("notes/%d/revisions/%d", revision.NoteId, revision.Id)
+		revision.Author = fmt.Sprintf("users/%d", authorID)
+		revision.Content = content.String
+		revision.Summary = summary.String
+		revision.CreatedAt = createdAt.Unix()
+		revisions = append(revisions, &revision)
+	}
+	return revisions, rows.Err()
+}
+
+// getNoteDraft 获取用户对笔记的工作草稿，可以在事务中调用
+func (s *Store) getNoteDraft(ctx context.Context, q Queryer, noteID, userID int64) (*store.NoteDraft, error) {
+	rows, err := q.QueryContext(ctx, s.rebind(
+		`SELECT `+noteDraftColumns+` FROM note_drafts WHERE note_id = ? AND user_id = ?`), noteID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("note draft not found: %d", noteID)
+	}
+	return scanNoteDraft(rows)
+}
+
+// scanNoteDraft 将数据库行扫描到store.NoteDraft
+func scanNoteDraft(rows *sql.Rows) (*store.NoteDraft, error) {
+	var (
+		noteID     int64
+		userID     int64
+		createdAt  time.Time
+		updatedAt  time.Time
+		title      string
+		content    sql.NullString
+		summary    sql.NullString
+		categoryID sql.NullInt64
+		tagIDs     sql.NullString
+		coverImage sql.NullString
+		visibility sql.NullString
+	)
+	if err := rows.Scan(&noteID, &userID, &createdAt, &updatedAt, &title, &content, &summary, &categoryID, &tagIDs, &coverImage, &visibility); err != nil {
+		return nil, err
+	}
+
+	draft := &store.NoteDraft{
+		Name:       fmt.Sprintf("notes/%d/draft", noteID),
+		NoteId:     noteID,
+		Editor:     fmt.Sprintf("users/%d", userID),
+		Title:      title,
+		Content:    content.String,
+		Summary:    summary.String,
+		TagIds:     []string{},
+		CoverImage: coverImage.String,
+		Visibility: store.NoteVisibility_NOTE_VISIBILITY_PUBLIC,
+		CreatedAt:  createdAt.Unix(),
+		UpdatedAt:  updatedAt.Unix(),
+	}
+	if categoryID.Valid && categoryID.Int64 > 0 {
+		draft.CategoryId = strconv.FormatInt(categoryID.Int64, 10)
+	}
+	if tagIDs.String != "" {
+		draft.TagIds = strings.Split(tagIDs.String, ",")
+	}
+	if visibility.String == "PRIVATE" {
+		draft.Visibility = store.NoteVisibility_NOTE_VISIBILITY_PRIVATE
+	}
+	return draft, nil
+}
+
+// normalizeDraftTagIDs 检查并去重草稿的标签ID，返回逗号分隔的列表
+// 标签是否存在在发布时检查，草稿中可以暂时引用之后被删除的标签
+func normalizeDraftTagIDs(tagIDs []string) (string, error) {
+	seen := make(map[int64]bool, len(tagIDs))
+	ids := make([]string, 0, len(tagIDs))
+	for _, tagIDStr := range tagIDs {
+		tagID, err := strconv.ParseInt(strings.TrimSpace(tagIDStr), 10, 64)
+		if err != nil || tagID <= 0 {
+			return "", fmt.Errorf("invalid tag id: %q", tagIDStr)
+		}
+		if seen[tagID] {
+			continue
+		}
+		seen[tagID] = true
+		ids = append(ids, strconv.FormatInt(tagID, 10))
+	}
+	return strings.Join(ids, ","), nil
+}
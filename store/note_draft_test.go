@@ -0,0 +1,133 @@
+package store_test
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestNoteDraftPublish(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	editor := createTestUser(t, s, "editor", store.RoleUser)
+	tag, err := s.CreateTag(ctx, &pbstore.Tag{NameText: "Go"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	tagID := strconv.FormatInt(tag.Id, 10)
+
+	note := createTestNote(t, ctx, s, &pbstore.Note{Title: "published", Content: "old content", AuthorId: userID(author)})
+	publishedAt := note.PublishedAt
+
+	// 保存草稿不修改笔记，每个用户有各自的草稿
+	draft, err := s.SaveNoteDraft(ctx, &pbstore.NoteDraft{NoteId: note.Id, Title: "draft", Content: "new content", TagIds: []string{tagID, tagID}}, int64(editor.ID))
+	if err != nil {
+		t.Fatalf("SaveNoteDraft: %v", err)
+	}
+	if draft.Title != "draft" || !reflect.DeepEqual(draft.TagIds, []string{tagID}) {
+		t.Errorf("SaveNoteDraft = %q %v, want %q [%s]", draft.Title, draft.TagIds, "draft", tagID)
+	}
+	if _, err := s.SaveNoteDraft(ctx, &pbstore.NoteDraft{NoteId: note.Id, Title: "draft v2", Content: "newer content", TagIds: []string{tagID}}, int64(editor.ID)); err != nil {
+		t.Fatalf("SaveNoteDraft: %v", err)
+	}
+	if _, err := s.GetNoteDraft(ctx, note.Id, int64(author.ID)); err == nil {
+		t.Errorf("GetNoteDraft for another user succeeded, want error")
+	}
+	got, err := s.GetNote(ctx, note.Id)
+	if err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	if got.Title != "published" || got.Content != "old content" {
+		t.Errorf("note after SaveNoteDraft = %q %q, want it unchanged", got.Title, got.Content)
+	}
+	if _, err := s.SaveNoteDraft(ctx, &pbstore.NoteDraft{NoteId: note.Id + 100, Title: "missing"}, int64(editor.ID)); err == nil {
+		t.Errorf("SaveNoteDraft for a missing note succeeded, want error")
+	}
+
+	// 发布草稿更新笔记、记录修订并删除草稿，已发布的笔记保留原发布时间
+	published, err := s.PublishNoteDraft(ctx, note.Id, int64(editor.ID))
+	if err != nil {
+		t.Fatalf("PublishNoteDraft: %v", err)
+	}
+	if published.Title != "draft v2" || published.Content != "newer content" || !reflect.DeepEqual(published.TagIds, []string{tagID}) {
+		t.Errorf("PublishNoteDraft = %q %q %v, want the draft", published.Title, published.Content, published.TagIds)
+	}
+	if !published.Published || published.PublishedAt != publishedAt {
+		t.Errorf("PublishNoteDraft published = %v at %d, want true at %d", published.Published, published.PublishedAt, publishedAt)
+	}
+	revisions, err := s.ListNoteRevisions(ctx, note.Id)
+	if err != nil {
+		t.Fatalf("ListNoteRevisions: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Title != "draft v2" || revisions[0].Content != "newer content" || revisions[0].Author != "users/"+userID(editor) {
+		t.Errorf("ListNoteRevisions = %v, want one revision by the editor", revisions)
+	}
+	if _, err := s.GetNoteDraft(ctx, note.Id, int64(editor.ID)); err == nil {
+		t.Errorf("GetNoteDraft after publishing succeeded, want error")
+	}
+	if _, err := s.PublishNoteDraft(ctx, note.Id, int64(editor.ID)); err == nil {
+		t.Errorf("PublishNoteDraft without a draft succeeded, want error")
+	}
+}
+
+func TestNoteDraftPublishUnpublishedNote(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+
+	note, err := s.CreateNote(ctx, &pbstore.Note{Title: "unpublished", AuthorId: userID(author)})
+	if err != nil {
+		t.Fatalf("CreateNote: %v", err)
+	}
+	if note.Published {
+		t.Fatalf("CreateNote published the note, want a draft note")
+	}
+	if _, err := s.SaveNoteDraft(ctx, &pbstore.NoteDraft{NoteId: note.Id, Title: "ready", Content: "content"}, int64(author.ID)); err != nil {
+		t.Fatalf("SaveNoteDraft: %v", err)
+	}
+	published, err := s.PublishNoteDraft(ctx, note.Id, int64(author.ID))
+	if err != nil {
+		t.Fatalf("PublishNoteDraft: %v", err)
+	}
+	if !published.Published || published.PublishedAt == 0 {
+		t.Errorf("PublishNoteDraft published = %v at %d, want published now", published.Published, published.PublishedAt)
+	}
+}
+
+func TestNoteDraftDiscard(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	note := createTestNote(t, ctx, s, &pbstore.Note{Title: "published", Content: "old content", AuthorId: userID(author)})
+
+	if _, err := s.SaveNoteDraft(ctx, &pbstore.NoteDraft{NoteId: note.Id, Title: "discarded", Content: "new content"}, int64(author.ID)); err != nil {
+		t.Fatalf("SaveNoteDraft: %v", err)
+	}
+	if err := s.DeleteNoteDraft(ctx, note.Id, int64(author.ID)); err != nil {
+		t.Fatalf("DeleteNoteDraft: %v", err)
+	}
+	if err := s.DeleteNoteDraft(ctx, note.Id, int64(author.ID)); err == nil {
+		t.Errorf("DeleteNoteDraft twice succeeded, want error")
+	}
+
+	// 丢弃草稿不修改笔记也不记录修订
+	got, err := s.GetNote(ctx, note.Id)
+	if err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	if got.Title != "published" || got.Content != "old content" {
+		t.Errorf("note after DeleteNoteDraft = %q %q, want it unchanged", got.Title, got.Content)
+	}
+	revisions, err := s.ListNoteRevisions(ctx, note.Id)
+	if err != nil {
+		t.Fatalf("ListNoteRevisions: %v", err)
+	}
+	if len(revisions) != 0 {
+		t.Errorf("ListNoteRevisions = %v, want none", revisions)
+	}
+}
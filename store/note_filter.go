@@ -0,0 +1,264 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/internal/filter"
+)
+
+// ErrInvalidFilter 过滤表达式无效
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// noteFilterField 过滤表达式中可以使用的笔记字段
+type noteFilterField struct {
+	// column 对应的列
+	column string
+	// kind 字段类型：tag、id、visibility、bool、text、time、number
+	kind string
+	// ops 允许的运算符
+	ops []string
+}
+
+// noteFilterFields 笔记过滤表达式允许的字段
+var noteFilterFields = map[string]noteFilterField{
+	"tag":          {kind: "tag", ops: []string{"==", "!=", "in"}},
+	"category":     {column: "p.category_id", kind: "id", ops: []string{"==", "!=", "in"}},
+	"author":       {column: "p.author_id", kind: "id", ops: []string{"==", "!=", "in"}},
+	"visibility":   {column: "p.visibility", kind: "visibility", ops: []string{"==", "!=", "in"}},
+	"published":    {column: "p.published", kind: "bool", ops: []string{"==", "!="}},
+	"scheduled":    {column: "p.scheduled", kind: "bool", ops: []string{"==", "!="}},
+	"title":        {column: "p.title", kind: "text", ops: []string{"==", "!=", "contains"}},
+	"content":      {column: "p.content", kind: "text", ops: []string{"contains"}},
+	"summary":      {column: "p.summary", kind: "text", ops: []string{"contains"}},
+	"created_at":   {column: "p.created_at", kind: "time", ops: []string{"==", "!=", "<", "<=", ">", ">="}},
+	"updated_at":   {column: "p.updated_at", kind: "time", ops: []string{"==", "!=", "<", "<=", ">", ">="}},
+	"published_at": {column: "p.published_at", kind: "time", ops: []string{"==", "!=", "<", "<=", ">", ">="}},
+	"expire_at":    {column: "p.expire_at", kind: "time", ops: []string{"==", "!=", "<", "<=", ">", ">="}},
+	"view_count":   {column: "p.view_count", kind: "number", ops: []string{"==", "!=", "<", "<=", ">", ">=", "in"}},
+	"word_count":   {column: "p.word_count", kind: "number", ops: []string{"==", "!=", "<", "<=", ">", ">=", "in"}},
+	"char_count":   {column: "p.char_count", kind: "number", ops: []string{"==", "!=", "<", "<=", ">", ">=", "in"}},
+	"reading_time": {column: "p.reading_time", kind: "number", ops: []string{"==", "!=", "<", "<=", ">", ">=", "in"}},
+}
+
+// filterTimeLayouts 过滤表达式中时间值支持的格式，不带时区时使用服务器本地时区
+var filterTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// sqliteTimeLayout SQLite 中时间以驱动写入的 time.Time.String() 格式保存，比较时使用相同格式的文本
+// 时间统一以 UTC 保存（见 store/db/sqlite），文本顺序与时间顺序一致
+const sqliteTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// timeParam 返回与数据库中保存的时间比较时使用的参数
+func timeParam(driver string, t time.Time) interface{} {
+	if driver == "sqlite" {
+		return t.UTC().Format(sqliteTimeLayout)
+	}
+	return t
+}
+
+// noteFilterCompiler 将过滤表达式编译为笔记查询的 WHERE 条件
+type noteFilterCompiler struct {
+	// driver 数据库驱动
+	driver string
+	// viewerID 当前用户ID，author == "me" 匹配该用户的笔记
+	viewerID int64
+	// params 条件中占位符对应的参数
+	params []interface{}
+}
+
+// compileNoteFilter 解析过滤表达式并编译为参数化的条件，表达式为空时返回空字符串
+// viewerID 为当前用户ID（未登录时为 0），用于解析 author == "me"
+func (s *Store) compileNoteFilter(input string, viewerID int64) (string, []interface{}, error) {
+	expr, err := filter.Parse(input)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	if expr == nil {
+		return "", nil, nil
+	}
+
+	c := &noteFilterCompiler{driver: s.profile.Driver, viewerID: viewerID}
+	condition, err := c.compile(expr)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return condition, c.params, nil
+}
+
+// compile 编译表达式节点
+func (c *noteFilterCompiler) compile(expr filter.Expr) (string, error) {
+	switch e := expr.(type) {
+	case filter.And:
+		return c.compileBinary(e.Left, e.Right, "AND")
+	case filter.Or:
+		return c.compileBinary(e.Left, e.Right, "OR")
+	case filter.Not:
+		inner, err := c.compile(e.Expr)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + inner, nil
+	case filter.Comparison:
+		return c.compileComparison(e)
+	}
+	return "", fmt.Errorf("unsupported expression %T", expr)
+}
+
+// compileBinary 编译逻辑与、逻辑或
+func (c *noteFilterCompiler) compileBinary(left, right filter.Expr, op string) (string, error) {
+	l, err := c.compile(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := c.compile(right)
+	if err != nil {
+		return "", err
+	}
+	return "(" + l + " " + op + " " + r + ")", nil
+}
+
+// compileComparison 编译字段比较
+func (c *noteFilterCompiler) compileComparison(cmp filter.Comparison) (string, error) {
+	field, ok := noteFilterFields[cmp.Field]
+	if !ok {
+		return "", fmt.Errorf("unknown field %q", cmp.Field)
+	}
+	allowed := false
+	for _, op := range field.ops {
+		if op == cmp.Op {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("operator %s is not supported for field %q", cmp.Op, cmp.Field)
+	}
+	if cmp.Op == "in" && len(cmp.Values) == 0 {
+		// 空列表不匹配任何笔记
+		return "(1 = 0)", nil
+	}
+
+	values := make([]interface{}, len(cmp.Values))
+	for i, value := range cmp.Values {
+		v, err := c.convertValue(cmp.Field, field.kind, value)
+		if err != nil {
+			return "", err
+		}
+		values[i] = v
+	}
+
+	switch field.kind {
+	case "tag":
+		placeholders := c.addParams(values)
+		condition := "p.id IN (SELECT nt.note_id FROM note_tags nt JOIN tags t ON t.id = nt.tag_id WHERE t.name_key IN (" + placeholders + ")" +
+			" OR t.id IN (SELECT tag_id FROM tag_aliases WHERE alias_key IN (" + c.addParams(values) + ")))"
+		if cmp.Op == "!=" {
+			return "NOT " + condition, nil
+		}
+		return condition, nil
+	case "bool":
+		// 布尔值作为参数绑定，PostgreSQL 的 BOOLEAN 列不能与整数字面量比较
+		c.params = append(c.params, values[0].(bool) == (cmp.Op == "=="))
+		return field.column + " = ?", nil
+	case "text":
+		if cmp.Op == "contains" {
+			c.params = append(c.params, "%"+likeEscaper.Replace(values[0].(string))+"%")
+			return field.column + " LIKE ? ESCAPE '!'", nil
+		}
+	}
+
+	switch cmp.Op {
+	case "in":
+		return field.column + " IN (" + c.addParams(values) + ")", nil
+	case "==":
+		c.params = append(c.params, values[0])
+		return field.column + " = ?", nil
+	case "!=":
+		c.params = append(c.params, values[0])
+		return field.column + " <> ?", nil
+	default:
+		c.params = append(c.params, values[0])
+		return field.column + " " + cmp.Op + " ?", nil
+	}
+}
+
+// addParams 添加参数并返回对应的占位符列表
+func (c *noteFilterCompiler) addParams(values []interface{}) string {
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		c.params = append(c.params, value)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// convertValue 检查值的类型并转换为查询参数
+func (c *noteFilterCompiler) convertValue(name, kind string, value filter.Value) (interface{}, error) {
+	invalid := fmt.Errorf("invalid value %s for field %q", value.Literal(), name)
+
+	switch kind {
+	case "tag":
+		if value.Kind != filter.StringValue {
+			return nil, invalid
+		}
+		return TagNameKey(value.String), nil
+	case "id":
+		switch value.Kind {
+		case filter.NumberValue:
+			return value.Number, nil
+		case filter.StringValue:
+			if name == "author" && value.String == "me" {
+				if c.viewerID <= 0 {
+					return nil, fmt.Errorf("author == \"me\" requires login")
+				}
+				return c.viewerID, nil
+			}
+			// 支持资源名称（categories/3、users/3）和纯数字ID
+			text := value.String
+			if i := strings.LastIndex(text, "/"); i >= 0 {
+				text = text[i+1:]
+			}
+			id, err := strconv.ParseInt(text, 10, 64)
+			if err != nil {
+				return nil, invalid
+			}
+			return id, nil
+		}
+	case "visibility":
+		if value.Kind == filter.StringValue {
+			switch strings.TrimPrefix(strings.ToUpper(value.String), "NOTE_VISIBILITY_") {
+			case "PUBLIC":
+				return "PUBLIC", nil
+			case "PRIVATE":
+				return "PRIVATE", nil
+			}
+		}
+	case "bool":
+		if value.Kind == filter.BoolValue {
+			return value.Bool, nil
+		}
+	case "text":
+		if value.Kind == filter.StringValue {
+			return value.String, nil
+		}
+	case "number":
+		if value.Kind == filter.NumberValue {
+			return value.Number, nil
+		}
+	case "time":
+		if value.Kind != filter.StringValue {
+			return nil, invalid
+		}
+		for _, layout := range filterTimeLayouts {
+			t, err := time.ParseInLocation(layout, value.String, time.Local)
+			if err != nil {
+				continue
+			}
+			return timeParam(c.driver, t), nil
+		}
+	}
+	return nil, invalid
+}
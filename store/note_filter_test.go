@@ -0,0 +1,183 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestListNotesFilter(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice", store.RoleUser)
+	bob := createTestUser(t, s, "bob", store.RoleUser)
+
+	goTag, err := s.CreateTag(ctx, &pbstore.Tag{NameText: "Go"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	if _, err := s.CreateTagAlias(ctx, goTag.Id, "golang"); err != nil {
+		t.Fatalf("CreateTagAlias: %v", err)
+	}
+	dbTag, err := s.CreateTag(ctx, &pbstore.Tag{NameText: "DB"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	tagIDs := func(tags ...*pbstore.Tag) []string {
+		ids := make([]string, len(tags))
+		for i, tag := range tags {
+			ids[i] = strconv.FormatInt(tag.Id, 10)
+		}
+		return ids
+	}
+
+	createTestNote(t, ctx, s, &pbstore.Note{Title: "go basics", Content: "one two three", AuthorId: userID(alice), TagIds: tagIDs(goTag)})
+	createTestNote(t, ctx, s, &pbstore.Note{Title: "go and db", Content: "one", AuthorId: userID(alice), TagIds: tagIDs(goTag, dbTag)})
+	createTestNote(t, ctx, s, &pbstore.Note{Title: "100% db", Content: "one two", AuthorId: userID(bob), TagIds: tagIDs(dbTag)})
+	createTestNote(t, ctx, s, &pbstore.Note{
+		Title:      "private draft",
+		Content:    "one two three four",
+		AuthorId:   userID(bob),
+		Visibility: pbstore.NoteVisibility_NOTE_VISIBILITY_PRIVATE,
+	})
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: ``, want: []string{"go basics", "go and db", "100% db", "private draft"}},
+		{filter: `tag == "go"`, want: []string{"go basics", "go and db"}},
+		// 标签按规范化的名称和别名匹配
+		{filter: `tag == "GOLANG"`, want: []string{"go basics", "go and db"}},
+		{filter: `tag in ["db", "missing"]`, want: []string{"go and db", "100% db"}},
+		{filter: `tag != "go"`, want: []string{"100% db", "private draft"}},
+		{filter: `tag in []`, want: []string{}},
+		{filter: `tag == "go" && tag == "db"`, want: []string{"go and db"}},
+		{filter: `author == "users/` + userID(bob) + `"`, want: []string{"100% db", "private draft"}},
+		{filter: `author == "me"`, want: []string{"go basics", "go and db"}},
+		{filter: `visibility == "PRIVATE"`, want: []string{"private draft"}},
+		{filter: `!(visibility == "PRIVATE") && title.contains("db")`, want: []string{"go and db", "100% db"}},
+		// LIKE 通配符按字面匹配
+		{filter: `title.contains("%")`, want: []string{"100% db"}},
+		{filter: `title == "go basics" || content.contains("four")`, want: []string{"go basics", "private draft"}},
+		{filter: `word_count >= 3`, want: []string{"go basics", "private draft"}},
+		{filter: `word_count in [1, 2]`, want: []string{"go and db", "100% db"}},
+		{filter: `created_at > "2000-01-01" && created_at < "2000-01-02"`, want: []string{}},
+		{filter: `published == true`, want: []string{"go basics", "go and db", "100% db", "private draft"}},
+		{filter: `published != true`, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			notes, total, err := s.ListNotes(ctx, &store.ListNotesRequest{
+				PageSize: 10,
+				SortBy:   "id asc",
+				Filter:   tt.filter,
+				ViewerID: int64(alice.ID),
+			})
+			if err != nil {
+				t.Fatalf("ListNotes: %v", err)
+			}
+			if got := noteTitles(notes); !reflect.DeepEqual(got, tt.want) || total != int64(len(tt.want)) {
+				t.Errorf("ListNotes = %v (total %d), want %v", got, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestListNotesFilterTimesAcrossZones(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+
+	local := time.Local
+	t.Cleanup(func() { time.Local = local })
+
+	// 两篇笔记在服务器时区不同时写入：SQLite 按文本比较时间，必须统一以 UTC 保存
+	early := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		title string
+		at    time.Time
+		zone  *time.Location
+	}{
+		{title: "early", at: early, zone: time.FixedZone("CST", 8*3600)},
+		{title: "late", at: late, zone: time.FixedZone("EST", -5*3600)},
+	} {
+		time.Local = tt.zone
+		note := createTestNote(t, ctx, s, &pbstore.Note{Title: tt.title, AuthorId: userID(author)})
+		if _, err := s.GetDB().ExecContext(ctx, `UPDATE notes SET created_at = ? WHERE id = ?`, tt.at.In(time.Local), note.Id); err != nil {
+			t.Fatalf("failed to set created_at: %v", err)
+		}
+	}
+	time.Local = time.FixedZone("MSK", 3*3600)
+
+	// 升级前以本地时区文本保存的时间（11:30 UTC）由迁移改写为 UTC
+	legacy := createTestNote(t, ctx, s, &pbstore.Note{Title: "legacy", AuthorId: userID(author)})
+	if _, err := s.GetDB().ExecContext(ctx, `UPDATE notes SET created_at = ? WHERE id = ?`, "2024-03-01 06:30:00.5 -0500 EST m=+0.001", legacy.Id); err != nil {
+		t.Fatalf("failed to set created_at: %v", err)
+	}
+	if err := s.RunMigrations(); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+
+	tests := []struct {
+		filter string
+		sortBy string
+		want   []string
+	}{
+		{filter: `created_at > "2024-03-01T11:00:00Z"`, want: []string{"late", "legacy"}},
+		{filter: `created_at < "2024-03-01T11:00:00Z"`, want: []string{"early"}},
+		// 不带时区的时间按服务器本地时区（MSK，UTC+3）解析
+		{filter: `created_at >= "2024-03-01 14:45:00"`, want: []string{"late"}},
+		{filter: `created_at >= "2024-03-01T08:00:00-02:00" && created_at <= "2024-03-01T10:00:00-02:00"`, want: []string{"early", "late", "legacy"}},
+		{sortBy: "created_at asc", want: []string{"early", "legacy", "late"}},
+		{sortBy: "created_at desc", want: []string{"late", "legacy", "early"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter+tt.sortBy, func(t *testing.T) {
+			sortBy := tt.sortBy
+			if sortBy == "" {
+				sortBy = "id asc"
+			}
+			notes, _, err := s.ListNotes(ctx, &store.ListNotesRequest{PageSize: 10, SortBy: sortBy, Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("ListNotes: %v", err)
+			}
+			if got := noteTitles(notes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListNotes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListNotesInvalidFilter(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{name: "syntax error", filter: `tag ==`},
+		{name: "unknown field", filter: `password == "x"`},
+		{name: "unsupported operator", filter: `content == "x"`},
+		{name: "wrong value type", filter: `word_count > "many"`},
+		{name: "invalid time", filter: `created_at > "yesterday"`},
+		{name: "invalid visibility", filter: `visibility == "SECRET"`},
+		{name: "me without login", filter: `author == "me"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.ListNotes(ctx, &store.ListNotesRequest{PageSize: 10, Filter: tt.filter})
+			if !errors.Is(err, store.ErrInvalidFilter) {
+				t.Errorf("ListNotes(%q): err = %v, want ErrInvalidFilter", tt.filter, err)
+			}
+		})
+	}
+}
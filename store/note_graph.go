@@ -0,0 +1,219 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// NoteGraph 笔记关系图的基础数据：全部笔记、笔记之间已解析的链接、笔记标签和分类
+// 不包含可见性信息，调用方根据 ListNoteIDs 的结果选取访问者可以查看的笔记
+type NoteGraph struct {
+	// Notes 笔记ID到笔记节点的映射
+	Notes map[int64]*NoteGraphNote
+	// Links 笔记之间的链接（源笔记ID、目标笔记ID），已去重且不包含指向自身的链接
+	Links [][2]int64
+	// Tags 标签ID到标签名称的映射
+	Tags map[int64]string
+	// Categories 分类ID到分类的映射
+	Categories map[int64]*NoteGraphCategory
+}
+
+// NoteGraphNote 关系图中的笔记
+type NoteGraphNote struct {
+	// ID 笔记ID
+	ID int64
+	// Title 标题
+	Title string
+	// ViewCount 浏览次数
+	ViewCount int64
+	// CategoryID 分类ID，未分类时为 0
+	CategoryID int64
+	// TagIDs 标签ID列表
+	TagIDs []int64
+}
+
+// NoteGraphCategory 关系图中的分类
+type NoteGraphCategory struct {
+	// Name 分类名称
+	Name string
+	// Visible 是否可见
+	Visible bool
+}
+
+// ListNoteIDs 获取符合列表请求条件（分类、标签、可见性、过滤表达式等）的全部笔记ID，忽略分页和排序
+func (s *Store) ListNoteIDs(ctx context.Context, req *ListNotesRequest) ([]int64, error) {
+	join, whereConditions, params, err := s.noteListConditions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT DISTINCT p.id FROM notes p` + join
+	if len(whereConditions) > 0 {
+		query += " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+	query += " ORDER BY p.id"
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// NoteGraphVersion 返回关系图数据的版本，笔记、链接、标签或分类变化后版本随之变化，用于判断缓存是否失效
+// 浏览次数的变化不会改变版本
+func (s *Store) NoteGraphVersion(ctx context.Context) (string, error) {
+	var (
+		noteCount, linkCount, noteTagCount int64
+		maxLinkID                          any
+		noteUpdatedAt, tagUpdatedAt        any
+		categoryUpdatedAt                  any
+	)
+	err := s.db.QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*) FROM notes), (SELECT MAX(updated_at) FROM notes),
+		(SELECT COUNT(*) FROM note_links), (SELECT MAX(id) FROM note_links),
+		(SELECT COUNT(*) FROM note_tags), (SELECT MAX(updated_at) FROM tags),
+		(SELECT MAX(updated_at) FROM categories)`,
+	).Scan(&noteCount, &noteUpdatedAt, &linkCount, &maxLinkID, &noteTagCount, &tagUpdatedAt, &categoryUpdatedAt)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d|%v|%d|%v|%d|%v|%v", noteCount, noteUpdatedAt, linkCount, maxLinkID, noteTagCount, tagUpdatedAt, categoryUpdatedAt), nil
+}
+
+// LoadNoteGraph 读取关系图的基础数据
+func (s *Store) LoadNoteGraph(ctx context.Context) (*NoteGraph, error) {
+	graph := &NoteGraph{
+		Notes:      make(map[int64]*NoteGraphNote),
+		Tags:       make(map[int64]string),
+		Categories: make(map[int64]*NoteGraphCategory),
+	}
+	loaders := []func(context.Context, *NoteGraph) error{
+		s.loadGraphNotes,
+		s.loadGraphLinks,
+		s.loadGraphNoteTags,
+		s.loadGraphTags,
+		s.loadGraphCategories,
+	}
+	for _, load := range loaders {
+		if err := load(ctx, graph); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+// loadGraphNotes 读取关系图中的笔记
+func (s *Store) loadGraphNotes(ctx context.Context, graph *NoteGraph) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, title, view_count, category_id FROM notes`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			node       NoteGraphNote
+			viewCount  sql.NullInt64
+			categoryID sql.NullInt64
+		)
+		if err := rows.Scan(&node.ID, &node.Title, &viewCount, &categoryID); err != nil {
+			return err
+		}
+		node.ViewCount = viewCount.Int64
+		node.CategoryID = categoryID.Int64
+		graph.Notes[node.ID] = &node
+	}
+	return rows.Err()
+}
+
+// loadGraphLinks 读取笔记之间已解析的链接
+func (s *Store) loadGraphLinks(ctx context.Context, graph *NoteGraph) error {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT source_note_id, target_note_id FROM note_links
+		WHERE target_note_id IS NOT NULL AND source_note_id <> target_note_id
+		ORDER BY source_note_id, target_note_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link [2]int64
+		if err := rows.Scan(&link[0], &link[1]); err != nil {
+			return err
+		}
+		graph.Links = append(graph.Links, link)
+	}
+	return rows.Err()
+}
+
+// loadGraphNoteTags 读取笔记的标签
+func (s *Store) loadGraphNoteTags(ctx context.Context, graph *NoteGraph) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT note_id, tag_id FROM note_tags ORDER BY note_id, tag_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var noteID, tagID int64
+		if err := rows.Scan(&noteID, &tagID); err != nil {
+			return err
+		}
+		if node, ok := graph.Notes[noteID]; ok {
+			node.TagIDs = append(node.TagIDs, tagID)
+		}
+	}
+	return rows.Err()
+}
+
+// loadGraphTags 读取标签名称
+func (s *Store) loadGraphTags(ctx context.Context, graph *NoteGraph) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name_text FROM tags WHERE deleted_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		graph.Tags[id] = name
+	}
+	return rows.Err()
+}
+
+// loadGraphCategories 读取分类名称和是否可见
+func (s *Store) loadGraphCategories(ctx context.Context, graph *NoteGraph) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name_text, visible FROM categories WHERE deleted_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+		var visible sql.NullBool
+		if err := rows.Scan(&id, &name, &visible); err != nil {
+			return err
+		}
+		graph.Categories[id] = &NoteGraphCategory{Name: name, Visible: !visible.Valid || visible.Bool}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,356 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// 笔记内容中的 [[笔记标题]]、[[notes/123]] 以及带显示文本的 [[笔记标题|文本]] 在保存时解析并写入 note_links。
+// 链接按 notes/{id} 或标题的规范化形式（见 NoteLinkKey）解析，解析后记录目标笔记ID，
+// 目标笔记改名后已解析的链接仍然有效；没有找到目标的链接在同名笔记创建或改名后自动解析
+
+var (
+	// noteLinkPattern 匹配 [[目标]] 和 [[目标|显示文本]]
+	noteLinkPattern = regexp.MustCompile(`\[\[([^\[\]\n]+)\]\]`)
+	// noteLinkIDPattern 匹配按资源名称引用的链接目标 notes/{id}
+	noteLinkIDPattern = regexp.MustCompile(`^notes/(\d+)$`)
+	// inlineCodePattern 匹配行内代码，其中的 [[...]] 不作为链接
+	inlineCodePattern = regexp.MustCompile("`[^`\n]*`")
+)
+
+// maxNoteLinkTargetLength 链接目标和显示文本的最大长度（字符数），与表字段长度一致
+const maxNoteLinkTargetLength = 255
+
+// noteLinkRef 从笔记内容中解析出的链接
+type noteLinkRef struct {
+	// target 链接中书写的目标
+	target string
+	// key 规范化的目标
+	key string
+	// alias 显示文本
+	alias string
+	// noteID 按 notes/{id} 引用时的笔记ID
+	noteID int64
+}
+
+// NoteLinkKey 返回链接目标的规范化形式，用于按标题解析链接：
+// 全角字符转换为半角、转换为小写，空白、下划线和连字符合并为一个连字符，并移除其他标点符号，
+// 因此 [[my-note]] 和 [[My Note]] 都会链接到标题为 "My Note" 的笔记
+func NoteLinkKey(title string) string {
+	var b strings.Builder
+	pendingSeparator := false
+	for _, r := range strings.ToLower(width.Fold.String(title)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pendingSeparator && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingSeparator = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			pendingSeparator = true
+		}
+	}
+	return b.String()
+}
+
+// parseNoteLinks 解析笔记内容中的链接，忽略代码块和行内代码中的内容，同一目标只保留第一个
+func parseNoteLinks(content string) []noteLinkRef {
+	var refs []noteLinkRef
+	seen := make(map[string]bool)
+	fence := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		line = inlineCodePattern.ReplaceAllString(line, "")
+		for _, match := range noteLinkPattern.FindAllStringSubmatch(line, -1) {
+			target, alias, _ := strings.Cut(match[1], "|")
+			// 忽略指向标题的部分，例如 [[笔记标题#小节]]
+			target, _, _ = strings.Cut(target, "#")
+			target = truncateRunes(strings.TrimSpace(target), maxNoteLinkTargetLength)
+			alias = truncateRunes(strings.TrimSpace(alias), maxNoteLinkTargetLength)
+
+			ref := noteLinkRef{target: target, alias: alias}
+			if m := noteLinkIDPattern.FindStringSubmatch(target); m != nil {
+				ref.noteID, _ = strconv.ParseInt(m[1], 10, 64)
+				ref.key = target
+			} else {
+				ref.key = NoteLinkKey(target)
+			}
+			if ref.key == "" || seen[ref.key] {
+				continue
+			}
+			seen[ref.key] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// setNoteLinks 根据笔记内容重新写入笔记的链接，需要在修改笔记的事务中调用
+// 按标题找不到目标时沿用之前解析到的笔记，目标笔记改名后重新保存不会使链接失效
+func (s *Store) setNoteLinks(ctx context.Context, tx *sql.Tx, noteID int64, content string) error {
+	previous := make(map[string]int64)
+	rows, err := tx.QueryContext(ctx,
+		s.rebind(`SELECT target_key, target_note_id FROM note_links WHERE source_note_id = ? AND target_note_id IS NOT NULL`), noteID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var key string
+		var targetID int64
+		if err := rows.Scan(&key, &targetID); err != nil {
+			rows.Close()
+			return err
+		}
+		previous[key] = targetID
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM note_links WHERE source_note_id = ?`), noteID); err != nil {
+		return err
+	}
+
+	refs := parseNoteLinks(content)
+	var titleKeys map[string]int64
+	for _, ref := range refs {
+		var targetID int64
+		if ref.noteID > 0 {
+			// 只能链接到同一工作区中的笔记
+			var count int
+			if err := tx.QueryRowContext(ctx,
+				s.rebind(`SELECT COUNT(*) FROM notes WHERE id = ? AND workspace_id = (SELECT workspace_id FROM notes WHERE id = ?)`),
+				ref.noteID, noteID,
+			).Scan(&count); err != nil {
+				return err
+			}
+			if count > 0 {
+				targetID = ref.noteID
+			}
+		} else {
+			if titleKeys == nil {
+				if titleKeys, err = s.noteTitleKeys(ctx, tx, noteID); err != nil {
+					return err
+				}
+			}
+			targetID = titleKeys[ref.key]
+		}
+		if targetID == 0 {
+			targetID = previous[ref.key]
+		}
+
+		var target sql.NullInt64
+		if targetID > 0 {
+			target = sql.NullInt64{Int64: targetID, Valid: true}
+		}
+		var alias sql.NullString
+		if ref.alias != "" {
+			alias = sql.NullString{String: ref.alias, Valid: true}
+		}
+		if _, err := tx.ExecContext(ctx,
+			s.rebind(`INSERT INTO note_links (source_note_id, target_note_id, target, target_key, alias) VALUES (?, ?, ?, ?, ?)`),
+			noteID, target, ref.target, ref.key, alias,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveNoteLinks 将同一工作区中指向 title 的未解析链接解析到笔记，在笔记创建或改名时调用
+func (s *Store) resolveNoteLinks(ctx context.Context, tx *sql.Tx, noteID int64, title string) error {
+	key := NoteLinkKey(title)
+	if key == "" {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx,
+		s.rebind(`UPDATE note_links SET target_note_id = ? WHERE target_note_id IS NULL AND target_key = ?
+			AND source_note_id IN (SELECT id FROM notes WHERE workspace_id = (SELECT workspace_id FROM notes WHERE id = ?))`),
+		noteID, key, noteID)
+	return err
+}
+
+// deleteNoteLinks 删除笔记中的链接，指向该笔记的链接变为未解析，在删除笔记的事务中调用
+func (s *Store) deleteNoteLinks(ctx context.Context, tx *sql.Tx, noteID int64) error {
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM note_links WHERE source_note_id = ?`), noteID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, s.rebind(`UPDATE note_links SET target_note_id = NULL WHERE target_note_id = ?`), noteID)
+	return err
+}
+
+// noteTitleKeys 返回与 noteID 同一工作区的笔记的规范化标题到笔记ID的映射，标题相同时使用ID最小的笔记
+func (s *Store) noteTitleKeys(ctx context.Context, tx *sql.Tx, noteID int64) (map[string]int64, error) {
+	rows, err := tx.QueryContext(ctx,
+		s.rebind(`SELECT id, title FROM notes WHERE workspace_id = (SELECT workspace_id FROM notes WHERE id = ?) ORDER BY id`), noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make(map[string]int64)
+	for rows.Next() {
+		var id int64
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return nil, err
+		}
+		if key := NoteLinkKey(title); key != "" {
+			if _, ok := keys[key]; !ok {
+				keys[key] = id
+			}
+		}
+	}
+	return keys, rows.Err()
+}
+
+// ListBacklinkNotes 获取链接到指定笔记的其他笔记，按更新时间倒序
+// 返回的笔记未按访问者过滤，调用方需要检查可见性
+func (s *Store) ListBacklinkNotes(ctx context.Context, noteID int64) ([]*store.Note, error) {
+	query := `SELECT ` + noteSelectColumns("") + ` FROM notes
+		WHERE id IN (SELECT source_note_id FROM note_links WHERE target_note_id = ? AND source_note_id <> ?)
+		ORDER BY updated_at DESC, id DESC`
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), noteID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []*store.Note{}
+	for rows.Next() {
+		note, err := scanNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := s.loadNoteTagIDs(ctx, s.db, notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// ListUnresolvedNoteLinks 获取没有找到目标笔记的链接，按链接目标排序
+// authorID 不为 0 时只返回该用户笔记中的链接
+func (s *Store) ListUnresolvedNoteLinks(ctx context.Context, authorID int64) ([]*store.NoteLink, error) {
+	query := `SELECT l.source_note_id, n.title, n.author_id, l.target, l.alias FROM note_links l
+		JOIN notes n ON n.id = l.source_note_id
+		WHERE l.target_note_id IS NULL`
+	var params []any
+	if condition, workspaceParams := workspaceCondition(ctx, "n.workspace_id"); condition != "" {
+		query += ` AND ` + condition
+		params = append(params, workspaceParams...)
+	}
+	if authorID > 0 {
+		query += ` AND n.author_id = ?`
+		params = append(params, authorID)
+	}
+	query += ` ORDER BY l.target_key, l.source_note_id`
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*store.NoteLink{}
+	for rows.Next() {
+		var (
+			sourceID    int64
+			sourceTitle string
+			author      int64
+			target      string
+			alias       sql.NullString
+		)
+		if err := rows.Scan(&sourceID, &sourceTitle, &author, &target, &alias); err != nil {
+			return nil, err
+		}
+		links = append(links, &store.NoteLink{
+			Source:       fmt.Sprintf("notes/%d", sourceID),
+			SourceTitle:  sourceTitle,
+			SourceAuthor: fmt.Sprintf("users/%d", author),
+			Target:       target,
+			Alias:        alias.String,
+		})
+	}
+	return links, rows.Err()
+}
+
+// RebuildNoteLinks 重新解析所有笔记中的链接，返回处理的笔记数量
+func (s *Store) RebuildNoteLinks(ctx context.Context) (int, error) {
+	type noteContent struct {
+		id      int64
+		content string
+	}
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT id, content FROM notes ORDER BY id`))
+	if err != nil {
+		return 0, err
+	}
+	var notes []noteContent
+	for rows.Next() {
+		var id int64
+		var content sql.NullString
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		notes = append(notes, noteContent{id: id, content: content.String})
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, err
+	}
+	rows.Close()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	for _, note := range notes {
+		if err := s.setNoteLinks(ctx, tx, note.id, note.content); err != nil {
+			return 0, fmt.Errorf("failed to parse links of note %d: %w", note.id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(notes), nil
+}
+
+// truncateRunes 将字符串截断为最多 n 个字符
+func truncateRunes(value string, n int) string {
+	if utf8.RuneCountInString(value) <= n {
+		return value
+	}
+	return string([]rune(value)[:n])
+}
@@ -0,0 +1,97 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestNoteLinkKey(t *testing.T) {
+	tests := map[string]string{
+		"My Note":        "my-note",
+		"my-note":        "my-note",
+		"  My__Note!  ":  "my-note",
+		"ＭＹ　Ｎｏｔｅ":        "my-note",
+		"C++ / Go 笔记":    "c-go-笔记",
+		"!!!":            "",
+		"notes about #1": "notes-about-1",
+	}
+	for title, want := range tests {
+		if got := store.NoteLinkKey(title); got != want {
+			t.Errorf("NoteLinkKey(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestNoteLinksAndBacklinks(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	teamCtx := store.WithWorkspace(ctx, createTestWorkspace(t, s, "team", author))
+
+	target := createTestNote(t, ctx, s, &pbstore.Note{Title: "Target Note", AuthorId: userID(author)})
+	other := createTestNote(t, ctx, s, &pbstore.Note{Title: "Other", AuthorId: userID(author)})
+	content := fmt.Sprintf("see [[target-note#intro]] and [[notes/%d|the other one]]\n"+
+		"`[[inline code]]` is not a link\n```\n[[fenced]]\n```\n[[Missing Page]] [[missing page]]", other.Id)
+	source := createTestNote(t, ctx, s, &pbstore.Note{Title: "Source", AuthorId: userID(author), Content: content})
+	// 其他工作区中的同名链接不会解析到默认工作区的笔记
+	team := createTestNote(t, teamCtx, s, &pbstore.Note{Title: "Team", AuthorId: userID(author), Content: "[[Target Note]]"})
+
+	assertBacklinks := func(noteID int64, want ...string) {
+		t.Helper()
+		notes, err := s.ListBacklinkNotes(ctx, noteID)
+		if err != nil {
+			t.Fatalf("ListBacklinkNotes: %v", err)
+		}
+		if got := noteTitles(notes); !reflect.DeepEqual(got, append([]string{}, want...)) {
+			t.Errorf("ListBacklinkNotes(%d) = %v, want %v", noteID, got, want)
+		}
+	}
+	assertUnresolved := func(ctx context.Context, want ...string) {
+		t.Helper()
+		links, err := s.ListUnresolvedNoteLinks(ctx, 0)
+		if err != nil {
+			t.Fatalf("ListUnresolvedNoteLinks: %v", err)
+		}
+		got := []string{}
+		for _, link := range links {
+			got = append(got, link.SourceTitle+" -> "+link.Target)
+		}
+		if !reflect.DeepEqual(got, append([]string{}, want...)) {
+			t.Errorf("ListUnresolvedNoteLinks = %v, want %v", got, want)
+		}
+	}
+
+	assertBacklinks(target.Id, "Source")
+	assertBacklinks(other.Id, "Source")
+	assertBacklinks(source.Id)
+	// 代码中的链接被忽略，同一目标只记录一次
+	assertUnresolved(ctx, "Source -> Missing Page")
+	assertUnresolved(teamCtx, "Team -> Target Note")
+
+	// 创建同名笔记后未解析的链接自动解析
+	missing := createTestNote(t, ctx, s, &pbstore.Note{Title: "missing  page", AuthorId: userID(author)})
+	assertBacklinks(missing.Id, "Source")
+	assertUnresolved(ctx)
+
+	// 目标笔记改名后已解析的链接仍然有效，重新保存来源笔记也不会使链接失效
+	target.Title = "Renamed"
+	if _, err := s.UpdateNote(ctx, target); err != nil {
+		t.Fatalf("UpdateNote: %v", err)
+	}
+	if _, err := s.UpdateNote(ctx, source); err != nil {
+		t.Fatalf("UpdateNote: %v", err)
+	}
+	assertBacklinks(target.Id, "Source")
+
+	// 删除目标笔记后指向它的链接变为未解析
+	if err := s.DeleteNote(ctx, other.Id); err != nil {
+		t.Fatalf("DeleteNote: %v", err)
+	}
+	assertUnresolved(ctx, fmt.Sprintf("Source -> notes/%d", other.Id))
+	assertBacklinks(team.Id)
+}
@@ -0,0 +1,115 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestListNotesPageTokens(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+
+	// 七篇笔记：前五篇的创建时间完全相同，标题也有重复
+	var ids []int64
+	for i := 0; i < 7; i++ {
+		note := createTestNote(t, ctx, s, &pbstore.Note{Title: fmt.Sprintf("note %d", i%3), AuthorId: userID(author)})
+		ids = append(ids, note.Id)
+	}
+	same := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, id := range ids {
+		createdAt := same
+		if i >= 5 {
+			createdAt = same.Add(time.Duration(i) * time.Hour)
+		}
+		if _, err := s.GetDB().ExecContext(ctx, `UPDATE notes SET created_at = ? WHERE id = ?`, createdAt, id); err != nil {
+			t.Fatalf("failed to set created_at: %v", err)
+		}
+	}
+
+	for _, sortBy := range []string{"created_at desc", "created_at asc", "created_at desc, title asc", "title"} {
+		for _, pageSize := range []int32{1, 2, 3, 7} {
+			t.Run(fmt.Sprintf("%s/%d", sortBy, pageSize), func(t *testing.T) {
+				all, err := s.ListNotesPage(ctx, &store.ListNotesRequest{PageSize: 100, SortBy: sortBy})
+				if err != nil {
+					t.Fatalf("ListNotesPage: %v", err)
+				}
+				if all.NextPageToken != "" {
+					t.Errorf("NextPageToken = %q with every note on one page, want empty", all.NextPageToken)
+				}
+
+				// 逐页读取的结果与一次读取全部的顺序完全一致，没有重复或遗漏
+				var got []int64
+				token := ""
+				for pages := 0; ; pages++ {
+					if pages > len(ids) {
+						t.Fatalf("paging did not finish after %d pages", pages)
+					}
+					page, err := s.ListNotesPage(ctx, &store.ListNotesRequest{PageSize: pageSize, SortBy: sortBy, PageToken: token})
+					if err != nil {
+						t.Fatalf("ListNotesPage: %v", err)
+					}
+					if page.Total != int64(len(ids)) {
+						t.Errorf("Total = %d, want %d", page.Total, len(ids))
+					}
+					if len(page.Notes) > int(pageSize) {
+						t.Fatalf("page has %d notes, want at most %d", len(page.Notes), pageSize)
+					}
+					for _, note := range page.Notes {
+						got = append(got, note.Id)
+					}
+					if page.NextPageToken == "" {
+						break
+					}
+					// 还有下一页时当前页是满的
+					if len(page.Notes) != int(pageSize) {
+						t.Errorf("page with a next token has %d notes, want %d", len(page.Notes), pageSize)
+					}
+					token = page.NextPageToken
+				}
+				want := make([]int64, len(all.Notes))
+				for i, note := range all.Notes {
+					want[i] = note.Id
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("paged ids = %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestListNotesPageRejectsInvalidToken(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	for i := 0; i < 3; i++ {
+		createTestNote(t, ctx, s, &pbstore.Note{Title: fmt.Sprintf("note %d", i), AuthorId: userID(author)})
+	}
+
+	page, err := s.ListNotesPage(ctx, &store.ListNotesRequest{PageSize: 1, SortBy: "title"})
+	if err != nil {
+		t.Fatalf("ListNotesPage: %v", err)
+	}
+	if page.NextPageToken == "" {
+		t.Fatalf("NextPageToken is empty, want a token")
+	}
+
+	for _, req := range []*store.ListNotesRequest{
+		{PageSize: 1, SortBy: "title", PageToken: "not-a-token"},
+		{PageSize: 1, SortBy: "title", PageToken: page.NextPageToken + "x"},
+		// 游标只能用于生成它的排序方式
+		{PageSize: 1, SortBy: "title desc", PageToken: page.NextPageToken},
+	} {
+		if _, err := s.ListNotesPage(ctx, req); !errors.Is(err, store.ErrInvalidPageToken) {
+			t.Errorf("ListNotesPage(%q, %q) = %v, want %v", req.SortBy, req.PageToken, err, store.ErrInvalidPageToken)
+		}
+	}
+}
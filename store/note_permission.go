@@ -0,0 +1,360 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// 笔记权限授予用户对一篇笔记或一个分类的协作者角色（查看者、评论者、编辑者）。
+// 分类上的权限由该分类及其所有子分类中的笔记继承，用户对笔记的角色取直接授予和继承的角色中最高的一个；
+// 作者、管理员和笔记所属工作区的所有者、管理员不需要授权。API 和文件服务都通过 NoteAccessLevelOf 计算访问级别。
+
+// NoteAccessLevel 用户对笔记的访问级别，数值越大权限越多
+type NoteAccessLevel int
+
+const (
+	// NoteAccessNone 无法访问
+	NoteAccessNone NoteAccessLevel = iota
+	// NoteAccessPublic 公开笔记的访问者，可以查看已发布的内容
+	NoteAccessPublic
+	// NoteAccessViewer 被授予查看者角色，还可以查看私有和未发布的笔记
+	NoteAccessViewer
+	// NoteAccessCommenter 被授予评论者角色，还可以发表评论
+	NoteAccessCommenter
+	// NoteAccessEditor 被授予编辑者角色，还可以编辑笔记、草稿和附件，以及管理评论
+	NoteAccessEditor
+	// NoteAccessOwner 作者或管理员，还可以删除笔记、管理分享链接和权限
+	NoteAccessOwner
+)
+
+// notePermissionColumns 笔记权限表的查询字段，顺序与 scanNotePermission 保持一致
+const notePermissionColumns = `user_id, note_id, category_id, role, granted_by, created_at, updated_at`
+
+// notePermissionRoleNames 角色在数据库中保存的名称
+var notePermissionRoleNames = map[store.NotePermissionRole]string{
+	store.NotePermissionRole_NOTE_PERMISSION_ROLE_VIEWER:    "VIEWER",
+	store.NotePermissionRole_NOTE_PERMISSION_ROLE_COMMENTER: "COMMENTER",
+	store.NotePermissionRole_NOTE_PERMISSION_ROLE_EDITOR:    "EDITOR",
+}
+
+// parseNotePermissionRole 解析数据库中保存的角色名称，无法识别时返回 UNSPECIFIED
+func parseNotePermissionRole(name string) store.NotePermissionRole {
+	for role, roleName := range notePermissionRoleNames {
+		if roleName == name {
+			return role
+		}
+	}
+	return store.NotePermissionRole_NOTE_PERMISSION_ROLE_UNSPECIFIED
+}
+
+// UpsertNotePermission 授予用户对笔记（categoryID 为 0）或分类（noteID 为 0）的角色，已有权限时更新角色
+func (s *Store) UpsertNotePermission(ctx context.Context, userID, noteID, categoryID int64, role store.NotePermissionRole, grantedBy int64) (*store.NotePermission, error) {
+	roleName, ok := notePermissionRoleNames[role]
+	if !ok {
+		return nil, fmt.Errorf("invalid note permission role: %v", role)
+	}
+	if (noteID > 0) == (categoryID > 0) {
+		return nil, fmt.Errorf("exactly one of note and category is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	exists, err := s.rowExists(ctx, tx,
+		`SELECT COUNT(*) FROM note_permissions WHERE user_id = ? AND note_id = ? AND category_id = ?`,
+		userID, noteID, categoryID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if exists {
+		_, err = tx.ExecContext(ctx, s.rebind(
+			`UPDATE note_permissions SET role = ?, granted_by = ?, updated_at = ? WHERE user_id = ? AND note_id = ? AND category_id = ?`),
+			roleName, grantedBy, now, userID, noteID, categoryID,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, s.rebind(
+			`INSERT INTO note_permissions (user_id, note_id, category_id, role, granted_by, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+			userID, noteID, categoryID, roleName, grantedBy, now, now,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.listNotePermissions(ctx, `user_id = ? AND note_id = ? AND category_id = ?`, userID, noteID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) == 0 {
+		return nil, fmt.Errorf("note permission not found after upsert")
+	}
+	return permissions[0], nil
+}
+
+// DeleteNotePermission 撤销用户对笔记或分类的权限，返回是否存在该权限
+func (s *Store) DeleteNotePermission(ctx context.Context, userID, noteID, categoryID int64) (bool, error) {
+	return s.execNoteTransition(ctx,
+		s.rebind(`DELETE FROM note_permissions WHERE user_id = ? AND note_id = ? AND category_id = ?`),
+		userID, noteID, categoryID,
+	)
+}
+
+// ListCategoryPermissions 获取直接授予在分类上的权限
+func (s *Store) ListCategoryPermissions(ctx context.Context, categoryID int64) ([]*store.NotePermission, error) {
+	return s.listNotePermissions(ctx, `category_id = ? AND note_id = 0`, categoryID)
+}
+
+// ListNotePermissions 获取笔记的权限：直接授予在笔记上的权限，以及从所属分类及其上级分类继承的权限
+// 与 GetNotePermissionRole 一样，上级分类与上下文选择的工作区无关
+func (s *Store) ListNotePermissions(ctx context.Context, noteID, categoryID int64) ([]*store.NotePermission, error) {
+	condition := `(note_id = ? AND category_id = 0)`
+	params := []interface{}{noteID}
+	if categoryID > 0 {
+		parents, err := s.categoryParents(AllWorkspaces(ctx), s.db)
+		if err != nil {
+			return nil, err
+		}
+		ancestors := categoryAncestorIDs(parents, categoryID)
+		condition += ` OR (note_id = 0 AND category_id IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(ancestors)), ", ") + `))`
+		for _, id := range ancestors {
+			params = append(params, id)
+		}
+	}
+	return s.listNotePermissions(ctx, condition, params...)
+}
+
+// NoteAccessLevelOf 计算用户对笔记的访问级别：作者、管理员和笔记所属工作区的所有者、管理员拥有全部权限，
+// 其他用户取被授予的角色（直接授予或从分类继承），公开笔记至少可以查看；user 为 nil 表示未登录的访客
+// 其他工作区（不是默认工作区）中的公开笔记只对该工作区的成员公开，访客和非成员需要被授予角色或使用分享链接
+// 工作区角色按笔记所属的工作区计算，与上下文选择的工作区无关
+func (s *Store) NoteAccessLevelOf(ctx context.Context, note *store.Note, user *User) (NoteAccessLevel, error) {
+	level := NoteAccessNone
+	workspaceRole := store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+	if user != nil {
+		authorID, _ := strconv.ParseUint(note.AuthorId, 10, 32)
+		if user.ID == uint(authorID) || user.Role == RoleAdmin || user.Role == RoleHost {
+			return NoteAccessOwner, nil
+		}
+		var err error
+		workspaceRole, err = s.GetNoteWorkspaceRole(ctx, note.Id, int64(user.ID))
+		if err != nil {
+			return level, err
+		}
+		if workspaceRole >= store.WorkspaceRole_WORKSPACE_ROLE_ADMIN {
+			return NoteAccessOwner, nil
+		}
+	}
+
+	if note.Visibility != store.NoteVisibility_NOTE_VISIBILITY_PRIVATE {
+		public := workspaceRole != store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+		if !public {
+			var err error
+			if public, err = s.isDefaultWorkspaceNote(ctx, note.Id); err != nil {
+				return level, err
+			}
+		}
+		if public {
+			level = NoteAccessPublic
+		}
+	}
+	if user == nil {
+		return level, nil
+	}
+
+	categoryID, _ := strconv.ParseInt(note.CategoryId, 10, 64)
+	role, err := s.GetNotePermissionRole(ctx, int64(user.ID), note.Id, categoryID)
+	if err != nil {
+		return level, err
+	}
+	switch role {
+	case store.NotePermissionRole_NOTE_PERMISSION_ROLE_VIEWER:
+		level = NoteAccessViewer
+	case store.NotePermissionRole_NOTE_PERMISSION_ROLE_COMMENTER:
+		level = NoteAccessCommenter
+	case store.NotePermissionRole_NOTE_PERMISSION_ROLE_EDITOR:
+		level = NoteAccessEditor
+	}
+	return level, nil
+}
+
+// GetNotePermissionRole 返回用户对笔记的角色：直接授予的角色和从所属分类及其上级分类继承的角色中最高的一个
+// 没有任何权限时返回 UNSPECIFIED；上级分类与笔记属于同一工作区，与上下文选择的工作区无关
+func (s *Store) GetNotePermissionRole(ctx context.Context, userID, noteID, categoryID int64) (store.NotePermissionRole, error) {
+	role := store.NotePermissionRole_NOTE_PERMISSION_ROLE_UNSPECIFIED
+	grants, err := s.userNotePermissions(ctx, userID)
+	if err != nil || len(grants.notes) == 0 && len(grants.categories) == 0 {
+		return role, err
+	}
+
+	if noteRole, ok := grants.notes[noteID]; ok {
+		role = noteRole
+	}
+	if categoryID > 0 && len(grants.categories) > 0 {
+		parents, err := s.categoryParents(AllWorkspaces(ctx), s.db)
+		if err != nil {
+			return role, err
+		}
+		for _, id := range categoryAncestorIDs(parents, categoryID) {
+			if categoryRole, ok := grants.categories[id]; ok && categoryRole > role {
+				role = categoryRole
+			}
+		}
+	}
+	return role, nil
+}
+
+// notePermissionCondition 构建笔记列表的条件：用户被授予了权限（直接授予或从分类继承）的笔记
+// 用户没有任何权限时返回空字符串
+func (s *Store) notePermissionCondition(ctx context.Context, userID int64) (string, []interface{}, error) {
+	grants, err := s.userNotePermissions(ctx, userID)
+	if err != nil || len(grants.notes) == 0 && len(grants.categories) == 0 {
+		return "", nil, err
+	}
+
+	var conditions []string
+	var params []interface{}
+	if len(grants.notes) > 0 {
+		conditions = append(conditions, `p.id IN (SELECT note_id FROM note_permissions WHERE user_id = ? AND note_id > 0)`)
+		params = append(params, userID)
+	}
+	if len(grants.categories) > 0 {
+		// 分类上的权限由其所有子分类继承
+		parents, err := s.categoryParents(ctx, s.db)
+		if err != nil {
+			return "", nil, err
+		}
+		var categoryIDs []interface{}
+		for id := range parents {
+			for _, ancestorID := range categoryAncestorIDs(parents, id) {
+				if _, ok := grants.categories[ancestorID]; ok {
+					categoryIDs = append(categoryIDs, id)
+					break
+				}
+			}
+		}
+		if len(categoryIDs) > 0 {
+			conditions = append(conditions, `p.category_id IN (`+strings.TrimSuffix(strings.Repeat("?, ", len(categoryIDs)), ", ")+`)`)
+			params = append(params, categoryIDs...)
+		}
+	}
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", params, nil
+}
+
+// userNotePermissionSet 用户被授予的全部权限
+type userNotePermissionSet struct {
+	// notes 笔记ID到角色的映射
+	notes map[int64]store.NotePermissionRole
+	// categories 分类ID到角色的映射
+	categories map[int64]store.NotePermissionRole
+}
+
+// userNotePermissions 获取用户被授予的全部权限
+func (s *Store) userNotePermissions(ctx context.Context, userID int64) (*userNotePermissionSet, error) {
+	grants := &userNotePermissionSet{
+		notes:      map[int64]store.NotePermissionRole{},
+		categories: map[int64]store.NotePermissionRole{},
+	}
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT note_id, category_id, role FROM note_permissions WHERE user_id = ?`), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var noteID, categoryID int64
+		var roleName string
+		if err := rows.Scan(&noteID, &categoryID, &roleName); err != nil {
+			return nil, err
+		}
+		role := parseNotePermissionRole(roleName)
+		switch {
+		case role == store.NotePermissionRole_NOTE_PERMISSION_ROLE_UNSPECIFIED:
+		case noteID > 0:
+			grants.notes[noteID] = role
+		case categoryID > 0:
+			grants.categories[categoryID] = role
+		}
+	}
+	return grants, rows.Err()
+}
+
+// listNotePermissions 按条件获取权限，按资源和用户排序
+func (s *Store) listNotePermissions(ctx context.Context, condition string, params ...interface{}) ([]*store.NotePermission, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(
+		`SELECT `+notePermissionColumns+` FROM note_permissions WHERE `+condition+` ORDER BY note_id DESC, category_id, user_id`),
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []*store.NotePermission
+	for rows.Next() {
+		permission, err := scanNotePermission(rows)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, rows.Err()
+}
+
+// scanNotePermission 扫描一行权限，字段顺序见 notePermissionColumns
+func scanNotePermission(rows *sql.Rows) (*store.NotePermission, error) {
+	var (
+		userID     int64
+		noteID     int64
+		categoryID int64
+		roleName   string
+		grantedBy  int64
+		createdAt  time.Time
+		updatedAt  time.Time
+	)
+	if err := rows.Scan(&userID, &noteID, &categoryID, &roleName, &grantedBy, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	resource := fmt.Sprintf("notes/%d", noteID)
+	if noteID == 0 {
+		resource = fmt.Sprintf("categories/%d", categoryID)
+	}
+	return &store.NotePermission{
+		Name:      fmt.Sprintf("%s/permissions/%d", resource, userID),
+		Resource:  resource,
+		User:      fmt.Sprintf("users/%d", userID),
+		Role:      parseNotePermissionRole(roleName),
+		GrantedBy: fmt.Sprintf("users/%d", grantedBy),
+		CreatedAt: createdAt.Unix(),
+		UpdatedAt: updatedAt.Unix(),
+	}, nil
+}
+
+// categoryAncestorIDs 返回分类本身及其所有上级分类的ID，从分类本身开始
+func categoryAncestorIDs(parents map[int64]int64, categoryID int64) []int64 {
+	var ids []int64
+	seen := map[int64]bool{}
+	for id := categoryID; id > 0 && !seen[id]; id = parents[id] {
+		ids = append(ids, id)
+		seen[id] = true
+	}
+	return ids
+}
@@ -0,0 +1,159 @@
+package store_test
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestNoteAccessLevelOf(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	admin := createTestUser(t, s, "admin", store.RoleAdmin)
+	teamAdmin := createTestUser(t, s, "team-admin", store.RoleUser)
+	defaultAdmin := createTestUser(t, s, "default-admin", store.RoleUser)
+	teamMember := createTestUser(t, s, "team-member", store.RoleUser)
+	viewer := createTestUser(t, s, "viewer", store.RoleUser)
+	editor := createTestUser(t, s, "editor", store.RoleUser)
+	stranger := createTestUser(t, s, "stranger", store.RoleUser)
+
+	teamID := createTestWorkspace(t, s, "team", author)
+	teamCtx := store.WithWorkspace(ctx, teamID)
+	defaultID, err := s.DefaultWorkspaceID(ctx)
+	if err != nil {
+		t.Fatalf("DefaultWorkspaceID: %v", err)
+	}
+	setMember := func(workspaceID int64, user *store.User, role pbstore.WorkspaceRole) {
+		t.Helper()
+		if err := s.SetWorkspaceMember(ctx, workspaceID, int64(user.ID), role); err != nil {
+			t.Fatalf("SetWorkspaceMember: %v", err)
+		}
+	}
+	setMember(teamID, teamAdmin, pbstore.WorkspaceRole_WORKSPACE_ROLE_ADMIN)
+	setMember(teamID, teamMember, pbstore.WorkspaceRole_WORKSPACE_ROLE_MEMBER)
+	setMember(defaultID, defaultAdmin, pbstore.WorkspaceRole_WORKSPACE_ROLE_ADMIN)
+
+	parent, err := s.CreateCategory(teamCtx, &pbstore.Category{NameText: "parent"})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	child, err := s.CreateCategory(teamCtx, &pbstore.Category{NameText: "child", ParentId: parent.Id})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	private := createTestNote(t, teamCtx, s, &pbstore.Note{
+		Title:      "private",
+		AuthorId:   userID(author),
+		CategoryId: strconv.FormatInt(child.Id, 10),
+		Visibility: pbstore.NoteVisibility_NOTE_VISIBILITY_PRIVATE,
+	})
+	public := createTestNote(t, teamCtx, s, &pbstore.Note{Title: "public", AuthorId: userID(author)})
+	defaultPublic := createTestNote(t, ctx, s, &pbstore.Note{Title: "default public", AuthorId: userID(author)})
+
+	grant := func(user *store.User, noteID, categoryID int64, role pbstore.NotePermissionRole) {
+		t.Helper()
+		if _, err := s.UpsertNotePermission(ctx, int64(user.ID), noteID, categoryID, role, int64(author.ID)); err != nil {
+			t.Fatalf("UpsertNotePermission: %v", err)
+		}
+	}
+	// 分类上的权限由子分类中的笔记继承
+	grant(viewer, 0, parent.Id, pbstore.NotePermissionRole_NOTE_PERMISSION_ROLE_VIEWER)
+	// 直接授予和继承的角色取最高的一个
+	grant(editor, 0, parent.Id, pbstore.NotePermissionRole_NOTE_PERMISSION_ROLE_COMMENTER)
+	grant(editor, private.Id, 0, pbstore.NotePermissionRole_NOTE_PERMISSION_ROLE_VIEWER)
+	grant(editor, 0, child.Id, pbstore.NotePermissionRole_NOTE_PERMISSION_ROLE_EDITOR)
+
+	tests := []struct {
+		name string
+		note *pbstore.Note
+		user *store.User
+		want store.NoteAccessLevel
+	}{
+		{name: "guest on private note", note: private, want: store.NoteAccessNone},
+		{name: "guest on public note in the default workspace", note: defaultPublic, want: store.NoteAccessPublic},
+		// 其他工作区中的公开笔记只对成员公开
+		{name: "guest on public note in another workspace", note: public, want: store.NoteAccessNone},
+		{name: "non-member on public note in another workspace", note: public, user: stranger, want: store.NoteAccessNone},
+		{name: "non-member on public note in the default workspace", note: defaultPublic, user: stranger, want: store.NoteAccessPublic},
+		{name: "author", note: private, user: author, want: store.NoteAccessOwner},
+		{name: "instance admin", note: private, user: admin, want: store.NoteAccessOwner},
+		{name: "admin of the note's workspace", note: private, user: teamAdmin, want: store.NoteAccessOwner},
+		// 工作区角色按笔记所属的工作区计算
+		{name: "admin of another workspace", note: private, user: defaultAdmin, want: store.NoteAccessNone},
+		{name: "member of the note's workspace", note: private, user: teamMember, want: store.NoteAccessNone},
+		{name: "member on public note", note: public, user: teamMember, want: store.NoteAccessPublic},
+		{name: "inherited viewer", note: private, user: viewer, want: store.NoteAccessViewer},
+		{name: "non-member with grants on other notes", note: public, user: viewer, want: store.NoteAccessNone},
+		{name: "highest of direct and inherited", note: private, user: editor, want: store.NoteAccessEditor},
+		{name: "stranger", note: private, user: stranger, want: store.NoteAccessNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 访问级别与上下文选择的工作区无关
+			for _, ctx := range []context.Context{ctx, teamCtx, store.AllWorkspaces(ctx)} {
+				got, err := s.NoteAccessLevelOf(ctx, tt.note, tt.user)
+				if err != nil {
+					t.Fatalf("NoteAccessLevelOf: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("NoteAccessLevelOf = %d, want %d", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestListNotesVisibleToUser(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	viewer := createTestUser(t, s, "viewer", store.RoleUser)
+
+	category, err := s.CreateCategory(ctx, &pbstore.Category{NameText: "shared"})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	privateNote := func(title, categoryID string) {
+		createTestNote(t, ctx, s, &pbstore.Note{
+			Title:      title,
+			AuthorId:   userID(author),
+			CategoryId: categoryID,
+			Visibility: pbstore.NoteVisibility_NOTE_VISIBILITY_PRIVATE,
+		})
+	}
+	createTestNote(t, ctx, s, &pbstore.Note{Title: "public", AuthorId: userID(author)})
+	privateNote("shared", strconv.FormatInt(category.Id, 10))
+	privateNote("hidden", "")
+	if _, err := s.UpsertNotePermission(ctx, int64(viewer.ID), 0, category.Id, pbstore.NotePermissionRole_NOTE_PERMISSION_ROLE_VIEWER, int64(author.ID)); err != nil {
+		t.Fatalf("UpsertNotePermission: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		user *store.User
+		want []string
+	}{
+		{name: "author", user: author, want: []string{"public", "shared", "hidden"}},
+		{name: "viewer", user: viewer, want: []string{"public", "shared"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, _, err := s.ListNotes(ctx, &store.ListNotesRequest{
+				PageSize:        10,
+				SortBy:          "id asc",
+				VisibleToUserID: userID(tt.user),
+			})
+			if err != nil {
+				t.Fatalf("ListNotes: %v", err)
+			}
+			if got := noteTitles(notes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListNotes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// 定时发布的笔记保存为 published = false、scheduled = true，到达 published_at 时由定时任务发布；
+// 设置了 expire_at 的已发布笔记到达过期时间后由定时任务取消发布。
+// 状态切换使用带条件的 UPDATE，多个实例同时执行时只有一个实例会切换成功，避免重复触发事件
+
+// notePublishState 根据请求的发布状态和发布时间计算笔记保存的 published 和 scheduled
+// 请求发布（Published 或 Scheduled 为 true）且发布时间在未来时等待定时发布
+func notePublishState(note *store.Note, publishedAt, now time.Time) (published, scheduled bool) {
+	if !note.Published && !note.Scheduled {
+		return false, false
+	}
+	if publishedAt.After(now) {
+		return false, true
+	}
+	return true, false
+}
+
+// noteExpireAt 返回笔记的过期时间，未设置时为 NULL
+func noteExpireAt(note *store.Note) sql.NullTime {
+	if note.ExpireAt <= 0 {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: time.Unix(note.ExpireAt, 0), Valid: true}
+}
+
+// PublishDueNotes 发布到达发布时间的定时笔记，返回本次由当前调用发布的笔记ID
+func (s *Store) PublishDueNotes(ctx context.Context, now time.Time) ([]int64, error) {
+	due, err := s.dueNoteIDs(ctx, s.rebind(`SELECT id FROM notes WHERE scheduled = ? AND published_at <= ? ORDER BY published_at, id`), now)
+	if err != nil {
+		return nil, err
+	}
+
+	var published []int64
+	for _, id := range due {
+		// 再次检查条件，其他实例已经发布或笔记被修改时不会更新
+		ok, err := s.execNoteTransition(ctx,
+			s.rebind(`UPDATE notes SET published = ?, scheduled = ?, updated_at = ? WHERE id = ? AND scheduled = ? AND published_at <= ?`),
+			true, false, now, id, true, timeParam(s.profile.Driver, now),
+		)
+		if err != nil {
+			return published, err
+		}
+		if ok {
+			published = append(published, id)
+		}
+	}
+	return published, nil
+}
+
+// ExpireDueNotes 取消发布到达过期时间的笔记，返回本次由当前调用取消发布的笔记ID
+func (s *Store) ExpireDueNotes(ctx context.Context, now time.Time) ([]int64, error) {
+	due, err := s.dueNoteIDs(ctx, s.rebind(`SELECT id FROM notes WHERE published = ? AND expire_at IS NOT NULL AND expire_at <= ? ORDER BY expire_at, id`), now)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []int64
+	for _, id := range due {
+		ok, err := s.execNoteTransition(ctx,
+			s.rebind(`UPDATE notes SET published = ?, updated_at = ? WHERE id = ? AND published = ? AND expire_at IS NOT NULL AND expire_at <= ?`),
+			false, now, id, true, timeParam(s.profile.Driver, now),
+		)
+		if err != nil {
+			return expired, err
+		}
+		if ok {
+			expired = append(expired, id)
+		}
+	}
+	return expired, nil
+}
+
+// NextNoteScheduleTime 返回下一个待执行的定时发布或过期时间，没有时返回零值
+func (s *Store) NextNoteScheduleTime(ctx context.Context) (time.Time, error) {
+	var next time.Time
+	queries := []string{
+		s.rebind(`SELECT published_at FROM notes WHERE scheduled = ? ORDER BY published_at LIMIT 1`),
+		s.rebind(`SELECT expire_at FROM notes WHERE published = ? AND expire_at IS NOT NULL ORDER BY expire_at LIMIT 1`),
+	}
+	for _, query := range queries {
+		var t sql.NullTime
+		err := s.db.QueryRowContext(ctx, query, true).Scan(&t)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t.Valid && (next.IsZero() || t.Time.Before(next)) {
+			next = t.Time
+		}
+	}
+	return next, nil
+}
+
+// dueNoteIDs 查询到期的笔记ID，query 需已经过 rebind，参数为 true 和当前时间
+func (s *Store) dueNoteIDs(ctx context.Context, query string, now time.Time) ([]int64, error) {
+	rows, err := s.db.QueryContext(ctx, query, true, timeParam(s.profile.Driver, now))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// execNoteTransition 执行带条件的状态切换（query 需已经过 rebind），返回是否有笔记被更新
+func (s *Store) execNoteTransition(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
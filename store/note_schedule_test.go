@@ -0,0 +1,152 @@
+package store_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestNoteScheduleTransitions(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+
+	now := time.Now()
+	publishAt := now.Add(time.Hour)
+	expireAt := now.Add(2 * time.Hour)
+	scheduled, err := s.CreateNote(ctx, &pbstore.Note{
+		Title:       "scheduled",
+		AuthorId:    userID(author),
+		Published:   true,
+		PublishedAt: publishAt.Unix(),
+	})
+	if err != nil {
+		t.Fatalf("CreateNote: %v", err)
+	}
+	if scheduled.Published || !scheduled.Scheduled {
+		t.Fatalf("CreateNote published = %v, scheduled = %v, want a scheduled note", scheduled.Published, scheduled.Scheduled)
+	}
+	expiring := createTestNote(t, ctx, s, &pbstore.Note{Title: "expiring", AuthorId: userID(author), ExpireAt: expireAt.Unix()})
+
+	next, err := s.NextNoteScheduleTime(ctx)
+	if err != nil {
+		t.Fatalf("NextNoteScheduleTime: %v", err)
+	}
+	if next.Unix() != publishAt.Unix() {
+		t.Errorf("NextNoteScheduleTime = %v, want %v", next, publishAt)
+	}
+
+	// 未到期时不切换
+	if ids, err := s.PublishDueNotes(ctx, now); err != nil || len(ids) != 0 {
+		t.Errorf("PublishDueNotes before due = %v (%v), want none", ids, err)
+	}
+	if ids, err := s.ExpireDueNotes(ctx, now); err != nil || len(ids) != 0 {
+		t.Errorf("ExpireDueNotes before due = %v (%v), want none", ids, err)
+	}
+
+	ids, err := s.PublishDueNotes(ctx, publishAt.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("PublishDueNotes: %v", err)
+	}
+	if want := []int64{scheduled.Id}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("PublishDueNotes = %v, want %v", ids, want)
+	}
+	got, err := s.GetNote(ctx, scheduled.Id)
+	if err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	if !got.Published || got.Scheduled {
+		t.Errorf("published note published = %v, scheduled = %v, want published only", got.Published, got.Scheduled)
+	}
+	// 已经发布的笔记不会再次触发
+	if ids, err := s.PublishDueNotes(ctx, publishAt.Add(time.Minute)); err != nil || len(ids) != 0 {
+		t.Errorf("second PublishDueNotes = %v (%v), want none", ids, err)
+	}
+
+	next, err = s.NextNoteScheduleTime(ctx)
+	if err != nil {
+		t.Fatalf("NextNoteScheduleTime: %v", err)
+	}
+	if next.Unix() != expireAt.Unix() {
+		t.Errorf("NextNoteScheduleTime = %v, want %v", next, expireAt)
+	}
+
+	ids, err = s.ExpireDueNotes(ctx, expireAt.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ExpireDueNotes: %v", err)
+	}
+	if want := []int64{expiring.Id}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ExpireDueNotes = %v, want %v", ids, want)
+	}
+	if got, err := s.GetNote(ctx, expiring.Id); err != nil || got.Published {
+		t.Errorf("expired note = %v (%v), want unpublished", got, err)
+	}
+	if ids, err := s.ExpireDueNotes(ctx, expireAt.Add(time.Minute)); err != nil || len(ids) != 0 {
+		t.Errorf("second ExpireDueNotes = %v (%v), want none", ids, err)
+	}
+
+	if next, err := s.NextNoteScheduleTime(ctx); err != nil || !next.IsZero() {
+		t.Errorf("NextNoteScheduleTime = %v (%v), want zero", next, err)
+	}
+}
+
+func TestPublishDueNotesFiresOnce(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+
+	now := time.Now()
+	want := map[int64]bool{}
+	for i := 0; i < 50; i++ {
+		note, err := s.CreateNote(ctx, &pbstore.Note{
+			Title:       "scheduled",
+			AuthorId:    userID(author),
+			Published:   true,
+			PublishedAt: now.Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			t.Fatalf("CreateNote: %v", err)
+		}
+		want[note.Id] = true
+	}
+
+	// 多个实例同时执行时，带条件的更新保证每篇笔记只由一个实例发布
+	const instances = 4
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make([][]int64, instances)
+	errs := make([]error, instances)
+	for i := 0; i < instances; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = s.PublishDueNotes(ctx, now.Add(2*time.Hour))
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	got := map[int64]int{}
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("PublishDueNotes: %v", errs[i])
+		}
+		for _, id := range results[i] {
+			got[id]++
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("published %v, want %v", got, want)
+	}
+	for id, count := range got {
+		if !want[id] || count != 1 {
+			t.Errorf("note %d published %d times, want once", id, count)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+)
+
+// 分享链接让没有权限的访问者通过令牌查看私密或未发布的笔记。
+// 数据库中只保存令牌的 SHA-256 哈希，令牌本身只在创建时返回给作者；
+// 每次通过令牌打开笔记都会在一条带条件的 UPDATE 中检查并增加查看次数，并发访问不会超过 max_views。
+
+// noteShareLinkColumns 分享链接表的查询字段，顺序与 scanNoteShareLink 保持一致
+const noteShareLinkColumns = `id, created_at, note_id, creator_id, token_hash, password_hash, expire_at, max_views, view_count, revoked`
+
+// NoteShareLink 笔记分享链接
+type NoteShareLink struct {
+	// ID 分享链接ID
+	ID int64
+	// CreatedAt 创建时间
+	CreatedAt time.Time
+	// NoteID 分享的笔记ID
+	NoteID int64
+	// CreatorID 创建者ID
+	CreatorID int64
+	// TokenHash 分享令牌的哈希，见 HashShareToken
+	TokenHash string
+	// PasswordHash 访问密码的 bcrypt 哈希，为空表示不需要密码
+	PasswordHash string
+	// ExpireAt 过期时间，零值表示永不过期
+	ExpireAt time.Time
+	// MaxViews 最多查看次数，0 表示不限制
+	MaxViews int32
+	// ViewCount 已查看次数
+	ViewCount int32
+	// Revoked 是否已撤销
+	Revoked bool
+}
+
+// Active 判断分享链接在 now 时是否仍然有效（未撤销且未过期），不检查查看次数
+func (l *NoteShareLink) Active(now time.Time) bool {
+	return !l.Revoked && (l.ExpireAt.IsZero() || now.Before(l.ExpireAt))
+}
+
+// Exhausted 判断分享链接的查看次数是否已经用完
+func (l *NoteShareLink) Exhausted() bool {
+	return l.MaxViews > 0 && l.ViewCount >= l.MaxViews
+}
+
+// HashShareToken 计算分享令牌的哈希，用于保存和查找分享链接
+func HashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateNoteShareLink 创建分享链接
+func (s *Store) CreateNoteShareLink(ctx context.Context, link *NoteShareLink) (*NoteShareLink, error) {
+	var passwordHash sql.NullString
+	if link.PasswordHash != "" {
+		passwordHash = sql.NullString{String: link.PasswordHash, Valid: true}
+	}
+	var expireAt sql.NullTime
+	if !link.ExpireAt.IsZero() {
+		expireAt = sql.NullTime{Time: link.ExpireAt, Valid: true}
+	}
+	result, err := s.db.ExecContext(ctx,
+		s.rebind(`INSERT INTO note_share_links (created_at, note_id, creator_id, token_hash, password_hash, expire_at, max_views, view_count, revoked)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		time.Now(), link.NoteID, link.CreatorID, link.TokenHash, passwordHash, expireAt, link.MaxViews, 0, false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return s.GetNoteShareLink(ctx, id)
+}
+
+// GetNoteShareLink 根据ID获取分享链接，不存在时返回 nil
+func (s *Store) GetNoteShareLink(ctx context.Context, id int64) (*NoteShareLink, error) {
+	return s.getNoteShareLink(ctx, `id = ?`, id)
+}
+
+// GetNoteShareLinkByTokenHash 根据令牌哈希获取分享链接，不存在时返回 nil
+func (s *Store) GetNoteShareLinkByTokenHash(ctx context.Context, tokenHash string) (*NoteShareLink, error) {
+	return s.getNoteShareLink(ctx, `token_hash = ?`, tokenHash)
+}
+
+// getNoteShareLink 按条件获取一条分享链接，不存在时返回 nil
+func (s *Store) getNoteShareLink(ctx context.Context, condition string, arg interface{}) (*NoteShareLink, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT `+noteShareLinkColumns+` FROM note_share_links WHERE `+condition), arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	return scanNoteShareLink(rows)
+}
+
+// ListNoteShareLinks 获取笔记的分享链接，按创建时间倒序
+func (s *Store) ListNoteShareLinks(ctx context.Context, noteID int64) ([]*NoteShareLink, error) {
+	rows, err := s.db.QueryContext(ctx,
+		s.rebind(`SELECT `+noteShareLinkColumns+` FROM note_share_links WHERE note_id = ? ORDER BY created_at DESC, id DESC`), noteID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*NoteShareLink
+	for rows.Next() {
+		link, err := scanNoteShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// RevokeNoteShareLink 撤销分享链接
+func (s *Store) RevokeNoteShareLink(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(`UPDATE note_share_links SET revoked = ? WHERE id = ?`), true, id)
+	return err
+}
+
+// ConsumeNoteShareLinkView 在分享链接仍然有效且查看次数未用完时将查看次数加一
+// 返回 false 表示链接已撤销、已过期或查看次数已用完
+func (s *Store) ConsumeNoteShareLinkView(ctx context.Context, id int64, now time.Time) (bool, error) {
+	return s.execNoteTransition(ctx,
+		s.rebind(`UPDATE note_share_links SET view_count = view_count + 1
+		WHERE id = ? AND revoked = ? AND (max_views = 0 OR view_count < max_views) AND (expire_at IS NULL OR expire_at > ?)`),
+		id, false, timeParam(s.profile.Driver, now),
+	)
+}
+
+// scanNoteShareLink 扫描一行分享链接，字段顺序见 noteShareLinkColumns
+func scanNoteShareLink(rows *sql.Rows) (*NoteShareLink, error) {
+	var (
+		link         NoteShareLink
+		passwordHash sql.NullString
+		expireAt     sql.NullTime
+		maxViews     sql.NullInt64
+		viewCount    sql.NullInt64
+		revoked      sql.NullBool
+	)
+	if err := rows.Scan(&link.ID, &link.CreatedAt, &link.NoteID, &link.CreatorID, &link.TokenHash,
+		&passwordHash, &expireAt, &maxViews, &viewCount, &revoked); err != nil {
+		return nil, err
+	}
+	link.PasswordHash = passwordHash.String
+	if expireAt.Valid {
+		link.ExpireAt = expireAt.Time
+	}
+	link.MaxViews = int32(maxViews.Int64)
+	link.ViewCount = int32(viewCount.Int64)
+	link.Revoked = revoked.Bool
+	return &link, nil
+}
@@ -0,0 +1,158 @@
+package store_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestConsumeNoteShareLinkView(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+	note := createTestNote(t, ctx, s, &pbstore.Note{Title: "shared", AuthorId: userID(author)})
+	now := time.Now()
+
+	createLink := func(token string, expireAt time.Time, maxViews int32) *store.NoteShareLink {
+		t.Helper()
+		link, err := s.CreateNoteShareLink(ctx, &store.NoteShareLink{
+			NoteID:    note.Id,
+			CreatorID: int64(author.ID),
+			TokenHash: store.HashShareToken(token),
+			ExpireAt:  expireAt,
+			MaxViews:  maxViews,
+		})
+		if err != nil {
+			t.Fatalf("CreateNoteShareLink: %v", err)
+		}
+		return link
+	}
+	consume := func(link *store.NoteShareLink, at time.Time) bool {
+		t.Helper()
+		ok, err := s.ConsumeNoteShareLinkView(ctx, link.ID, at)
+		if err != nil {
+			t.Fatalf("ConsumeNoteShareLinkView: %v", err)
+		}
+		return ok
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		link := createLink("unlimited", time.Time{}, 0)
+		for i := 0; i < 5; i++ {
+			if !consume(link, now) {
+				t.Fatalf("view %d rejected, want accepted", i+1)
+			}
+		}
+		got, err := s.GetNoteShareLinkByTokenHash(ctx, store.HashShareToken("unlimited"))
+		if err != nil || got == nil {
+			t.Fatalf("GetNoteShareLinkByTokenHash = %v, %v", got, err)
+		}
+		if got.ID != link.ID || got.ViewCount != 5 {
+			t.Errorf("link %d has %d views, want link %d with 5 views", got.ID, got.ViewCount, link.ID)
+		}
+	})
+
+	t.Run("max views", func(t *testing.T) {
+		link := createLink("max-views", time.Time{}, 2)
+		if !consume(link, now) || !consume(link, now) {
+			t.Fatalf("views within the limit rejected, want accepted")
+		}
+		if consume(link, now) {
+			t.Errorf("view after the limit accepted, want rejected")
+		}
+		got, err := s.GetNoteShareLink(ctx, link.ID)
+		if err != nil {
+			t.Fatalf("GetNoteShareLink: %v", err)
+		}
+		if got.ViewCount != 2 || !got.Exhausted() {
+			t.Errorf("link has %d views (exhausted %v), want 2 views and exhausted", got.ViewCount, got.Exhausted())
+		}
+	})
+
+	t.Run("concurrent views", func(t *testing.T) {
+		// 并发访问时查看次数不会超过 max_views
+		link := createLink("concurrent", time.Time{}, 3)
+		var (
+			wg       sync.WaitGroup
+			accepted int32
+			start    = make(chan struct{})
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				ok, err := s.ConsumeNoteShareLinkView(ctx, link.ID, now)
+				if err != nil {
+					t.Errorf("ConsumeNoteShareLinkView: %v", err)
+				}
+				if ok {
+					atomic.AddInt32(&accepted, 1)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+		if accepted != 3 {
+			t.Errorf("%d concurrent views accepted, want 3", accepted)
+		}
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		// 过期时间以其他时区给出时同样按时刻比较
+		expireAt := now.Add(time.Hour).In(time.FixedZone("CST", 8*3600))
+		link := createLink("expiry", expireAt, 0)
+		if !consume(link, now) {
+			t.Errorf("view before expiry rejected, want accepted")
+		}
+		if consume(link, expireAt) || consume(link, now.Add(2*time.Hour)) {
+			t.Errorf("view after expiry accepted, want rejected")
+		}
+		got, err := s.GetNoteShareLink(ctx, link.ID)
+		if err != nil {
+			t.Fatalf("GetNoteShareLink: %v", err)
+		}
+		if d := got.ExpireAt.Sub(expireAt); d < -time.Second || d > time.Second {
+			t.Errorf("ExpireAt = %v, want %v", got.ExpireAt, expireAt)
+		}
+		if !got.Active(now) || got.Active(expireAt) {
+			t.Errorf("Active = %v before and %v at expiry, want true and false", got.Active(now), got.Active(expireAt))
+		}
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		link := createLink("revoked", time.Time{}, 0)
+		if !consume(link, now) {
+			t.Fatalf("view before revocation rejected, want accepted")
+		}
+		if err := s.RevokeNoteShareLink(ctx, link.ID); err != nil {
+			t.Fatalf("RevokeNoteShareLink: %v", err)
+		}
+		if consume(link, now) {
+			t.Errorf("view after revocation accepted, want rejected")
+		}
+		got, err := s.GetNoteShareLink(ctx, link.ID)
+		if err != nil {
+			t.Fatalf("GetNoteShareLink: %v", err)
+		}
+		if !got.Revoked || got.Active(now) || got.ViewCount != 1 {
+			t.Errorf("link revoked %v, active %v, %d views; want revoked, inactive, 1 view", got.Revoked, got.Active(now), got.ViewCount)
+		}
+	})
+
+	if ok, err := s.ConsumeNoteShareLinkView(ctx, 12345, now); err != nil || ok {
+		t.Errorf("ConsumeNoteShareLinkView for a missing link = %v, %v; want false", ok, err)
+	}
+	links, err := s.ListNoteShareLinks(ctx, note.Id)
+	if err != nil {
+		t.Fatalf("ListNoteShareLinks: %v", err)
+	}
+	if len(links) != 5 {
+		t.Errorf("ListNoteShareLinks returned %d links, want 5", len(links))
+	}
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// note_tags 是笔记标签关系的唯一数据来源，tags.count 在修改关系的同一事务中根据 note_tags 重新计算
+
+// loadNoteTagIDs 从 note_tags 加载笔记的标签ID（按标签ID排序）
+func (s *Store) loadNoteTagIDs(ctx context.Context, q Queryer, notes []*store.Note) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*store.Note, len(notes))
+	placeholders := make([]string, 0, len(notes))
+	params := make([]interface{}, 0, len(notes))
+	for _, note := range notes {
+		note.TagIds = []string{}
+		if _, ok := byID[note.Id]; ok {
+			continue
+		}
+		byID[note.Id] = note
+		placeholders = append(placeholders, "?")
+		params = append(params, note.Id)
+	}
+
+	query := `SELECT note_id, tag_id FROM note_tags WHERE note_id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY note_id, tag_id`
+	rows, err := q.QueryContext(ctx, s.rebind(query), params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var noteID, tagID int64
+		if err := rows.Scan(&noteID, &tagID); err != nil {
+			return err
+		}
+		note := byID[noteID]
+		note.TagIds = append(note.TagIds, strconv.FormatInt(tagID, 10))
+	}
+	return rows.Err()
+}
+
+// setNoteTags 将笔记的标签设置为 tagIDs（完整列表，为空时移除全部标签），并重新计算受影响标签的计数
+// 需要在修改笔记的事务中调用
+func (s *Store) setNoteTags(ctx context.Context, tx *sql.Tx, noteID int64, tagIDs []string) error {
+	// 解析并去重新的标签ID
+	wanted := make(map[int64]bool, len(tagIDs))
+	for _, tagIDStr := range tagIDs {
+		tagID, err := strconv.ParseInt(strings.TrimSpace(tagIDStr), 10, 64)
+		if err != nil || tagID <= 0 {
+			return fmt.Errorf("invalid tag id: %q", tagIDStr)
+		}
+		wanted[tagID] = true
+	}
+
+	// 加载现有标签
+	existing := make(map[int64]bool)
+	rows, err := tx.QueryContext(ctx, s.rebind(`SELECT tag_id FROM note_tags WHERE note_id = ?`), noteID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var tagID int64
+		if err := rows.Scan(&tagID); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[tagID] = true
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	var changed []int64
+	// 删除不再使用的标签
+	for tagID := range existing {
+		if wanted[tagID] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM note_tags WHERE note_id = ? AND tag_id = ?"), noteID, tagID); err != nil {
+			return err
+		}
+		changed = append(changed, tagID)
+	}
+	// 添加新标签，标签必须存在且与笔记属于同一工作区
+	for tagID := range wanted {
+		if existing[tagID] {
+			continue
+		}
+		var exists int
+		if err := tx.QueryRowContext(ctx,
+			s.rebind("SELECT COUNT(*) FROM tags WHERE id = ? AND workspace_id = (SELECT workspace_id FROM notes WHERE id = ?)"),
+			tagID, noteID,
+		).Scan(&exists); err != nil {
+			return err
+		}
+		if exists == 0 {
+			return fmt.Errorf("tag not found: %d", tagID)
+		}
+		if _, err := tx.ExecContext(ctx, s.rebind("INSERT INTO note_tags (note_id, tag_id) VALUES (?, ?)"), noteID, tagID); err != nil {
+			return err
+		}
+		changed = append(changed, tagID)
+	}
+
+	return s.recountTags(ctx, tx, changed)
+}
+
+// recountTags 根据 note_tags 重新计算指定标签的使用次数
+func (s *Store) recountTags(ctx context.Context, tx *sql.Tx, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	sort.Slice(tagIDs, func(i, j int) bool { return tagIDs[i] < tagIDs[j] })
+
+	placeholders := make([]string, len(tagIDs))
+	params := make([]interface{}, len(tagIDs))
+	for i, tagID := range tagIDs {
+		placeholders[i] = "?"
+		params[i] = tagID
+	}
+	query := `UPDATE tags SET count = (SELECT COUNT(*) FROM note_tags WHERE note_tags.tag_id = tags.id) WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+	_, err := tx.ExecContext(ctx, s.rebind(query), params...)
+	return err
+}
+
+// RecountTagsResult 标签计数修复结果
+type RecountTagsResult struct {
+	// UpdatedTags 计数被修正的标签数量
+	UpdatedTags int64
+	// RemovedOrphans 删除的无效关联数量（笔记或标签已不存在）
+	RemovedOrphans int64
+}
+
+// RecountTags 修复上下文所选工作区的标签计数：删除指向不存在的笔记或标签的 note_tags 记录，
+// 并根据 note_tags 重新计算工作区中全部标签的使用次数
+func (s *Store) RecountTags(ctx context.Context) (*RecountTagsResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 无效关联只剩一端，按仍然存在的笔记或标签所属的工作区清理
+	deleteQuery := `DELETE FROM note_tags WHERE note_id NOT IN (SELECT id FROM notes) OR tag_id NOT IN (SELECT id FROM tags)`
+	var deleteParams []interface{}
+	if condition, params := workspaceCondition(ctx, "workspace_id"); condition != "" {
+		deleteQuery = `DELETE FROM note_tags WHERE
+			(note_id NOT IN (SELECT id FROM notes) AND tag_id IN (SELECT id FROM tags WHERE ` + condition + `))
+			OR (tag_id NOT IN (SELECT id FROM tags) AND note_id IN (SELECT id FROM notes WHERE ` + condition + `))`
+		deleteParams = append(append(deleteParams, params...), params...)
+	}
+	result := &RecountTagsResult{}
+	deleted, err := tx.ExecContext(ctx, s.rebind(deleteQuery), deleteParams...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove orphaned note tags: %w", err)
+	}
+	if result.RemovedOrphans, err = deleted.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	// 先统计计数不正确的标签（MySQL 的 RowsAffected 不包含值未变化的行，各数据库行为不一致）
+	const actual = `(SELECT COUNT(*) FROM note_tags WHERE note_tags.tag_id = tags.id)`
+	query, params := andWorkspace(ctx, `SELECT COUNT(*) FROM tags WHERE (count IS NULL OR count <> `+actual+`)`, "workspace_id")
+	if err := tx.QueryRowContext(ctx, s.rebind(query), params...).Scan(&result.UpdatedTags); err != nil {
+		return nil, err
+	}
+	query, params = andWorkspace(ctx, `UPDATE tags SET count = `+actual+` WHERE (count IS NULL OR count <> `+actual+`)`, "workspace_id")
+	if _, err := tx.ExecContext(ctx, s.rebind(query), params...); err != nil {
+		return nil, fmt.Errorf("failed to recount tags: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
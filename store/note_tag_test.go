@@ -0,0 +1,130 @@
+package store_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestNoteTagsKeepCounts(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	author := createTestUser(t, s, "author", store.RoleUser)
+
+	createTag := func(name string) string {
+		t.Helper()
+		tag, err := s.CreateTag(ctx, &pbstore.Tag{NameText: name})
+		if err != nil {
+			t.Fatalf("CreateTag: %v", err)
+		}
+		return strconv.FormatInt(tag.Id, 10)
+	}
+	golang := createTag("go")
+	sqlTag := createTag("sql")
+	assertCount := func(tagID string, want int32) {
+		t.Helper()
+		id, _ := strconv.ParseInt(tagID, 10, 64)
+		tag, err := s.GetTag(ctx, id)
+		if err != nil {
+			t.Fatalf("GetTag: %v", err)
+		}
+		if tag.Count != want {
+			t.Errorf("tag %s count = %d, want %d", tag.NameText, tag.Count, want)
+		}
+	}
+
+	first := createTestNote(t, ctx, s, &pbstore.Note{Title: "first", AuthorId: userID(author), TagIds: []string{golang, sqlTag}})
+	second := createTestNote(t, ctx, s, &pbstore.Note{Title: "second", AuthorId: userID(author), TagIds: []string{golang}})
+	assertCount(golang, 2)
+	assertCount(sqlTag, 1)
+
+	got, err := s.GetNote(ctx, first.Id)
+	if err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	tagIDs := append([]string(nil), got.TagIds...)
+	sort.Strings(tagIDs)
+	if want := []string{golang, sqlTag}; !reflect.DeepEqual(tagIDs, want) {
+		t.Errorf("GetNote tag ids = %v, want %v", tagIDs, want)
+	}
+
+	first.TagIds = []string{sqlTag}
+	if _, err := s.UpdateNote(ctx, first); err != nil {
+		t.Fatalf("UpdateNote: %v", err)
+	}
+	assertCount(golang, 1)
+	assertCount(sqlTag, 1)
+
+	if err := s.DeleteNote(ctx, second.Id); err != nil {
+		t.Fatalf("DeleteNote: %v", err)
+	}
+	assertCount(golang, 0)
+
+	// 手动破坏计数后由 RecountTags 修复
+	if _, err := s.GetDB().ExecContext(ctx, `UPDATE tags SET count = 5`); err != nil {
+		t.Fatalf("failed to corrupt tag counts: %v", err)
+	}
+	result, err := s.RecountTags(ctx)
+	if err != nil {
+		t.Fatalf("RecountTags: %v", err)
+	}
+	if result.UpdatedTags != 2 {
+		t.Errorf("RecountTags updated %d tags, want 2", result.UpdatedTags)
+	}
+	assertCount(golang, 0)
+	assertCount(sqlTag, 1)
+}
+
+func TestRecountTagsStaysInWorkspace(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s, "owner", store.RoleUser)
+	teamCtx := store.WithWorkspace(ctx, createTestWorkspace(t, s, "team", owner))
+
+	defaultTag, err := s.CreateTag(ctx, &pbstore.Tag{NameText: "go"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	teamTag, err := s.CreateTag(teamCtx, &pbstore.Tag{NameText: "go"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	note := createTestNote(t, teamCtx, s, &pbstore.Note{Title: "team", AuthorId: userID(owner), TagIds: []string{strconv.FormatInt(teamTag.Id, 10)}})
+
+	db := s.GetDB()
+	if _, err := db.ExecContext(ctx, `UPDATE tags SET count = 5`); err != nil {
+		t.Fatalf("failed to corrupt tag counts: %v", err)
+	}
+	// 指向已删除笔记的关联：一个属于默认工作区的标签，一个属于 team 工作区的标签
+	for _, tagID := range []int64{defaultTag.Id, teamTag.Id} {
+		if _, err := db.ExecContext(ctx, `INSERT INTO note_tags (note_id, tag_id) VALUES (?, ?)`, 999, tagID); err != nil {
+			t.Fatalf("failed to insert orphaned note tag: %v", err)
+		}
+	}
+
+	result, err := s.RecountTags(teamCtx)
+	if err != nil {
+		t.Fatalf("RecountTags: %v", err)
+	}
+	if result.UpdatedTags != 1 || result.RemovedOrphans != 1 {
+		t.Errorf("RecountTags = %+v, want 1 updated tag and 1 removed orphan", result)
+	}
+	if got, err := s.GetTag(teamCtx, teamTag.Id); err != nil || got.Count != 1 {
+		t.Errorf("team tag = %v (%v), want count 1", got, err)
+	}
+	if got, err := s.GetTag(ctx, defaultTag.Id); err != nil || got.Count != 5 {
+		t.Errorf("default tag = %v (%v), want the untouched count 5", got, err)
+	}
+	if got, err := s.GetNote(teamCtx, note.Id); err != nil || len(got.TagIds) != 1 {
+		t.Errorf("GetNote = %v (%v), want the note to keep its tag", got, err)
+	}
+
+	if result, err := s.RecountTags(ctx); err != nil || result.UpdatedTags != 1 || result.RemovedOrphans != 1 {
+		t.Errorf("RecountTags(default) = %+v (%v), want 1 updated tag and 1 removed orphan", result, err)
+	}
+}
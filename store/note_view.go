@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// NoteViewDateLayout 每日浏览量统计的日期格式
+const NoteViewDateLayout = "2006-01-02"
+
+// NoteViewKey 浏览量累加的键：笔记ID + 日期
+type NoteViewKey struct {
+	// NoteID 笔记ID
+	NoteID int64
+	// Date 日期，格式：YYYY-MM-DD（UTC）
+	Date string
+}
+
+// NoteViewCount 某笔记某一天的浏览量
+type NoteViewCount struct {
+	// Date 日期，格式：YYYY-MM-DD（UTC）
+	Date string
+	// ViewCount 浏览量
+	ViewCount int64
+}
+
+// IncrementNoteViews 批量累加笔记浏览量
+// 在同一个事务中更新 notes.view_count 并累加 note_views 中对应日期的记录
+func (s *Store) IncrementNoteViews(ctx context.Context, counts map[NoteViewKey]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for key, count := range counts {
+		if count <= 0 {
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, s.rebind("UPDATE notes SET view_count = view_count + ? WHERE id = ?"), count, key.NoteID)
+		if err != nil {
+			return fmt.Errorf("failed to increment note view count: %w", err)
+		}
+		// 笔记可能已被删除，跳过每日统计
+		if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+			continue
+		}
+
+		// 先尝试累加，当天没有记录时再插入
+		result, err = tx.ExecContext(ctx,
+			s.rebind("UPDATE note_views SET view_count = view_count + ? WHERE note_id = ? AND view_date = ?"),
+			count, key.NoteID, key.Date,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update note views: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if affected == 0 {
+			_, err = tx.ExecContext(ctx,
+				s.rebind("INSERT INTO note_views (note_id, view_date, view_count) VALUES (?, ?, ?)"),
+				key.NoteID, key.Date, count,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert note views: %w", err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListNoteViews 获取笔记在指定日期范围内（包含首尾）的每日浏览量，按日期升序
+// 没有浏览记录的日期不会返回
+func (s *Store) ListNoteViews(ctx context.Context, noteID int64, startDate, endDate string) ([]*NoteViewCount, error) {
+	query := `SELECT view_date, view_count FROM note_views WHERE note_id = ? AND view_date >= ? AND view_date <= ? ORDER BY view_date ASC`
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), noteID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list note views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []*NoteViewCount
+	for rows.Next() {
+		view := &NoteViewCount{}
+		if err := rows.Scan(&view.Date, &view.ViewCount); err != nil {
+			return nil, fmt.Errorf("failed to scan note views: %w", err)
+		}
+		views = append(views, view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
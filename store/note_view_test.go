@@ -0,0 +1,43 @@
+package store_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestIncrementNoteViews(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	note := createTestNote(t, ctx, s, &pbstore.Note{Title: "viewed"})
+
+	batches := []map[store.NoteViewKey]int64{
+		{{NoteID: note.Id, Date: "2026-01-01"}: 2, {NoteID: note.Id, Date: "2026-01-02"}: 1},
+		// 已有记录的日期累加，已删除或不存在的笔记跳过
+		{{NoteID: note.Id, Date: "2026-01-02"}: 3, {NoteID: note.Id + 100, Date: "2026-01-02"}: 5},
+	}
+	for _, counts := range batches {
+		if err := s.IncrementNoteViews(ctx, counts); err != nil {
+			t.Fatalf("IncrementNoteViews: %v", err)
+		}
+	}
+
+	views, err := s.ListNoteViews(ctx, note.Id, "2026-01-01", "2026-01-31")
+	if err != nil {
+		t.Fatalf("ListNoteViews: %v", err)
+	}
+	want := []*store.NoteViewCount{{Date: "2026-01-01", ViewCount: 2}, {Date: "2026-01-02", ViewCount: 4}}
+	if !reflect.DeepEqual(views, want) {
+		t.Errorf("ListNoteViews = %+v, want %+v", views, want)
+	}
+	got, err := s.GetNote(ctx, note.Id)
+	if err != nil {
+		t.Fatalf("GetNote: %v", err)
+	}
+	if got.ViewCount != 6 {
+		t.Errorf("view count = %d, want 6", got.ViewCount)
+	}
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// pageColumns 页面表的查询字段，顺序与 scanPage 保持一致
+const pageColumns = `id, created_at, updated_at, deleted_at, title, slug, content, published, in_navigation, "order"`
+
+// ListPages 获取页面列表，按排序顺序升序
+func (s *Store) ListPages(ctx context.Context, includeUnpublished bool) ([]*store.Page, error) {
+	query, params := andWorkspace(ctx, `SELECT `+pageColumns+` FROM pages WHERE deleted_at IS NULL`, "workspace_id")
+
+	if !includeUnpublished {
+		query += ` AND published = ?`
+		params = append(params, true)
+	}
+
+	query += ` ORDER BY created_at asc`
+
+	rows, err := s.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []*store.Page
+	for rows.Next() {
+		page, err := scanPage(rows)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+// GetPageBySlug 通过slug获取页面
+func (s *Store) GetPageBySlug(ctx context.Context, slug string) (*store.Page, error) {
+	query, params := andWorkspace(ctx, `SELECT `+pageColumns+` FROM pages WHERE slug = ? AND deleted_at IS NULL`, "workspace_id", slug)
+	row := s.db.QueryRowContext(ctx, query, params...)
+
+	page, err := scanPage(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("page not found with slug: %s", slug)
+		}
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// CreatePage 创建新页面
+func (s *Store) CreatePage(ctx context.Context, page *store.Page) (*store.Page, error) {
+	now := time.Now()
+	if page.CreatedAt > 0 {
+		now = time.Unix(page.CreatedAt, 0)
+	}
+
+	workspaceID, err := s.writeWorkspaceID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO pages (
+			title, slug, content, published, in_navigation, "order", created_at, updated_at, workspace_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := s.db.ExecContext(ctx, query,
+		page.Title,
+		page.Slug,
+		page.Content,
+		page.Published,
+		page.InNavigation,
+		page.Order,
+		now,
+		now,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+pageColumns+` FROM pages WHERE id = ?`, id)
+	return scanPage(row)
+}
+
+// pageRow 用于扫描数据库行的临时结构体
+type pageRow struct {
+	// id 页面ID
+	id uint
+	// createdAt 创建时间
+	createdAt time.Time
+	// updatedAt 更新时间
+	updatedAt time.Time
+	// deletedAt 删除时间（软删除）
+	deletedAt sql.NullTime
+	// title 标题
+	title string
+	// slug URL友好的标识符
+	slug string
+	// content 内容
+	content sql.NullString
+	// published 是否已发布
+	published bool
+	// inNavigation 是否在导航中显示
+	inNavigation bool
+	// order 排序顺序
+	order int
+}
+
+// scanPage 将数据库行扫描到store.Page
+func scanPage(rows interface{}) (*store.Page, error) {
+	var row pageRow
+
+	switch v := rows.(type) {
+	case *sql.Row:
+		if err := v.Scan(
+			&row.id,
+			&row.createdAt,
+			&row.updatedAt,
+			&row.deletedAt,
+			&row.title,
+			&row.slug,
+			&row.content,
+			&row.published,
+			&row.inNavigation,
+			&row.order,
+		); err != nil {
+			return nil, err
+		}
+	case *sql.Rows:
+		if err := v.Scan(
+			&row.id,
+			&row.createdAt,
+			&row.updatedAt,
+			&row.deletedAt,
+			&row.title,
+			&row.slug,
+			&row.content,
+			&row.published,
+			&row.inNavigation,
+			&row.order,
+		); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rows type: %T", rows)
+	}
+
+	return &store.Page{
+		Name:         fmt.Sprintf("pages/%d", row.id),
+		Id:           int64(row.id),
+		Title:        row.title,
+		Slug:         row.slug,
+		Content:      row.content.String,
+		Published:    row.published,
+		InNavigation: row.inNavigation,
+		Order:        int32(row.order),
+		CreatedAt:    row.createdAt.Unix(),
+		UpdatedAt:    row.updatedAt.Unix(),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SaveRedirect 记录旧地址到新地址的重定向，旧地址已存在时更新目标地址
+func (s *Store) SaveRedirect(ctx context.Context, sourcePath, targetPath string) error {
+	existing, err := s.GetRedirect(ctx, sourcePath)
+	if err != nil {
+		return err
+	}
+	if existing != "" {
+		if _, err := s.db.ExecContext(ctx, `UPDATE redirects SET target_path = ? WHERE source_path = ?`, targetPath, sourcePath); err != nil {
+			return fmt.Errorf("failed to update redirect: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO redirects (source_path, target_path) VALUES (?, ?)`, sourcePath, targetPath); err != nil {
+		return fmt.Errorf("failed to create redirect: %w", err)
+	}
+	return nil
+}
+
+// GetRedirect 查询旧地址对应的新地址，不存在时返回空字符串
+func (s *Store) GetRedirect(ctx context.Context, sourcePath string) (string, error) {
+	var targetPath string
+	err := s.db.QueryRowContext(ctx, `SELECT target_path FROM redirects WHERE source_path = ?`, sourcePath).Scan(&targetPath)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get redirect: %w", err)
+	}
+	return targetPath, nil
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// savedSearchColumns 保存的搜索表的查询字段，顺序与 scanSavedSearch 保持一致
+const savedSearchColumns = `id, created_at, updated_at, owner_id, name_text, filter, sort_by, sort_desc, pinned, shared, feed_enabled`
+
+// ListSavedSearches 获取用户自己的和其他用户共享的保存的搜索，置顶的在前
+func (s *Store) ListSavedSearches(ctx context.Context, userID int64) ([]*store.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches
+		WHERE owner_id = ? OR shared = ?
+		ORDER BY pinned DESC, name_text ASC, id ASC`
+	rows, err := s.db.QueryContext(ctx, query, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	searches := []*store.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, search)
+	}
+	return searches, rows.Err()
+}
+
+// GetSavedSearch 根据ID获取保存的搜索
+func (s *Store) GetSavedSearch(ctx context.Context, id int64) (*store.SavedSearch, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+savedSearchColumns+` FROM saved_searches WHERE id = ?`, id)
+	search, err := scanSavedSearch(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("saved search not found: %d", id)
+		}
+		return nil, err
+	}
+	return search, nil
+}
+
+// CreateSavedSearch 创建保存的搜索，过滤表达式和排序字段无效时返回 ErrInvalidFilter 或 ErrInvalidSortField
+func (s *Store) CreateSavedSearch(ctx context.Context, search *store.SavedSearch, ownerID int64) (*store.SavedSearch, error) {
+	if err := s.validateSavedSearch(search, ownerID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO saved_searches (owner_id, name_text, filter, sort_by, sort_desc, pinned, shared, feed_enabled, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ownerID, strings.TrimSpace(search.NameText), search.Filter, search.SortBy, search.SortDesc,
+		search.Pinned, search.Shared, search.FeedEnabled, now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSavedSearch(ctx, id)
+}
+
+// UpdateSavedSearch 更新保存的搜索
+func (s *Store) UpdateSavedSearch(ctx context.Context, search *store.SavedSearch, ownerID int64) (*store.SavedSearch, error) {
+	if err := s.validateSavedSearch(search, ownerID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE saved_searches SET name_text = ?, filter = ?, sort_by = ?, sort_desc = ?, pinned = ?, shared = ?, feed_enabled = ?, updated_at = ?
+		WHERE id = ?`,
+		strings.TrimSpace(search.NameText), search.Filter, search.SortBy, search.SortDesc,
+		search.Pinned, search.Shared, search.FeedEnabled, time.Now(), search.Id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("saved search not found: %d", search.Id)
+	}
+	return s.GetSavedSearch(ctx, search.Id)
+}
+
+// DeleteSavedSearch 删除保存的搜索及其浏览记录
+func (s *Store) DeleteSavedSearch(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM saved_search_views WHERE saved_search_id = ?`, id); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM saved_searches WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("saved search not found: %d", id)
+	}
+	return tx.Commit()
+}
+
+// GetSavedSearchLastViewed 获取用户上次查看保存的搜索的时间，从未查看时返回零值
+func (s *Store) GetSavedSearchLastViewed(ctx context.Context, searchID, userID int64) (time.Time, error) {
+	var lastViewedAt time.Time
+	err := s.db.QueryRowContext(ctx,
+		`SELECT last_viewed_at FROM saved_search_views WHERE saved_search_id = ? AND user_id = ?`,
+		searchID, userID,
+	).Scan(&lastViewedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return lastViewedAt, err
+}
+
+// MarkSavedSearchViewed 记录用户查看保存的搜索的时间
+func (s *Store) MarkSavedSearchViewed(ctx context.Context, searchID, userID int64, viewedAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 先删除再插入，避免使用各数据库不同的 upsert 语法
+	if _, err := tx.ExecContext(ctx, `DELETE FROM saved_search_views WHERE saved_search_id = ? AND user_id = ?`, searchID, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO saved_search_views (saved_search_id, user_id, last_viewed_at) VALUES (?, ?, ?)`,
+		searchID, userID, viewedAt,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// validateSavedSearch 检查名称、过滤表达式和排序字段
+func (s *Store) validateSavedSearch(search *store.SavedSearch, ownerID int64) error {
+	if strings.TrimSpace(search.NameText) == "" {
+		return fmt.Errorf("saved search name is required")
+	}
+	if _, _, err := s.compileNoteFilter(search.Filter, ownerID); err != nil {
+		return err
+	}
+	if _, err := parseNoteSort(search.SortBy, search.SortDesc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// scanSavedSearch 将数据库行扫描到store.SavedSearch
+func scanSavedSearch(row interface{ Scan(dest ...any) error }) (*store.SavedSearch, error) {
+	var (
+		id          int64
+		createdAt   time.Time
+		updatedAt   time.Time
+		ownerID     int64
+		nameText    string
+		filter      sql.NullString
+		sortBy      sql.NullString
+		sortDesc    bool
+		pinned      bool
+		shared      bool
+		feedEnabled bool
+	)
+	if err := row.Scan(&id, &createdAt, &updatedAt, &ownerID, &nameText, &filter, &sortBy, &sortDesc, &pinned, &shared, &feedEnabled); err != nil {
+		return nil, err
+	}
+
+	return &store.SavedSearch{
+		Name:        fmt.Sprintf("savedSearches/%d", id),
+		Id:          id,
+		Owner:       fmt.Sprintf("users/%d", ownerID),
+		NameText:    nameText,
+		Filter:      filter.String,
+		SortBy:      sortBy.String,
+		SortDesc:    sortDesc,
+		Pinned:      pinned,
+		Shared:      shared,
+		FeedEnabled: feedEnabled,
+		CreatedAt:   createdAt.Unix(),
+		UpdatedAt:   updatedAt.Unix(),
+	}, nil
+}
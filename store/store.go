@@ -1,8 +1,12 @@
 package store
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/wdmsyhh/simple-notes/internal/profile"
 )
@@ -80,6 +84,7 @@ func (s *Store) runSQLiteMigrations() error {
 		parent_id INTEGER, -- 父分类ID，可选，用于构建分类树
 		"order" INTEGER DEFAULT 0, -- 排序顺序，默认0
 		visible BOOLEAN DEFAULT TRUE, -- 是否可见，默认可见
+		workspace_id INTEGER NOT NULL DEFAULT 0, -- 所属工作区ID，必填
 		FOREIGN KEY (parent_id) REFERENCES categories(id) -- 外键，引用父分类
 	);`
 
@@ -92,7 +97,21 @@ func (s *Store) runSQLiteMigrations() error {
 		deleted_at DATETIME, -- 删除时间（软删除），NULL表示未删除
 		name_text VARCHAR(100) NOT NULL, -- 标签名称，必填
 		description VARCHAR(500), -- 标签描述，可选
-		count INTEGER DEFAULT 0 -- 使用次数，默认0
+		count INTEGER DEFAULT 0, -- 使用次数，默认0
+		name_key VARCHAR(100), -- 规范化后的标签名称（忽略大小写和全角/半角），唯一索引见 migrateTagNameKeys
+		workspace_id INTEGER NOT NULL DEFAULT 0 -- 所属工作区ID，必填
+	);`
+
+	// 创建标签别名表，别名在创建标签时解析为对应的标签
+	tagAliasesTableSQL := `
+	CREATE TABLE IF NOT EXISTS tag_aliases (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 别名ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		tag_id INTEGER NOT NULL, -- 标签ID，必填
+		alias VARCHAR(100) NOT NULL, -- 别名，必填
+		alias_key VARCHAR(100) NOT NULL, -- 规范化后的别名，必填，工作区内唯一，唯一索引见 migrateTagAliasWorkspaces
+		workspace_id INTEGER NOT NULL DEFAULT 0, -- 所属工作区ID，与标签所属的工作区相同
+		FOREIGN KEY (tag_id) REFERENCES tags(id) -- 外键，引用标签
 	);`
 
 	// 创建笔记表
@@ -106,7 +125,6 @@ func (s *Store) runSQLiteMigrations() error {
 		content TEXT, -- 笔记内容（Markdown格式），可选
 		summary VARCHAR(500), -- 笔记摘要，可选
 		category_id INTEGER, -- 分类ID，可选
-		tag_ids VARCHAR(500), -- 标签ID列表（逗号分隔），可选
 		published BOOLEAN DEFAULT FALSE, -- 是否已发布，默认未发布
 		author_id INTEGER, -- 作者ID，可选
 		published_at DATETIME, -- 发布时间，可选
@@ -114,6 +132,11 @@ func (s *Store) runSQLiteMigrations() error {
 		reading_time INTEGER DEFAULT 0, -- 阅读时间（分钟），默认0
 		view_count INTEGER DEFAULT 0, -- 浏览次数，默认0
 		visibility VARCHAR(20) DEFAULT 'PUBLIC', -- 可见性（PUBLIC/PRIVATE），默认公开
+		word_count INTEGER DEFAULT 0, -- 字数，默认0
+		char_count INTEGER DEFAULT 0, -- 字符数（不含空白），默认0
+		scheduled BOOLEAN DEFAULT FALSE, -- 是否等待定时发布（到达 published_at 时发布），默认否
+		expire_at DATETIME, -- 过期时间，到达后自动取消发布，可选
+		workspace_id INTEGER NOT NULL DEFAULT 0, -- 所属工作区ID，必填
 		FOREIGN KEY (category_id) REFERENCES categories(id), -- 外键，引用分类
 		FOREIGN KEY (author_id) REFERENCES users(id) -- 外键，引用用户
 	);`
@@ -157,7 +180,8 @@ func (s *Store) runSQLiteMigrations() error {
 		content TEXT, -- 页面内容，可选
 		published BOOLEAN DEFAULT FALSE, -- 是否已发布，默认未发布
 		in_navigation BOOLEAN DEFAULT FALSE, -- 是否在导航中显示，默认不显示
-		"order" INTEGER DEFAULT 0 -- 排序顺序，默认0
+		"order" INTEGER DEFAULT 0, -- 排序顺序，默认0
+		workspace_id INTEGER NOT NULL DEFAULT 0 -- 所属工作区ID，必填
 	);`
 
 	// 创建附件表
@@ -173,20 +197,183 @@ func (s *Store) runSQLiteMigrations() error {
 		blob BLOB, -- 文件二进制内容，可选（可存储在文件系统中）
 		note_id INTEGER, -- 关联的笔记ID，可选
 		author_id INTEGER NOT NULL, -- 上传者ID，必填
+		workspace_id INTEGER NOT NULL DEFAULT 0, -- 所属工作区ID，必填
+		FOREIGN KEY (note_id) REFERENCES notes(id), -- 外键，引用笔记
+		FOREIGN KEY (author_id) REFERENCES users(id) -- 外键，引用用户
+	);`
+
+	// 创建笔记每日浏览量汇总表
+	noteViewsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_views (
+		note_id INTEGER NOT NULL, -- 笔记ID
+		view_date VARCHAR(10) NOT NULL, -- 日期（YYYY-MM-DD，UTC）
+		view_count INTEGER DEFAULT 0, -- 当日浏览次数，默认0
+		PRIMARY KEY (note_id, view_date), -- 联合主键，每个笔记每天一条记录
+		FOREIGN KEY (note_id) REFERENCES notes(id) -- 外键，引用笔记
+	);`
+
+	// 创建旧地址重定向表（例如从 WordPress 导入的文章的固定链接）
+	redirectsTableSQL := `
+	CREATE TABLE IF NOT EXISTS redirects (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 重定向ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		source_path VARCHAR(500) NOT NULL UNIQUE, -- 旧地址路径，必填，唯一
+		target_path VARCHAR(500) NOT NULL -- 新地址路径，必填
+	);`
+
+	// 创建保存的搜索表
+	savedSearchesTableSQL := `
+	CREATE TABLE IF NOT EXISTS saved_searches (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 保存的搜索ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 更新时间，默认当前时间
+		owner_id INTEGER NOT NULL, -- 创建者ID，必填
+		name_text VARCHAR(100) NOT NULL, -- 名称，必填
+		filter TEXT, -- 过滤表达式，可选
+		sort_by VARCHAR(200), -- 排序字段，可选
+		sort_desc BOOLEAN DEFAULT TRUE, -- 是否降序，默认降序
+		pinned BOOLEAN DEFAULT FALSE, -- 是否置顶，默认不置顶
+		shared BOOLEAN DEFAULT FALSE, -- 是否共享给其他用户，默认不共享
+		feed_enabled BOOLEAN DEFAULT FALSE, -- 是否发布为订阅源，默认不发布
+		FOREIGN KEY (owner_id) REFERENCES users(id) -- 外键，引用用户
+	);`
+
+	// 创建保存的搜索浏览记录表，用于计算上次查看后新增的笔记数量
+	savedSearchViewsTableSQL := `
+	CREATE TABLE IF NOT EXISTS saved_search_views (
+		saved_search_id INTEGER NOT NULL, -- 保存的搜索ID
+		user_id INTEGER NOT NULL, -- 用户ID
+		last_viewed_at DATETIME NOT NULL, -- 上次查看时间
+		PRIMARY KEY (saved_search_id, user_id), -- 联合主键，每个用户每个保存的搜索一条记录
+		FOREIGN KEY (saved_search_id) REFERENCES saved_searches(id), -- 外键，引用保存的搜索
+		FOREIGN KEY (user_id) REFERENCES users(id) -- 外键，引用用户
+	);`
+
+	// 创建笔记草稿表，每个用户对每篇笔记有一份工作草稿，自动保存不会修改已发布的内容
+	noteDraftsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_drafts (
+		note_id INTEGER NOT NULL, -- 笔记ID
+		user_id INTEGER NOT NULL, -- 编辑者ID
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 最后自动保存时间，默认当前时间
+		title VARCHAR(255) NOT NULL, -- 笔记标题，必填
+		content TEXT, -- 笔记内容（Markdown格式），可选
+		summary VARCHAR(500), -- 笔记摘要，可选
+		category_id INTEGER, -- 分类ID，可选
+		tag_ids VARCHAR(1000), -- 标签ID列表（逗号分隔），可选
+		cover_image VARCHAR(255), -- 封面图片URL，可选
+		visibility VARCHAR(20) DEFAULT 'PUBLIC', -- 可见性（PUBLIC/PRIVATE），默认公开
+		PRIMARY KEY (note_id, user_id), -- 联合主键，每个用户每篇笔记一份草稿
+		FOREIGN KEY (note_id) REFERENCES notes(id), -- 外键，引用笔记
+		FOREIGN KEY (user_id) REFERENCES users(id) -- 外键，引用用户
+	);`
+
+	// 创建笔记修订记录表，每次发布草稿时记录发布的内容
+	noteRevisionsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_revisions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 修订ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 发布时间，默认当前时间
+		note_id INTEGER NOT NULL, -- 笔记ID，必填
+		author_id INTEGER NOT NULL, -- 发布者ID，必填
+		title VARCHAR(255) NOT NULL, -- 笔记标题，必填
+		content TEXT, -- 笔记内容（Markdown格式），可选
+		summary VARCHAR(500), -- 笔记摘要，可选
 		FOREIGN KEY (note_id) REFERENCES notes(id), -- 外键，引用笔记
 		FOREIGN KEY (author_id) REFERENCES users(id) -- 外键，引用用户
 	);`
 
+	// 创建笔记链接表（笔记内容中的 [[链接]]）
+	noteLinksTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_links (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 链接ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		source_note_id INTEGER NOT NULL, -- 链接所在的笔记ID，必填
+		target_note_id INTEGER, -- 链接指向的笔记ID，未解析时为空
+		target VARCHAR(255) NOT NULL, -- 链接中书写的目标（笔记标题或 notes/{id}），必填
+		target_key VARCHAR(255) NOT NULL, -- 规范化的链接目标，用于按标题解析，必填
+		alias VARCHAR(255), -- 链接显示的文本，可选
+		FOREIGN KEY (source_note_id) REFERENCES notes(id) -- 外键，引用笔记
+	);`
+
+	// 创建笔记分享链接表，令牌只保存 SHA-256 哈希
+	noteShareLinksTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_share_links (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 分享链接ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		note_id INTEGER NOT NULL, -- 分享的笔记ID，必填
+		creator_id INTEGER NOT NULL, -- 创建者ID，必填
+		token_hash VARCHAR(64) NOT NULL UNIQUE, -- 分享令牌的 SHA-256 哈希，必填，唯一
+		password_hash VARCHAR(255), -- 访问密码哈希值，可选
+		expire_at DATETIME, -- 过期时间，可选
+		max_views INTEGER DEFAULT 0, -- 最多查看次数，0 表示不限制
+		view_count INTEGER DEFAULT 0, -- 已查看次数，默认0
+		revoked BOOLEAN DEFAULT FALSE, -- 是否已撤销，默认未撤销
+		FOREIGN KEY (note_id) REFERENCES notes(id), -- 外键，引用笔记
+		FOREIGN KEY (creator_id) REFERENCES users(id) -- 外键，引用用户
+	);`
+
+	// 创建笔记权限表，授予用户对笔记或分类（note_id 和 category_id 只有一个不为 0）的协作者角色
+	notePermissionsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_permissions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 权限ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 更新时间，默认当前时间
+		user_id INTEGER NOT NULL, -- 被授权的用户ID，必填
+		note_id INTEGER NOT NULL DEFAULT 0, -- 授权的笔记ID，授权分类时为0
+		category_id INTEGER NOT NULL DEFAULT 0, -- 授权的分类ID，授权笔记时为0
+		role VARCHAR(20) NOT NULL, -- 角色（VIEWER/COMMENTER/EDITOR），必填
+		granted_by INTEGER NOT NULL, -- 授权者ID，必填
+		UNIQUE (user_id, note_id, category_id), -- 每个用户对每个资源只有一条权限
+		FOREIGN KEY (user_id) REFERENCES users(id) -- 外键，引用用户
+	);`
+
+	// 创建工作区表，笔记、分类、标签、页面和附件按工作区隔离
+	workspacesTableSQL := `
+	CREATE TABLE IF NOT EXISTS workspaces (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 工作区ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 更新时间，默认当前时间
+		slug VARCHAR(100) NOT NULL UNIQUE, -- URL友好的标识符，必填，唯一
+		name_text VARCHAR(100) NOT NULL, -- 工作区名称，必填
+		description VARCHAR(500), -- 工作区描述，可选
+		creator_id INTEGER NOT NULL DEFAULT 0 -- 创建者ID，默认工作区为0
+	);`
+
+	// 创建工作区成员表
+	workspaceMembersTableSQL := `
+	CREATE TABLE IF NOT EXISTS workspace_members (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, -- 成员ID，主键，自增
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 创建时间，默认当前时间
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- 更新时间，默认当前时间
+		workspace_id INTEGER NOT NULL, -- 工作区ID，必填
+		user_id INTEGER NOT NULL, -- 用户ID，必填
+		role VARCHAR(20) NOT NULL, -- 角色（VIEWER/MEMBER/ADMIN/OWNER），必填
+		UNIQUE (workspace_id, user_id), -- 每个用户在每个工作区只有一条成员记录
+		FOREIGN KEY (workspace_id) REFERENCES workspaces(id), -- 外键，引用工作区
+		FOREIGN KEY (user_id) REFERENCES users(id) -- 外键，引用用户
+	);`
 	// 执行所有迁移SQL语句
 	migrations := []string{
 		usersTableSQL,
 		categoriesTableSQL,
 		tagsTableSQL,
+		tagAliasesTableSQL,
 		notesTableSQL,
 		noteTagsTableSQL,
 		commentsTableSQL,
 		pagesTableSQL,
 		attachmentsTableSQL,
+		noteViewsTableSQL,
+		redirectsTableSQL,
+		savedSearchesTableSQL,
+		savedSearchViewsTableSQL,
+		noteDraftsTableSQL,
+		noteRevisionsTableSQL,
+		noteLinksTableSQL,
+		noteShareLinksTableSQL,
+		notePermissionsTableSQL,
+		workspacesTableSQL,
+		workspaceMembersTableSQL,
 	}
 
 	for _, migration := range migrations {
@@ -201,6 +388,46 @@ func (s *Store) runSQLiteMigrations() error {
 		fmt.Printf("Warning: failed to migrate users email removal: %v\n", err)
 	}
 
+	// 迁移现有表：为 notes 表添加字数和字符数字段
+	if err := s.migrateAddNoteStatsColumns(); err != nil {
+		return fmt.Errorf("failed to migrate note stats columns: %w", err)
+	}
+
+	// 迁移现有表：为 notes 表添加定时发布和过期时间字段
+	if err := s.migrateAddNoteScheduleColumns(); err != nil {
+		return fmt.Errorf("failed to migrate note schedule columns: %w", err)
+	}
+
+	// 迁移现有表：为按工作区隔离的数据表添加工作区字段，并将已有数据和用户放入默认工作区
+	if err := s.migrateWorkspaces(); err != nil {
+		return fmt.Errorf("failed to migrate workspaces: %w", err)
+	}
+
+	// 迁移现有表：为标签别名添加工作区字段，别名改为在工作区内唯一
+	if err := s.migrateTagAliasWorkspaces(); err != nil {
+		return fmt.Errorf("failed to migrate tag alias workspaces: %w", err)
+	}
+
+	// 迁移现有表：将 notes.tag_ids 合并到 note_tags 后删除该字段，并重新计算标签计数
+	if err := s.migrateDropNoteTagIDs(); err != nil {
+		return fmt.Errorf("failed to migrate note tag ids: %w", err)
+	}
+
+	// 迁移现有表：为标签生成规范化名称并建立唯一索引，名称重复的标签合并为一个
+	if err := s.migrateTagNameKeys(); err != nil {
+		return fmt.Errorf("failed to migrate tag name keys: %w", err)
+	}
+
+	// 迁移现有表：为笔记链接建立索引，并解析已有笔记中的链接
+	if err := s.migrateNoteLinks(); err != nil {
+		return fmt.Errorf("failed to migrate note links: %w", err)
+	}
+
+	// 迁移现有数据：将以服务器本地时区保存的时间转换为 UTC
+	if err := s.migrateSQLiteTimesToUTC(); err != nil {
+		return fmt.Errorf("failed to migrate times to UTC: %w", err)
+	}
+
 	return nil
 }
 
@@ -233,6 +460,7 @@ func (s *Store) runMySQLMigrations() error {
 		parent_id INT NULL COMMENT '父分类ID，可选，用于构建分类树',
 		` + "`order`" + ` INT DEFAULT 0 COMMENT '排序顺序，默认0',
 		visible BOOLEAN DEFAULT TRUE COMMENT '是否可见，默认可见',
+		workspace_id INT NOT NULL DEFAULT 0 COMMENT '所属工作区ID，必填',
 		FOREIGN KEY (parent_id) REFERENCES categories(id)
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
 
@@ -245,7 +473,22 @@ func (s *Store) runMySQLMigrations() error {
 		deleted_at DATETIME NULL COMMENT '删除时间（软删除），NULL表示未删除',
 		name_text VARCHAR(100) NOT NULL COMMENT '标签名称，必填',
 		description VARCHAR(500) NULL COMMENT '标签描述，可选',
-		count INT DEFAULT 0 COMMENT '使用次数，默认0'
+		count INT DEFAULT 0 COMMENT '使用次数，默认0',
+		name_key VARCHAR(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NULL COMMENT '规范化后的标签名称（忽略大小写和全角/半角），唯一',
+		workspace_id INT NOT NULL DEFAULT 0 COMMENT '所属工作区ID，必填'
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建标签别名表，别名在创建标签时解析为对应的标签
+	// 规范化后的字段使用二进制排序规则，唯一性只由规范化规则决定
+	tagAliasesTableSQL := `
+	CREATE TABLE IF NOT EXISTS tag_aliases (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '别名ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		tag_id INT NOT NULL COMMENT '标签ID，必填',
+		alias VARCHAR(100) NOT NULL COMMENT '别名，必填',
+		alias_key VARCHAR(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT '规范化后的别名，必填，工作区内唯一',
+		workspace_id INT NOT NULL DEFAULT 0 COMMENT '所属工作区ID，与标签所属的工作区相同',
+		FOREIGN KEY (tag_id) REFERENCES tags(id)
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
 
 	// 创建笔记表
@@ -259,7 +502,6 @@ func (s *Store) runMySQLMigrations() error {
 		content TEXT NULL COMMENT '笔记内容（Markdown格式），可选',
 		summary VARCHAR(500) NULL COMMENT '笔记摘要，可选',
 		category_id INT NULL COMMENT '分类ID，可选',
-		tag_ids VARCHAR(500) NULL COMMENT '标签ID列表（逗号分隔），可选',
 		published BOOLEAN DEFAULT FALSE COMMENT '是否已发布，默认未发布',
 		author_id INT NULL COMMENT '作者ID，可选',
 		published_at DATETIME NULL COMMENT '发布时间，可选',
@@ -267,6 +509,11 @@ func (s *Store) runMySQLMigrations() error {
 		reading_time INT DEFAULT 0 COMMENT '阅读时间（分钟），默认0',
 		view_count INT DEFAULT 0 COMMENT '浏览次数，默认0',
 		visibility VARCHAR(20) DEFAULT 'PUBLIC' COMMENT '可见性（PUBLIC/PRIVATE），默认公开',
+		word_count INT DEFAULT 0 COMMENT '字数，默认0',
+		char_count INT DEFAULT 0 COMMENT '字符数（不含空白），默认0',
+		scheduled BOOLEAN DEFAULT FALSE COMMENT '是否等待定时发布（到达 published_at 时发布），默认否',
+		expire_at DATETIME NULL COMMENT '过期时间，到达后自动取消发布，可选',
+		workspace_id INT NOT NULL DEFAULT 0 COMMENT '所属工作区ID，必填',
 		FOREIGN KEY (category_id) REFERENCES categories(id),
 		FOREIGN KEY (author_id) REFERENCES users(id)
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
@@ -310,7 +557,8 @@ func (s *Store) runMySQLMigrations() error {
 		content TEXT NULL COMMENT '页面内容，可选',
 		published BOOLEAN DEFAULT FALSE COMMENT '是否已发布，默认未发布',
 		in_navigation BOOLEAN DEFAULT FALSE COMMENT '是否在导航中显示，默认不显示',
-		` + "`order`" + ` INT DEFAULT 0 COMMENT '排序顺序，默认0'
+		` + "`order`" + ` INT DEFAULT 0 COMMENT '排序顺序，默认0',
+		workspace_id INT NOT NULL DEFAULT 0 COMMENT '所属工作区ID，必填'
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
 
 	// 创建附件表
@@ -326,20 +574,183 @@ func (s *Store) runMySQLMigrations() error {
 		blob LONGBLOB NULL COMMENT '文件二进制内容，可选（可存储在文件系统中）',
 		note_id INT NULL COMMENT '关联的笔记ID，可选',
 		author_id INT NOT NULL COMMENT '上传者ID，必填',
+		workspace_id INT NOT NULL DEFAULT 0 COMMENT '所属工作区ID，必填',
+		FOREIGN KEY (note_id) REFERENCES notes(id),
+		FOREIGN KEY (author_id) REFERENCES users(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建笔记每日浏览量汇总表
+	noteViewsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_views (
+		note_id INT NOT NULL COMMENT '笔记ID',
+		view_date VARCHAR(10) NOT NULL COMMENT '日期（YYYY-MM-DD，UTC）',
+		view_count INT DEFAULT 0 COMMENT '当日浏览次数，默认0',
+		PRIMARY KEY (note_id, view_date),
+		FOREIGN KEY (note_id) REFERENCES notes(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建旧地址重定向表
+	redirectsTableSQL := `
+	CREATE TABLE IF NOT EXISTS redirects (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '重定向ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		source_path VARCHAR(500) NOT NULL UNIQUE COMMENT '旧地址路径，必填，唯一',
+		target_path VARCHAR(500) NOT NULL COMMENT '新地址路径，必填'
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建保存的搜索表
+	savedSearchesTableSQL := `
+	CREATE TABLE IF NOT EXISTS saved_searches (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '保存的搜索ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间，默认当前时间',
+		owner_id INT NOT NULL COMMENT '创建者ID，必填',
+		name_text VARCHAR(100) NOT NULL COMMENT '名称，必填',
+		filter TEXT COMMENT '过滤表达式，可选',
+		sort_by VARCHAR(200) COMMENT '排序字段，可选',
+		sort_desc BOOLEAN DEFAULT TRUE COMMENT '是否降序，默认降序',
+		pinned BOOLEAN DEFAULT FALSE COMMENT '是否置顶，默认不置顶',
+		shared BOOLEAN DEFAULT FALSE COMMENT '是否共享给其他用户，默认不共享',
+		feed_enabled BOOLEAN DEFAULT FALSE COMMENT '是否发布为订阅源，默认不发布',
+		FOREIGN KEY (owner_id) REFERENCES users(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建保存的搜索浏览记录表
+	savedSearchViewsTableSQL := `
+	CREATE TABLE IF NOT EXISTS saved_search_views (
+		saved_search_id INT NOT NULL COMMENT '保存的搜索ID',
+		user_id INT NOT NULL COMMENT '用户ID',
+		last_viewed_at DATETIME NOT NULL COMMENT '上次查看时间',
+		PRIMARY KEY (saved_search_id, user_id),
+		FOREIGN KEY (saved_search_id) REFERENCES saved_searches(id),
+		FOREIGN KEY (user_id) REFERENCES users(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建笔记草稿表
+	noteDraftsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_drafts (
+		note_id INT NOT NULL COMMENT '笔记ID',
+		user_id INT NOT NULL COMMENT '编辑者ID',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后自动保存时间，默认当前时间',
+		title VARCHAR(255) NOT NULL COMMENT '笔记标题，必填',
+		content TEXT NULL COMMENT '笔记内容（Markdown格式），可选',
+		summary VARCHAR(500) NULL COMMENT '笔记摘要，可选',
+		category_id INT NULL COMMENT '分类ID，可选',
+		tag_ids VARCHAR(1000) NULL COMMENT '标签ID列表（逗号分隔），可选',
+		cover_image VARCHAR(255) NULL COMMENT '封面图片URL，可选',
+		visibility VARCHAR(20) DEFAULT 'PUBLIC' COMMENT '可见性（PUBLIC/PRIVATE），默认公开',
+		PRIMARY KEY (note_id, user_id),
+		FOREIGN KEY (note_id) REFERENCES notes(id),
+		FOREIGN KEY (user_id) REFERENCES users(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建笔记修订记录表
+	noteRevisionsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_revisions (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '修订ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '发布时间，默认当前时间',
+		note_id INT NOT NULL COMMENT '笔记ID，必填',
+		author_id INT NOT NULL COMMENT '发布者ID，必填',
+		title VARCHAR(255) NOT NULL COMMENT '笔记标题，必填',
+		content TEXT NULL COMMENT '笔记内容（Markdown格式），可选',
+		summary VARCHAR(500) NULL COMMENT '笔记摘要，可选',
 		FOREIGN KEY (note_id) REFERENCES notes(id),
 		FOREIGN KEY (author_id) REFERENCES users(id)
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
 
+	// 创建笔记链接表
+	noteLinksTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_links (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '链接ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		source_note_id INT NOT NULL COMMENT '链接所在的笔记ID，必填',
+		target_note_id INT NULL COMMENT '链接指向的笔记ID，未解析时为空',
+		target VARCHAR(255) NOT NULL COMMENT '链接中书写的目标（笔记标题或 notes/{id}），必填',
+		target_key VARCHAR(255) NOT NULL COMMENT '规范化的链接目标，用于按标题解析，必填',
+		alias VARCHAR(255) NULL COMMENT '链接显示的文本，可选',
+		FOREIGN KEY (source_note_id) REFERENCES notes(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建笔记分享链接表
+	noteShareLinksTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_share_links (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '分享链接ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		note_id INT NOT NULL COMMENT '分享的笔记ID，必填',
+		creator_id INT NOT NULL COMMENT '创建者ID，必填',
+		token_hash VARCHAR(64) NOT NULL UNIQUE COMMENT '分享令牌的 SHA-256 哈希，必填，唯一',
+		password_hash VARCHAR(255) NULL COMMENT '访问密码哈希值，可选',
+		expire_at DATETIME NULL COMMENT '过期时间，可选',
+		max_views INT DEFAULT 0 COMMENT '最多查看次数，0 表示不限制',
+		view_count INT DEFAULT 0 COMMENT '已查看次数，默认0',
+		revoked BOOLEAN DEFAULT FALSE COMMENT '是否已撤销，默认未撤销',
+		FOREIGN KEY (note_id) REFERENCES notes(id),
+		FOREIGN KEY (creator_id) REFERENCES users(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建笔记权限表
+	notePermissionsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_permissions (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '权限ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间，默认当前时间',
+		user_id INT NOT NULL COMMENT '被授权的用户ID，必填',
+		note_id INT NOT NULL DEFAULT 0 COMMENT '授权的笔记ID，授权分类时为0',
+		category_id INT NOT NULL DEFAULT 0 COMMENT '授权的分类ID，授权笔记时为0',
+		role VARCHAR(20) NOT NULL COMMENT '角色（VIEWER/COMMENTER/EDITOR），必填',
+		granted_by INT NOT NULL COMMENT '授权者ID，必填',
+		UNIQUE KEY uk_note_permissions_resource (user_id, note_id, category_id),
+		FOREIGN KEY (user_id) REFERENCES users(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建工作区表
+	workspacesTableSQL := `
+	CREATE TABLE IF NOT EXISTS workspaces (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '工作区ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间，默认当前时间',
+		slug VARCHAR(100) NOT NULL UNIQUE COMMENT 'URL友好的标识符，必填，唯一',
+		name_text VARCHAR(100) NOT NULL COMMENT '工作区名称，必填',
+		description VARCHAR(500) NULL COMMENT '工作区描述，可选',
+		creator_id INT NOT NULL DEFAULT 0 COMMENT '创建者ID，默认工作区为0'
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
+
+	// 创建工作区成员表
+	workspaceMembersTableSQL := `
+	CREATE TABLE IF NOT EXISTS workspace_members (
+		id INT AUTO_INCREMENT PRIMARY KEY COMMENT '成员ID，主键，自增',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间，默认当前时间',
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间，默认当前时间',
+		workspace_id INT NOT NULL COMMENT '工作区ID，必填',
+		user_id INT NOT NULL COMMENT '用户ID，必填',
+		role VARCHAR(20) NOT NULL COMMENT '角色（VIEWER/MEMBER/ADMIN/OWNER），必填',
+		UNIQUE KEY uk_workspace_members_user (workspace_id, user_id),
+		FOREIGN KEY (workspace_id) REFERENCES workspaces(id),
+		FOREIGN KEY (user_id) REFERENCES users(id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`
 	// 执行所有迁移SQL语句
 	migrations := []string{
 		usersTableSQL,
 		categoriesTableSQL,
 		tagsTableSQL,
+		tagAliasesTableSQL,
 		notesTableSQL,
 		noteTagsTableSQL,
 		commentsTableSQL,
 		pagesTableSQL,
 		attachmentsTableSQL,
+		noteViewsTableSQL,
+		redirectsTableSQL,
+		savedSearchesTableSQL,
+		savedSearchViewsTableSQL,
+		noteDraftsTableSQL,
+		noteRevisionsTableSQL,
+		noteLinksTableSQL,
+		noteShareLinksTableSQL,
+		notePermissionsTableSQL,
+		workspacesTableSQL,
+		workspaceMembersTableSQL,
 	}
 
 	for _, migration := range migrations {
@@ -354,6 +765,41 @@ func (s *Store) runMySQLMigrations() error {
 		fmt.Printf("Warning: failed to migrate users email removal: %v\n", err)
 	}
 
+	// 迁移现有表：为 notes 表添加字数和字符数字段
+	if err := s.migrateAddNoteStatsColumns(); err != nil {
+		return fmt.Errorf("failed to migrate note stats columns: %w", err)
+	}
+
+	// 迁移现有表：为 notes 表添加定时发布和过期时间字段
+	if err := s.migrateAddNoteScheduleColumns(); err != nil {
+		return fmt.Errorf("failed to migrate note schedule columns: %w", err)
+	}
+
+	// 迁移现有表：为按工作区隔离的数据表添加工作区字段，并将已有数据和用户放入默认工作区
+	if err := s.migrateWorkspaces(); err != nil {
+		return fmt.Errorf("failed to migrate workspaces: %w", err)
+	}
+
+	// 迁移现有表：为标签别名添加工作区字段，别名改为在工作区内唯一
+	if err := s.migrateTagAliasWorkspaces(); err != nil {
+		return fmt.Errorf("failed to migrate tag alias workspaces: %w", err)
+	}
+
+	// 迁移现有表：将 notes.tag_ids 合并到 note_tags 后删除该字段，并重新计算标签计数
+	if err := s.migrateDropNoteTagIDs(); err != nil {
+		return fmt.Errorf("failed to migrate note tag ids: %w", err)
+	}
+
+	// 迁移现有表：为标签生成规范化名称并建立唯一索引，名称重复的标签合并为一个
+	if err := s.migrateTagNameKeys(); err != nil {
+		return fmt.Errorf("failed to migrate tag name keys: %w", err)
+	}
+
+	// 迁移现有表：为笔记链接建立索引，并解析已有笔记中的链接
+	if err := s.migrateNoteLinks(); err != nil {
+		return fmt.Errorf("failed to migrate note links: %w", err)
+	}
+
 	return nil
 }
 
@@ -386,6 +832,7 @@ func (s *Store) runPostgresMigrations() error {
 		parent_id INTEGER,
 		"order" INTEGER DEFAULT 0,
 		visible BOOLEAN DEFAULT TRUE,
+		workspace_id INTEGER NOT NULL DEFAULT 0,
 		FOREIGN KEY (parent_id) REFERENCES categories(id)
 	);`
 
@@ -398,7 +845,20 @@ func (s *Store) runPostgresMigrations() error {
 		deleted_at TIMESTAMP NULL,
 		name_text VARCHAR(100) NOT NULL,
 		description VARCHAR(500),
-		count INTEGER DEFAULT 0
+		count INTEGER DEFAULT 0,
+		name_key VARCHAR(100),
+		workspace_id INTEGER NOT NULL DEFAULT 0
+	);`
+
+	// 创建标签别名表
+	tagAliasesTableSQL := `
+	CREATE TABLE IF NOT EXISTS tag_aliases (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		tag_id INTEGER NOT NULL REFERENCES tags(id),
+		alias VARCHAR(100) NOT NULL,
+		alias_key VARCHAR(100) NOT NULL,
+		workspace_id INTEGER NOT NULL DEFAULT 0
 	);`
 
 	// 创建笔记表
@@ -412,7 +872,6 @@ func (s *Store) runPostgresMigrations() error {
 		content TEXT,
 		summary VARCHAR(500),
 		category_id INTEGER,
-		tag_ids VARCHAR(500),
 		published BOOLEAN DEFAULT FALSE,
 		author_id INTEGER,
 		published_at TIMESTAMP,
@@ -420,6 +879,11 @@ func (s *Store) runPostgresMigrations() error {
 		reading_time INTEGER DEFAULT 0,
 		view_count INTEGER DEFAULT 0,
 		visibility VARCHAR(20) DEFAULT 'PUBLIC',
+		word_count INTEGER DEFAULT 0,
+		char_count INTEGER DEFAULT 0,
+		scheduled BOOLEAN DEFAULT FALSE,
+		expire_at TIMESTAMP NULL,
+		workspace_id INTEGER NOT NULL DEFAULT 0,
 		FOREIGN KEY (category_id) REFERENCES categories(id),
 		FOREIGN KEY (author_id) REFERENCES users(id)
 	);`
@@ -463,7 +927,8 @@ func (s *Store) runPostgresMigrations() error {
 		content TEXT,
 		published BOOLEAN DEFAULT FALSE,
 		in_navigation BOOLEAN DEFAULT FALSE,
-		"order" INTEGER DEFAULT 0
+		"order" INTEGER DEFAULT 0,
+		workspace_id INTEGER NOT NULL DEFAULT 0
 	);`
 
 	// 创建附件表
@@ -479,10 +944,148 @@ func (s *Store) runPostgresMigrations() error {
 		blob BYTEA,
 		note_id INTEGER,
 		author_id INTEGER NOT NULL,
+		workspace_id INTEGER NOT NULL DEFAULT 0,
 		FOREIGN KEY (note_id) REFERENCES notes(id),
 		FOREIGN KEY (author_id) REFERENCES users(id)
 	);`
 
+	// 创建笔记每日浏览量汇总表
+	noteViewsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_views (
+		note_id INTEGER NOT NULL,
+		view_date VARCHAR(10) NOT NULL,
+		view_count INTEGER DEFAULT 0,
+		PRIMARY KEY (note_id, view_date),
+		FOREIGN KEY (note_id) REFERENCES notes(id)
+	);`
+
+	// 创建旧地址重定向表
+	redirectsTableSQL := `
+	CREATE TABLE IF NOT EXISTS redirects (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		source_path VARCHAR(500) NOT NULL UNIQUE,
+		target_path VARCHAR(500) NOT NULL
+	);`
+
+	// 创建保存的搜索表
+	savedSearchesTableSQL := `
+	CREATE TABLE IF NOT EXISTS saved_searches (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		owner_id INTEGER NOT NULL REFERENCES users(id),
+		name_text VARCHAR(100) NOT NULL,
+		filter TEXT,
+		sort_by VARCHAR(200),
+		sort_desc BOOLEAN DEFAULT TRUE,
+		pinned BOOLEAN DEFAULT FALSE,
+		shared BOOLEAN DEFAULT FALSE,
+		feed_enabled BOOLEAN DEFAULT FALSE
+	);`
+
+	// 创建保存的搜索浏览记录表
+	savedSearchViewsTableSQL := `
+	CREATE TABLE IF NOT EXISTS saved_search_views (
+		saved_search_id INTEGER NOT NULL REFERENCES saved_searches(id),
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		last_viewed_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (saved_search_id, user_id)
+	);`
+
+	// 创建笔记草稿表
+	noteDraftsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_drafts (
+		note_id INTEGER NOT NULL REFERENCES notes(id),
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		title VARCHAR(255) NOT NULL,
+		content TEXT,
+		summary VARCHAR(500),
+		category_id INTEGER,
+		tag_ids VARCHAR(1000),
+		cover_image VARCHAR(255),
+		visibility VARCHAR(20) DEFAULT 'PUBLIC',
+		PRIMARY KEY (note_id, user_id)
+	);`
+
+	// 创建笔记修订记录表
+	noteRevisionsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_revisions (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		note_id INTEGER NOT NULL REFERENCES notes(id),
+		author_id INTEGER NOT NULL REFERENCES users(id),
+		title VARCHAR(255) NOT NULL,
+		content TEXT,
+		summary VARCHAR(500)
+	);`
+
+	// 创建笔记链接表
+	noteLinksTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_links (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		source_note_id INTEGER NOT NULL REFERENCES notes(id),
+		target_note_id INTEGER,
+		target VARCHAR(255) NOT NULL,
+		target_key VARCHAR(255) NOT NULL,
+		alias VARCHAR(255)
+	);`
+
+	// 创建笔记分享链接表
+	noteShareLinksTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_share_links (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		note_id INTEGER NOT NULL REFERENCES notes(id),
+		creator_id INTEGER NOT NULL REFERENCES users(id),
+		token_hash VARCHAR(64) NOT NULL UNIQUE,
+		password_hash VARCHAR(255),
+		expire_at TIMESTAMP,
+		max_views INTEGER DEFAULT 0,
+		view_count INTEGER DEFAULT 0,
+		revoked BOOLEAN DEFAULT FALSE
+	);`
+
+	// 创建笔记权限表
+	notePermissionsTableSQL := `
+	CREATE TABLE IF NOT EXISTS note_permissions (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		note_id INTEGER NOT NULL DEFAULT 0,
+		category_id INTEGER NOT NULL DEFAULT 0,
+		role VARCHAR(20) NOT NULL,
+		granted_by INTEGER NOT NULL,
+		UNIQUE (user_id, note_id, category_id)
+	);`
+
+	// 创建工作区表
+	workspacesTableSQL := `
+	CREATE TABLE IF NOT EXISTS workspaces (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		slug VARCHAR(100) NOT NULL UNIQUE,
+		name_text VARCHAR(100) NOT NULL,
+		description VARCHAR(500),
+		creator_id INTEGER NOT NULL DEFAULT 0
+	);`
+
+	// 创建工作区成员表
+	workspaceMembersTableSQL := `
+	CREATE TABLE IF NOT EXISTS workspace_members (
+		id SERIAL PRIMARY KEY,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		workspace_id INTEGER NOT NULL REFERENCES workspaces(id),
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		role VARCHAR(20) NOT NULL,
+		UNIQUE (workspace_id, user_id)
+	);`
 	// 执行所有迁移SQL语句
 	migrations := []struct {
 		tableSQL string
@@ -515,6 +1118,7 @@ func (s *Store) runPostgresMigrations() error {
 				"COMMENT ON COLUMN categories.parent_id IS '父分类ID，可选，用于构建分类树'",
 				"COMMENT ON COLUMN categories.\"order\" IS '排序顺序，默认0'",
 				"COMMENT ON COLUMN categories.visible IS '是否可见，默认可见'",
+				"COMMENT ON COLUMN categories.workspace_id IS '所属工作区ID，必填'",
 			},
 		},
 		{
@@ -527,6 +1131,19 @@ func (s *Store) runPostgresMigrations() error {
 				"COMMENT ON COLUMN tags.name_text IS '标签名称，必填'",
 				"COMMENT ON COLUMN tags.description IS '标签描述，可选'",
 				"COMMENT ON COLUMN tags.count IS '使用次数，默认0'",
+				"COMMENT ON COLUMN tags.name_key IS '规范化后的标签名称（忽略大小写和全角/半角），唯一'",
+				"COMMENT ON COLUMN tags.workspace_id IS '所属工作区ID，必填'",
+			},
+		},
+		{
+			tableSQL: tagAliasesTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN tag_aliases.id IS '别名ID，主键，自增'",
+				"COMMENT ON COLUMN tag_aliases.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN tag_aliases.tag_id IS '标签ID，必填'",
+				"COMMENT ON COLUMN tag_aliases.alias IS '别名，必填'",
+				"COMMENT ON COLUMN tag_aliases.alias_key IS '规范化后的别名，必填，工作区内唯一'",
+				"COMMENT ON COLUMN tag_aliases.workspace_id IS '所属工作区ID，与标签所属的工作区相同'",
 			},
 		},
 		{
@@ -540,7 +1157,6 @@ func (s *Store) runPostgresMigrations() error {
 				"COMMENT ON COLUMN notes.content IS '笔记内容（Markdown格式），可选'",
 				"COMMENT ON COLUMN notes.summary IS '笔记摘要，可选'",
 				"COMMENT ON COLUMN notes.category_id IS '分类ID，可选'",
-				"COMMENT ON COLUMN notes.tag_ids IS '标签ID列表（逗号分隔），可选'",
 				"COMMENT ON COLUMN notes.published IS '是否已发布，默认未发布'",
 				"COMMENT ON COLUMN notes.author_id IS '作者ID，可选'",
 				"COMMENT ON COLUMN notes.published_at IS '发布时间，可选'",
@@ -548,6 +1164,11 @@ func (s *Store) runPostgresMigrations() error {
 				"COMMENT ON COLUMN notes.reading_time IS '阅读时间（分钟），默认0'",
 				"COMMENT ON COLUMN notes.view_count IS '浏览次数，默认0'",
 				"COMMENT ON COLUMN notes.visibility IS '可见性（PUBLIC/PRIVATE），默认公开'",
+				"COMMENT ON COLUMN notes.word_count IS '字数，默认0'",
+				"COMMENT ON COLUMN notes.char_count IS '字符数（不含空白），默认0'",
+				"COMMENT ON COLUMN notes.scheduled IS '是否等待定时发布（到达 published_at 时发布），默认否'",
+				"COMMENT ON COLUMN notes.expire_at IS '过期时间，到达后自动取消发布，可选'",
+				"COMMENT ON COLUMN notes.workspace_id IS '所属工作区ID，必填'",
 			},
 		},
 		{
@@ -585,6 +1206,7 @@ func (s *Store) runPostgresMigrations() error {
 				"COMMENT ON COLUMN pages.published IS '是否已发布，默认未发布'",
 				"COMMENT ON COLUMN pages.in_navigation IS '是否在导航中显示，默认不显示'",
 				"COMMENT ON COLUMN pages.\"order\" IS '排序顺序，默认0'",
+				"COMMENT ON COLUMN pages.workspace_id IS '所属工作区ID，必填'",
 			},
 		},
 		{
@@ -600,6 +1222,139 @@ func (s *Store) runPostgresMigrations() error {
 				"COMMENT ON COLUMN attachments.blob IS '文件二进制内容，可选（可存储在文件系统中）'",
 				"COMMENT ON COLUMN attachments.note_id IS '关联的笔记ID，可选'",
 				"COMMENT ON COLUMN attachments.author_id IS '上传者ID，必填'",
+				"COMMENT ON COLUMN attachments.workspace_id IS '所属工作区ID，必填'",
+			},
+		},
+		{
+			tableSQL: noteViewsTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN note_views.note_id IS '笔记ID'",
+				"COMMENT ON COLUMN note_views.view_date IS '日期（YYYY-MM-DD，UTC）'",
+				"COMMENT ON COLUMN note_views.view_count IS '当日浏览次数，默认0'",
+			},
+		},
+		{
+			tableSQL: redirectsTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN redirects.id IS '重定向ID，主键，自增'",
+				"COMMENT ON COLUMN redirects.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN redirects.source_path IS '旧地址路径，必填，唯一'",
+				"COMMENT ON COLUMN redirects.target_path IS '新地址路径，必填'",
+			},
+		},
+		{
+			tableSQL: savedSearchesTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN saved_searches.id IS '保存的搜索ID，主键，自增'",
+				"COMMENT ON COLUMN saved_searches.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN saved_searches.updated_at IS '更新时间，默认当前时间'",
+				"COMMENT ON COLUMN saved_searches.owner_id IS '创建者ID，必填'",
+				"COMMENT ON COLUMN saved_searches.name_text IS '名称，必填'",
+				"COMMENT ON COLUMN saved_searches.filter IS '过滤表达式，可选'",
+				"COMMENT ON COLUMN saved_searches.sort_by IS '排序字段，可选'",
+				"COMMENT ON COLUMN saved_searches.sort_desc IS '是否降序，默认降序'",
+				"COMMENT ON COLUMN saved_searches.pinned IS '是否置顶，默认不置顶'",
+				"COMMENT ON COLUMN saved_searches.shared IS '是否共享给其他用户，默认不共享'",
+				"COMMENT ON COLUMN saved_searches.feed_enabled IS '是否发布为订阅源，默认不发布'",
+			},
+		},
+		{
+			tableSQL: savedSearchViewsTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN saved_search_views.saved_search_id IS '保存的搜索ID'",
+				"COMMENT ON COLUMN saved_search_views.user_id IS '用户ID'",
+				"COMMENT ON COLUMN saved_search_views.last_viewed_at IS '上次查看时间'",
+			},
+		},
+		{
+			tableSQL: noteDraftsTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN note_drafts.note_id IS '笔记ID'",
+				"COMMENT ON COLUMN note_drafts.user_id IS '编辑者ID'",
+				"COMMENT ON COLUMN note_drafts.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN note_drafts.updated_at IS '最后自动保存时间，默认当前时间'",
+				"COMMENT ON COLUMN note_drafts.title IS '笔记标题，必填'",
+				"COMMENT ON COLUMN note_drafts.content IS '笔记内容（Markdown格式），可选'",
+				"COMMENT ON COLUMN note_drafts.summary IS '笔记摘要，可选'",
+				"COMMENT ON COLUMN note_drafts.category_id IS '分类ID，可选'",
+				"COMMENT ON COLUMN note_drafts.tag_ids IS '标签ID列表（逗号分隔），可选'",
+				"COMMENT ON COLUMN note_drafts.cover_image IS '封面图片URL，可选'",
+				"COMMENT ON COLUMN note_drafts.visibility IS '可见性（PUBLIC/PRIVATE），默认公开'",
+			},
+		},
+		{
+			tableSQL: noteRevisionsTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN note_revisions.id IS '修订ID，主键，自增'",
+				"COMMENT ON COLUMN note_revisions.created_at IS '发布时间，默认当前时间'",
+				"COMMENT ON COLUMN note_revisions.note_id IS '笔记ID，必填'",
+				"COMMENT ON COLUMN note_revisions.author_id IS '发布者ID，必填'",
+				"COMMENT ON COLUMN note_revisions.title IS '笔记标题，必填'",
+				"COMMENT ON COLUMN note_revisions.content IS '笔记内容（Markdown格式），可选'",
+				"COMMENT ON COLUMN note_revisions.summary IS '笔记摘要，可选'",
+			},
+		},
+		{
+			tableSQL: noteLinksTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN note_links.id IS '链接ID，主键，自增'",
+				"COMMENT ON COLUMN note_links.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN note_links.source_note_id IS '链接所在的笔记ID，必填'",
+				"COMMENT ON COLUMN note_links.target_note_id IS '链接指向的笔记ID，未解析时为空'",
+				"COMMENT ON COLUMN note_links.target IS '链接中书写的目标（笔记标题或 notes/{id}），必填'",
+				"COMMENT ON COLUMN note_links.target_key IS '规范化的链接目标，用于按标题解析，必填'",
+				"COMMENT ON COLUMN note_links.alias IS '链接显示的文本，可选'",
+			},
+		},
+		{
+			tableSQL: noteShareLinksTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN note_share_links.id IS '分享链接ID，主键，自增'",
+				"COMMENT ON COLUMN note_share_links.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN note_share_links.note_id IS '分享的笔记ID，必填'",
+				"COMMENT ON COLUMN note_share_links.creator_id IS '创建者ID，必填'",
+				"COMMENT ON COLUMN note_share_links.token_hash IS '分享令牌的 SHA-256 哈希，必填，唯一'",
+				"COMMENT ON COLUMN note_share_links.password_hash IS '访问密码哈希值，可选'",
+				"COMMENT ON COLUMN note_share_links.expire_at IS '过期时间，可选'",
+				"COMMENT ON COLUMN note_share_links.max_views IS '最多查看次数，0 表示不限制'",
+				"COMMENT ON COLUMN note_share_links.view_count IS '已查看次数，默认0'",
+				"COMMENT ON COLUMN note_share_links.revoked IS '是否已撤销，默认未撤销'",
+			},
+		},
+		{
+			tableSQL: notePermissionsTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN note_permissions.id IS '权限ID，主键，自增'",
+				"COMMENT ON COLUMN note_permissions.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN note_permissions.updated_at IS '更新时间，默认当前时间'",
+				"COMMENT ON COLUMN note_permissions.user_id IS '被授权的用户ID，必填'",
+				"COMMENT ON COLUMN note_permissions.note_id IS '授权的笔记ID，授权分类时为0'",
+				"COMMENT ON COLUMN note_permissions.category_id IS '授权的分类ID，授权笔记时为0'",
+				"COMMENT ON COLUMN note_permissions.role IS '角色（VIEWER/COMMENTER/EDITOR），必填'",
+				"COMMENT ON COLUMN note_permissions.granted_by IS '授权者ID，必填'",
+			},
+		},
+		{
+			tableSQL: workspacesTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN workspaces.id IS '工作区ID，主键，自增'",
+				"COMMENT ON COLUMN workspaces.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN workspaces.updated_at IS '更新时间，默认当前时间'",
+				"COMMENT ON COLUMN workspaces.slug IS 'URL友好的标识符，必填，唯一'",
+				"COMMENT ON COLUMN workspaces.name_text IS '工作区名称，必填'",
+				"COMMENT ON COLUMN workspaces.description IS '工作区描述，可选'",
+				"COMMENT ON COLUMN workspaces.creator_id IS '创建者ID，默认工作区为0'",
+			},
+		},
+		{
+			tableSQL: workspaceMembersTableSQL,
+			comments: []string{
+				"COMMENT ON COLUMN workspace_members.id IS '成员ID，主键，自增'",
+				"COMMENT ON COLUMN workspace_members.created_at IS '创建时间，默认当前时间'",
+				"COMMENT ON COLUMN workspace_members.updated_at IS '更新时间，默认当前时间'",
+				"COMMENT ON COLUMN workspace_members.workspace_id IS '工作区ID，必填'",
+				"COMMENT ON COLUMN workspace_members.user_id IS '用户ID，必填'",
+				"COMMENT ON COLUMN workspace_members.role IS '角色（VIEWER/MEMBER/ADMIN/OWNER），必填'",
 			},
 		},
 	}
@@ -627,6 +1382,41 @@ func (s *Store) runPostgresMigrations() error {
 		fmt.Printf("Warning: failed to migrate users email removal: %v\n", err)
 	}
 
+	// 迁移现有表：为 notes 表添加字数和字符数字段
+	if err := s.migrateAddNoteStatsColumns(); err != nil {
+		return fmt.Errorf("failed to migrate note stats columns: %w", err)
+	}
+
+	// 迁移现有表：为 notes 表添加定时发布和过期时间字段
+	if err := s.migrateAddNoteScheduleColumns(); err != nil {
+		return fmt.Errorf("failed to migrate note schedule columns: %w", err)
+	}
+
+	// 迁移现有表：为按工作区隔离的数据表添加工作区字段，并将已有数据和用户放入默认工作区
+	if err := s.migrateWorkspaces(); err != nil {
+		return fmt.Errorf("failed to migrate workspaces: %w", err)
+	}
+
+	// 迁移现有表：为标签别名添加工作区字段，别名改为在工作区内唯一
+	if err := s.migrateTagAliasWorkspaces(); err != nil {
+		return fmt.Errorf("failed to migrate tag alias workspaces: %w", err)
+	}
+
+	// 迁移现有表：将 notes.tag_ids 合并到 note_tags 后删除该字段，并重新计算标签计数
+	if err := s.migrateDropNoteTagIDs(); err != nil {
+		return fmt.Errorf("failed to migrate note tag ids: %w", err)
+	}
+
+	// 迁移现有表：为标签生成规范化名称并建立唯一索引，名称重复的标签合并为一个
+	if err := s.migrateTagNameKeys(); err != nil {
+		return fmt.Errorf("failed to migrate tag name keys: %w", err)
+	}
+
+	// 迁移现有表：为笔记链接建立索引，并解析已有笔记中的链接
+	if err := s.migrateNoteLinks(); err != nil {
+		return fmt.Errorf("failed to migrate note links: %w", err)
+	}
+
 	return nil
 }
 
@@ -765,3 +1555,484 @@ func (s *Store) migrateRemoveUsersEmailPostgres() error {
 
 	return nil
 }
+
+// migrateAddNoteStatsColumns 为 notes 表添加 word_count 和 char_count 字段
+func (s *Store) migrateAddNoteStatsColumns() error {
+	columns := []struct {
+		name       string
+		definition string
+	}{
+		{name: "word_count", definition: "INTEGER DEFAULT 0"},
+		{name: "char_count", definition: "INTEGER DEFAULT 0"},
+	}
+
+	for _, column := range columns {
+		if err := s.addColumnIfNotExists("notes", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateAddNoteScheduleColumns 为 notes 表添加 scheduled 和 expire_at 字段
+func (s *Store) migrateAddNoteScheduleColumns() error {
+	timeType := "DATETIME NULL"
+	if s.profile.Driver == "postgres" {
+		timeType = "TIMESTAMP NULL"
+	}
+	if err := s.addColumnIfNotExists("notes", "scheduled", "BOOLEAN DEFAULT FALSE"); err != nil {
+		return err
+	}
+	return s.addColumnIfNotExists("notes", "expire_at", timeType)
+}
+
+// migrateNoteLinks 为 note_links 建立索引；首次建立索引时解析已有笔记中的链接
+func (s *Store) migrateNoteLinks() error {
+	indexed, err := s.indexExists("note_links", "idx_note_links_source")
+	if err != nil {
+		return fmt.Errorf("failed to check index idx_note_links_source: %w", err)
+	}
+	indexes := []struct {
+		name    string
+		columns string
+	}{
+		{"idx_note_links_source", "source_note_id"},
+		{"idx_note_links_target", "target_note_id"},
+		{"idx_note_links_target_key", "target_key"},
+	}
+	for _, index := range indexes {
+		if err := s.createIndexIfNotExists("note_links", index.name, index.columns, false); err != nil {
+			return err
+		}
+	}
+	if indexed {
+		return nil
+	}
+
+	if _, err := s.RebuildNoteLinks(context.Background()); err != nil {
+		return fmt.Errorf("failed to parse existing note links: %w", err)
+	}
+	return nil
+}
+
+// migrateDropNoteTagIDs 删除 notes 表中逗号分隔的 tag_ids 字段，note_tags 成为笔记标签的唯一数据来源
+// 删除前将只存在于 tag_ids 中的关联（标签仍存在时）补充到 note_tags，删除后根据 note_tags 重新计算标签计数
+func (s *Store) migrateDropNoteTagIDs() error {
+	exists, err := s.columnExists("notes", "tag_ids")
+	if err != nil {
+		return fmt.Errorf("failed to check column notes.tag_ids: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT id, tag_ids FROM notes WHERE tag_ids IS NOT NULL AND tag_ids <> ''`)
+	if err != nil {
+		return fmt.Errorf("failed to read notes.tag_ids: %w", err)
+	}
+	type noteTag struct {
+		noteID int64
+		tagID  int64
+	}
+	var pairs []noteTag
+	for rows.Next() {
+		var noteID int64
+		var tagIDs string
+		if err := rows.Scan(&noteID, &tagIDs); err != nil {
+			rows.Close()
+			return err
+		}
+		for _, part := range strings.Split(tagIDs, ",") {
+			tagID, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil || tagID <= 0 {
+				continue
+			}
+			pairs = append(pairs, noteTag{noteID: noteID, tagID: tagID})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	// 只补充标签仍存在且 note_tags 中还没有的关联
+	insert := `
+		INSERT INTO note_tags (note_id, tag_id)
+		SELECT ?, ? FROM tags
+		WHERE id = ? AND NOT EXISTS (SELECT 1 FROM note_tags WHERE note_id = ? AND tag_id = ?)
+	`
+	if s.profile.Driver == "postgres" {
+		insert = `
+		INSERT INTO note_tags (note_id, tag_id)
+		SELECT $1, $2 FROM tags
+		WHERE id = $2 AND NOT EXISTS (SELECT 1 FROM note_tags WHERE note_id = $1 AND tag_id = $2)
+	`
+	}
+	for _, pair := range pairs {
+		args := []any{pair.noteID, pair.tagID, pair.tagID, pair.noteID, pair.tagID}
+		if s.profile.Driver == "postgres" {
+			args = args[:2]
+		}
+		if _, err := tx.Exec(insert, args...); err != nil {
+			return fmt.Errorf("failed to copy tag ids of note %d: %w", pair.noteID, err)
+		}
+	}
+
+	if _, err := tx.Exec(`ALTER TABLE notes DROP COLUMN tag_ids`); err != nil {
+		return fmt.Errorf("failed to drop column notes.tag_ids: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if _, err := s.RecountTags(context.Background()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// migrateSQLiteTimesToUTC 将 SQLite 中以服务器本地时区保存的时间改写为 UTC
+// 新写入的时间由驱动统一转换为 UTC（见 store/db/sqlite），只需处理在查询中比较的列；
+// 已经是 UTC 的值和 CURRENT_TIMESTAMP 默认值（本身就是 UTC，不带时区）不会被修改
+func (s *Store) migrateSQLiteTimesToUTC() error {
+	ctx := context.Background()
+	columns := []struct {
+		table  string
+		column string
+	}{
+		{"notes", "created_at"},
+		{"notes", "updated_at"},
+		{"notes", "published_at"},
+		{"notes", "expire_at"},
+		{"note_share_links", "expire_at"},
+	}
+	for _, c := range columns {
+		text := "CAST(" + c.column + " AS TEXT)"
+		rows, err := s.db.QueryContext(ctx, `SELECT id, `+text+` FROM `+c.table+
+			` WHERE `+c.column+` IS NOT NULL AND `+text+` LIKE '% % %' AND `+text+` NOT LIKE '% +0000 UTC%'`)
+		if err != nil {
+			return fmt.Errorf("failed to read %s.%s: %w", c.table, c.column, err)
+		}
+		times := map[int64]time.Time{}
+		for rows.Next() {
+			var id int64
+			var value string
+			if err := rows.Scan(&id, &value); err != nil {
+				rows.Close()
+				return err
+			}
+			// 去掉 time.Time.String() 附带的单调时钟读数
+			value, _, _ = strings.Cut(value, " m=")
+			t, err := time.Parse(sqliteTimeLayout, value)
+			if err != nil {
+				continue
+			}
+			times[id] = t
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return err
+		}
+		rows.Close()
+
+		for id, t := range times {
+			if _, err := s.db.ExecContext(ctx, `UPDATE `+c.table+` SET `+c.column+` = ? WHERE id = ?`, t.UTC(), id); err != nil {
+				return fmt.Errorf("failed to update %s.%s: %w", c.table, c.column, err)
+			}
+		}
+	}
+	return nil
+}
+
+// migrateTagNameKeys 为标签生成规范化名称（见 TagNameKey）并建立工作区内唯一的索引
+// 同一工作区中规范化后名称相同的标签（例如 "Go" 和 "ｇｏ"）合并到ID最小的标签，笔记标签关系和别名一并转移
+func (s *Store) migrateTagNameKeys() error {
+	definition := "VARCHAR(100)"
+	if s.profile.Driver == "mysql" {
+		definition = "VARCHAR(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NULL"
+	}
+	if err := s.addColumnIfNotExists("tags", "name_key", definition); err != nil {
+		return err
+	}
+
+	rows, err := s.db.Query(`SELECT id, name_text, name_key, workspace_id FROM tags ORDER BY id`)
+	if err != nil {
+		return fmt.Errorf("failed to read tags: %w", err)
+	}
+	type tagKey struct {
+		id  int64
+		key string
+	}
+	type workspaceKey struct {
+		workspaceID int64
+		key         string
+	}
+	owners := make(map[workspaceKey]int64)
+	merges := make(map[int64][]int64)
+	var updates []tagKey
+	for rows.Next() {
+		var id, workspaceID int64
+		var name string
+		var current sql.NullString
+		if err := rows.Scan(&id, &name, &current, &workspaceID); err != nil {
+			rows.Close()
+			return err
+		}
+		key := TagNameKey(name)
+		if owner, ok := owners[workspaceKey{workspaceID, key}]; ok {
+			merges[owner] = append(merges[owner], id)
+			continue
+		}
+		owners[workspaceKey{workspaceID, key}] = id
+		if !current.Valid || current.String != key {
+			updates = append(updates, tagKey{id: id, key: key})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	if len(merges) > 0 || len(updates) > 0 {
+		ctx := context.Background()
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		for target, sources := range merges {
+			if err := s.mergeTags(ctx, tx, target, sources); err != nil {
+				return fmt.Errorf("failed to merge duplicate tags into %d: %w", target, err)
+			}
+		}
+		// 先清空再写入，避免更新过程中与其他标签的旧值冲突
+		for _, update := range updates {
+			if _, err := tx.Exec(s.rebind(`UPDATE tags SET name_key = NULL WHERE id = ?`), update.id); err != nil {
+				return err
+			}
+		}
+		for _, update := range updates {
+			if _, err := tx.Exec(s.rebind(`UPDATE tags SET name_key = ? WHERE id = ?`), update.key, update.id); err != nil {
+				return fmt.Errorf("failed to update name key of tag %d: %w", update.id, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+
+	// 标签名称只需在工作区内唯一，替换早期版本建立的全局唯一索引
+	if err := s.dropIndexIfExists("tags", "idx_tags_name_key"); err != nil {
+		return err
+	}
+	return s.createIndexIfNotExists("tags", "idx_tags_workspace_name_key", "workspace_id, name_key", true)
+}
+
+// migrateTagAliasWorkspaces 为标签别名添加 workspace_id 字段（取自所属的标签），并将别名的唯一索引从全局改为工作区内
+// 早期版本在建表时为 alias_key 声明了全局唯一约束，需要按数据库分别删除：SQLite 的自动索引不能单独删除，只能重建表
+func (s *Store) migrateTagAliasWorkspaces() error {
+	if err := s.addColumnIfNotExists("tag_aliases", "workspace_id", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	// workspace_id 为 0 的别名（升级前的数据或从旧版本备份恢复的数据）放入所属标签的工作区
+	if _, err := s.db.Exec(`UPDATE tag_aliases SET workspace_id = (SELECT workspace_id FROM tags WHERE tags.id = tag_aliases.tag_id)
+		WHERE workspace_id = 0 AND tag_id IN (SELECT id FROM tags)`); err != nil {
+		return fmt.Errorf("failed to move tag aliases to workspaces: %w", err)
+	}
+
+	switch s.profile.Driver {
+	case "sqlite":
+		if err := s.rebuildTagAliasesSQLite(); err != nil {
+			return err
+		}
+	case "mysql":
+		// 列级 UNIQUE 约束建立的索引以列名命名
+		if err := s.dropIndexIfExists("tag_aliases", "alias_key"); err != nil {
+			return err
+		}
+	case "postgres":
+		if _, err := s.db.Exec(`ALTER TABLE tag_aliases DROP CONSTRAINT IF EXISTS tag_aliases_alias_key_key`); err != nil {
+			return fmt.Errorf("failed to drop unique constraint of tag alias keys: %w", err)
+		}
+	}
+	return s.createIndexIfNotExists("tag_aliases", "idx_tag_aliases_workspace_alias_key", "workspace_id, alias_key", true)
+}
+
+// rebuildTagAliasesSQLite 重建 tag_aliases 表以去掉 alias_key 的全局唯一约束（SQLite），表中没有该约束时不做任何修改
+func (s *Store) rebuildTagAliasesSQLite() error {
+	var autoIndexes int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND tbl_name = 'tag_aliases' AND name LIKE 'sqlite_autoindex_%'`).Scan(&autoIndexes); err != nil {
+		return fmt.Errorf("failed to check tag alias indexes: %w", err)
+	}
+	if autoIndexes == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		`CREATE TABLE tag_aliases_new (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			tag_id INTEGER NOT NULL,
+			alias VARCHAR(100) NOT NULL,
+			alias_key VARCHAR(100) NOT NULL,
+			workspace_id INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (tag_id) REFERENCES tags(id)
+		)`,
+		`INSERT INTO tag_aliases_new (id, created_at, tag_id, alias, alias_key, workspace_id)
+			SELECT id, created_at, tag_id, alias, alias_key, workspace_id FROM tag_aliases`,
+		`DROP TABLE tag_aliases`,
+		`ALTER TABLE tag_aliases_new RENAME TO tag_aliases`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("failed to rebuild tag aliases table: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// columnExists 检查表中是否存在指定列
+func (s *Store) columnExists(table, column string) (bool, error) {
+	var query string
+	switch s.profile.Driver {
+	case "sqlite":
+		query = "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?"
+	case "mysql":
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?"
+	case "postgres":
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = 'public' AND table_name = $1 AND column_name = $2"
+	default:
+		return false, fmt.Errorf("unsupported database driver: %s", s.profile.Driver)
+	}
+
+	var count int
+	if err := s.db.QueryRow(query, table, column).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// indexExists 检查表中是否存在指定索引
+func (s *Store) indexExists(table, index string) (bool, error) {
+	var count int
+	var err error
+	switch s.profile.Driver {
+	case "sqlite":
+		err = s.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND name = ?", table, index).Scan(&count)
+	case "mysql":
+		err = s.db.QueryRow("SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?", table, index).Scan(&count)
+	case "postgres":
+		err = s.db.QueryRow("SELECT COUNT(*) FROM pg_indexes WHERE schemaname = 'public' AND tablename = $1 AND indexname = $2", table, index).Scan(&count)
+	default:
+		return false, fmt.Errorf("unsupported database driver: %s", s.profile.Driver)
+	}
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// createIndexIfNotExists 当索引不存在时创建索引（MySQL 不支持 CREATE INDEX IF NOT EXISTS）
+func (s *Store) createIndexIfNotExists(table, index, columns string, unique bool) error {
+	exists, err := s.indexExists(table, index)
+	if err != nil {
+		return fmt.Errorf("failed to check index %s: %w", index, err)
+	}
+	if exists {
+		return nil
+	}
+
+	statement := "CREATE INDEX"
+	if unique {
+		statement = "CREATE UNIQUE INDEX"
+	}
+	if _, err := s.db.Exec(fmt.Sprintf("%s %s ON %s (%s)", statement, index, table, columns)); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", index, err)
+	}
+	return nil
+}
+
+// dropIndexIfExists 当索引存在时删除索引（MySQL 的 DROP INDEX 需要指定表名）
+func (s *Store) dropIndexIfExists(table, index string) error {
+	exists, err := s.indexExists(table, index)
+	if err != nil {
+		return fmt.Errorf("failed to check index %s: %w", index, err)
+	}
+	if !exists {
+		return nil
+	}
+
+	statement := fmt.Sprintf("DROP INDEX %s", index)
+	if s.profile.Driver == "mysql" {
+		statement = fmt.Sprintf("DROP INDEX %s ON %s", index, table)
+	}
+	if _, err := s.db.Exec(statement); err != nil {
+		return fmt.Errorf("failed to drop index %s: %w", index, err)
+	}
+	return nil
+}
+
+// rowExists 执行 COUNT(*) 查询，返回记录是否存在，可以在事务中调用
+// 更新或插入前用它检查记录是否存在，而不是使用各数据库语法不同的 upsert（ON CONFLICT、ON DUPLICATE KEY UPDATE）
+func (s *Store) rowExists(ctx context.Context, q rowQueryer, query string, args ...any) (bool, error) {
+	var count int
+	if err := q.QueryRowContext(ctx, s.rebind(query), args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// rebind 将查询中的 ? 占位符转换为当前数据库的占位符（PostgreSQL 使用 $1, $2, ...）
+// 用于迁移过程中也会执行的参数化查询
+func (s *Store) rebind(query string) string {
+	if s.profile.Driver != "postgres" {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// addColumnIfNotExists 当列不存在时为表添加该列
+// definition 为列类型及约束，例如 "INTEGER DEFAULT 0"，需同时兼容三种数据库
+func (s *Store) addColumnIfNotExists(table, column, definition string) error {
+	exists, err := s.columnExists(table, column)
+	if err != nil {
+		return fmt.Errorf("failed to check column %s.%s: %w", table, column, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/wdmsyhh/simple-notes/internal/profile"
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+	"github.com/wdmsyhh/simple-notes/store/db"
+)
+
+// newTestStore 在临时目录中创建 SQLite 数据库并执行迁移
+func newTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	dir := t.TempDir()
+	p := &profile.Profile{Driver: "sqlite", DSN: filepath.Join(dir, "test.db"), Data: dir}
+	driver, err := db.NewDBDriver(p)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	s := store.NewStore(driver, p)
+	t.Cleanup(func() { s.Close() })
+	if err := s.RunMigrations(); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	return s
+}
+
+// createTestUser 创建用户
+func createTestUser(t *testing.T, s *store.Store, username string, role store.UserRole) *store.User {
+	t.Helper()
+	user, err := s.CreateUser(context.Background(), &store.User{Username: username, PasswordHash: "x", Role: role})
+	if err != nil {
+		t.Fatalf("failed to create user %s: %v", username, err)
+	}
+	return user
+}
+
+// createTestWorkspace 创建由 owner 所有的工作区，返回工作区ID
+func createTestWorkspace(t *testing.T, s *store.Store, slug string, owner *store.User) int64 {
+	t.Helper()
+	workspace, err := s.CreateWorkspace(context.Background(), &pbstore.Workspace{Slug: slug, NameText: slug}, int64(owner.ID))
+	if err != nil {
+		t.Fatalf("failed to create workspace %s: %v", slug, err)
+	}
+	return workspace.Id
+}
+
+// createTestNote 在上下文所选的工作区中创建已发布的笔记
+func createTestNote(t *testing.T, ctx context.Context, s *store.Store, note *pbstore.Note) *pbstore.Note {
+	t.Helper()
+	note.Published = true
+	created, err := s.CreateNote(ctx, note)
+	if err != nil {
+		t.Fatalf("failed to create note %q: %v", note.Title, err)
+	}
+	return created
+}
+
+// userID 返回用户ID的字符串形式，与笔记中的 AuthorId 一致
+func userID(user *store.User) string {
+	return strconv.FormatUint(uint64(user.ID), 10)
+}
+
+// noteTitles 返回笔记标题，用于比较查询结果
+func noteTitles(notes []*pbstore.Note) []string {
+	titles := make([]string, len(notes))
+	for i, note := range notes {
+		titles[i] = note.Title
+	}
+	return titles
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	apiv1 "github.com/wdmsyhh/simple-notes/proto/gen/api/v1"
@@ -13,20 +14,26 @@ import (
 
 // ListTags 获取标签列表，支持可选的分页
 func (s *Store) ListTags(ctx context.Context, req *apiv1.ListTagsRequest) ([]*store.Tag, int64, error) {
+	// 按工作区过滤
+	where := ""
+	condition, params := workspaceCondition(ctx, "workspace_id")
+	if condition != "" {
+		where = " WHERE " + condition
+	}
+
 	// 构建计数查询
-	countQuery := `SELECT COUNT(*) FROM tags`
+	countQuery := `SELECT COUNT(*) FROM tags` + where
 
 	// 获取总记录数
 	var total int64
-	if err := s.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+	if err := s.db.QueryRowContext(ctx, countQuery, params...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	// 构建主查询
-	query := `SELECT * FROM tags ORDER BY count desc, name_text asc`
+	query := `SELECT ` + tagColumns + ` FROM tags` + where + ` ORDER BY count desc, name_text asc`
 
 	// 应用分页
-	params := []interface{}{}
 	if req.Limit > 0 {
 		query += " LIMIT ?"
 		params = append(params, req.Limit)
@@ -57,6 +64,11 @@ func (s *Store) ListTags(ctx context.Context, req *apiv1.ListTagsRequest) ([]*st
 	if err := rows.Err(); err != nil {
 		return nil, 0, err
 	}
+	rows.Close()
+
+	if err := s.loadTagAliases(ctx, tags); err != nil {
+		return nil, 0, err
+	}
 
 	return tags, total, nil
 }
@@ -64,8 +76,8 @@ func (s *Store) ListTags(ctx context.Context, req *apiv1.ListTagsRequest) ([]*st
 // GetTag 根据ID获取标签
 func (s *Store) GetTag(ctx context.Context, tagID int64) (*store.Tag, error) {
 	// 根据ID查询标签
-	query := `SELECT * FROM tags WHERE id = ?`
-	row := s.db.QueryRowContext(ctx, query, tagID)
+	query, params := andWorkspace(ctx, `SELECT `+tagColumns+` FROM tags WHERE id = ?`, "workspace_id", tagID)
+	row := s.db.QueryRowContext(ctx, query, params...)
 
 	tag, err := scanTag(row)
 	if err != nil {
@@ -75,26 +87,53 @@ func (s *Store) GetTag(ctx context.Context, tagID int64) (*store.Tag, error) {
 		return nil, err
 	}
 
+	if err := s.loadTagAliases(ctx, []*store.Tag{tag}); err != nil {
+		return nil, err
+	}
+
 	return tag, nil
 }
 
-// CreateTag 创建新标签
+// CreateTag 创建新标签，使用次数由笔记标签关系维护，新标签的计数为0
+// 名称规范化后（见 TagNameKey）与已有标签的名称或别名相同时，不会创建新标签，而是返回已有的标签
 func (s *Store) CreateTag(ctx context.Context, tag *store.Tag) (*store.Tag, error) {
+	name := strings.TrimSpace(tag.NameText)
+	key := TagNameKey(name)
+	if key == "" {
+		return nil, fmt.Errorf("tag name is required")
+	}
+
+	// 只在新标签所属的工作区中查找同名标签
+	workspaceID, err := s.writeWorkspaceID(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	ctx = WithWorkspace(ctx, workspaceID)
+
+	existingID, err := s.resolveTagKey(ctx, s.db, key)
+	if err != nil {
+		return nil, err
+	}
+	if existingID > 0 {
+		return s.GetTag(ctx, existingID)
+	}
+
 	now := time.Now()
 
 	// 插入标签（不包含 slug 字段）
 	query := `
 		INSERT INTO tags (
-			name_text, description, count, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?)
+			name_text, name_key, description, count, created_at, updated_at, workspace_id
+		) VALUES (?, ?, ?, 0, ?, ?, ?)
 	`
 
 	result, err := s.db.ExecContext(ctx, query,
-		tag.NameText,
+		name,
+		key,
 		tag.Description,
-		tag.Count,
 		now,
 		now,
+		workspaceID,
 	)
 	if err != nil {
 		return nil, err
@@ -109,22 +148,47 @@ func (s *Store) CreateTag(ctx context.Context, tag *store.Tag) (*store.Tag, erro
 	return s.GetTag(ctx, id)
 }
 
-// UpdateTag 更新现有标签
+// UpdateTag 更新现有标签（使用次数不会被覆盖）
+// 新名称规范化后与其他标签的名称或别名相同时返回 ErrTagExists；与本标签的别名相同时删除该别名
 func (s *Store) UpdateTag(ctx context.Context, tag *store.Tag) (*store.Tag, error) {
+	name := strings.TrimSpace(tag.NameText)
+	key := TagNameKey(name)
+	if key == "" {
+		return nil, fmt.Errorf("tag name is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	existingID, err := s.resolveTagKey(ctx, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if existingID > 0 && existingID != tag.Id {
+		return nil, fmt.Errorf("%w: %s", ErrTagExists, name)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tag_aliases WHERE tag_id = ? AND alias_key = ?", tag.Id, key); err != nil {
+		return nil, err
+	}
+
 	// 更新标签（不包含 slug 字段）
 	query := `
 		UPDATE tags SET 
-			name_text = ?, description = ?, count = ?, updated_at = ?
+			name_text = ?, name_key = ?, description = ?, updated_at = ?
 		WHERE id = ?
 	`
 
-	result, err := s.db.ExecContext(ctx, query,
-		tag.NameText,
+	query, params := andWorkspace(ctx, query, "workspace_id",
+		name,
+		key,
 		tag.Description,
-		tag.Count,
 		time.Now(),
 		tag.Id,
 	)
+	result, err := tx.ExecContext(ctx, query, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +202,10 @@ func (s *Store) UpdateTag(ctx context.Context, tag *store.Tag) (*store.Tag, erro
 		return nil, fmt.Errorf("tag not found: %d", tag.Id)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return s.GetTag(ctx, tag.Id)
 }
 
@@ -167,8 +235,14 @@ func (s *Store) DeleteTag(ctx context.Context, tagID int64) error {
 		return err
 	}
 
+	// 删除标签的别名
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tag_aliases WHERE tag_id = ?", tagID); err != nil {
+		return err
+	}
+
 	// 删除标签
-	result, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE id = ?", tagID)
+	query, params := andWorkspace(ctx, "DELETE FROM tags WHERE id = ?", "workspace_id", tagID)
+	result, err := tx.ExecContext(ctx, query, params...)
 	if err != nil {
 		return err
 	}
@@ -189,8 +263,8 @@ func (s *Store) DeleteTag(ctx context.Context, tagID int64) error {
 // GetTagBySlug 通过slug获取标签
 func (s *Store) GetTagBySlug(ctx context.Context, slug string) (*store.Tag, error) {
 	// 根据slug查询标签
-	query := `SELECT * FROM tags WHERE slug = ?`
-	row := s.db.QueryRowContext(ctx, query, slug)
+	query, params := andWorkspace(ctx, `SELECT `+tagColumns+` FROM tags WHERE slug = ?`, "workspace_id", slug)
+	row := s.db.QueryRowContext(ctx, query, params...)
 
 	tag, err := scanTag(row)
 	if err != nil {
@@ -203,48 +277,60 @@ func (s *Store) GetTagBySlug(ctx context.Context, slug string) (*store.Tag, erro
 	return tag, nil
 }
 
-// IncrementTagCount 增加标签计数
-func (s *Store) IncrementTagCount(ctx context.Context, tagID int64) error {
-	// 更新标签计数
-	query := `UPDATE tags SET count = count + 1, updated_at = ? WHERE id = ?`
-	result, err := s.db.ExecContext(ctx, query, time.Now(), tagID)
-	if err != nil {
-		return err
+// FindTagByName 根据名称或别名查找标签（忽略大小写和全角/半角），不存在时返回 nil
+func (s *Store) FindTagByName(ctx context.Context, name string) (*store.Tag, error) {
+	key := TagNameKey(name)
+	if key == "" {
+		return nil, nil
 	}
-
-	// 检查标签是否存在并已更新
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("tag not found: %d", tagID)
+	id, err := s.resolveTagKey(ctx, s.db, key)
+	if err != nil || id == 0 {
+		return nil, err
 	}
-
-	return nil
+	return s.GetTag(ctx, id)
 }
 
-// DecrementTagCount 减少标签计数，确保不小于0
-func (s *Store) DecrementTagCount(ctx context.Context, tagID int64) error {
-	// 更新标签计数
-	query := `UPDATE tags SET count = GREATEST(count - 1, 0), updated_at = ? WHERE id = ?`
-	result, err := s.db.ExecContext(ctx, query, time.Now(), tagID)
+// SuggestTags 返回名称或别名以 prefix 开头的标签（忽略大小写和全角/半角），按使用次数排序，用于标签输入的自动补全
+func (s *Store) SuggestTags(ctx context.Context, prefix string, limit int) ([]*store.Tag, error) {
+	pattern := likeEscaper.Replace(TagNameKey(prefix)) + "%"
+	query, params := andWorkspace(ctx, `SELECT `+tagColumns+` FROM tags
+		WHERE (name_key LIKE ? ESCAPE '!'
+			OR id IN (SELECT tag_id FROM tag_aliases WHERE alias_key LIKE ? ESCAPE '!'))`, "workspace_id", pattern, pattern)
+	query += `
+		ORDER BY count DESC, name_text ASC
+		LIMIT ?`
+
+	rows, err := s.db.QueryContext(ctx, query, append(params, limit)...)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	// 检查标签是否存在并已更新
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
+	tags := []*store.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
 	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("tag not found: %d", tagID)
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	rows.Close()
 
-	return nil
+	if err := s.loadTagAliases(ctx, tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
 }
 
+// likeEscaper 转义 LIKE 模式中的通配符，配合 ESCAPE '!' 使用（MySQL 字符串中的反斜杠需要再次转义，因此不使用反斜杠）
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// tagColumns 标签表的查询字段，顺序与 scanTag 保持一致
+const tagColumns = `id, created_at, updated_at, deleted_at, name_text, description, count`
+
 // tagRow 用于扫描数据库行的临时结构体
 type tagRow struct {
 	// id 标签ID
@@ -0,0 +1,252 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/width"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// ErrTagExists 标签名称或别名已被其他标签使用
+var ErrTagExists = errors.New("tag name already in use")
+
+// TagNameKey 返回标签名称的规范化形式，用于判断名称是否重复：
+// 全角字符转换为半角、转换为小写，并合并连续的空白字符
+func TagNameKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(width.Fold.String(name))), " ")
+}
+
+// resolveTagKey 根据规范化名称在上下文所选的工作区中查找标签ID，先匹配标签名称再匹配别名，不存在时返回 0
+func (s *Store) resolveTagKey(ctx context.Context, q rowQueryer, key string) (int64, error) {
+	var id int64
+	query, params := andWorkspace(ctx, `SELECT id FROM tags WHERE name_key = ?`, "workspace_id", key)
+	err := q.QueryRowContext(ctx, query, params...).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	query, params = andWorkspace(ctx, `SELECT tag_id FROM tag_aliases WHERE alias_key = ?`, "workspace_id", key)
+	err = q.QueryRowContext(ctx, query, params...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+// rowQueryer 可以执行单行查询的数据库连接或事务
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// loadTagAliases 加载标签的别名（按别名排序）
+func (s *Store) loadTagAliases(ctx context.Context, tags []*store.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*store.Tag, len(tags))
+	placeholders := make([]string, 0, len(tags))
+	params := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		tag.Aliases = []string{}
+		if _, ok := byID[tag.Id]; ok {
+			continue
+		}
+		byID[tag.Id] = tag
+		placeholders = append(placeholders, "?")
+		params = append(params, tag.Id)
+	}
+
+	query := `SELECT tag_id, alias FROM tag_aliases WHERE tag_id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY tag_id, alias`
+	rows, err := s.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tagID int64
+		var alias string
+		if err := rows.Scan(&tagID, &alias); err != nil {
+			return err
+		}
+		tag := byID[tagID]
+		tag.Aliases = append(tag.Aliases, alias)
+	}
+	return rows.Err()
+}
+
+// CreateTagAlias 为标签添加别名，创建名称与别名相同的标签时会解析为该标签
+// 别名规范化后与其他标签的名称或别名相同时返回 ErrTagExists
+func (s *Store) CreateTagAlias(ctx context.Context, tagID int64, alias string) (*store.Tag, error) {
+	alias = strings.TrimSpace(alias)
+	key := TagNameKey(alias)
+	if key == "" {
+		return nil, fmt.Errorf("alias is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 别名属于标签所在的工作区
+	var workspaceID int64
+	query, params := andWorkspace(ctx, "SELECT workspace_id FROM tags WHERE id = ?", "workspace_id", tagID)
+	if err := tx.QueryRowContext(ctx, query, params...).Scan(&workspaceID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("tag not found: %d", tagID)
+		}
+		return nil, err
+	}
+
+	existingID, err := s.resolveTagKey(ctx, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case existingID == tagID:
+		// 已经是该标签的名称或别名
+	case existingID > 0:
+		return nil, fmt.Errorf("%w: %s", ErrTagExists, alias)
+	default:
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO tag_aliases (tag_id, alias, alias_key, workspace_id, created_at) VALUES (?, ?, ?, ?, ?)",
+			tagID, alias, key, workspaceID, time.Now(),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetTag(ctx, tagID)
+}
+
+// DeleteTagAlias 删除标签的别名
+func (s *Store) DeleteTagAlias(ctx context.Context, tagID int64, alias string) (*store.Tag, error) {
+	query, params := andWorkspace(ctx, "DELETE FROM tag_aliases WHERE tag_id = ? AND alias_key = ?", "workspace_id", tagID, TagNameKey(alias))
+	result, err := s.db.ExecContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("tag alias not found: %s", alias)
+	}
+	return s.GetTag(ctx, tagID)
+}
+
+// MergeTags 将 sourceIDs 中的标签合并到 targetID：
+// 笔记标签关系转移到目标标签，来源标签的名称和别名成为目标标签的别名，然后删除来源标签并重新计算计数，全部在一个事务中完成
+func (s *Store) MergeTags(ctx context.Context, targetID int64, sourceIDs []int64) (*store.Tag, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, id := range append([]int64{targetID}, sourceIDs...) {
+		var exists int
+		query, params := andWorkspace(ctx, "SELECT COUNT(*) FROM tags WHERE id = ?", "workspace_id", id)
+		if err := tx.QueryRowContext(ctx, query, params...).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if exists == 0 {
+			return nil, fmt.Errorf("tag not found: %d", id)
+		}
+	}
+
+	if err := s.mergeTags(ctx, tx, targetID, sourceIDs); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetTag(ctx, targetID)
+}
+
+// mergeTags 在事务中将来源标签合并到目标标签，迁移时也会调用，因此查询使用 rebind
+func (s *Store) mergeTags(ctx context.Context, tx *sql.Tx, targetID int64, sourceIDs []int64) error {
+	// 迁移时目标标签的 name_key 可能还没有生成，因此根据名称计算
+	var targetName string
+	var workspaceID int64
+	if err := tx.QueryRowContext(ctx, s.rebind("SELECT name_text, workspace_id FROM tags WHERE id = ?"), targetID).Scan(&targetName, &workspaceID); err != nil {
+		return err
+	}
+	targetKey := TagNameKey(targetName)
+
+	merged := make(map[int64]bool, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID || merged[sourceID] {
+			continue
+		}
+		merged[sourceID] = true
+
+		var sourceName string
+		if err := tx.QueryRowContext(ctx, s.rebind("SELECT name_text FROM tags WHERE id = ?"), sourceID).Scan(&sourceName); err != nil {
+			return err
+		}
+
+		// 转移笔记标签关系，目标标签已有的关系不重复添加
+		statements := []struct {
+			query string
+			args  []any
+		}{
+			{`INSERT INTO note_tags (note_id, tag_id)
+				SELECT note_id, ? FROM note_tags
+				WHERE tag_id = ? AND note_id NOT IN (SELECT note_id FROM note_tags WHERE tag_id = ?)`,
+				[]any{targetID, sourceID, targetID}},
+			{`DELETE FROM note_tags WHERE tag_id = ?`, []any{sourceID}},
+			{`UPDATE tag_aliases SET tag_id = ? WHERE tag_id = ?`, []any{targetID, sourceID}},
+		}
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, s.rebind(statement.query), statement.args...); err != nil {
+				return err
+			}
+		}
+
+		// 来源标签的名称成为目标标签的别名
+		sourceKey := TagNameKey(sourceName)
+		if sourceKey != "" && sourceKey != targetKey {
+			var aliased int
+			if err := tx.QueryRowContext(ctx, s.rebind("SELECT COUNT(*) FROM tag_aliases WHERE workspace_id = ? AND alias_key = ?"), workspaceID, sourceKey).Scan(&aliased); err != nil {
+				return err
+			}
+			if aliased == 0 {
+				if _, err := tx.ExecContext(ctx,
+					s.rebind("INSERT INTO tag_aliases (tag_id, alias, alias_key, workspace_id, created_at) VALUES (?, ?, ?, ?, ?)"),
+					targetID, strings.TrimSpace(sourceName), sourceKey, workspaceID, time.Now(),
+				); err != nil {
+					return err
+				}
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM tags WHERE id = ?"), sourceID); err != nil {
+			return err
+		}
+	}
+
+	// 目标标签的名称不需要作为别名
+	if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM tag_aliases WHERE workspace_id = ? AND alias_key = ?"), workspaceID, targetKey); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, s.rebind(`UPDATE tags SET count = (SELECT COUNT(*) FROM note_tags WHERE note_tags.tag_id = tags.id) WHERE id = ?`), targetID)
+	return err
+}
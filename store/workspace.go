@@ -0,0 +1,574 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wdmsyhh/simple-notes/proto/gen/store"
+)
+
+// 工作区用于隔离不同团队的数据：笔记、分类、标签、页面和附件都属于一个工作区（workspace_id 字段）。
+// 请求选择的工作区保存在上下文中（见 WithWorkspace），存储层的查询只返回该工作区的数据，新建的数据属于该工作区；
+// 上下文没有选择工作区时按默认工作区处理，遗漏选择工作区的调用不会读到其他工作区的数据。
+// 导出全部数据等需要跨工作区访问的内部调用使用 AllWorkspaces 显式取消过滤，这时新建的数据属于默认工作区。
+// 评论、链接、草稿、权限等附属数据通过所属的笔记间接属于工作区。
+
+// DefaultWorkspaceSlug 默认工作区的标识符，迁移时已有的数据和用户放入默认工作区
+const DefaultWorkspaceSlug = "default"
+
+// ErrWorkspaceNotEmpty 工作区中还有内容，不能删除
+var ErrWorkspaceNotEmpty = errors.New("workspace is not empty")
+
+// ErrLastWorkspaceOwner 工作区至少需要保留一个所有者
+var ErrLastWorkspaceOwner = errors.New("workspace must keep at least one owner")
+
+// workspaceScopedTables 按工作区隔离的数据表
+var workspaceScopedTables = []string{"notes", "categories", "tags", "pages", "attachments"}
+
+// workspaceColumns 工作区表的查询字段，顺序与 scanWorkspace 保持一致
+const workspaceColumns = `w.id, w.created_at, w.updated_at, w.slug, w.name_text, w.description, w.creator_id`
+
+// workspaceRoleNames 工作区角色在数据库中保存的名称
+var workspaceRoleNames = map[store.WorkspaceRole]string{
+	store.WorkspaceRole_WORKSPACE_ROLE_VIEWER: "VIEWER",
+	store.WorkspaceRole_WORKSPACE_ROLE_MEMBER: "MEMBER",
+	store.WorkspaceRole_WORKSPACE_ROLE_ADMIN:  "ADMIN",
+	store.WorkspaceRole_WORKSPACE_ROLE_OWNER:  "OWNER",
+}
+
+// parseWorkspaceRole 解析数据库中保存的角色名称，无法识别时返回 UNSPECIFIED
+func parseWorkspaceRole(name string) store.WorkspaceRole {
+	for role, roleName := range workspaceRoleNames {
+		if roleName == name {
+			return role
+		}
+	}
+	return store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+}
+
+// workspaceContextKey 上下文中保存所选工作区ID的键
+type workspaceContextKey struct{}
+
+// allWorkspacesID 上下文中表示不按工作区过滤的特殊值，见 AllWorkspaces
+const allWorkspacesID int64 = -1
+
+// WithWorkspace 返回选择了工作区的上下文，workspaceID 为 0 时按默认工作区处理
+func WithWorkspace(ctx context.Context, workspaceID int64) context.Context {
+	return context.WithValue(ctx, workspaceContextKey{}, workspaceID)
+}
+
+// AllWorkspaces 返回不按工作区过滤的上下文，只用于导出用户的全部数据、通过分享链接查找笔记等需要跨工作区访问的内部调用
+func AllWorkspaces(ctx context.Context) context.Context {
+	return context.WithValue(ctx, workspaceContextKey{}, allWorkspacesID)
+}
+
+// WorkspaceFromContext 返回上下文选择的工作区ID，未选择工作区或不按工作区过滤时返回 0
+func WorkspaceFromContext(ctx context.Context) int64 {
+	if id, ok := ctx.Value(workspaceContextKey{}).(int64); ok && id > 0 {
+		return id
+	}
+	return 0
+}
+
+// isAllWorkspaces 检查上下文是否通过 AllWorkspaces 取消了工作区过滤
+func isAllWorkspaces(ctx context.Context) bool {
+	id, ok := ctx.Value(workspaceContextKey{}).(int64)
+	return ok && id == allWorkspacesID
+}
+
+// workspaceCondition 返回将 column 限制为上下文所选工作区的条件，未选择工作区时限制为默认工作区，
+// 只有通过 AllWorkspaces 取消过滤时返回空字符串
+func workspaceCondition(ctx context.Context, column string) (string, []interface{}) {
+	if isAllWorkspaces(ctx) {
+		return "", nil
+	}
+	if workspaceID := WorkspaceFromContext(ctx); workspaceID > 0 {
+		return column + " = ?", []interface{}{workspaceID}
+	}
+	return column + " = (SELECT id FROM workspaces WHERE slug = ?)", []interface{}{DefaultWorkspaceSlug}
+}
+
+// andWorkspace 在查询条件后追加工作区条件，用于按ID查询单条数据
+func andWorkspace(ctx context.Context, query, column string, params ...interface{}) (string, []interface{}) {
+	condition, workspaceParams := workspaceCondition(ctx, column)
+	if condition == "" {
+		return query, params
+	}
+	return query + " AND " + condition, append(params, workspaceParams...)
+}
+
+// writeWorkspaceID 返回新建数据所属的工作区：上下文所选的工作区，未选择时为默认工作区
+func (s *Store) writeWorkspaceID(ctx context.Context, q rowQueryer) (int64, error) {
+	if workspaceID := WorkspaceFromContext(ctx); workspaceID > 0 {
+		return workspaceID, nil
+	}
+	return s.defaultWorkspaceID(ctx, q)
+}
+
+// ContextWorkspaceID 返回上下文对应的工作区ID：所选的工作区，未选择时为默认工作区；通过 AllWorkspaces 取消过滤时返回 0
+func (s *Store) ContextWorkspaceID(ctx context.Context) (int64, error) {
+	if isAllWorkspaces(ctx) {
+		return 0, nil
+	}
+	return s.writeWorkspaceID(ctx, s.db)
+}
+
+// DefaultWorkspaceID 返回默认工作区的ID
+func (s *Store) DefaultWorkspaceID(ctx context.Context) (int64, error) {
+	return s.defaultWorkspaceID(ctx, s.db)
+}
+
+// defaultWorkspaceID 查询默认工作区的ID
+func (s *Store) defaultWorkspaceID(ctx context.Context, q rowQueryer) (int64, error) {
+	var id int64
+	if err := q.QueryRowContext(ctx, s.rebind(`SELECT id FROM workspaces WHERE slug = ?`), DefaultWorkspaceSlug).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("default workspace not found")
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// ListWorkspaces 获取工作区，按创建顺序排列，并填充 userID 在每个工作区中的角色
+// memberOnly 为 true 时只返回 userID 所属的工作区
+func (s *Store) ListWorkspaces(ctx context.Context, userID int64, memberOnly bool) ([]*store.Workspace, error) {
+	query := `SELECT ` + workspaceColumns + `, m.role FROM workspaces w
+		LEFT JOIN workspace_members m ON m.workspace_id = w.id AND m.user_id = ?`
+	if memberOnly {
+		query += ` WHERE m.user_id IS NOT NULL`
+	}
+	query += ` ORDER BY w.id`
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workspaces := []*store.Workspace{}
+	for rows.Next() {
+		workspace, err := scanWorkspace(rows)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, rows.Err()
+}
+
+// GetWorkspaceBySlug 根据标识符获取工作区，并填充 userID 在其中的角色
+func (s *Store) GetWorkspaceBySlug(ctx context.Context, slug string, userID int64) (*store.Workspace, error) {
+	row := s.db.QueryRowContext(ctx, s.rebind(`SELECT `+workspaceColumns+`, m.role FROM workspaces w
+		LEFT JOIN workspace_members m ON m.workspace_id = w.id AND m.user_id = ?
+		WHERE w.slug = ?`), userID, slug)
+	workspace, err := scanWorkspace(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("workspace not found: %s", slug)
+		}
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// CreateWorkspace 创建工作区，创建者成为所有者
+func (s *Store) CreateWorkspace(ctx context.Context, workspace *store.Workspace, creatorID int64) (*store.Workspace, error) {
+	slug := strings.TrimSpace(workspace.Slug)
+	if slug == "" {
+		return nil, fmt.Errorf("workspace slug is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRowContext(ctx, s.rebind(`SELECT COUNT(*) FROM workspaces WHERE slug = ?`), slug).Scan(&count); err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("workspace already exists: %s", slug)
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx,
+		s.rebind(`INSERT INTO workspaces (slug, name_text, description, creator_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`),
+		slug, strings.TrimSpace(workspace.NameText), workspace.Description, creatorID, now, now,
+	); err != nil {
+		return nil, err
+	}
+	// 按标识符读取新工作区的ID，PostgreSQL 驱动不支持 LastInsertId
+	var id int64
+	if err := tx.QueryRowContext(ctx, s.rebind(`SELECT id FROM workspaces WHERE slug = ?`), slug).Scan(&id); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		s.rebind(`INSERT INTO workspace_members (workspace_id, user_id, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`),
+		id, creatorID, workspaceRoleNames[store.WorkspaceRole_WORKSPACE_ROLE_OWNER], now, now,
+	); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetWorkspaceBySlug(ctx, slug, creatorID)
+}
+
+// UpdateWorkspace 更新工作区的名称和描述
+func (s *Store) UpdateWorkspace(ctx context.Context, workspace *store.Workspace) error {
+	result, err := s.db.ExecContext(ctx,
+		s.rebind(`UPDATE workspaces SET name_text = ?, description = ?, updated_at = ? WHERE id = ?`),
+		strings.TrimSpace(workspace.NameText), workspace.Description, time.Now(), workspace.Id,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("workspace not found: %d", workspace.Id)
+	}
+	return nil
+}
+
+// DeleteWorkspace 删除工作区及其成员，工作区中还有内容时返回 ErrWorkspaceNotEmpty，默认工作区不能删除
+func (s *Store) DeleteWorkspace(ctx context.Context, workspaceID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	defaultID, err := s.defaultWorkspaceID(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if workspaceID == defaultID {
+		return fmt.Errorf("cannot delete the default workspace")
+	}
+	for _, table := range workspaceScopedTables {
+		var count int
+		if err := tx.QueryRowContext(ctx, s.rebind(fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE workspace_id = ?`, table)), workspaceID).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %d %s", ErrWorkspaceNotEmpty, count, table)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM workspace_members WHERE workspace_id = ?`), workspaceID); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM workspaces WHERE id = ?`), workspaceID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("workspace not found: %d", workspaceID)
+	}
+	return tx.Commit()
+}
+
+// ListWorkspaceMembers 获取工作区成员，按用户ID排序
+func (s *Store) ListWorkspaceMembers(ctx context.Context, workspace *store.Workspace) ([]*store.WorkspaceMember, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT m.user_id, u.username, m.role, m.created_at, m.updated_at
+		FROM workspace_members m JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = ? ORDER BY m.user_id`), workspace.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*store.WorkspaceMember{}
+	for rows.Next() {
+		var (
+			userID    int64
+			username  string
+			roleName  string
+			createdAt time.Time
+			updatedAt time.Time
+		)
+		if err := rows.Scan(&userID, &username, &roleName, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &store.WorkspaceMember{
+			Name:      fmt.Sprintf("%s/members/%d", workspace.Name, userID),
+			User:      fmt.Sprintf("users/%d", userID),
+			Username:  username,
+			Role:      parseWorkspaceRole(roleName),
+			CreatedAt: createdAt.Unix(),
+			UpdatedAt: updatedAt.Unix(),
+		})
+	}
+	return members, rows.Err()
+}
+
+// GetWorkspaceRole 返回用户在工作区中的角色，不是成员时返回 UNSPECIFIED
+func (s *Store) GetWorkspaceRole(ctx context.Context, workspaceID, userID int64) (store.WorkspaceRole, error) {
+	var roleName string
+	err := s.db.QueryRowContext(ctx, s.rebind(`SELECT role FROM workspace_members WHERE workspace_id = ? AND user_id = ?`), workspaceID, userID).Scan(&roleName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED, nil
+	}
+	if err != nil {
+		return store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED, err
+	}
+	return parseWorkspaceRole(roleName), nil
+}
+
+// GetNoteWorkspaceRole 返回用户在笔记所属工作区中的角色，不是成员或笔记不存在时返回 UNSPECIFIED
+func (s *Store) GetNoteWorkspaceRole(ctx context.Context, noteID, userID int64) (store.WorkspaceRole, error) {
+	var roleName string
+	err := s.db.QueryRowContext(ctx, s.rebind(`SELECT m.role FROM workspace_members m
+		JOIN notes n ON n.workspace_id = m.workspace_id
+		WHERE n.id = ? AND m.user_id = ?`), noteID, userID).Scan(&roleName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED, nil
+	}
+	if err != nil {
+		return store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED, err
+	}
+	return parseWorkspaceRole(roleName), nil
+}
+
+// isDefaultWorkspaceNote 检查笔记是否属于默认工作区，笔记不存在时返回 false
+func (s *Store) isDefaultWorkspaceNote(ctx context.Context, noteID int64) (bool, error) {
+	return s.rowExists(ctx, s.db, `SELECT COUNT(*) FROM notes n JOIN workspaces w ON w.id = n.workspace_id WHERE n.id = ? AND w.slug = ?`,
+		noteID, DefaultWorkspaceSlug)
+}
+
+// SetWorkspaceMember 添加工作区成员或修改成员的角色
+// 将最后一个所有者改为其他角色时返回 ErrLastWorkspaceOwner
+func (s *Store) SetWorkspaceMember(ctx context.Context, workspaceID, userID int64, role store.WorkspaceRole) error {
+	roleName, ok := workspaceRoleNames[role]
+	if !ok {
+		return fmt.Errorf("invalid workspace role: %v", role)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, s.rebind(`SELECT role FROM workspace_members WHERE workspace_id = ? AND user_id = ?`), workspaceID, userID).Scan(&current)
+	now := time.Now()
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = tx.ExecContext(ctx,
+			s.rebind(`INSERT INTO workspace_members (workspace_id, user_id, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`),
+			workspaceID, userID, roleName, now, now,
+		)
+	case err != nil:
+		return err
+	default:
+		if err := s.checkRemainingOwner(ctx, tx, workspaceID, current, role); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			s.rebind(`UPDATE workspace_members SET role = ?, updated_at = ? WHERE workspace_id = ? AND user_id = ?`),
+			roleName, now, workspaceID, userID,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveWorkspaceMember 移除工作区成员，返回是否存在该成员；移除最后一个所有者时返回 ErrLastWorkspaceOwner
+func (s *Store) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID int64) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, s.rebind(`SELECT role FROM workspace_members WHERE workspace_id = ? AND user_id = ?`), workspaceID, userID).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := s.checkRemainingOwner(ctx, tx, workspaceID, current, store.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED); err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM workspace_members WHERE workspace_id = ? AND user_id = ?`), workspaceID, userID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// JoinDefaultWorkspace 将新用户加入默认工作区，系统角色对应的工作区角色见 defaultWorkspaceRole
+func (s *Store) JoinDefaultWorkspace(ctx context.Context, userID int64, userRole UserRole) error {
+	workspaceID, err := s.DefaultWorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	return s.SetWorkspaceMember(ctx, workspaceID, userID, defaultWorkspaceRole(userRole))
+}
+
+// defaultWorkspaceRole 返回用户在默认工作区中的角色：系统管理员（HOST）为所有者，管理员为管理员，普通用户为成员
+func defaultWorkspaceRole(userRole UserRole) store.WorkspaceRole {
+	switch userRole {
+	case RoleHost:
+		return store.WorkspaceRole_WORKSPACE_ROLE_OWNER
+	case RoleAdmin:
+		return store.WorkspaceRole_WORKSPACE_ROLE_ADMIN
+	default:
+		return store.WorkspaceRole_WORKSPACE_ROLE_MEMBER
+	}
+}
+
+// checkRemainingOwner 检查将角色为 current 的成员改为 role（UNSPECIFIED 表示移除）后工作区仍有所有者
+func (s *Store) checkRemainingOwner(ctx context.Context, tx *sql.Tx, workspaceID int64, current string, role store.WorkspaceRole) error {
+	owner := workspaceRoleNames[store.WorkspaceRole_WORKSPACE_ROLE_OWNER]
+	if current != owner || role == store.WorkspaceRole_WORKSPACE_ROLE_OWNER {
+		return nil
+	}
+	var owners int
+	if err := tx.QueryRowContext(ctx, s.rebind(`SELECT COUNT(*) FROM workspace_members WHERE workspace_id = ? AND role = ?`), workspaceID, owner).Scan(&owners); err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastWorkspaceOwner
+	}
+	return nil
+}
+
+// migrateWorkspaces 为按工作区隔离的数据表添加 workspace_id 字段，并将已有数据放入默认工作区
+// 首次创建默认工作区时将全部已有用户加入默认工作区，之后 workspace_id 为 0 的数据（例如从旧版本备份恢复的数据）同样放入默认工作区
+func (s *Store) migrateWorkspaces() error {
+	for _, table := range workspaceScopedTables {
+		if err := s.addColumnIfNotExists(table, "workspace_id", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+			return err
+		}
+		if err := s.createIndexIfNotExists(table, "idx_"+table+"_workspace", "workspace_id", false); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var workspaceID int64
+	err = tx.QueryRow(s.rebind(`SELECT id FROM workspaces WHERE slug = ?`), DefaultWorkspaceSlug).Scan(&workspaceID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to read default workspace: %w", err)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		now := time.Now()
+		if _, err := tx.Exec(s.rebind(
+			`INSERT INTO workspaces (slug, name_text, description, creator_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`),
+			DefaultWorkspaceSlug, "Default", "", 0, now, now,
+		); err != nil {
+			return fmt.Errorf("failed to create default workspace: %w", err)
+		}
+		if workspaceID, err = s.defaultWorkspaceID(ctx, tx); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(`SELECT id, role FROM users WHERE deleted_at IS NULL`)
+		if err != nil {
+			return fmt.Errorf("failed to read users: %w", err)
+		}
+		type member struct {
+			userID int64
+			role   string
+		}
+		var members []member
+		for rows.Next() {
+			var m member
+			var userRole sql.NullString
+			if err := rows.Scan(&m.userID, &userRole); err != nil {
+				rows.Close()
+				return err
+			}
+			m.role = workspaceRoleNames[defaultWorkspaceRole(UserRole(userRole.String))]
+			members = append(members, m)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return err
+		}
+		rows.Close()
+		for _, m := range members {
+			if _, err := tx.Exec(s.rebind(
+				`INSERT INTO workspace_members (workspace_id, user_id, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`),
+				workspaceID, m.userID, m.role, now, now,
+			); err != nil {
+				return fmt.Errorf("failed to add user %d to default workspace: %w", m.userID, err)
+			}
+		}
+	}
+
+	for _, table := range workspaceScopedTables {
+		if _, err := tx.Exec(s.rebind(fmt.Sprintf(`UPDATE %s SET workspace_id = ? WHERE workspace_id = 0`, table)), workspaceID); err != nil {
+			return fmt.Errorf("failed to move %s to default workspace: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// scanWorkspace 扫描一行工作区，字段顺序见 workspaceColumns，最后一个字段为成员角色（可为空）
+func scanWorkspace(row interface{ Scan(dest ...any) error }) (*store.Workspace, error) {
+	var (
+		id          int64
+		createdAt   time.Time
+		updatedAt   time.Time
+		slug        string
+		nameText    string
+		description sql.NullString
+		creatorID   int64
+		roleName    sql.NullString
+	)
+	if err := row.Scan(&id, &createdAt, &updatedAt, &slug, &nameText, &description, &creatorID, &roleName); err != nil {
+		return nil, err
+	}
+	return &store.Workspace{
+		Name:        "workspaces/" + slug,
+		Id:          id,
+		Slug:        slug,
+		NameText:    nameText,
+		Description: description.String,
+		Creator:     fmt.Sprintf("users/%d", creatorID),
+		Role:        parseWorkspaceRole(roleName.String),
+		CreatedAt:   createdAt.Unix(),
+		UpdatedAt:   updatedAt.Unix(),
+	}, nil
+}
+
+// checkCategoryWorkspace 检查分类不属于 workspaceID 以外的工作区，categoryID 为 0 时不检查
+func (s *Store) checkCategoryWorkspace(ctx context.Context, tx *sql.Tx, categoryID uint, workspaceID int64) error {
+	if categoryID == 0 {
+		return nil
+	}
+	var count int
+	if err := tx.QueryRowContext(ctx, s.rebind(`SELECT COUNT(*) FROM categories WHERE id = ? AND workspace_id <> ?`), categoryID, workspaceID).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("category %d belongs to another workspace", categoryID)
+	}
+	return nil
+}
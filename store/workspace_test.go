@@ -0,0 +1,144 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	pbstore "github.com/wdmsyhh/simple-notes/proto/gen/store"
+	"github.com/wdmsyhh/simple-notes/store"
+)
+
+func TestWorkspaceIsolation(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s, "owner", store.RoleUser)
+	teamID := createTestWorkspace(t, s, "team", owner)
+	teamCtx := store.WithWorkspace(ctx, teamID)
+
+	defaultNote := createTestNote(t, ctx, s, &pbstore.Note{Title: "default note", AuthorId: userID(owner)})
+	teamNote := createTestNote(t, teamCtx, s, &pbstore.Note{Title: "team note", AuthorId: userID(owner)})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		// 没有选择工作区时只返回默认工作区的数据
+		{name: "unscoped", ctx: ctx, want: []string{"default note"}},
+		{name: "team", ctx: teamCtx, want: []string{"team note"}},
+		{name: "all workspaces", ctx: store.AllWorkspaces(ctx), want: []string{"default note", "team note"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, total, err := s.ListNotes(tt.ctx, &store.ListNotesRequest{PageSize: 10, SortBy: "id asc"})
+			if err != nil {
+				t.Fatalf("ListNotes: %v", err)
+			}
+			if got := noteTitles(notes); !reflect.DeepEqual(got, tt.want) || total != int64(len(tt.want)) {
+				t.Errorf("ListNotes = %v (total %d), want %v", got, total, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.GetNote(ctx, teamNote.Id); err == nil {
+		t.Errorf("GetNote without a workspace returned a note from another workspace")
+	}
+	if _, err := s.GetNote(teamCtx, defaultNote.Id); err == nil {
+		t.Errorf("GetNote in the team workspace returned a note from the default workspace")
+	}
+	if _, err := s.GetNote(store.AllWorkspaces(ctx), teamNote.Id); err != nil {
+		t.Errorf("GetNote across all workspaces: %v", err)
+	}
+}
+
+func TestContextWorkspaceID(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s, "owner", store.RoleUser)
+	teamID := createTestWorkspace(t, s, "team", owner)
+	defaultID, err := s.DefaultWorkspaceID(ctx)
+	if err != nil {
+		t.Fatalf("DefaultWorkspaceID: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want int64
+	}{
+		{name: "unscoped", ctx: ctx, want: defaultID},
+		{name: "zero", ctx: store.WithWorkspace(ctx, 0), want: defaultID},
+		{name: "team", ctx: store.WithWorkspace(ctx, teamID), want: teamID},
+		{name: "all workspaces", ctx: store.AllWorkspaces(ctx), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ContextWorkspaceID(tt.ctx)
+			if err != nil {
+				t.Fatalf("ContextWorkspaceID: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ContextWorkspaceID = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagAliasesPerWorkspace(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s, "owner", store.RoleUser)
+	teamCtx := store.WithWorkspace(ctx, createTestWorkspace(t, s, "team", owner))
+
+	defaultTag, err := s.CreateTag(ctx, &pbstore.Tag{NameText: "Go"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	teamTag, err := s.CreateTag(teamCtx, &pbstore.Tag{NameText: "Go"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	if defaultTag.Id == teamTag.Id {
+		t.Fatalf("tags with the same name in different workspaces share ID %d", defaultTag.Id)
+	}
+
+	// 同一别名可以在不同工作区中分别使用
+	if _, err := s.CreateTagAlias(ctx, defaultTag.Id, "golang"); err != nil {
+		t.Fatalf("CreateTagAlias in the default workspace: %v", err)
+	}
+	if _, err := s.CreateTagAlias(teamCtx, teamTag.Id, "golang"); err != nil {
+		t.Fatalf("CreateTagAlias in the team workspace: %v", err)
+	}
+
+	// 别名在工作区内仍然唯一
+	other, err := s.CreateTag(ctx, &pbstore.Tag{NameText: "Rust"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	if _, err := s.CreateTagAlias(ctx, other.Id, "Golang"); !errors.Is(err, store.ErrTagExists) {
+		t.Errorf("CreateTagAlias with a duplicate alias: err = %v, want ErrTagExists", err)
+	}
+
+	// 创建与别名同名的标签解析为所在工作区的标签
+	resolved, err := s.CreateTag(teamCtx, &pbstore.Tag{NameText: "golang"})
+	if err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	if resolved.Id != teamTag.Id {
+		t.Errorf("CreateTag(golang) in the team workspace = tag %d, want %d", resolved.Id, teamTag.Id)
+	}
+
+	// 删除别名只影响所在工作区
+	if _, err := s.DeleteTagAlias(teamCtx, teamTag.Id, "golang"); err != nil {
+		t.Fatalf("DeleteTagAlias: %v", err)
+	}
+	tag, err := s.GetTag(ctx, defaultTag.Id)
+	if err != nil {
+		t.Fatalf("GetTag: %v", err)
+	}
+	if !reflect.DeepEqual(tag.Aliases, []string{"golang"}) {
+		t.Errorf("default tag aliases = %v, want [golang]", tag.Aliases)
+	}
+}